@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+)
+
+func (c *cli) auditLog(ctx context.Context, args []string) error {
+	fs := c.flags("audit")
+	actor := fs.String("actor", "", "only entries by this user, API key or device ID")
+	action := fs.String("action", "", "only actions starting with this, such as fleet. or device.exec")
+	targetType := fs.String("target-type", "", "only entries about this kind of target, such as schedule")
+	target := fs.String("target", "", "only entries about this target ID")
+	since := fs.Duration("since", 0, "only entries from this long ago onwards, such as 24h")
+	limit := fs.Int("limit", 50, "show at most this many entries, newest first")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *limit <= 0 {
+		return errors.New("--limit must be positive")
+	}
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	all := &com.ListAuditEntriesResponse{}
+	req := &com.ListAuditEntriesRequest{
+		OrganizationId: organizationID,
+		ActorId:        *actor,
+		Action:         *action,
+		TargetType:     *targetType,
+		TargetId:       *target,
+	}
+	if *since > 0 {
+		req.Since = timestamppb.New(time.Now().Add(-*since))
+	}
+	for len(all.Entries) < *limit {
+		req.PageSize = int32(min(*limit-len(all.Entries), 500))
+		resp, err := c.audit.ListAuditEntries(ctx, connect.NewRequest(req))
+		if err != nil {
+			return errors.Wrap(err, "failed to list audit log")
+		}
+		all.Entries = append(all.Entries, resp.Msg.Entries...)
+		if resp.Msg.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.Msg.NextPageToken
+	}
+
+	return c.print(all, func(w io.Writer) {
+		row(w, "WHEN", "ACTOR", "ACTION", "TARGET")
+		for _, entry := range all.Entries {
+			row(w, ago(entry.CreatedAt), entry.ActorType+":"+orDash(entry.ActorName), entry.Action, entry.TargetId)
+		}
+	})
+}
@@ -0,0 +1,504 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+)
+
+const (
+	logFollowInterval = 2 * time.Second
+	// The server keeps this many lines per device, so following never asks for fewer than it may have
+	logFollowTail = 5000
+)
+
+// organizationID is the profile's organization, or the caller's only organization when the profile names none.
+func (c *cli) organizationID(ctx context.Context) (string, error) {
+	if c.profile.OrganizationID != "" {
+		return c.profile.OrganizationID, nil
+	}
+	resp, err := c.organization.ListOrganizations(ctx, connect.NewRequest(&com.ListOrganizationsRequest{}))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list organizations")
+	}
+	if len(resp.Msg.Organizations) != 1 {
+		return "", errors.Errorf("credentials have access to %d organizations; set one with `pandoctl profile set --organization`", len(resp.Msg.Organizations))
+	}
+	c.profile.OrganizationID = resp.Msg.Organizations[0].Id
+	return c.profile.OrganizationID, nil
+}
+
+// resolveFleet finds a fleet by ID or name.
+func (c *cli) resolveFleet(ctx context.Context, organizationID string, fleet string) (*com.Fleet, error) {
+	resp, err := c.fleets.ListFleets(ctx, connect.NewRequest(&com.ListFleetsRequest{OrganizationId: organizationID}))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list fleets")
+	}
+	for _, f := range resp.Msg.Fleets {
+		if f.Id == fleet || f.Name == fleet {
+			return f, nil
+		}
+	}
+	return nil, errors.Errorf("fleet %s not found", fleet)
+}
+
+func (c *cli) listFleets(ctx context.Context, args []string) error {
+	fs := c.flags("fleets")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.fleets.ListFleets(ctx, connect.NewRequest(&com.ListFleetsRequest{OrganizationId: organizationID}))
+	if err != nil {
+		return errors.Wrap(err, "failed to list fleets")
+	}
+	return c.print(resp.Msg, func(w io.Writer) {
+		row(w, "NAME", "ID", "DEVICES", "DEFAULT SCHEDULE", "AGENT IMAGE")
+		for _, fleet := range resp.Msg.Fleets {
+			row(w, fleet.Name, fleet.Id, strconv.FormatInt(fleet.DeviceCount, 10), orDash(fleet.DefaultScheduleId), orDash(fleet.AgentImage))
+		}
+	})
+}
+
+func (c *cli) listDevices(ctx context.Context, args []string) error {
+	fs := c.flags("devices")
+	fleetRef := fs.String("fleet", "", "fleet name or ID (required)")
+	status := fs.String("status", "", "only devices with this status: online, stale or offline")
+	name := fs.String("name", "", "only devices whose name contains this")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *fleetRef == "" {
+		return errors.New("--fleet is required")
+	}
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+	fleet, err := c.resolveFleet(ctx, organizationID, *fleetRef)
+	if err != nil {
+		return err
+	}
+
+	all := &com.ListDevicesResponse{}
+	req := &com.ListDevicesRequest{
+		OrganizationId: organizationID,
+		FleetId:        fleet.Id,
+		NameContains:   *name,
+		Status:         *status,
+		PageSize:       500,
+	}
+	for {
+		resp, err := c.fleets.ListDevices(ctx, connect.NewRequest(req))
+		if err != nil {
+			return errors.Wrap(err, "failed to list devices")
+		}
+		all.Devices = append(all.Devices, resp.Msg.Devices...)
+		if resp.Msg.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.Msg.NextPageToken
+	}
+
+	return c.print(all, func(w io.Writer) {
+		row(w, "NAME", "ID", "STATUS", "LAST SEEN", "AGENT VERSION", "ADDRESS")
+		for _, device := range all.Devices {
+			row(w, device.Name, device.Id, device.Status, ago(device.LastSeenAt), orDash(device.AgentVersion), orDash(device.RemoteAddress))
+		}
+	})
+}
+
+func (c *cli) describeDevice(ctx context.Context, args []string) error {
+	fs := c.flags("device")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New("usage: pandoctl device DEVICE")
+	}
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.devices.DescribeDevice(ctx, connect.NewRequest(&com.DescribeDeviceRequest{
+		OrganizationId: organizationID,
+		Device:         positional[0],
+	}))
+	if err != nil {
+		return errors.Wrap(err, "failed to describe device")
+	}
+	return c.print(resp.Msg, func(w io.Writer) {
+		device := resp.Msg.Device
+		row(w, "Name:", device.Name)
+		row(w, "ID:", device.Id)
+		row(w, "Fleet:", device.FleetId)
+		row(w, "Status:", fmt.Sprintf("%s (last seen %s)", device.Status, ago(device.LastSeenAt)))
+		row(w, "Agent version:", orDash(device.AgentVersion))
+		row(w, "Address:", orDash(device.RemoteAddress))
+		if schedule := resp.Msg.DesiredSchedule; schedule != nil {
+			row(w, "Schedule:", fmt.Sprintf("%s (%s)", schedule.Name, schedule.Id))
+		} else {
+			row(w, "Schedule:", "-")
+		}
+		row(w, "Reported:", ago(resp.Msg.ReportedAt))
+		row(w, "Maintenance:", fmt.Sprintf("%s (%s)", formatDeviceMaintenance(device), orDefault(device.TimeZone, "fleet's time zone")))
+		if device.ApplyNowUntil != nil {
+			row(w, "Apply now until:", device.ApplyNowUntil.AsTime().Local().Format(time.DateTime))
+		}
+		if pending := resp.Msg.PendingUpdate; pending != nil {
+			next := "no window scheduled"
+			if pending.NextWindowAt != nil {
+				next = "next window " + pending.NextWindowAt.AsTime().Local().Format(time.DateTime)
+			}
+			row(w, "Pending update:", fmt.Sprintf("%d containers of schedule %s wait for a maintenance window (%s)", pending.DeferredChanges, pending.ScheduleId, next))
+		}
+		row(w)
+
+		reported := map[string]*com.ContainerState{}
+		for _, state := range resp.Msg.ReportedContainers {
+			reported[state.Id] = state
+		}
+		row(w, "CONTAINER", "IMAGE", "DESIRED", "REPORTED")
+		for _, container := range resp.Msg.DesiredSchedule.GetContainers() {
+			desired, state := "running", "missing"
+			if job := container.Job; job != nil {
+				// job runs are listed by pandoctl jobs instead
+				desired, state = "once", "-"
+				if job.Cron != "" {
+					desired = "cron " + job.Cron
+				}
+			}
+			if s, ok := reported[container.Id]; ok {
+				state = s.Status
+				delete(reported, container.Id)
+			}
+			row(w, container.Name, container.ContainerImage, desired, state)
+		}
+		// what is left is running but no longer wanted
+		leftover := make([]*com.ContainerState, 0, len(reported))
+		for _, state := range reported {
+			leftover = append(leftover, state)
+		}
+		sort.Slice(leftover, func(i, j int) bool { return leftover[i].Name < leftover[j].Name })
+		for _, state := range leftover {
+			row(w, orDash(state.Name), "-", "removed", state.Status)
+		}
+	})
+}
+
+func (c *cli) push(ctx context.Context, args []string) error {
+	fs := c.flags("push")
+	fleetRef := fs.String("fleet", "", "fleet name or ID (required)")
+	file := fs.String("f", "", "schedule in protobuf JSON form, or - for standard input (required)")
+	name := fs.String("name", "", "schedule name; defaults to the name in the file, then the file's name")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *fleetRef == "" || *file == "" {
+		return errors.New("--fleet and -f are required")
+	}
+
+	data, err := readInput(*file)
+	if err != nil {
+		return err
+	}
+	schedule := &com.Schedule{}
+	if err := protojson.Unmarshal(data, schedule); err != nil {
+		return errors.Wrapf(err, "failed to parse %s", *file)
+	}
+	switch {
+	case *name != "":
+		schedule.Name = *name
+	case schedule.Name == "" && *file != "-":
+		schedule.Name = strings.TrimSuffix(filepath.Base(*file), filepath.Ext(*file))
+	}
+
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+	fleet, err := c.resolveFleet(ctx, organizationID, *fleetRef)
+	if err != nil {
+		return err
+	}
+
+	created, err := c.schedules.CreateSchedule(ctx, connect.NewRequest(&com.CreateScheduleRequest{
+		OrganizationId: organizationID,
+		Name:           schedule.Name,
+		State:          schedule.State,
+		Containers:     schedule.Containers,
+	}))
+	if err != nil {
+		return errors.Wrap(err, "failed to create schedule")
+	}
+	updated, err := c.fleets.SetFleetDefaultSchedule(ctx, connect.NewRequest(&com.SetFleetDefaultScheduleRequest{
+		OrganizationId: organizationID,
+		FleetId:        fleet.Id,
+		ScheduleId:     created.Msg.Schedule.Id,
+	}))
+	if err != nil {
+		return errors.Wrapf(err, "created schedule %s but failed to set it as the fleet's default", created.Msg.Schedule.Id)
+	}
+
+	return c.print(created.Msg.Schedule, func(w io.Writer) {
+		row(w, fmt.Sprintf("Schedule %s (%s) is now the default for fleet %s", created.Msg.Schedule.Name, created.Msg.Schedule.Id, updated.Msg.Fleet.Name))
+	})
+}
+
+func (c *cli) agentImage(ctx context.Context, args []string) error {
+	fs := c.flags("agent-image")
+	fleetRef := fs.String("fleet", "", "fleet name or ID (required)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *fleetRef == "" || len(positional) != 1 {
+		return errors.New("usage: pandoctl agent-image --fleet FLEET IMAGE")
+	}
+
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+	fleet, err := c.resolveFleet(ctx, organizationID, *fleetRef)
+	if err != nil {
+		return err
+	}
+	updated, err := c.fleets.SetFleetAgentImage(ctx, connect.NewRequest(&com.SetFleetAgentImageRequest{
+		OrganizationId: organizationID,
+		FleetId:        fleet.Id,
+		AgentImage:     positional[0],
+	}))
+	if err != nil {
+		return errors.Wrap(err, "failed to set agent image")
+	}
+
+	return c.print(updated.Msg.Fleet, func(w io.Writer) {
+		if updated.Msg.Fleet.AgentImage == "" {
+			row(w, fmt.Sprintf("Agents in fleet %s are no longer updated", updated.Msg.Fleet.Name))
+			return
+		}
+		row(w, fmt.Sprintf("Agents in fleet %s will update to %s", updated.Msg.Fleet.Name, updated.Msg.Fleet.AgentImage))
+	})
+}
+
+func (c *cli) logs(ctx context.Context, args []string) error {
+	fs := c.flags("logs")
+	follow := fs.Bool("f", false, "keep printing new lines as they arrive")
+	tail := fs.Int("tail", 100, "number of recent lines to show first")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 1 || len(positional) > 2 {
+		return errors.New("usage: pandoctl logs [-f] [--tail N] DEVICE [CONTAINER]")
+	}
+	container := ""
+	if len(positional) == 2 {
+		container = positional[1]
+	}
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &com.GetContainerLogsRequest{
+		OrganizationId: organizationID,
+		Device:         positional[0],
+		Container:      container,
+		Tail:           int32(*tail),
+	}
+	for {
+		resp, err := c.devices.GetContainerLogs(ctx, connect.NewRequest(req))
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(err, "failed to get logs")
+		}
+		for _, entry := range resp.Msg.Entries {
+			if c.output == "json" {
+				encoded, err := protojson.Marshal(entry)
+				if err != nil {
+					return errors.Wrap(err, "failed to encode output")
+				}
+				fmt.Println(string(encoded))
+				continue
+			}
+			fmt.Printf("%s %s | %s\n", entry.Time.AsTime().Local().Format(time.RFC3339), entry.Container, entry.Line)
+		}
+		if !*follow {
+			return nil
+		}
+
+		req.AfterSequence = resp.Msg.NextSequence
+		req.Tail = logFollowTail
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(logFollowInterval):
+		}
+	}
+}
+
+func (c *cli) exec(ctx context.Context, args []string) error {
+	fs := c.flags("exec")
+	timeout := fs.Int("timeout", 30, "seconds the command may run, at most 300")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 3 {
+		return errors.New("usage: pandoctl exec [--timeout S] DEVICE CONTAINER -- COMMAND...")
+	}
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.devices.ExecInContainer(ctx, connect.NewRequest(&com.ExecInContainerRequest{
+		OrganizationId: organizationID,
+		Device:         positional[0],
+		Container:      positional[1],
+		Command:        positional[2:],
+		TimeoutSeconds: int32(*timeout),
+	}))
+	if err != nil {
+		return errors.Wrap(err, "failed to exec")
+	}
+	result := resp.Msg.Result
+
+	if c.output == "json" {
+		if err := c.print(result, nil); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprint(os.Stdout, result.GetStdout())
+		fmt.Fprint(os.Stderr, result.GetStderr())
+		if result.GetTruncated() {
+			fmt.Fprintln(os.Stderr, "warning: output was truncated, as devices keep at most 1 MiB of each stream")
+		}
+	}
+	if result.GetError() != "" {
+		return errors.New(result.GetError())
+	}
+	if result.GetExitCode() != 0 {
+		return exitCode(result.GetExitCode())
+	}
+	return nil
+}
+
+func (c *cli) deviceToken(ctx context.Context, args []string) error {
+	fs := c.flags("device-token")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New("usage: pandoctl device-token DEVICE")
+	}
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.devices.IssueDeviceRelayToken(ctx, connect.NewRequest(&com.IssueDeviceRelayTokenRequest{
+		OrganizationId: organizationID,
+		Device:         positional[0],
+	}))
+	if err != nil {
+		return errors.Wrap(err, "failed to issue device relay token")
+	}
+	return c.print(resp.Msg, func(w io.Writer) {
+		fmt.Fprintln(w, resp.Msg.Token)
+	})
+}
+
+func (c *cli) profileCommand(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: pandoctl profile set|use|list")
+	}
+	cfg, err := loadConfig(c.configPath)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "set":
+		fs := c.flags("profile set")
+		server := fs.String("server", "", "server URL")
+		apiKey := fs.String("api-key", "", "API key, as created in the web UI or with CreateAPIKey")
+		organizationID := fs.String("organization", "", "organization ID; needed when the key can see several")
+		positional, err := parseArgs(fs, args[1:])
+		if err != nil {
+			return err
+		}
+		if len(positional) != 1 {
+			return errors.New("usage: pandoctl profile set NAME [--server URL] [--api-key KEY] [--organization ID]")
+		}
+		p, ok := cfg.Profiles[positional[0]]
+		if !ok {
+			p = &profile{}
+			cfg.Profiles[positional[0]] = p
+		}
+		// only the given flags change an existing profile
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "server":
+				p.Server = *server
+			case "api-key":
+				p.APIKey = *apiKey
+			case "organization":
+				p.OrganizationID = *organizationID
+			}
+		})
+		if cfg.CurrentProfile == "" {
+			cfg.CurrentProfile = positional[0]
+		}
+		return cfg.save(c.configPath)
+	case "use":
+		if len(args) != 2 {
+			return errors.New("usage: pandoctl profile use NAME")
+		}
+		if _, ok := cfg.Profiles[args[1]]; !ok {
+			return errors.Errorf("no profile named %s", args[1])
+		}
+		cfg.CurrentProfile = args[1]
+		return cfg.save(c.configPath)
+	case "list":
+		names := make([]string, 0, len(cfg.Profiles))
+		for name := range cfg.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			marker := " "
+			if name == cfg.CurrentProfile {
+				marker = "*"
+			}
+			fmt.Printf("%s %s\t%s\n", marker, name, cfg.Profiles[name].Server)
+		}
+		return nil
+	default:
+		return errors.Errorf("unknown profile command %q", args[0])
+	}
+}
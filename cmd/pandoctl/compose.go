@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/pkg/compose"
+	"github.com/uinta-labs/pando/pkg/manifest"
+)
+
+// printUnsupported lists what a compose conversion left out, on standard error so output stays usable.
+func printUnsupported(unsupported []string) {
+	for _, problem := range unsupported {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", problem)
+	}
+}
+
+func (c *cli) composeCommand(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: pandoctl compose convert|deploy|export")
+	}
+	switch args[0] {
+	case "convert":
+		return c.composeConvert(args[1:])
+	case "deploy":
+		if err := c.connect(); err != nil {
+			return err
+		}
+		return c.composeDeploy(ctx, args[1:])
+	case "export":
+		if err := c.connect(); err != nil {
+			return err
+		}
+		return c.composeExport(ctx, args[1:])
+	default:
+		return errors.Errorf("unknown compose command %q", args[0])
+	}
+}
+
+// composeConvert prints a compose file as a schedule manifest, without contacting the server.
+func (c *cli) composeConvert(args []string) error {
+	fs := c.flags("compose convert")
+	file := fs.String("f", "compose.yaml", "compose file, or - for standard input")
+	name := fs.String("name", "", "schedule name; defaults to the compose file's name")
+	format := fs.String("format", manifest.FormatYAML, "manifest format: yaml or json")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	data, err := readInput(*file)
+	if err != nil {
+		return err
+	}
+
+	conversion, err := compose.ToSchedule(data, *name)
+	if err != nil {
+		return err
+	}
+	printUnsupported(conversion.Unsupported)
+	encoded, err := manifest.FromSchedule(conversion.Schedule).Encode(*format)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(encoded))
+	return nil
+}
+
+func (c *cli) composeDeploy(ctx context.Context, args []string) error {
+	fs := c.flags("compose deploy")
+	file := fs.String("f", "compose.yaml", "compose file, or - for standard input")
+	name := fs.String("name", "", "schedule name; defaults to the compose file's name")
+	fleetRef := fs.String("fleet", "", "fleet name or ID whose default schedule it becomes (required)")
+	dryRun := fs.Bool("dry-run", false, "only show what would change")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *fleetRef == "" {
+		return errors.New("--fleet is required")
+	}
+	data, err := readInput(*file)
+	if err != nil {
+		return err
+	}
+	// converted here too, to report a broken file before contacting the server
+	conversion, err := compose.ToSchedule(data, *name)
+	if err != nil {
+		return err
+	}
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+	fleet, err := c.resolveFleet(ctx, organizationID, *fleetRef)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.schedules.ImportCompose(ctx, connect.NewRequest(&com.ImportComposeRequest{
+		OrganizationId: organizationID,
+		Compose:        string(data),
+		Name:           *name,
+		FleetId:        fleet.Id,
+		DryRun:         *dryRun,
+	}))
+	if err != nil {
+		return errors.Wrap(err, "failed to deploy compose file")
+	}
+	printUnsupported(resp.Msg.Unsupported)
+	return c.print(resp.Msg, func(w io.Writer) {
+		scheduleName := conversion.Schedule.Name
+		printChanges(w, scheduleName, resp.Msg.Changes)
+		if !*dryRun {
+			fmt.Fprintf(w, "schedule %s is the default for fleet %s\n", scheduleName, fleet.Name)
+		}
+	})
+}
+
+func (c *cli) composeExport(ctx context.Context, args []string) error {
+	fs := c.flags("compose export")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New("usage: pandoctl compose export SCHEDULE")
+	}
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.schedules.ExportCompose(ctx, connect.NewRequest(&com.ExportComposeRequest{
+		OrganizationId: organizationID,
+		Schedule:       positional[0],
+	}))
+	if err != nil {
+		return errors.Wrap(err, "failed to export schedule")
+	}
+	fmt.Print(resp.Msg.Compose)
+	return nil
+}
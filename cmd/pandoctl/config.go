@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// profile is one server and the credentials used against it.
+type profile struct {
+	Server         string `yaml:"server"`
+	APIKey         string `yaml:"api_key"`
+	OrganizationID string `yaml:"organization_id,omitempty"`
+}
+
+// config is pandoctl's config file, by default ~/.config/pandoctl/config.yaml.
+type config struct {
+	CurrentProfile string              `yaml:"current_profile"`
+	Profiles       map[string]*profile `yaml:"profiles"`
+}
+
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "pandoctl.yaml"
+	}
+	return filepath.Join(dir, "pandoctl", "config.yaml")
+}
+
+// loadConfig reads the config file, treating a missing file as an empty config.
+func loadConfig(path string) (*config, error) {
+	cfg := &config{Profiles: map[string]*profile{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read config")
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", path)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]*profile{}
+	}
+	return cfg, nil
+}
+
+// save writes the config, readable only by its owner as it holds API keys.
+func (c *config) save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode config")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return errors.Wrap(err, "failed to create config directory")
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return errors.Wrap(err, "failed to write config")
+	}
+	return nil
+}
+
+// resolveProfile picks the named profile, PANDO_PROFILE or the current one, in that order, then applies the
+// PANDO_SERVER, PANDO_API_KEY and PANDO_ORGANIZATION overrides. Without a config file the environment alone
+// is enough.
+func (c *config) resolveProfile(name string) (*profile, error) {
+	if name == "" {
+		name = os.Getenv("PANDO_PROFILE")
+	}
+	if name == "" {
+		name = c.CurrentProfile
+	}
+
+	resolved := profile{}
+	if name != "" {
+		p, ok := c.Profiles[name]
+		if !ok {
+			return nil, errors.Errorf("no profile named %s", name)
+		}
+		resolved = *p
+	}
+	if server := os.Getenv("PANDO_SERVER"); server != "" {
+		resolved.Server = server
+	}
+	if apiKey := os.Getenv("PANDO_API_KEY"); apiKey != "" {
+		resolved.APIKey = apiKey
+	}
+	if organizationID := os.Getenv("PANDO_ORGANIZATION"); organizationID != "" {
+		resolved.OrganizationID = organizationID
+	}
+
+	if resolved.Server == "" {
+		return nil, errors.New("no server configured; run `pandoctl profile set` or set PANDO_SERVER")
+	}
+	if resolved.APIKey == "" {
+		return nil, errors.New("no API key configured; run `pandoctl profile set` or set PANDO_API_KEY")
+	}
+	return &resolved, nil
+}
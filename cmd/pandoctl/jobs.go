@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+)
+
+func (c *cli) jobRuns(ctx context.Context, args []string) error {
+	fs := c.flags("jobs")
+	job := fs.String("job", "", "only runs of the job with this name or task ID")
+	limit := fs.Int("limit", 20, "show at most this many runs, newest first")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New("usage: pandoctl jobs [--job NAME] [--limit N] DEVICE")
+	}
+	if *limit <= 0 {
+		return errors.New("--limit must be positive")
+	}
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	all := &com.ListJobRunsResponse{}
+	req := &com.ListJobRunsRequest{
+		OrganizationId: organizationID,
+		Device:         positional[0],
+		Job:            *job,
+	}
+	for len(all.Runs) < *limit {
+		req.PageSize = int32(min(*limit-len(all.Runs), 500))
+		resp, err := c.devices.ListJobRuns(ctx, connect.NewRequest(req))
+		if err != nil {
+			return errors.Wrap(err, "failed to list job runs")
+		}
+		all.Runs = append(all.Runs, resp.Msg.Runs...)
+		if resp.Msg.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.Msg.NextPageToken
+	}
+
+	// the output itself is only in -o json, as it runs to many lines
+	return c.print(all, func(w io.Writer) {
+		row(w, "STARTED", "JOB", "STATUS", "EXIT", "DURATION", "ERROR")
+		for _, run := range all.Runs {
+			duration := "-"
+			if run.StartedAt != nil && run.FinishedAt != nil {
+				duration = run.FinishedAt.AsTime().Sub(run.StartedAt.AsTime()).String()
+			}
+			row(w, ago(run.StartedAt), orDash(run.Name), run.Status, strconv.Itoa(int(run.ExitCode)), duration, orDash(run.Error))
+		}
+	})
+}
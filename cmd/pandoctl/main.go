@@ -0,0 +1,200 @@
+// pandoctl is a command line client for the pando server's operator APIs.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com/comconnect"
+)
+
+const usage = `Usage: pandoctl [global flags] <command> [flags] [args]
+
+Commands:
+  fleets                                   List fleets
+  devices --fleet FLEET [--status S] [--name N]
+                                           List a fleet's devices
+  device DEVICE                            Show a device's desired and reported state
+  push --fleet FLEET -f SCHEDULE.json [--name N]
+                                           Create a schedule and make it the fleet's default
+  agent-image --fleet FLEET IMAGE          Update the fleet's agents to IMAGE; "" stops updating them
+  maintenance (--fleet FLEET | --device DEVICE) [--tz ZONE] [--inherit] [WINDOW...]
+                                           Only restart containers in these windows, such as sat,sun@01:00+4h;
+                                           none allows it any time
+  apply-now (--fleet FLEET | --device DEVICE) [--for DURATION] [--cancel]
+                                           Apply deferred updates without waiting for a maintenance window
+  apply -f MANIFEST                        Create or update the schedule a manifest describes
+  diff -f MANIFEST                         Show what apply would change
+  export [--format yaml|json] SCHEDULE     Print a schedule as a manifest
+  compose convert [-f FILE] [--name N]     Print a compose file as a schedule manifest
+  compose deploy --fleet FLEET [-f FILE] [--name N] [--dry-run]
+                                           Apply a compose file and make it the fleet's default
+  compose export SCHEDULE                  Print a schedule as a compose file
+  logs [-f] [--tail N] DEVICE [CONTAINER]  Show or follow container logs
+  exec [--timeout S] DEVICE CONTAINER -- COMMAND...
+                                           Run a command in a container
+  device-token DEVICE                      Issue the token the device's agent needs for logs and exec, as
+                                           DEVICE_TOKEN; its previous token stops working
+  jobs [--job NAME] [--limit N] DEVICE     Show a device's job runs, newest first
+  audit [--actor ID] [--action A] [--target-type T] [--target ID] [--since D] [--limit N]
+                                           Show the audit log, newest first
+  profile set NAME --server URL --api-key KEY [--organization ID]
+  profile use NAME
+  profile list
+
+Global flags:
+`
+
+// cli holds the global flags, and the clients built from the selected profile.
+type cli struct {
+	configPath  string
+	profileName string
+	output      string
+
+	profile      *profile
+	organization comconnect.OrganizationServiceClient
+	fleets       comconnect.FleetServiceClient
+	schedules    comconnect.ScheduleServiceClient
+	devices      comconnect.DeviceServiceClient
+	audit        comconnect.AuditServiceClient
+}
+
+// flags starts a subcommand's flag set, which also accepts -o so it can follow the subcommand.
+func (c *cli) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.StringVar(&c.output, "o", c.output, "output format: table or json")
+	return fs
+}
+
+// parseArgs parses flags wherever they appear among the positional arguments, up to a "--", after which
+// everything is positional.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	positional := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// bearerAuth sends the profile's API key with every call.
+func bearerAuth(apiKey string) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			req.Header().Set("Authorization", "Bearer "+apiKey)
+			return next(ctx, req)
+		}
+	}
+}
+
+func (c *cli) connect() error {
+	cfg, err := loadConfig(c.configPath)
+	if err != nil {
+		return err
+	}
+	c.profile, err = cfg.resolveProfile(c.profileName)
+	if err != nil {
+		return err
+	}
+
+	httpClient := &http.Client{}
+	baseURL := strings.TrimSuffix(c.profile.Server, "/")
+	options := connect.WithInterceptors(bearerAuth(c.profile.APIKey))
+	c.organization = comconnect.NewOrganizationServiceClient(httpClient, baseURL, options)
+	c.fleets = comconnect.NewFleetServiceClient(httpClient, baseURL, options)
+	c.schedules = comconnect.NewScheduleServiceClient(httpClient, baseURL, options)
+	c.devices = comconnect.NewDeviceServiceClient(httpClient, baseURL, options)
+	c.audit = comconnect.NewAuditServiceClient(httpClient, baseURL, options)
+	return nil
+}
+
+func main() {
+	c := &cli{}
+	global := flag.NewFlagSet("pandoctl", flag.ContinueOnError)
+	global.StringVar(&c.configPath, "config", defaultConfigPath(), "config file")
+	global.StringVar(&c.profileName, "profile", "", "profile to use instead of the current one")
+	global.StringVar(&c.output, "o", "table", "output format: table or json")
+	global.Usage = func() {
+		fmt.Fprint(global.Output(), usage)
+		global.PrintDefaults()
+	}
+	if err := global.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
+	if global.NArg() == 0 {
+		global.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := c.run(ctx, global.Arg(0), global.Args()[1:]); err != nil {
+		var exit exitCode
+		if errors.As(err, &exit) {
+			os.Exit(int(exit))
+		}
+		fmt.Fprintf(os.Stderr, "pandoctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// exitCode ends pandoctl with a specific status and no message, as for exec.
+type exitCode int
+
+func (e exitCode) Error() string {
+	return fmt.Sprintf("exit status %d", int(e))
+}
+
+func (c *cli) run(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "profile":
+		return c.profileCommand(args)
+	case "compose":
+		return c.composeCommand(ctx, args)
+	}
+
+	commands := map[string]func(context.Context, []string) error{
+		"fleets":       c.listFleets,
+		"devices":      c.listDevices,
+		"device":       c.describeDevice,
+		"push":         c.push,
+		"agent-image":  c.agentImage,
+		"maintenance":  c.maintenance,
+		"apply-now":    c.applyNow,
+		"apply":        c.apply,
+		"diff":         c.diff,
+		"export":       c.export,
+		"logs":         c.logs,
+		"exec":         c.exec,
+		"device-token": c.deviceToken,
+		"jobs":         c.jobRuns,
+		"audit":        c.auditLog,
+	}
+	run, ok := commands[command]
+	if !ok {
+		return errors.Errorf("unknown command %q; run pandoctl -h for usage", command)
+	}
+	if err := c.connect(); err != nil {
+		return err
+	}
+	return run(ctx, args)
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+)
+
+// parseWindow parses a maintenance window written as [DAYS@]HH:MM+DURATION, such as "sat,sun@01:00+4h" or
+// "02:00+90m". Without days, the window opens every day.
+func parseWindow(s string) (*com.MaintenanceWindow, error) {
+	window := &com.MaintenanceWindow{}
+	if days, rest, ok := strings.Cut(s, "@"); ok {
+		window.Days = strings.Split(strings.ToLower(days), ",")
+		s = rest
+	}
+	start, length, ok := strings.Cut(s, "+")
+	if !ok {
+		return nil, errors.Errorf("maintenance window %q must look like [DAYS@]HH:MM+DURATION, such as sat,sun@01:00+4h", s)
+	}
+	duration, err := time.ParseDuration(length)
+	if err != nil {
+		return nil, errors.Wrapf(err, "maintenance window %q has an invalid duration", s)
+	}
+	window.Start = start
+	window.DurationMinutes = int32(duration / time.Minute)
+	return window, nil
+}
+
+// formatWindows writes windows the way parseWindow reads them, or "any time" if there are none.
+func formatWindows(windows []*com.MaintenanceWindow) string {
+	if len(windows) == 0 {
+		return "any time"
+	}
+	formatted := make([]string, 0, len(windows))
+	for _, window := range windows {
+		length := strconv.Itoa(int(window.DurationMinutes)) + "m"
+		if window.DurationMinutes%60 == 0 {
+			length = strconv.Itoa(int(window.DurationMinutes/60)) + "h"
+		}
+		days := ""
+		if len(window.Days) > 0 {
+			days = strings.Join(window.Days, ",") + "@"
+		}
+		formatted = append(formatted, days+window.Start+"+"+length)
+	}
+	return strings.Join(formatted, " ")
+}
+
+// resolveDeviceID finds a device's ID from its ID or name.
+func (c *cli) resolveDeviceID(ctx context.Context, organizationID string, device string) (string, error) {
+	resp, err := c.devices.DescribeDevice(ctx, connect.NewRequest(&com.DescribeDeviceRequest{
+		OrganizationId: organizationID,
+		Device:         device,
+	}))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find device %s", device)
+	}
+	return resp.Msg.Device.Id, nil
+}
+
+func (c *cli) maintenance(ctx context.Context, args []string) error {
+	fs := c.flags("maintenance")
+	fleetRef := fs.String("fleet", "", "fleet name or ID")
+	deviceRef := fs.String("device", "", "device name or ID")
+	timeZone := fs.String("tz", "", "IANA time zone of the windows, such as America/Denver; defaults to UTC, or the fleet's for a device")
+	inherit := fs.Bool("inherit", false, "make the device use its fleet's windows again")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if (*fleetRef == "") == (*deviceRef == "") || (*inherit && (*deviceRef == "" || len(positional) > 0)) {
+		return errors.New("usage: pandoctl maintenance (--fleet FLEET | --device DEVICE) [--tz ZONE] [WINDOW...], or --device DEVICE --inherit")
+	}
+	var windows *com.MaintenanceWindows
+	if !*inherit {
+		windows = &com.MaintenanceWindows{}
+		for _, arg := range positional {
+			window, err := parseWindow(arg)
+			if err != nil {
+				return err
+			}
+			windows.Windows = append(windows.Windows, window)
+		}
+	}
+	if *fleetRef != "" && len(windows.Windows) == 0 {
+		// fleets without windows are stored as having none at all
+		windows = nil
+	}
+
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+	if *fleetRef != "" {
+		fleet, err := c.resolveFleet(ctx, organizationID, *fleetRef)
+		if err != nil {
+			return err
+		}
+		updated, err := c.fleets.SetFleetMaintenance(ctx, connect.NewRequest(&com.SetFleetMaintenanceRequest{
+			OrganizationId:     organizationID,
+			FleetId:            fleet.Id,
+			MaintenanceWindows: windows,
+			TimeZone:           *timeZone,
+		}))
+		if err != nil {
+			return errors.Wrap(err, "failed to set maintenance windows")
+		}
+		return c.print(updated.Msg.Fleet, func(w io.Writer) {
+			fleet := updated.Msg.Fleet
+			row(w, fmt.Sprintf("Devices in fleet %s may restart containers %s (%s)", fleet.Name,
+				formatWindows(fleet.MaintenanceWindows.GetWindows()), orDefault(fleet.TimeZone, "UTC")))
+		})
+	}
+
+	deviceID, err := c.resolveDeviceID(ctx, organizationID, *deviceRef)
+	if err != nil {
+		return err
+	}
+	updated, err := c.fleets.SetDeviceMaintenance(ctx, connect.NewRequest(&com.SetDeviceMaintenanceRequest{
+		OrganizationId:     organizationID,
+		DeviceId:           deviceID,
+		MaintenanceWindows: windows,
+		TimeZone:           *timeZone,
+	}))
+	if err != nil {
+		return errors.Wrap(err, "failed to set maintenance windows")
+	}
+	return c.print(updated.Msg.Device, func(w io.Writer) {
+		device := updated.Msg.Device
+		row(w, fmt.Sprintf("Device %s may restart containers %s (%s)", device.Name,
+			formatDeviceMaintenance(device), orDefault(device.TimeZone, "fleet's time zone")))
+	})
+}
+
+// formatDeviceMaintenance describes the device's own windows, or notes that it uses its fleet's.
+func formatDeviceMaintenance(device *com.Device) string {
+	if device.MaintenanceWindows == nil {
+		return "in its fleet's windows"
+	}
+	return formatWindows(device.MaintenanceWindows.Windows)
+}
+
+func orDefault(s string, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+func (c *cli) applyNow(ctx context.Context, args []string) error {
+	fs := c.flags("apply-now")
+	fleetRef := fs.String("fleet", "", "fleet name or ID")
+	deviceRef := fs.String("device", "", "device name or ID")
+	duration := fs.Duration("for", time.Hour, "how long devices may restart containers without waiting for a maintenance window")
+	cancel := fs.Bool("cancel", false, "make devices wait for their maintenance window again")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if (*fleetRef == "") == (*deviceRef == "") || len(positional) > 0 {
+		return errors.New("usage: pandoctl apply-now (--fleet FLEET | --device DEVICE) [--for DURATION] [--cancel]")
+	}
+
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+	req := &com.ApplyUpdatesNowRequest{
+		OrganizationId:  organizationID,
+		DurationMinutes: int32(*duration / time.Minute),
+		Cancel:          *cancel,
+	}
+	if *fleetRef != "" {
+		fleet, err := c.resolveFleet(ctx, organizationID, *fleetRef)
+		if err != nil {
+			return err
+		}
+		req.FleetId = fleet.Id
+	} else {
+		req.DeviceId, err = c.resolveDeviceID(ctx, organizationID, *deviceRef)
+		if err != nil {
+			return err
+		}
+	}
+	resp, err := c.fleets.ApplyUpdatesNow(ctx, connect.NewRequest(req))
+	if err != nil {
+		return errors.Wrap(err, "failed to apply updates now")
+	}
+	return c.print(resp.Msg, func(w io.Writer) {
+		if *cancel {
+			row(w, fmt.Sprintf("%d devices will wait for their maintenance window", resp.Msg.DeviceCount))
+			return
+		}
+		row(w, fmt.Sprintf("%d devices will apply updates until %s", resp.Msg.DeviceCount, resp.Msg.Until.AsTime().Local().Format(time.DateTime)))
+	})
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/pkg/manifest"
+)
+
+// readInput reads a file, or standard input for "-".
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		return data, errors.Wrap(err, "failed to read standard input")
+	}
+	data, err := os.ReadFile(path)
+	return data, errors.Wrapf(err, "failed to read %s", path)
+}
+
+// printChanges lists manifest changes the way diff(1) marks lines: + created, ~ updated, - deleted.
+func printChanges(w io.Writer, scheduleName string, changes []*com.ManifestChange) {
+	if len(changes) == 0 {
+		fmt.Fprintf(w, "schedule %s is up to date\n", scheduleName)
+		return
+	}
+	markers := map[string]string{
+		manifest.ActionCreate: "+",
+		manifest.ActionUpdate: "~",
+		manifest.ActionDelete: "-",
+	}
+	for _, change := range changes {
+		target := "schedule " + scheduleName
+		if change.Container != "" {
+			target = "container " + change.Container
+		}
+		fmt.Fprintf(w, "%s %s\n", markers[change.Action], target)
+		for _, field := range change.Fields {
+			fmt.Fprintf(w, "    %s: %s -> %s\n", field.Field, field.From, field.To)
+		}
+	}
+}
+
+// applyManifest runs apply, or diff when dryRun is set.
+func (c *cli) applyManifest(ctx context.Context, args []string, dryRun bool) error {
+	name := "apply"
+	if dryRun {
+		name = "diff"
+	}
+	fs := c.flags(name)
+	file := fs.String("f", "", "schedule manifest, as YAML or JSON, or - for standard input (required)")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("-f is required")
+	}
+	data, err := readInput(*file)
+	if err != nil {
+		return err
+	}
+	// parsed here too, to report a broken file before contacting the server
+	m, err := manifest.Parse(data)
+	if err != nil {
+		return err
+	}
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.schedules.ApplyManifest(ctx, connect.NewRequest(&com.ApplyManifestRequest{
+		OrganizationId: organizationID,
+		Manifest:       string(data),
+		DryRun:         dryRun,
+	}))
+	if err != nil {
+		return errors.Wrapf(err, "failed to %s manifest", name)
+	}
+	return c.print(resp.Msg, func(w io.Writer) {
+		printChanges(w, m.Name, resp.Msg.Changes)
+	})
+}
+
+func (c *cli) apply(ctx context.Context, args []string) error {
+	return c.applyManifest(ctx, args, false)
+}
+
+func (c *cli) diff(ctx context.Context, args []string) error {
+	return c.applyManifest(ctx, args, true)
+}
+
+func (c *cli) export(ctx context.Context, args []string) error {
+	fs := c.flags("export")
+	format := fs.String("format", manifest.FormatYAML, "manifest format: yaml or json")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New("usage: pandoctl export [--format yaml|json] SCHEDULE")
+	}
+	organizationID, err := c.organizationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.schedules.ExportSchedule(ctx, connect.NewRequest(&com.ExportScheduleRequest{
+		OrganizationId: organizationID,
+		Schedule:       positional[0],
+		Format:         *format,
+	}))
+	if err != nil {
+		return errors.Wrap(err, "failed to export schedule")
+	}
+	fmt.Print(resp.Msg.Manifest)
+	return nil
+}
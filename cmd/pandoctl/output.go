@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// print writes m as JSON with -o json, and otherwise calls table to write it as a table.
+func (c *cli) print(m proto.Message, table func(w io.Writer)) error {
+	switch c.output {
+	case "json":
+		encoded, err := protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(m)
+		if err != nil {
+			return errors.Wrap(err, "failed to encode output")
+		}
+		fmt.Println(string(encoded))
+		return nil
+	case "table":
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		table(w)
+		return w.Flush()
+	default:
+		return errors.Errorf("unknown output format %q; use table or json", c.output)
+	}
+}
+
+// row writes one tab-separated table row.
+func row(w io.Writer, columns ...string) {
+	fmt.Fprintln(w, strings.Join(columns, "\t"))
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// ago formats a timestamp relative to now, such as "3m ago".
+func ago(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return "never"
+	}
+	elapsed := time.Since(ts.AsTime())
+	if elapsed < time.Second {
+		return "just now"
+	}
+	return elapsed.Truncate(time.Second).String() + " ago"
+}
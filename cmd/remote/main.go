@@ -2,8 +2,8 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,168 +12,72 @@ import (
 
 	"connectrpc.com/connect"
 
-	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
-	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com/comconnect"
 	"github.com/uinta-labs/pando/pkg"
+	"github.com/uinta-labs/pando/pkg/agent"
 )
 
-const (
-	DefaultBaseUrl     = "https://graphene.fluffy-broadnose.ts.net"
-	DockerEngineSocket = "/var/run/balena-engine.sock"
-)
-
-func getEnv(key, fallback string) string {
-	if value, ok := os.LookupEnv(key); ok {
-		return value
-	}
-	return fallback
-}
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-func applySchedule(ctx context.Context, client comconnect.RemoteServiceClient, runner *pkg.Runner, schedule *com.Schedule) error {
+	time.Local = time.UTC
 
-	// prune old containers i.e. containers that are not in the schedule
-	existingContainers, err := runner.ListContainersMatchingLabel(ctx, "io.uinta.pando.managed", "true")
+	cfg, err := pkg.ReadAgentConfig()
 	if err != nil {
-		log.Printf("Error listing containers: %v", err)
-		return err
-	}
-
-	currentlyRunningContainers := map[string]bool{}
-	for _, container := range existingContainers {
-		found := false
-		for _, task := range schedule.Containers {
-			if container.Labels["io.uinta.pando.task-id"] == task.Id {
-				found = true
-				currentlyRunningContainers[task.Id] = true
-				continue
-			}
-		}
-		if !found {
-			log.Printf("Removing container %s", container.ID)
-			err := runner.KillContainer(ctx, container.ID)
-			if err != nil {
-				log.Printf("Error removing container: %v", err)
-			}
-		}
+		log.Panicf("failed to read config: %+v\n", err)
 	}
-
-	log.Printf("Running schedule: %s", schedule.Id)
-
-	for _, task := range schedule.Containers {
-		if currentlyRunningContainers[task.Id] {
-			log.Printf("Task %s already running", task.Id)
-			continue
-		}
-		startImageCtx := context.WithValue(ctx, "task", task)
-		log.Printf("Running task: %s", task.Name)
-		err := runner.PullImage(startImageCtx, task.ContainerImage)
-		if err != nil {
-			log.Printf("Error pulling image: %v", err)
-			continue
-		}
-
-		var containerID string
-		logChannels := pkg.NewLogChannels(ctx)
-		go func() {
-			// for now, leak a goroutine and just log the output
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-logChannels.ChannelClosed:
-					return
-				case line := <-logChannels.Mixed:
-					log.Printf("Container %s: %s", containerID, line)
-				}
-			}
-		}()
-		environmentVariables := []string{}
-		for k, v := range task.Env {
-			environmentVariables = append(environmentVariables, fmt.Sprintf("%s=%s", k, v))
-		}
-		commandLine := []string{}
-		if task.Command != "" {
-			commandLine = append(commandLine, task.Command)
-		}
-
-		labels := map[string]string{
-			"io.uinta.pando.task-id":     task.Id,
-			"io.uinta.pando.task-name":   task.Name,
-			"io.uinta.pando-schedule-id": schedule.Id,
-		}
-
-		containerID, err = runner.RunContainer(startImageCtx, task.ContainerImage, task.Id, commandLine, environmentVariables, labels, &pkg.AdvancedOptions{
-			BindMountDockerSocket: task.BindDockerSocket,
-			//NetworkModeContainer:  "",
-			NetworkModeHost:            task.NetworkMode == com.Container_HOST,
-			DockerEngineSocketOverride: getEnv("DOCKER_HOST", DockerEngineSocket),
-		}, logChannels, false)
-		if err != nil {
-			log.Printf("Error running container: %v", err)
-			continue
-		}
-
-		log.Printf("Container %s(%s) started", task.Id, containerID)
+	if err := pkg.SetupLogging(cfg.LogFormat, cfg.LogLevel); err != nil {
+		log.Panicf("failed to set up logging: %+v\n", err)
 	}
-
-	return nil
-}
-
-func runSchedulerTick(ctx context.Context, client comconnect.RemoteServiceClient, runner *pkg.Runner) {
-	log.Println("Running scheduler")
-	hostname, err := os.Hostname()
+	shutdownTracing, err := pkg.SetupTracing(ctx, "pando-agent", cfg.TracingConfig)
 	if err != nil {
-		log.Printf("Error: %v", err)
+		log.Panicf("failed to set up tracing: %+v\n", err)
 	}
-	schedule, err := client.GetSchedule(ctx, &connect.Request[com.GetScheduleRequest]{
-		Msg: &com.GetScheduleRequest{
-			DeviceId: hostname,
-		},
-	})
+	tracing, err := pkg.TracingInterceptor(false)
 	if err != nil {
-		log.Printf("Error: %v", err)
+		log.Panicf("failed to set up tracing: %+v\n", err)
 	}
 
-	if schedule != nil && schedule.Msg != nil && schedule.Msg.Schedule != nil {
-		err = applySchedule(ctx, client, runner, schedule.Msg.Schedule)
-		if err != nil {
-			log.Printf("Error applying schedule: %v", err)
-			return
-		}
-	} else {
-		log.Println("Received empty schedule")
+	hostname, err := os.Hostname()
+	if err != nil {
+		slog.Error("Failed to read hostname", pkg.Err(err))
 	}
-}
+	ctx = pkg.WithLogAttrs(ctx, slog.String(pkg.LogKeyDeviceID, hostname))
 
-func runScheduler(ctx context.Context, client comconnect.RemoteServiceClient, runner *pkg.Runner) {
-	runSchedulerTick(ctx, client, runner)
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-time.After(15 * time.Second):
-			runSchedulerTick(ctx, client, runner)
-		}
+	dockerClient, err := pkg.NewRunner(cfg.DockerHost)
+	if err != nil {
+		log.Panicf("failed to create runner: %+v\n", err)
 	}
-}
 
-func main() {
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
-	defer stop()
+	slog.InfoContext(ctx, "Starting agent", "api_url", cfg.APIURL, "version", pkg.Version)
 
-	time.Local = time.UTC
-
-	dockerClient := pkg.NewRunner(getEnv("DOCKER_HOST", DockerEngineSocket))
-
-	httpClient := &http.Client{}
-
-	apiURL := getEnv("API_URL", DefaultBaseUrl)
-	log.Printf("API_URL: %s", apiURL)
+	registry := pkg.NewMetricsRegistry()
+	if cfg.MetricsAddress != "" {
+		go pkg.ServeMetrics(ctx, cfg.MetricsAddress, registry)
+	}
+	if cfg.LogLevelAddress != "" {
+		go pkg.ServeLogLevel(ctx, cfg.LogLevelAddress)
+	}
 
-	client := comconnect.NewRemoteServiceClient(httpClient, apiURL, connect.WithClientOptions(connect.WithSendGzip()))
+	a, err := agent.New(cfg, agent.Options{
+		DeviceID:     hostname,
+		Runtime:      dockerClient,
+		HTTPClient:   &http.Client{},
+		Interceptors: []connect.Interceptor{tracing},
+		Registerer:   registry,
+	})
+	if err != nil {
+		log.Panicf("failed to create agent: %+v\n", err)
+	}
+	// Errors are also kept for the local status API
+	slog.SetDefault(slog.New(a.RecordErrors(slog.Default().Handler())))
 
-	go runScheduler(ctx, client, dockerClient)
+	a.Run(ctx)
 
-	<-ctx.Done()
-	log.Println("Shutting down")
+	slog.InfoContext(ctx, "Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("Failed to flush traces", pkg.Err(err))
+	}
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+var errRelayDisabled = connect.NewError(connect.CodeFailedPrecondition, errors.New("the device relay is disabled; set DEVICE_RELAY_ENABLED to enable it"))
+
+// authenticateRelay checks a relay call carries the relay token issued to the device it claims to be. RemoteService
+// is otherwise unauthenticated, and without this anyone could read a device's exec requests or forge its logs.
+func (s *server) authenticateRelay(ctx context.Context, header http.Header, deviceID string) (uuid.UUID, error) {
+	if !s.cfg.DeviceRelayEnabled {
+		return uuid.Nil, errRelayDisabled
+	}
+	deviceUUID, err := s.resolveDevice(ctx, deviceID)
+	if err != nil {
+		return uuid.Nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	token := header.Get(pkg.DeviceTokenHeader)
+	if token == "" {
+		return uuid.Nil, connect.NewError(connect.CodeUnauthenticated, errors.Errorf("missing %s header", pkg.DeviceTokenHeader))
+	}
+	tokenHash, err := s.db.Q.GetDeviceRelayTokenHash(ctx, deviceUUID)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "failed to look up device relay token")
+	}
+	if tokenHash == nil {
+		return uuid.Nil, connect.NewError(connect.CodePermissionDenied, errors.New("no relay token has been issued to this device"))
+	}
+	if subtle.ConstantTimeCompare([]byte(pkg.HashAPIKeyToken(token)), []byte(*tokenHash)) != 1 {
+		return uuid.Nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid device relay token"))
+	}
+	return deviceUUID, nil
+}
+
+func (s *server) PollActions(ctx context.Context, req *connect.Request[com.PollActionsRequest]) (*connect.Response[com.PollActionsResponse], error) {
+	deviceUUID, err := s.authenticateRelay(ctx, req.Header(), req.Msg.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+
+	actions := s.relay.poll(ctx, deviceUUID, actionPollTimeout)
+
+	return &connect.Response[com.PollActionsResponse]{
+		Msg: &com.PollActionsResponse{Actions: actions},
+	}, nil
+}
+
+func (s *server) ReportActionResult(ctx context.Context, req *connect.Request[com.ReportActionResultRequest]) (*connect.Response[com.ReportActionResultResponse], error) {
+	deviceUUID, err := s.authenticateRelay(ctx, req.Header(), req.Msg.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.relay.complete(deviceUUID, req.Msg) {
+		slog.InfoContext(ctx, "Nobody is waiting for action result any more", "action_id", req.Msg.GetActionId())
+	}
+
+	return &connect.Response[com.ReportActionResultResponse]{
+		Msg: &com.ReportActionResultResponse{},
+	}, nil
+}
+
+func (s *server) PushLogs(ctx context.Context, req *connect.Request[com.PushLogsRequest]) (*connect.Response[com.PushLogsResponse], error) {
+	deviceUUID, err := s.authenticateRelay(ctx, req.Header(), req.Msg.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+
+	s.relay.appendLogs(deviceUUID, req.Msg.GetLines())
+
+	return &connect.Response[com.PushLogsResponse]{
+		Msg: &com.PushLogsResponse{},
+	}, nil
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"log/slog"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/parrotmac/goutil"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/models"
+	"github.com/uinta-labs/pando/pkg"
+	"github.com/uinta-labs/pando/pkg/db"
+)
+
+const (
+	auditActorUser   = "user"
+	auditActorAPIKey = "api_key"
+	auditActorDevice = "device"
+
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 500
+
+	auditPurgeInterval = time.Hour
+)
+
+// auditServer implements AuditService, which lists an organization's audit log.
+type auditServer struct {
+	db   *db.DB
+	auth *pkg.Authenticator
+}
+
+type auditActor struct {
+	kind string
+	id   uuid.UUID
+	name string
+}
+
+func actorFromPrincipal(principal *pkg.Principal) auditActor {
+	if principal.IsAPIKey() {
+		return auditActor{kind: auditActorAPIKey, id: principal.APIKeyID, name: principal.APIKeyName}
+	}
+	return auditActor{kind: auditActorUser, id: principal.UserID, name: principal.Email}
+}
+
+// auditJSON encodes a snapshot of an audited target; nil, including a typed nil, is stored as NULL.
+func auditJSON(message proto.Message) ([]byte, error) {
+	if message == nil || !message.ProtoReflect().IsValid() {
+		return nil, nil
+	}
+	encoded, err := protojson.Marshal(message)
+	return encoded, errors.Wrap(err, "failed to encode audit snapshot")
+}
+
+// recordAudit appends an entry to the audit log. action is "<target type>.<verb>", and before and after are the
+// target's state around the change. Pass the transaction making the change, so the entry is only kept with it.
+func recordAudit(ctx context.Context, q models.Querier, actor auditActor, organizationID uuid.UUID, action string, targetID string, before proto.Message, after proto.Message) error {
+	targetType, _, _ := strings.Cut(action, ".")
+	beforeJSON, err := auditJSON(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := auditJSON(after)
+	if err != nil {
+		return err
+	}
+	_, err = q.InsertAuditLog(ctx, models.InsertAuditLogParams{
+		OrganizationID: organizationID,
+		ActorType:      &actor.kind,
+		ActorID:        actor.id,
+		ActorName:      &actor.name,
+		Action:         &action,
+		TargetType:     &targetType,
+		TargetID:       &targetID,
+		Before:         beforeJSON,
+		After:          afterJSON,
+	})
+	return errors.Wrapf(err, "failed to record %s in audit log", action)
+}
+
+// audit is recordAudit on behalf of the authenticated caller.
+func audit(ctx context.Context, q models.Querier, organizationID uuid.UUID, action string, targetID string, before proto.Message, after proto.Message) error {
+	principal, ok := pkg.PrincipalFromContext(ctx)
+	if !ok {
+		return errors.New("cannot audit an unauthenticated request")
+	}
+	return recordAudit(ctx, q, actorFromPrincipal(principal), organizationID, action, targetID, before, after)
+}
+
+// auditDevice is recordAudit on behalf of a device's agent.
+func auditDevice(ctx context.Context, q models.Querier, deviceID uuid.UUID, action string, after proto.Message) error {
+	device, err := q.GetDeviceOrganization(ctx, deviceID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get device organization")
+	}
+	actor := auditActor{kind: auditActorDevice, id: deviceID, name: goutil.UnwrapOr(device.Name, "")}
+	return recordAudit(ctx, q, actor, device.OrganizationID, action, deviceID.String(), nil, after)
+}
+
+// purgeAuditLog deletes audit entries older than retention every auditPurgeInterval, until ctx is done.
+func purgeAuditLog(ctx context.Context, q models.Querier, retention time.Duration) {
+	ticker := time.NewTicker(auditPurgeInterval)
+	defer ticker.Stop()
+	for {
+		// created_at is written from the server's UTC clock
+		cutoff := time.Now().UTC().Add(-retention)
+		tag, err := q.DeleteAuditLogBefore(ctx, &cutoff)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to purge audit log", pkg.Err(err))
+		} else if tag.RowsAffected() > 0 {
+			slog.InfoContext(ctx, "Purged audit log", "entries", tag.RowsAffected(), "before", cutoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func auditEntryFromRow(row models.ListAuditLogRow) *com.AuditEntry {
+	entry := &com.AuditEntry{
+		Id:             row.ID.String(),
+		OrganizationId: row.OrganizationID.String(),
+		ActorType:      goutil.UnwrapOr(row.ActorType, ""),
+		ActorId:        row.ActorID.String(),
+		ActorName:      goutil.UnwrapOr(row.ActorName, ""),
+		Action:         goutil.UnwrapOr(row.Action, ""),
+		TargetType:     goutil.UnwrapOr(row.TargetType, ""),
+		TargetId:       goutil.UnwrapOr(row.TargetID, ""),
+		Before:         string(row.Before),
+		After:          string(row.After),
+	}
+	if row.CreatedAt != nil {
+		entry.CreatedAt = timestamppb.New(*row.CreatedAt)
+	}
+	return entry
+}
+
+// Audit page tokens are the creation time and ID of the last entry on the previous page.
+func encodeAuditPageToken(row models.ListAuditLogRow) string {
+	createdAt := goutil.UnwrapOr(row.CreatedAt, time.Time{})
+	return base64.RawURLEncoding.EncodeToString([]byte(row.ID.String() + createdAt.Format(time.RFC3339Nano)))
+}
+
+func decodeAuditPageToken(token string) (*time.Time, uuid.UUID, error) {
+	if token == "" {
+		return nil, uuid.Nil, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(decoded) < 36 {
+		return nil, uuid.Nil, invalidArgument(errors.New("invalid page token"))
+	}
+	beforeID, err := uuid.Parse(string(decoded[:36]))
+	if err != nil {
+		return nil, uuid.Nil, invalidArgument(errors.New("invalid page token"))
+	}
+	beforeCreatedAt, err := time.Parse(time.RFC3339Nano, string(decoded[36:]))
+	if err != nil {
+		return nil, uuid.Nil, invalidArgument(errors.New("invalid page token"))
+	}
+	return &beforeCreatedAt, beforeID, nil
+}
+
+func (s *auditServer) ListAuditEntries(ctx context.Context, req *connect.Request[com.ListAuditEntriesRequest]) (*connect.Response[com.ListAuditEntriesResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	actorID := uuid.Nil
+	if req.Msg.GetActorId() != "" {
+		if actorID, err = parseID("actor", req.Msg.GetActorId()); err != nil {
+			return nil, err
+		}
+	}
+	var since, until *time.Time
+	if req.Msg.GetSince() != nil {
+		since = goutil.Ptr(req.Msg.GetSince().AsTime().UTC())
+	}
+	if req.Msg.GetUntil() != nil {
+		until = goutil.Ptr(req.Msg.GetUntil().AsTime().UTC())
+	}
+
+	pageSize := int(req.Msg.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultAuditPageSize
+	}
+	if pageSize > maxAuditPageSize {
+		pageSize = maxAuditPageSize
+	}
+	beforeCreatedAt, beforeID, err := decodeAuditPageToken(req.Msg.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	action := req.Msg.GetAction()
+	targetType := req.Msg.GetTargetType()
+	targetID := req.Msg.GetTargetId()
+	// fetch one extra row to learn whether there is another page
+	limit := pageSize + 1
+	rows, err := s.db.Q.ListAuditLog(ctx, models.ListAuditLogParams{
+		OrganizationID:  organizationID,
+		ActorID:         actorID,
+		ActionPrefix:    &action,
+		TargetType:      &targetType,
+		TargetID:        &targetID,
+		Since:           since,
+		Until:           until,
+		BeforeCreatedAt: beforeCreatedAt,
+		BeforeID:        beforeID,
+		PageSize:        &limit,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list audit log")
+	}
+
+	resp := &com.ListAuditEntriesResponse{}
+	if len(rows) > pageSize {
+		rows = rows[:pageSize]
+		resp.NextPageToken = encodeAuditPageToken(rows[len(rows)-1])
+	}
+	resp.Entries = make([]*com.AuditEntry, 0, len(rows))
+	for _, row := range rows {
+		resp.Entries = append(resp.Entries, auditEntryFromRow(row))
+	}
+
+	return &connect.Response[com.ListAuditEntriesResponse]{
+		Msg: resp,
+	}, nil
+}
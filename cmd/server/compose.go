@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/models"
+	"github.com/uinta-labs/pando/pkg"
+	"github.com/uinta-labs/pando/pkg/compose"
+)
+
+func (s *scheduleServer) ImportCompose(ctx context.Context, req *connect.Request[com.ImportComposeRequest]) (*connect.Response[com.ImportComposeResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	fleetID := uuid.Nil
+	if req.Msg.GetFleetId() != "" {
+		if fleetID, err = parseID("fleet", req.Msg.GetFleetId()); err != nil {
+			return nil, err
+		}
+		if err := s.auth.RequirePermission(ctx, pkg.PermissionFleetsDeploy); err != nil {
+			return nil, err
+		}
+	}
+	conversion, err := compose.ToSchedule([]byte(req.Msg.GetCompose()), strings.TrimSpace(req.Msg.GetName()))
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	if err := pkg.ValidateSchedule(conversion.Schedule); err != nil {
+		return nil, validationError(err)
+	}
+
+	resp := &com.ImportComposeResponse{Unsupported: conversion.Unsupported}
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		var fleet fleetRow
+		if fleetID != uuid.Nil {
+			if fleet, err = loadFleet(ctx, q, fleetID, organizationID); err != nil {
+				return err
+			}
+		}
+		resp.Schedule, resp.Changes, err = applySchedule(ctx, q, organizationID, conversion.Schedule, req.Msg.GetDryRun())
+		if err != nil || fleetID == uuid.Nil || req.Msg.GetDryRun() {
+			return err
+		}
+		// redeploying the same file leaves the fleet's schedule history alone
+		if fleet.DefaultScheduleID.String() == resp.Schedule.Id {
+			return nil
+		}
+		if err := setDefaultSchedule(ctx, q, fleetID, organizationID, uuid.MustParse(resp.Schedule.Id)); err != nil {
+			return err
+		}
+		updated, err := loadFleet(ctx, q, fleetID, organizationID)
+		if err != nil {
+			return err
+		}
+		return audit(ctx, q, organizationID, "fleet.set_default_schedule", fleetID.String(), fleetFromRow(fleet), fleetFromRow(updated))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !req.Msg.GetDryRun() {
+		slog.InfoContext(ctx, "Imported compose file", pkg.LogKeyScheduleID, resp.Schedule.Id, "name", resp.Schedule.Name, "changes", len(resp.Changes))
+	}
+
+	return &connect.Response[com.ImportComposeResponse]{
+		Msg: resp,
+	}, nil
+}
+
+func (s *scheduleServer) ExportCompose(ctx context.Context, req *connect.Request[com.ExportComposeRequest]) (*connect.Response[com.ExportComposeResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := findSchedule(ctx, s.db.Q, organizationID, req.Msg.GetSchedule())
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := compose.FromSchedule(schedule)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to export schedule")
+	}
+
+	return &connect.Response[com.ExportComposeResponse]{
+		Msg: &com.ExportComposeResponse{Compose: string(encoded)},
+	}, nil
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parrotmac/goutil"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/models"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+// Every query selecting c.* yields a row type with the same fields, so they all convert to this one.
+type containerRow = models.GetContainersForScheduleRow
+
+// Likewise for s.*
+type scheduleRow = models.GetScheduleForOrganizationRow
+
+func containerFromRow(component containerRow) (*com.Container, error) {
+	env := make(map[string]string, len(component.Env))
+	err := json.Unmarshal(component.Env, &env)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal env")
+	}
+
+	var networkMode com.Container_NetworkMode
+	if component.NetworkMode != nil {
+		switch *component.NetworkMode {
+		case "host":
+			networkMode = com.Container_HOST
+		case "bridge":
+			networkMode = com.Container_BRIDGE
+		case "none":
+			networkMode = com.Container_NONE
+		// TODO: Support 'container' network mode (join network of another container)
+		// Also have a more clear default/zero value
+		default:
+			networkMode = com.Container_BRIDGE
+		}
+	}
+
+	ports := make([]*com.Container_Port, 0, len(component.Ports))
+	err = json.Unmarshal(component.Ports, &ports)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal ports")
+	}
+
+	var job *com.Job
+	if len(component.Job) > 0 {
+		job = &com.Job{}
+		if err := protojson.Unmarshal(component.Job, job); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal job")
+		}
+	}
+
+	return &com.Container{
+		Id:               component.ID.String(),
+		Name:             goutil.UnwrapOr(component.Name, ""),
+		ContainerImage:   goutil.UnwrapOr(component.ContainerImage, ""),
+		Env:              env,
+		Privileged:       component.Privileged,
+		NetworkMode:      networkMode,
+		Ports:            ports,
+		BindDev:          component.BindDev,
+		BindProc:         component.BindProc,
+		BindSys:          component.BindSys,
+		BindShm:          component.BindShm,
+		BindCgroup:       component.BindCgroup,
+		BindDockerSocket: component.BindDockerSocket,
+		BindBoot:         component.BindBoot,
+		Command:          goutil.UnwrapOr(component.Command, ""),
+		Entrypoint:       goutil.UnwrapOr(component.Entrypoint, ""),
+
+		StopSignal:             goutil.UnwrapOr(component.StopSignal, ""),
+		StopGracePeriodSeconds: component.StopGracePeriodSeconds,
+		UpdateStrategy:         goutil.UnwrapOr(component.UpdateStrategy, ""),
+		Job:                    job,
+	}, nil
+}
+
+func networkModeString(mode com.Container_NetworkMode) string {
+	switch mode {
+	case com.Container_HOST:
+		return "host"
+	case com.Container_NONE:
+		return "none"
+	default:
+		return "bridge"
+	}
+}
+
+// containerColumns encodes a container's fields the way they are stored, for InsertContainer and UpdateContainer.
+func containerColumns(container *com.Container) (models.UpdateContainerParams, error) {
+	env := container.Env
+	if env == nil {
+		env = map[string]string{}
+	}
+	encodedEnv, err := json.Marshal(env)
+	if err != nil {
+		return models.UpdateContainerParams{}, errors.Wrap(err, "failed to marshal env")
+	}
+	ports := container.Ports
+	if ports == nil {
+		ports = []*com.Container_Port{}
+	}
+	encodedPorts, err := json.Marshal(ports)
+	if err != nil {
+		return models.UpdateContainerParams{}, errors.Wrap(err, "failed to marshal ports")
+	}
+	var encodedJob []byte
+	if container.Job != nil {
+		encodedJob, err = protojson.Marshal(container.Job)
+		if err != nil {
+			return models.UpdateContainerParams{}, errors.Wrap(err, "failed to marshal job")
+		}
+	}
+
+	return models.UpdateContainerParams{
+		Name:                   &container.Name,
+		ContainerImage:         &container.ContainerImage,
+		Env:                    encodedEnv,
+		Privileged:             container.Privileged,
+		NetworkMode:            goutil.Ptr(networkModeString(container.NetworkMode)),
+		Ports:                  encodedPorts,
+		BindDev:                container.BindDev,
+		BindProc:               container.BindProc,
+		BindSys:                container.BindSys,
+		BindShm:                container.BindShm,
+		BindCgroup:             container.BindCgroup,
+		BindDockerSocket:       container.BindDockerSocket,
+		BindBoot:               container.BindBoot,
+		Command:                &container.Command,
+		Entrypoint:             &container.Entrypoint,
+		StopSignal:             &container.StopSignal,
+		StopGracePeriodSeconds: container.StopGracePeriodSeconds,
+		UpdateStrategy:         &container.UpdateStrategy,
+		Job:                    encodedJob,
+	}, nil
+}
+
+func scheduleFromRow(row scheduleRow, containers []*com.Container) *com.Schedule {
+	schedule := &com.Schedule{
+		Id:         row.ID.String(),
+		Name:       goutil.UnwrapOr(row.Name, ""),
+		State:      goutil.UnwrapOr(row.State, ""),
+		Containers: containers,
+	}
+	if row.OrganizationID != uuid.Nil {
+		schedule.OrganizationId = row.OrganizationID.String()
+	}
+	if row.CreatedAt != nil {
+		schedule.CreatedAt = timestamppb.New(*row.CreatedAt)
+	}
+	if row.UpdatedAt != nil {
+		schedule.UpdatedAt = timestamppb.New(*row.UpdatedAt)
+	}
+	return schedule
+}
+
+// Every query selecting f.* with its device count yields a row type with the same fields
+type fleetRow = models.GetFleetForOrganizationRow
+
+// Likewise for d.*
+type deviceRow = models.GetDeviceForOrganizationRow
+
+func fleetFromRow(row fleetRow) *com.Fleet {
+	fleet := &com.Fleet{
+		Id:             row.ID.String(),
+		OrganizationId: row.OrganizationID.String(),
+		Name:           goutil.UnwrapOr(row.Name, ""),
+		DeviceCount:    int64(goutil.UnwrapOr(row.DeviceCount, 0)),
+		AgentImage:     goutil.UnwrapOr(row.AgentImage, ""),
+		TimeZone:       goutil.UnwrapOr(row.TimeZone, ""),
+	}
+	fleet.MaintenanceWindows = maintenanceWindowsFromColumn(row.MaintenanceWindows)
+	if row.DefaultScheduleID != uuid.Nil {
+		fleet.DefaultScheduleId = row.DefaultScheduleID.String()
+	}
+	if row.CreatedAt != nil {
+		fleet.CreatedAt = timestamppb.New(*row.CreatedAt)
+	}
+	if row.UpdatedAt != nil {
+		fleet.UpdatedAt = timestamppb.New(*row.UpdatedAt)
+	}
+	return fleet
+}
+
+func deviceFromRow(row deviceRow, cfg pkg.Config, now time.Time) *com.Device {
+	device := &com.Device{
+		Id:            row.ID.String(),
+		FleetId:       row.FleetID.String(),
+		Name:          goutil.UnwrapOr(row.Name, ""),
+		AgentVersion:  goutil.UnwrapOr(row.AgentVersion, ""),
+		RemoteAddress: goutil.UnwrapOr(row.RemoteAddress, ""),
+		Status:        cfg.DeviceStatus(row.LastSeenAt, now),
+		TimeZone:      goutil.UnwrapOr(row.TimeZone, ""),
+	}
+	device.MaintenanceWindows = maintenanceWindowsFromColumn(row.MaintenanceWindows)
+	if row.CreatedAt != nil {
+		device.CreatedAt = timestamppb.New(*row.CreatedAt)
+	}
+	if row.UpdatedAt != nil {
+		device.UpdatedAt = timestamppb.New(*row.UpdatedAt)
+	}
+	if row.LastSeenAt != nil {
+		device.LastSeenAt = timestamppb.New(*row.LastSeenAt)
+	}
+	if row.ApplyNowUntil != nil && row.ApplyNowUntil.After(now) {
+		device.ApplyNowUntil = timestamppb.New(*row.ApplyNowUntil)
+	}
+	return device
+}
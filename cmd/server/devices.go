@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/models"
+	"github.com/uinta-labs/pando/pkg"
+	"github.com/uinta-labs/pando/pkg/db"
+)
+
+const (
+	defaultLogTail        = 100
+	defaultExecTimeout    = 30 * time.Second
+	maxExecTimeout        = 300 * time.Second
+	execDeliveryAllowance = 10 * time.Second
+)
+
+// deviceServer implements DeviceService: what a device should be running versus what it says it runs, and
+// remote access to its containers through the relay.
+type deviceServer struct {
+	cfg   pkg.Config
+	db    *db.DB
+	auth  *pkg.Authenticator
+	relay *relay
+}
+
+// withWriteDeadline lets calls to handler run longer than the HTTP server's write timeout.
+func withWriteDeadline(handler http.Handler, timeout time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout))
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// resolveOrganizationDevice looks a device up by ID or name, within the organization.
+func resolveOrganizationDevice(ctx context.Context, q models.Querier, organizationID uuid.UUID, device string) (deviceRow, error) {
+	deviceID, err := uuid.Parse(device)
+	if err != nil {
+		byName, err := q.GetDeviceByName(ctx, &device)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return deviceRow{}, connect.NewError(connect.CodeNotFound, errors.Errorf("device %s not found", device))
+			}
+			return deviceRow{}, errors.Wrap(err, "failed to look up device")
+		}
+		deviceID = byName.ID
+	}
+	return loadDevice(ctx, q, deviceID, organizationID)
+}
+
+func (s *deviceServer) DescribeDevice(ctx context.Context, req *connect.Request[com.DescribeDeviceRequest]) (*connect.Response[com.DescribeDeviceResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	device, err := resolveOrganizationDevice(ctx, s.db.Q, organizationID, req.Msg.GetDevice())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &com.DescribeDeviceResponse{
+		Device: deviceFromRow(device, s.cfg, time.Now().UTC()),
+	}
+
+	schedule, err := s.db.Q.GetCurrentScheduleForDevice(ctx, device.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get schedule")
+	}
+	if schedule.ID != uuid.Nil {
+		rows, err := s.db.Q.GetContainersForSchedule(ctx, schedule.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get schedule containers")
+		}
+		containers := make([]*com.Container, 0, len(rows))
+		for _, row := range rows {
+			container, err := containerFromRow(row)
+			if err != nil {
+				return nil, err
+			}
+			containers = append(containers, container)
+		}
+		resp.DesiredSchedule = scheduleFromRow(scheduleRow(schedule), containers)
+	}
+
+	if len(device.ReportedState) > 0 {
+		reported := &com.ReportScheduleStateRequest{}
+		if err := protojson.Unmarshal(device.ReportedState, reported); err != nil {
+			return nil, errors.Wrap(err, "failed to decode reported state")
+		}
+		resp.ReportedContainers = reported.ContainerStates
+		resp.PendingUpdate = reported.PendingUpdate
+	}
+	if device.ReportedAt != nil {
+		resp.ReportedAt = timestamppb.New(*device.ReportedAt)
+	}
+
+	return &connect.Response[com.DescribeDeviceResponse]{
+		Msg: resp,
+	}, nil
+}
+
+func (s *deviceServer) GetContainerLogs(ctx context.Context, req *connect.Request[com.GetContainerLogsRequest]) (*connect.Response[com.GetContainerLogsResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	if !s.cfg.DeviceRelayEnabled {
+		return nil, errRelayDisabled
+	}
+	device, err := resolveOrganizationDevice(ctx, s.db.Q, organizationID, req.Msg.GetDevice())
+	if err != nil {
+		return nil, err
+	}
+
+	tail := int(req.Msg.GetTail())
+	if tail <= 0 {
+		tail = defaultLogTail
+	}
+	entries, nextSequence := s.relay.logs(device.ID, req.Msg.GetContainer(), req.Msg.GetAfterSequence(), tail)
+
+	return &connect.Response[com.GetContainerLogsResponse]{
+		Msg: &com.GetContainerLogsResponse{
+			Entries:      entries,
+			NextSequence: nextSequence,
+		},
+	}, nil
+}
+
+func (s *deviceServer) ExecInContainer(ctx context.Context, req *connect.Request[com.ExecInContainerRequest]) (*connect.Response[com.ExecInContainerResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	if !s.cfg.DeviceRelayEnabled {
+		return nil, errRelayDisabled
+	}
+	if req.Msg.GetContainer() == "" {
+		return nil, invalidArgument(errors.New("container is required"))
+	}
+	if len(req.Msg.GetCommand()) == 0 {
+		return nil, invalidArgument(errors.New("command is required"))
+	}
+	timeout := time.Duration(req.Msg.GetTimeoutSeconds()) * time.Second
+	if timeout <= 0 {
+		timeout = defaultExecTimeout
+	}
+	if timeout > maxExecTimeout {
+		return nil, invalidArgument(errors.Errorf("timeout_seconds must be at most %d", int(maxExecTimeout.Seconds())))
+	}
+
+	device, err := resolveOrganizationDevice(ctx, s.db.Q, organizationID, req.Msg.GetDevice())
+	if err != nil {
+		return nil, err
+	}
+	// an action queued for an offline device would only time out
+	if status := s.cfg.DeviceStatus(device.LastSeenAt, time.Now().UTC()); status != pkg.DeviceOnline {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.Errorf("device is %s", status))
+	}
+
+	action := &com.DeviceAction{
+		Id: uuid.NewString(),
+		Action: &com.DeviceAction_Exec{
+			Exec: &com.ExecAction{
+				Container:      req.Msg.GetContainer(),
+				Command:        req.Msg.GetCommand(),
+				TimeoutSeconds: int32(timeout.Seconds()),
+			},
+		},
+	}
+	// recorded before running, so the entry exists whatever the outcome
+	if err := audit(ctx, s.db.Q, organizationID, "device.exec", device.ID.String(), nil, action); err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Queued exec in container", "action_id", action.Id, pkg.LogKeyDeviceID, device.ID, "container", req.Msg.GetContainer(), "command", req.Msg.GetCommand())
+	results := s.relay.enqueue(device.ID, action)
+
+	timer := time.NewTimer(timeout + execDeliveryAllowance)
+	defer timer.Stop()
+	select {
+	case result := <-results:
+		return &connect.Response[com.ExecInContainerResponse]{
+			Msg: &com.ExecInContainerResponse{Result: result.GetExec()},
+		}, nil
+	case <-timer.C:
+		s.relay.cancel(device.ID, action.Id)
+		return nil, connect.NewError(connect.CodeDeadlineExceeded, errors.New("device did not report a result in time"))
+	case <-ctx.Done():
+		s.relay.cancel(device.ID, action.Id)
+		return nil, ctx.Err()
+	}
+}
+
+// IssueDeviceRelayToken returns a new relay token for the device, which its agent presents to poll for actions and
+// push logs. The previous token, if any, stops working.
+func (s *deviceServer) IssueDeviceRelayToken(ctx context.Context, req *connect.Request[com.IssueDeviceRelayTokenRequest]) (*connect.Response[com.IssueDeviceRelayTokenResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := pkg.NewDeviceRelayToken()
+	if err != nil {
+		return nil, err
+	}
+	var deviceID uuid.UUID
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		device, err := resolveOrganizationDevice(ctx, q, organizationID, req.Msg.GetDevice())
+		if err != nil {
+			return err
+		}
+		deviceID = device.ID
+		if _, err := q.SetDeviceRelayTokenHash(ctx, &tokenHash, device.ID); err != nil {
+			return errors.Wrap(err, "failed to set device relay token")
+		}
+		return audit(ctx, q, organizationID, "device.issue_relay_token", device.ID.String(), nil, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Issued device relay token", pkg.LogKeyDeviceID, deviceID)
+
+	return &connect.Response[com.IssueDeviceRelayTokenResponse]{
+		Msg: &com.IssueDeviceRelayTokenResponse{Token: token},
+	}, nil
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parrotmac/goutil"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/models"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+// Events sent to webhooks
+const (
+	eventDeviceOnline         = "device.online"
+	eventDeviceOffline        = "device.offline"
+	eventContainerCrashed     = "container.crashed"
+	eventContainerUnhealthy   = "container.unhealthy"
+	eventScheduleApplied      = "schedule.applied"
+	eventFleetRolloutFinished = "fleet.rollout_finished"
+)
+
+const (
+	offlineSweepInterval = 30 * time.Second
+	// device.connectivity, set by heartbeats and cleared by sweepOfflineDevices
+	connectivityOnline = "online"
+
+	// The statuses agents give incidents
+	incidentStatusExited    = "exited"
+	incidentStatusUnhealthy = "unhealthy"
+	// A crash-looping task is reported once per restart; more than this per report are dropped, oldest first
+	maxIncidentsPerStateReport = 20
+)
+
+var eventTypes = []string{
+	eventDeviceOnline,
+	eventDeviceOffline,
+	eventContainerCrashed,
+	eventContainerUnhealthy,
+	eventScheduleApplied,
+	eventFleetRolloutFinished,
+}
+
+// webhookEvent is the JSON body of every webhook delivery; Data depends on Type.
+type webhookEvent struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	OrganizationID string    `json:"organizationId"`
+	CreatedAt      time.Time `json:"createdAt"`
+	Data           any       `json:"data"`
+}
+
+type eventDevice struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	FleetID string `json:"fleetId"`
+}
+
+// device.online and device.offline
+type deviceEventData struct {
+	Device eventDevice `json:"device"`
+}
+
+// container.crashed and container.unhealthy
+type containerEventData struct {
+	Device     eventDevice `json:"device"`
+	TaskID     string      `json:"taskId"`
+	TaskName   string      `json:"taskName"`
+	ScheduleID string      `json:"scheduleId"`
+	Reason     string      `json:"reason"`
+}
+
+type scheduleAppliedData struct {
+	Device     eventDevice `json:"device"`
+	ScheduleID string      `json:"scheduleId"`
+}
+
+// Every device in the fleet seen recently runs the fleet's default schedule
+type rolloutFinishedData struct {
+	FleetID    string `json:"fleetId"`
+	ScheduleID string `json:"scheduleId"`
+}
+
+func newEventDevice(id uuid.UUID, name *string, fleetID uuid.UUID) eventDevice {
+	return eventDevice{ID: id.String(), Name: goutil.UnwrapOr(name, ""), FleetID: fleetID.String()}
+}
+
+// publishEvent queues a delivery of the event to each of the organization's enabled webhooks subscribed to it.
+// Pass the transaction making the change, so the event is only sent if it commits.
+func publishEvent(ctx context.Context, q models.Querier, organizationID uuid.UUID, eventType string, data any) error {
+	eventID := uuid.New()
+	event := webhookEvent{
+		ID:             eventID.String(),
+		Type:           eventType,
+		OrganizationID: organizationID.String(),
+		CreatedAt:      time.Now().UTC(),
+		Data:           data,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode %s event", eventType)
+	}
+	_, err = q.EnqueueWebhookDeliveries(ctx, models.EnqueueWebhookDeliveriesParams{
+		EventID:        eventID,
+		EventType:      &eventType,
+		Payload:        payload,
+		OrganizationID: organizationID,
+	})
+	return errors.Wrapf(err, "failed to queue %s event", eventType)
+}
+
+// sweepOfflineDevices marks devices whose agents stopped calling as offline, publishing device.offline for each,
+// every offlineSweepInterval until ctx is done. Heartbeats mark them online again.
+func (s *server) sweepOfflineDevices(ctx context.Context) {
+	ticker := time.NewTicker(offlineSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		offlineBefore := time.Now().UTC().Add(-s.cfg.DeviceOfflineThreshold)
+		err := s.db.InTx(ctx, func(q models.Querier) error {
+			rows, err := q.MarkDevicesOffline(ctx, &offlineBefore)
+			if err != nil {
+				return errors.Wrap(err, "failed to mark devices offline")
+			}
+			for _, row := range rows {
+				data := deviceEventData{Device: newEventDevice(row.ID, row.Name, row.FleetID)}
+				if err := publishEvent(ctx, q, row.OrganizationID, eventDeviceOffline, data); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to sweep offline devices", pkg.Err(err))
+		}
+	}
+}
+
+// reportedSchedule is the schedule every reported container belongs to, or "" if they don't all share one.
+func reportedSchedule(states []*com.ContainerState) string {
+	scheduleID := ""
+	for _, state := range states {
+		if state.GetScheduleId() == "" || (scheduleID != "" && state.GetScheduleId() != scheduleID) {
+			return ""
+		}
+		scheduleID = state.GetScheduleId()
+	}
+	return scheduleID
+}
+
+// publishStateEvents publishes the events an agent's state report reveals, comparing it with the device's
+// previous report: the incidents it carries, and whether the device has just finished applying a schedule,
+// which may in turn finish its fleet's rollout.
+func (s *server) publishStateEvents(ctx context.Context, q models.Querier, device models.RecordDeviceReportedStateRow, deviceID uuid.UUID, report *com.ReportScheduleStateRequest) error {
+	eventDevice := newEventDevice(deviceID, device.Name, device.FleetID)
+
+	incidents := report.GetIncidents()
+	if len(incidents) > maxIncidentsPerStateReport {
+		incidents = incidents[len(incidents)-maxIncidentsPerStateReport:]
+	}
+	for _, incident := range incidents {
+		eventType := eventContainerCrashed
+		if incident.GetStatus() == incidentStatusUnhealthy {
+			eventType = eventContainerUnhealthy
+		} else if incident.GetStatus() != incidentStatusExited {
+			continue
+		}
+		data := containerEventData{
+			Device:     eventDevice,
+			TaskID:     incident.GetId(),
+			TaskName:   incident.GetName(),
+			ScheduleID: incident.GetScheduleId(),
+			Reason:     incident.GetError(),
+		}
+		if err := publishEvent(ctx, q, device.OrganizationID, eventType, data); err != nil {
+			return err
+		}
+	}
+
+	previous := &com.ReportScheduleStateRequest{}
+	if len(device.PreviousReportedState) > 0 {
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(device.PreviousReportedState, previous); err != nil {
+			// an unreadable previous report only means this one is compared with nothing
+			slog.WarnContext(ctx, "Failed to decode previous reported state", pkg.LogKeyDeviceID, deviceID, pkg.Err(err))
+		}
+	}
+	scheduleID := reportedSchedule(report.GetContainerStates())
+	if scheduleID == "" || scheduleID == reportedSchedule(previous.GetContainerStates()) {
+		return nil
+	}
+	data := scheduleAppliedData{Device: eventDevice, ScheduleID: scheduleID}
+	if err := publishEvent(ctx, q, device.OrganizationID, eventScheduleApplied, data); err != nil {
+		return err
+	}
+
+	fleet, err := loadFleet(ctx, q, device.FleetID, device.OrganizationID)
+	if err != nil {
+		return err
+	}
+	if fleet.DefaultScheduleID.String() != scheduleID {
+		return nil
+	}
+	// devices that have gone offline don't hold up the rollout
+	seenAfter := time.Now().UTC().Add(-s.cfg.DeviceOfflineThreshold)
+	behind, err := q.CountFleetDevicesBehindSchedule(ctx, models.CountFleetDevicesBehindScheduleParams{
+		FleetID:    device.FleetID,
+		SeenAfter:  &seenAfter,
+		ScheduleID: &scheduleID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to count devices behind schedule")
+	}
+	if goutil.UnwrapOr(behind, 0) > 0 {
+		return nil
+	}
+	return publishEvent(ctx, q, device.OrganizationID, eventFleetRolloutFinished, rolloutFinishedData{
+		FleetID:    device.FleetID.String(),
+		ScheduleID: scheduleID,
+	})
+}
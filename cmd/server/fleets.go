@@ -0,0 +1,575 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"log/slog"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/distribution/reference"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/parrotmac/goutil"
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/models"
+	"github.com/uinta-labs/pando/pkg"
+	"github.com/uinta-labs/pando/pkg/db"
+)
+
+const (
+	defaultDevicePageSize = 50
+	maxDevicePageSize     = 500
+)
+
+// fleetServer implements FleetService, which manages an organization's fleets and the devices in them.
+type fleetServer struct {
+	cfg  pkg.Config
+	db   *db.DB
+	auth *pkg.Authenticator
+}
+
+func loadFleet(ctx context.Context, q models.Querier, fleetID uuid.UUID, organizationID uuid.UUID) (fleetRow, error) {
+	row, err := q.GetFleetForOrganization(ctx, fleetID, organizationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return row, connect.NewError(connect.CodeNotFound, errors.Errorf("fleet %s not found", fleetID))
+		}
+		return row, errors.Wrap(err, "failed to get fleet")
+	}
+	return row, nil
+}
+
+func loadDevice(ctx context.Context, q models.Querier, deviceID uuid.UUID, organizationID uuid.UUID) (deviceRow, error) {
+	row, err := q.GetDeviceForOrganization(ctx, deviceID, organizationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return row, connect.NewError(connect.CodeNotFound, errors.Errorf("device %s not found", deviceID))
+		}
+		return row, errors.Wrap(err, "failed to get device")
+	}
+	return row, nil
+}
+
+// requireUniqueDeviceName fails unless name is free, or already belongs to deviceID. Agents look their device up
+// by hostname across all organizations, so names must be unique server-wide.
+func requireUniqueDeviceName(ctx context.Context, q models.Querier, name string, deviceID uuid.UUID) error {
+	if name == "" {
+		return invalidArgument(errors.New("name is required"))
+	}
+	existing, err := q.GetDeviceByName(ctx, &name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to look up device name")
+	}
+	if existing.ID != deviceID {
+		return connect.NewError(connect.CodeAlreadyExists, errors.Errorf("a device named %q already exists", name))
+	}
+	return nil
+}
+
+// setDefaultSchedule points the fleet at scheduleID (uuid.Nil to clear it) and records the change in the
+// fleet's schedule history.
+func setDefaultSchedule(ctx context.Context, q models.Querier, fleetID uuid.UUID, organizationID uuid.UUID, scheduleID uuid.UUID) error {
+	if scheduleID != uuid.Nil {
+		if _, err := q.GetScheduleForOrganization(ctx, scheduleID, organizationID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return connect.NewError(connect.CodeNotFound, errors.Errorf("schedule %s not found", scheduleID))
+			}
+			return errors.Wrap(err, "failed to get schedule")
+		}
+	}
+
+	tag, err := q.SetFleetDefaultSchedule(ctx, models.SetFleetDefaultScheduleParams{
+		ScheduleID:     scheduleID,
+		FleetID:        fleetID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to set default schedule")
+	}
+	if tag.RowsAffected() == 0 {
+		return connect.NewError(connect.CodeNotFound, errors.Errorf("fleet %s not found", fleetID))
+	}
+	if _, err := q.EndFleetSchedules(ctx, fleetID); err != nil {
+		return errors.Wrap(err, "failed to end previous fleet schedule")
+	}
+	if scheduleID != uuid.Nil {
+		if _, err := q.InsertFleetSchedule(ctx, fleetID, scheduleID); err != nil {
+			return errors.Wrap(err, "failed to record fleet schedule")
+		}
+	}
+	return nil
+}
+
+// Device page tokens are the name and ID of the last device on the previous page.
+func encodeDevicePageToken(row models.ListDevicesInFleetRow) string {
+	name := ""
+	if row.Name != nil {
+		name = *row.Name
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(row.ID.String() + name))
+}
+
+func decodeDevicePageToken(token string) (string, uuid.UUID, error) {
+	if token == "" {
+		return "", uuid.Nil, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(decoded) < 36 {
+		return "", uuid.Nil, invalidArgument(errors.New("invalid page token"))
+	}
+	afterID, err := uuid.Parse(string(decoded[:36]))
+	if err != nil {
+		return "", uuid.Nil, invalidArgument(errors.New("invalid page token"))
+	}
+	return string(decoded[36:]), afterID, nil
+}
+
+func (s *fleetServer) ListFleets(ctx context.Context, req *connect.Request[com.ListFleetsRequest]) (*connect.Response[com.ListFleetsResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Q.ListFleetsForOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list fleets")
+	}
+	fleets := make([]*com.Fleet, 0, len(rows))
+	for _, row := range rows {
+		fleets = append(fleets, fleetFromRow(fleetRow(row)))
+	}
+
+	return &connect.Response[com.ListFleetsResponse]{
+		Msg: &com.ListFleetsResponse{Fleets: fleets},
+	}, nil
+}
+
+func (s *fleetServer) CreateFleet(ctx context.Context, req *connect.Request[com.CreateFleetRequest]) (*connect.Response[com.CreateFleetResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Msg.GetName())
+	if name == "" {
+		return nil, invalidArgument(errors.New("name is required"))
+	}
+	defaultScheduleID := uuid.Nil
+	if req.Msg.GetDefaultScheduleId() != "" {
+		defaultScheduleID, err = parseID("schedule", req.Msg.GetDefaultScheduleId())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var fleet *com.Fleet
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		row, err := q.InsertFleet(ctx, &name, organizationID)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert fleet")
+		}
+		if defaultScheduleID != uuid.Nil {
+			if err := setDefaultSchedule(ctx, q, row.ID, organizationID, defaultScheduleID); err != nil {
+				return err
+			}
+		}
+		created, err := loadFleet(ctx, q, row.ID, organizationID)
+		if err != nil {
+			return err
+		}
+		fleet = fleetFromRow(created)
+		return audit(ctx, q, organizationID, "fleet.create", fleet.Id, nil, fleet)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Created fleet", "fleet_id", fleet.Id, "name", fleet.Name)
+
+	return &connect.Response[com.CreateFleetResponse]{
+		Msg: &com.CreateFleetResponse{Fleet: fleet},
+	}, nil
+}
+
+func (s *fleetServer) RenameFleet(ctx context.Context, req *connect.Request[com.RenameFleetRequest]) (*connect.Response[com.RenameFleetResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	fleetID, err := parseID("fleet", req.Msg.GetFleetId())
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Msg.GetName())
+	if name == "" {
+		return nil, invalidArgument(errors.New("name is required"))
+	}
+
+	var fleet *com.Fleet
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		existing, err := loadFleet(ctx, q, fleetID, organizationID)
+		if err != nil {
+			return err
+		}
+		_, err = q.RenameFleet(ctx, models.RenameFleetParams{
+			Name:           &name,
+			FleetID:        fleetID,
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to rename fleet")
+		}
+		row, err := loadFleet(ctx, q, fleetID, organizationID)
+		if err != nil {
+			return err
+		}
+		fleet = fleetFromRow(row)
+		return audit(ctx, q, organizationID, "fleet.rename", fleet.Id, fleetFromRow(existing), fleet)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &connect.Response[com.RenameFleetResponse]{
+		Msg: &com.RenameFleetResponse{Fleet: fleet},
+	}, nil
+}
+
+func (s *fleetServer) DeleteFleet(ctx context.Context, req *connect.Request[com.DeleteFleetRequest]) (*connect.Response[com.DeleteFleetResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	fleetID, err := parseID("fleet", req.Msg.GetFleetId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		fleet, err := loadFleet(ctx, q, fleetID, organizationID)
+		if err != nil {
+			return err
+		}
+		if deviceCount := goutil.UnwrapOr(fleet.DeviceCount, 0); deviceCount > 0 {
+			return connect.NewError(connect.CodeFailedPrecondition, errors.Errorf("fleet still has %d device(s); move or delete them first", deviceCount))
+		}
+		if _, err := q.DeleteFleet(ctx, fleetID, organizationID); err != nil {
+			return errors.Wrap(err, "failed to delete fleet")
+		}
+		return audit(ctx, q, organizationID, "fleet.delete", fleetID.String(), fleetFromRow(fleet), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Deleted fleet", "fleet_id", fleetID)
+
+	return &connect.Response[com.DeleteFleetResponse]{
+		Msg: &com.DeleteFleetResponse{},
+	}, nil
+}
+
+func (s *fleetServer) SetFleetDefaultSchedule(ctx context.Context, req *connect.Request[com.SetFleetDefaultScheduleRequest]) (*connect.Response[com.SetFleetDefaultScheduleResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	fleetID, err := parseID("fleet", req.Msg.GetFleetId())
+	if err != nil {
+		return nil, err
+	}
+	scheduleID := uuid.Nil
+	if req.Msg.GetScheduleId() != "" {
+		scheduleID, err = parseID("schedule", req.Msg.GetScheduleId())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var fleet *com.Fleet
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		existing, err := loadFleet(ctx, q, fleetID, organizationID)
+		if err != nil {
+			return err
+		}
+		if err := setDefaultSchedule(ctx, q, fleetID, organizationID, scheduleID); err != nil {
+			return err
+		}
+		row, err := loadFleet(ctx, q, fleetID, organizationID)
+		if err != nil {
+			return err
+		}
+		fleet = fleetFromRow(row)
+		return audit(ctx, q, organizationID, "fleet.set_default_schedule", fleet.Id, fleetFromRow(existing), fleet)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Set fleet default schedule", "fleet_id", fleetID, pkg.LogKeyScheduleID, fleet.DefaultScheduleId)
+
+	return &connect.Response[com.SetFleetDefaultScheduleResponse]{
+		Msg: &com.SetFleetDefaultScheduleResponse{Fleet: fleet},
+	}, nil
+}
+
+func (s *fleetServer) SetFleetAgentImage(ctx context.Context, req *connect.Request[com.SetFleetAgentImageRequest]) (*connect.Response[com.SetFleetAgentImageResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	fleetID, err := parseID("fleet", req.Msg.GetFleetId())
+	if err != nil {
+		return nil, err
+	}
+	agentImage := strings.TrimSpace(req.Msg.GetAgentImage())
+	if agentImage != "" {
+		if _, err := reference.ParseNormalizedNamed(agentImage); err != nil {
+			return nil, invalidArgument(errors.Wrapf(err, "agent_image %q is not a valid image reference", agentImage))
+		}
+	}
+
+	var fleet *com.Fleet
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		existing, err := loadFleet(ctx, q, fleetID, organizationID)
+		if err != nil {
+			return err
+		}
+		_, err = q.SetFleetAgentImage(ctx, models.SetFleetAgentImageParams{
+			AgentImage:     &agentImage,
+			FleetID:        fleetID,
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to set agent image")
+		}
+		row, err := loadFleet(ctx, q, fleetID, organizationID)
+		if err != nil {
+			return err
+		}
+		fleet = fleetFromRow(row)
+		return audit(ctx, q, organizationID, "fleet.set_agent_image", fleet.Id, fleetFromRow(existing), fleet)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Set fleet agent image", "fleet_id", fleetID, "agent_image", agentImage)
+
+	return &connect.Response[com.SetFleetAgentImageResponse]{
+		Msg: &com.SetFleetAgentImageResponse{Fleet: fleet},
+	}, nil
+}
+
+func (s *fleetServer) ListDevices(ctx context.Context, req *connect.Request[com.ListDevicesRequest]) (*connect.Response[com.ListDevicesResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	fleetID, err := parseID("fleet", req.Msg.GetFleetId())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := loadFleet(ctx, s.db.Q, fleetID, organizationID); err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.Msg.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultDevicePageSize
+	}
+	if pageSize > maxDevicePageSize {
+		pageSize = maxDevicePageSize
+	}
+	afterName, afterID, err := decodeDevicePageToken(req.Msg.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	nameContains := req.Msg.GetNameContains()
+	status := req.Msg.GetStatus()
+	switch status {
+	case "", pkg.DeviceOnline, pkg.DeviceStale, pkg.DeviceOffline:
+	default:
+		return nil, invalidArgument(errors.Errorf("unknown status %q", status))
+	}
+	// last_seen_at is written from the server's UTC clock, so compare against the same clock
+	now := time.Now().UTC()
+	onlineAfter, offlineAfter := s.cfg.DeviceStatusCutoffs(now)
+	// fetch one extra row to learn whether there is another page
+	limit := pageSize + 1
+	rows, err := s.db.Q.ListDevicesInFleet(ctx, models.ListDevicesInFleetParams{
+		FleetID:      fleetID,
+		NameContains: &nameContains,
+		Status:       &status,
+		OnlineAfter:  &onlineAfter,
+		OfflineAfter: &offlineAfter,
+		AfterName:    &afterName,
+		AfterID:      afterID,
+		PageSize:     &limit,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list devices")
+	}
+
+	resp := &com.ListDevicesResponse{}
+	if len(rows) > pageSize {
+		rows = rows[:pageSize]
+		resp.NextPageToken = encodeDevicePageToken(rows[len(rows)-1])
+	}
+	resp.Devices = make([]*com.Device, 0, len(rows))
+	for _, row := range rows {
+		resp.Devices = append(resp.Devices, deviceFromRow(deviceRow(row), s.cfg, now))
+	}
+
+	return &connect.Response[com.ListDevicesResponse]{
+		Msg: resp,
+	}, nil
+}
+
+func (s *fleetServer) CreateDevice(ctx context.Context, req *connect.Request[com.CreateDeviceRequest]) (*connect.Response[com.CreateDeviceResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	fleetID, err := parseID("fleet", req.Msg.GetFleetId())
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Msg.GetName())
+
+	var device *com.Device
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		if _, err := loadFleet(ctx, q, fleetID, organizationID); err != nil {
+			return err
+		}
+		if err := requireUniqueDeviceName(ctx, q, name, uuid.Nil); err != nil {
+			return err
+		}
+		row, err := q.InsertDevice(ctx, &name, fleetID)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert device")
+		}
+		device = deviceFromRow(deviceRow(row), s.cfg, time.Now().UTC())
+		return audit(ctx, q, organizationID, "device.create", device.Id, nil, device)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Created device", pkg.LogKeyDeviceID, device.Id, "name", device.Name, "fleet_id", fleetID)
+
+	return &connect.Response[com.CreateDeviceResponse]{
+		Msg: &com.CreateDeviceResponse{Device: device},
+	}, nil
+}
+
+func (s *fleetServer) RenameDevice(ctx context.Context, req *connect.Request[com.RenameDeviceRequest]) (*connect.Response[com.RenameDeviceResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	deviceID, err := parseID("device", req.Msg.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Msg.GetName())
+
+	var device *com.Device
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		existing, err := loadDevice(ctx, q, deviceID, organizationID)
+		if err != nil {
+			return err
+		}
+		if err := requireUniqueDeviceName(ctx, q, name, deviceID); err != nil {
+			return err
+		}
+		row, err := q.RenameDevice(ctx, &name, deviceID)
+		if err != nil {
+			return errors.Wrap(err, "failed to rename device")
+		}
+		now := time.Now().UTC()
+		device = deviceFromRow(deviceRow(row), s.cfg, now)
+		return audit(ctx, q, organizationID, "device.rename", device.Id, deviceFromRow(existing, s.cfg, now), device)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &connect.Response[com.RenameDeviceResponse]{
+		Msg: &com.RenameDeviceResponse{Device: device},
+	}, nil
+}
+
+func (s *fleetServer) MoveDevice(ctx context.Context, req *connect.Request[com.MoveDeviceRequest]) (*connect.Response[com.MoveDeviceResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	deviceID, err := parseID("device", req.Msg.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	fleetID, err := parseID("fleet", req.Msg.GetFleetId())
+	if err != nil {
+		return nil, err
+	}
+
+	var device *com.Device
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		existing, err := loadDevice(ctx, q, deviceID, organizationID)
+		if err != nil {
+			return err
+		}
+		// the destination must belong to the same organization
+		if _, err := loadFleet(ctx, q, fleetID, organizationID); err != nil {
+			return err
+		}
+		row, err := q.MoveDevice(ctx, fleetID, deviceID)
+		if err != nil {
+			return errors.Wrap(err, "failed to move device")
+		}
+		now := time.Now().UTC()
+		device = deviceFromRow(deviceRow(row), s.cfg, now)
+		return audit(ctx, q, organizationID, "device.move", device.Id, deviceFromRow(existing, s.cfg, now), device)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Moved device", pkg.LogKeyDeviceID, deviceID, "fleet_id", fleetID)
+
+	return &connect.Response[com.MoveDeviceResponse]{
+		Msg: &com.MoveDeviceResponse{Device: device},
+	}, nil
+}
+
+func (s *fleetServer) DeleteDevice(ctx context.Context, req *connect.Request[com.DeleteDeviceRequest]) (*connect.Response[com.DeleteDeviceResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	deviceID, err := parseID("device", req.Msg.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		existing, err := loadDevice(ctx, q, deviceID, organizationID)
+		if err != nil {
+			return err
+		}
+		if _, err := q.DeleteDevice(ctx, deviceID); err != nil {
+			return errors.Wrap(err, "failed to delete device")
+		}
+		return audit(ctx, q, organizationID, "device.delete", deviceID.String(), deviceFromRow(existing, s.cfg, time.Now().UTC()), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Deleted device", pkg.LogKeyDeviceID, deviceID)
+
+	return &connect.Response[com.DeleteDeviceResponse]{
+		Msg: &com.DeleteDeviceResponse{},
+	}, nil
+}
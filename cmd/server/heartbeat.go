@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/parrotmac/goutil"
+
+	"github.com/uinta-labs/pando/models"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+// deviceRequest is implemented by every RemoteService request message.
+type deviceRequest interface {
+	GetDeviceId() string
+}
+
+// heartbeatInterceptor records when a device's agent last called, its version and where it called from, for
+// every unary RemoteService request that names a device, and logs the call with the device's ID attached.
+type heartbeatInterceptor struct {
+	s *server
+}
+
+var _ connect.Interceptor = &heartbeatInterceptor{}
+
+func (i *heartbeatInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if msg, ok := req.Any().(deviceRequest); ok {
+			ctx = pkg.WithLogAttrs(ctx, slog.String(pkg.LogKeyDeviceID, msg.GetDeviceId()))
+			i.s.recordHeartbeat(ctx, msg.GetDeviceId(), req.Header(), req.Peer())
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *heartbeatInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *heartbeatInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// remoteAddress is the agent's address, without the port as that changes from call to call.
+func (s *server) remoteAddress(header http.Header, peer connect.Peer) string {
+	if s.cfg.TrustForwardedFor {
+		if forwardedFor := header.Get("X-Forwarded-For"); forwardedFor != "" {
+			client, _, _ := strings.Cut(forwardedFor, ",")
+			return strings.TrimSpace(client)
+		}
+	}
+	host, _, err := net.SplitHostPort(peer.Addr)
+	if err != nil {
+		return peer.Addr
+	}
+	return host
+}
+
+// recordHeartbeat never fails the call it was made for; a device the server doesn't know is simply not recorded.
+func (s *server) recordHeartbeat(ctx context.Context, deviceID string, header http.Header, peer connect.Peer) {
+	deviceUUID, err := s.resolveDevice(ctx, deviceID)
+	if err != nil {
+		slog.WarnContext(ctx, "Not recording heartbeat for unknown device", pkg.Err(err))
+		return
+	}
+
+	seenAt := time.Now().UTC()
+	agentVersion := header.Get(pkg.AgentVersionHeader)
+	remoteAddress := s.remoteAddress(header, peer)
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		device, err := q.RecordDeviceHeartbeat(ctx, models.RecordDeviceHeartbeatParams{
+			SeenAt:        &seenAt,
+			AgentVersion:  &agentVersion,
+			RemoteAddress: &remoteAddress,
+			DeviceID:      deviceUUID,
+		})
+		if err != nil {
+			return err
+		}
+		if goutil.UnwrapOr(device.PreviousConnectivity, "") == connectivityOnline {
+			return nil
+		}
+		data := deviceEventData{Device: newEventDevice(deviceUUID, device.Name, device.FleetID)}
+		return publishEvent(ctx, q, device.OrganizationID, eventDeviceOnline, data)
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to record heartbeat", pkg.Err(err))
+	}
+}
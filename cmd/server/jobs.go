@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/parrotmac/goutil"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/models"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+const (
+	defaultJobRunPageSize = 50
+	maxJobRunPageSize     = 500
+
+	// Agents send far fewer; these bound what a misbehaving one can store
+	maxJobRunsPerStateReport = 100
+	maxJobRunLogTailBytes    = 16 * 1024
+
+	jobRunPurgeInterval = time.Hour
+)
+
+// recordJobRuns adds the job runs a device reported to its history.
+func recordJobRuns(ctx context.Context, q models.Querier, deviceID uuid.UUID, runs []*com.JobRun) error {
+	if len(runs) > maxJobRunsPerStateReport {
+		runs = runs[len(runs)-maxJobRunsPerStateReport:]
+	}
+	for _, run := range runs {
+		if !run.GetStartedAt().IsValid() {
+			slog.WarnContext(ctx, "Ignoring job run without a start time", pkg.LogKeyDeviceID, deviceID, pkg.LogKeyTaskID, run.GetTaskId())
+			continue
+		}
+		logTail := run.GetLogTail()
+		if len(logTail) > maxJobRunLogTailBytes {
+			// cut at a character boundary
+			logTail = strings.TrimLeftFunc(logTail[len(logTail)-maxJobRunLogTailBytes:], func(r rune) bool { return r == utf8.RuneError })
+		}
+		var finishedAt *time.Time
+		if run.GetFinishedAt().IsValid() {
+			finishedAt = goutil.Ptr(run.GetFinishedAt().AsTime())
+		}
+		_, err := q.InsertJobRun(ctx, models.InsertJobRunParams{
+			DeviceID:   deviceID,
+			TaskID:     goutil.Ptr(run.GetTaskId()),
+			TaskName:   goutil.Ptr(run.GetName()),
+			ScheduleID: goutil.Ptr(run.GetScheduleId()),
+			Status:     goutil.Ptr(run.GetStatus()),
+			StartedAt:  goutil.Ptr(run.GetStartedAt().AsTime()),
+			FinishedAt: finishedAt,
+			ExitCode:   run.GetExitCode(),
+			Error:      goutil.Ptr(run.GetError()),
+			LogTail:    &logTail,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to record run of job %s", run.GetTaskId())
+		}
+	}
+	return nil
+}
+
+// purgeJobRuns deletes job runs recorded longer than retention ago every jobRunPurgeInterval, until ctx is done.
+func purgeJobRuns(ctx context.Context, q models.Querier, retention time.Duration) {
+	ticker := time.NewTicker(jobRunPurgeInterval)
+	defer ticker.Stop()
+	for {
+		cutoff := time.Now().UTC().Add(-retention)
+		tag, err := q.DeleteJobRunsBefore(ctx, &cutoff)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to purge job runs", pkg.Err(err))
+		} else if tag.RowsAffected() > 0 {
+			slog.InfoContext(ctx, "Purged job runs", "runs", tag.RowsAffected(), "before", cutoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func jobRunFromRow(row models.ListJobRunsRow) *com.JobRun {
+	run := &com.JobRun{
+		TaskId:     goutil.UnwrapOr(row.TaskID, ""),
+		Name:       goutil.UnwrapOr(row.TaskName, ""),
+		ScheduleId: goutil.UnwrapOr(row.ScheduleID, ""),
+		Status:     goutil.UnwrapOr(row.Status, ""),
+		ExitCode:   row.ExitCode,
+		Error:      goutil.UnwrapOr(row.Error, ""),
+		LogTail:    goutil.UnwrapOr(row.LogTail, ""),
+	}
+	if row.StartedAt != nil {
+		run.StartedAt = timestamppb.New(*row.StartedAt)
+	}
+	if row.FinishedAt != nil {
+		run.FinishedAt = timestamppb.New(*row.FinishedAt)
+	}
+	return run
+}
+
+// Job run page tokens are the ID and start time of the last run on the previous page, laid out like audit page
+// tokens.
+func encodeJobRunPageToken(row models.ListJobRunsRow) string {
+	startedAt := goutil.UnwrapOr(row.StartedAt, time.Time{})
+	return base64.RawURLEncoding.EncodeToString([]byte(row.ID.String() + startedAt.Format(time.RFC3339Nano)))
+}
+
+func (s *deviceServer) ListJobRuns(ctx context.Context, req *connect.Request[com.ListJobRunsRequest]) (*connect.Response[com.ListJobRunsResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	device, err := resolveOrganizationDevice(ctx, s.db.Q, organizationID, req.Msg.GetDevice())
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.Msg.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultJobRunPageSize
+	}
+	if pageSize > maxJobRunPageSize {
+		pageSize = maxJobRunPageSize
+	}
+	beforeStartedAt, beforeID, err := decodeAuditPageToken(req.Msg.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	// fetch one extra row to learn whether there is another page
+	limit := pageSize + 1
+	job := req.Msg.GetJob()
+	rows, err := s.db.Q.ListJobRuns(ctx, models.ListJobRunsParams{
+		DeviceID:        device.ID,
+		Job:             &job,
+		BeforeStartedAt: beforeStartedAt,
+		BeforeID:        beforeID,
+		PageSize:        &limit,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list job runs")
+	}
+
+	resp := &com.ListJobRunsResponse{}
+	if len(rows) > pageSize {
+		rows = rows[:pageSize]
+		resp.NextPageToken = encodeJobRunPageToken(rows[len(rows)-1])
+	}
+	resp.Runs = make([]*com.JobRun, 0, len(rows))
+	for _, row := range rows {
+		resp.Runs = append(resp.Runs, jobRunFromRow(row))
+	}
+
+	return &connect.Response[com.ListJobRunsResponse]{
+		Msg: resp,
+	}, nil
+}
@@ -3,55 +3,78 @@ package main
 import (
 	"connectrpc.com/connect"
 	"context"
-	"encoding/json"
 	"flag"
 	"github.com/google/uuid"
+	"github.com/parrotmac/goutil"
 	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/models"
 	"github.com/uinta-labs/pando/pkg"
 	"github.com/uinta-labs/pando/pkg/db"
 	"log"
+	"log/slog"
 	"net/http"
-	"os"
 	"time"
 
 	_ "connectrpc.com/connect"
 	"connectrpc.com/grpcreflect"
-	"github.com/parrotmac/goutil"
 	"github.com/pkg/errors"
 	"github.com/rs/cors"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com/comconnect"
 )
 
+// Callers may pick the request ID themselves, to follow a call across services; otherwise one is generated
+const requestIDHeader = "X-Request-Id"
+
+// loggingMiddleware gives every request an ID, returned in the response, and attaches it to everything the request
+// logs.
 func loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		startTime := time.Now()
-		next.ServeHTTP(w, r)
-		log.Printf("%s %s %s", r.Method, r.URL.Path, time.Since(startTime))
+		requestID := r.Header.Get(requestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(requestIDHeader, requestID)
+		ctx := pkg.WithLogAttrs(r.Context(), slog.String(pkg.LogKeyRequestID, requestID))
+		next.ServeHTTP(w, r.WithContext(ctx))
+		slog.DebugContext(ctx, "Handled request", "method", r.Method, "path", r.URL.Path, "duration", time.Since(startTime))
 	})
 }
 
 type server struct {
-	db *db.DB
+	cfg   pkg.Config
+	db    *db.DB
+	relay *relay
 }
 
-func (s *server) GetSchedule(ctx context.Context, req *connect.Request[com.GetScheduleRequest]) (*connect.Response[com.GetScheduleResponse], error) {
-	deviceID := req.Msg.GetDeviceId()
-	log.Printf("GetSchedule: %s\n", deviceID)
-
+// resolveDevice accepts either a device's ID or its name, as agents identify themselves by hostname.
+func (s *server) resolveDevice(ctx context.Context, deviceID string) (uuid.UUID, error) {
 	deviceUUID, err := uuid.Parse(deviceID)
 	if err != nil {
 		// try to use device name to lookup before considering failed
-		log.Printf("Failed to parse device id: %s\n", err)
-		log.Printf("Trying to resolve by name %s\n", deviceID)
+		slog.DebugContext(ctx, "Resolving device by name", "name", deviceID)
 		deviceFromName, deviceFromNameErr := s.db.Q.GetDeviceByName(ctx, &deviceID)
 		if deviceFromNameErr != nil {
-			return nil, errors.Wrap(deviceFromNameErr, "failed to parse device id or resolve by name")
+			return uuid.Nil, errors.Wrap(deviceFromNameErr, "failed to parse device id or resolve by name")
 		}
 		deviceUUID = deviceFromName.ID
 	}
+	return deviceUUID, nil
+}
+
+func (s *server) GetSchedule(ctx context.Context, req *connect.Request[com.GetScheduleRequest]) (*connect.Response[com.GetScheduleResponse], error) {
+	deviceID := req.Msg.GetDeviceId()
+	slog.DebugContext(ctx, "Getting schedule")
+
+	deviceUUID, err := s.resolveDevice(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
 
 	schedule, err := s.db.Q.GetCurrentScheduleForDevice(ctx, deviceUUID)
 	if err != nil {
@@ -65,52 +88,21 @@ func (s *server) GetSchedule(ctx context.Context, req *connect.Request[com.GetSc
 
 	containers := make([]*com.Container, 0, len(scheduleComponents))
 	for _, component := range scheduleComponents {
-		env := make(map[string]string, len(component.Env))
-		err = json.Unmarshal(component.Env, &env)
+		container, err := containerFromRow(component)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to unmarshal env")
-		}
-
-		var networkMode com.Container_NetworkMode
-		if component.NetworkMode != nil {
-			switch *component.NetworkMode {
-			case "host":
-				networkMode = com.Container_HOST
-			case "bridge":
-				networkMode = com.Container_BRIDGE
-			case "none":
-				networkMode = com.Container_NONE
-			// TODO: Support 'container' network mode (join network of another container)
-			// Also have a more clear default/zero value
-			default:
-				networkMode = com.Container_BRIDGE
-			}
+			return nil, err
 		}
+		containers = append(containers, container)
+	}
 
-		ports := make([]*com.Container_Port, 0, len(component.Ports))
-		err = json.Unmarshal(component.Ports, &ports)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to unmarshal ports")
-		}
+	agentImage, err := s.db.Q.GetAgentImageForDevice(ctx, deviceUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get agent image")
+	}
 
-		containers = append(containers, &com.Container{
-			Id:               component.ID.String(),
-			Name:             goutil.UnwrapOr(component.Name, ""),
-			ContainerImage:   goutil.UnwrapOr(component.ContainerImage, ""),
-			Env:              env,
-			Privileged:       component.Privileged,
-			NetworkMode:      networkMode,
-			Ports:            ports,
-			BindDev:          component.BindDev,
-			BindProc:         component.BindProc,
-			BindSys:          component.BindSys,
-			BindShm:          component.BindShm,
-			BindCgroup:       component.BindCgroup,
-			BindDockerSocket: component.BindDockerSocket,
-			BindBoot:         component.BindBoot,
-			Command:          goutil.UnwrapOr(component.Command, ""),
-			Entrypoint:       goutil.UnwrapOr(component.Entrypoint, ""),
-		})
+	maintenance, err := s.db.Q.GetMaintenanceForDevice(ctx, deviceUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get maintenance windows")
 	}
 
 	resp := &com.GetScheduleResponse{
@@ -119,6 +111,8 @@ func (s *server) GetSchedule(ctx context.Context, req *connect.Request[com.GetSc
 			Current:    true,
 			Containers: containers,
 		},
+		AgentImage:  goutil.UnwrapOr(agentImage, ""),
+		Maintenance: maintenancePolicy(maintenance),
 	}
 
 	return &connect.Response[com.GetScheduleResponse]{
@@ -126,8 +120,82 @@ func (s *server) GetSchedule(ctx context.Context, req *connect.Request[com.GetSc
 	}, nil
 }
 
+// ReportScheduleState is called by agents after every scheduler tick, and doubles as their heartbeat.
 func (s *server) ReportScheduleState(ctx context.Context, req *connect.Request[com.ReportScheduleStateRequest]) (*connect.Response[com.ReportScheduleStateResponse], error) {
-	return nil, nil
+	deviceUUID, err := s.resolveDevice(ctx, req.Msg.GetDeviceId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	// job runs are kept in their own history rather than with the device's latest state
+	stored := proto.Clone(req.Msg).(*com.ReportScheduleStateRequest)
+	stored.JobRuns = nil
+	reportedState, err := protojson.Marshal(stored)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode reported state")
+	}
+	reportedAt := time.Now().UTC()
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		if err := recordJobRuns(ctx, q, deviceUUID, req.Msg.GetJobRuns()); err != nil {
+			return err
+		}
+		device, err := q.RecordDeviceReportedState(ctx, models.RecordDeviceReportedStateParams{
+			ReportedState: reportedState,
+			ReportedAt:    &reportedAt,
+			DeviceID:      deviceUUID,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to record reported state")
+		}
+		return s.publishStateEvents(ctx, q, device, deviceUUID, req.Msg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &connect.Response[com.ReportScheduleStateResponse]{
+		Msg: &com.ReportScheduleStateResponse{},
+	}, nil
+}
+
+func (s *server) ReportRollback(ctx context.Context, req *connect.Request[com.ReportRollbackRequest]) (*connect.Response[com.ReportRollbackResponse], error) {
+	slog.WarnContext(ctx, "Device rolled back", "failed_schedule_id", req.Msg.GetFailedScheduleId(), "restored_schedule_id", req.Msg.GetRestoredScheduleId(), "reason", req.Msg.GetReason())
+
+	deviceUUID, err := s.resolveDevice(ctx, req.Msg.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	failedScheduleID, err := uuid.Parse(req.Msg.GetFailedScheduleId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.Wrap(err, "invalid failed schedule id"))
+	}
+	restoredScheduleID, err := uuid.Parse(req.Msg.GetRestoredScheduleId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.Wrap(err, "invalid restored schedule id"))
+	}
+
+	failedScheduleVersion := req.Msg.GetFailedScheduleVersion()
+	reason := req.Msg.GetReason()
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		_, err := q.InsertDeviceRollback(ctx, models.InsertDeviceRollbackParams{
+			DeviceID:              deviceUUID,
+			FailedScheduleID:      failedScheduleID,
+			FailedScheduleVersion: &failedScheduleVersion,
+			RestoredScheduleID:    restoredScheduleID,
+			Reason:                &reason,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to record rollback")
+		}
+		return auditDevice(ctx, q, deviceUUID, "device.rollback", req.Msg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &connect.Response[com.ReportRollbackResponse]{
+		Msg: &com.ReportRollbackResponse{},
+	}, nil
 }
 
 func main() {
@@ -140,30 +208,126 @@ func main() {
 	if err != nil {
 		log.Panicf("failed to read config: %+v\n", err)
 	}
+	if err := pkg.SetupLogging(cfg.LogFormat, cfg.LogLevel); err != nil {
+		log.Panicf("failed to set up logging: %+v\n", err)
+	}
+	flushSentry, err := pkg.SetupSentry(cfg.SentryDSN, cfg.Environment)
+	if err != nil {
+		log.Panicf("failed to set up Sentry: %+v\n", err)
+	}
+	defer flushSentry()
+	shutdownTracing, err := pkg.SetupTracing(ctx, "pando-server", cfg.TracingConfig)
+	if err != nil {
+		log.Panicf("failed to set up tracing: %+v\n", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			slog.Error("Failed to flush traces", pkg.Err(err))
+		}
+	}()
 
-	db, err := db.New(ctx, cfg.DatabaseURL, true)
+	db, err := db.New(ctx, cfg.DatabaseURL)
 	if err != nil {
 		log.Panicf("failed to connect to database: %+v\n", err)
 	}
 
+	deviceRelay := newRelay()
 	srv := &server{
-		db: db,
+		cfg:   cfg,
+		db:    db,
+		relay: deviceRelay,
 	}
 
+	registry := pkg.NewMetricsRegistry()
+	registry.MustRegister(db.Collector(), newDeviceCollector(cfg, db.Q))
+	// Agents continue the trace of their reconcile loop; operator clients' traces are only linked, as any browser can
+	// claim one
+	agentTracing, err := pkg.TracingInterceptor(true)
+	if err != nil {
+		log.Panicf("failed to set up tracing: %+v\n", err)
+	}
+	operatorTracing, err := pkg.TracingInterceptor(false)
+	if err != nil {
+		log.Panicf("failed to set up tracing: %+v\n", err)
+	}
+	// listed first, so their interceptors are outermost and see calls the others reject
+	rpcMetrics := newRPCMetrics(registry)
+	withAgentObservability := connect.WithInterceptors(rpcMetrics, agentTracing)
+	withObservability := connect.WithInterceptors(rpcMetrics, operatorTracing)
+
 	httpMux := http.NewServeMux()
 
 	reflector := grpcreflect.NewStaticReflector(
-		"remote.upd88.com.RemoteService",
+		comconnect.RemoteServiceName,
+		comconnect.ScheduleServiceName,
+		comconnect.OrganizationServiceName,
+		comconnect.FleetServiceName,
+		comconnect.DeviceServiceName,
+		comconnect.AuditServiceName,
+		comconnect.WebhookServiceName,
 	)
 	httpMux.Handle(grpcreflect.NewHandlerV1(reflector))
 	httpMux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
 
 	{
-		baseURL, connectHandler := comconnect.NewRemoteServiceHandler(srv)
-		log.Printf("Binding RemoteService to %s\n", baseURL)
+		baseURL, connectHandler := comconnect.NewRemoteServiceHandler(srv, withAgentObservability, connect.WithInterceptors(&heartbeatInterceptor{s: srv}))
+		slog.Debug("Binding service", "service", "RemoteService", "path", baseURL)
 		httpMux.Handle(baseURL, connectHandler)
 	}
 
+	// Operator-facing services require a web session or API key; RemoteService is called by agents
+	authenticator := pkg.NewAuthenticator(cfg, db.Q)
+	authInterceptor := connect.WithInterceptors(authenticator.Interceptor())
+	{
+		baseURL, connectHandler := comconnect.NewScheduleServiceHandler(&scheduleServer{db: db, auth: authenticator}, withObservability, authInterceptor)
+		slog.Debug("Binding service", "service", "ScheduleService", "path", baseURL)
+		httpMux.Handle(baseURL, connectHandler)
+	}
+	{
+		baseURL, connectHandler := comconnect.NewOrganizationServiceHandler(&organizationServer{db: db, auth: authenticator}, withObservability, authInterceptor)
+		slog.Debug("Binding service", "service", "OrganizationService", "path", baseURL)
+		httpMux.Handle(baseURL, connectHandler)
+	}
+	{
+		baseURL, connectHandler := comconnect.NewFleetServiceHandler(&fleetServer{cfg: cfg, db: db, auth: authenticator}, withObservability, authInterceptor)
+		slog.Debug("Binding service", "service", "FleetService", "path", baseURL)
+		httpMux.Handle(baseURL, connectHandler)
+	}
+	{
+		baseURL, connectHandler := comconnect.NewDeviceServiceHandler(&deviceServer{cfg: cfg, db: db, auth: authenticator, relay: deviceRelay}, withObservability, authInterceptor)
+		slog.Debug("Binding service", "service", "DeviceService", "path", baseURL)
+		// ExecInContainer waits on the device for up to its own timeout, which may exceed the server's
+		httpMux.Handle(baseURL, withWriteDeadline(connectHandler, maxExecTimeout+time.Minute))
+	}
+	{
+		baseURL, connectHandler := comconnect.NewAuditServiceHandler(&auditServer{db: db, auth: authenticator}, withObservability, authInterceptor)
+		slog.Debug("Binding service", "service", "AuditService", "path", baseURL)
+		httpMux.Handle(baseURL, connectHandler)
+	}
+
+	{
+		baseURL, connectHandler := comconnect.NewWebhookServiceHandler(&webhookServer{db: db, auth: authenticator}, withObservability, authInterceptor)
+		slog.Debug("Binding service", "service", "WebhookService", "path", baseURL)
+		httpMux.Handle(baseURL, connectHandler)
+	}
+
+	if cfg.MetricsAddress != "" {
+		go pkg.ServeMetrics(ctx, cfg.MetricsAddress, registry)
+	}
+	if cfg.LogLevelAddress != "" {
+		go pkg.ServeLogLevel(ctx, cfg.LogLevelAddress)
+	}
+	go newWebhookDispatcher(db).run(ctx)
+	go srv.sweepOfflineDevices(ctx)
+	if cfg.AuditLogRetention > 0 {
+		go purgeAuditLog(ctx, db.Q, cfg.AuditLogRetention)
+	}
+	if cfg.JobRunRetention > 0 {
+		go purgeJobRuns(ctx, db.Q, cfg.JobRunRetention)
+	}
+
 	corsConfig := cors.New(cors.Options{
 		AllowOriginFunc: func(origin string) bool {
 			return true
@@ -185,7 +349,10 @@ func main() {
 			"Content-Type",
 			"Cookie",
 			"Origin",
+			"X-Request-Id",
 			"Sentry-Trace",
+			"Traceparent",
+			"Tracestate",
 			"User-Agent",
 			"Baggage",
 			"Sentry-Trace",
@@ -202,21 +369,21 @@ func main() {
 		WriteTimeout:      time.Second * 30,
 		IdleTimeout:       time.Second * 60,
 		ReadHeaderTimeout: time.Second * 10,
-		ErrorLog:          log.New(os.Stderr, "HTTP Server: ", log.LstdFlags),
+		ErrorLog:          slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
 	}
 
 	go func() {
-		log.Println("Starting server on", httpServer.Addr)
+		slog.Info("Starting server", "address", httpServer.Addr)
 		err := httpServer.ListenAndServe()
 		if err != nil {
-			log.Println("Server error:", err)
+			slog.Error("Server failed", pkg.Err(err))
 		}
 	}()
 
 	<-ctx.Done()
 
-	log.Println("Shutting down server...")
+	slog.Info("Shutting down server")
 	if err := httpServer.Shutdown(ctx); err != nil {
-		log.Println("Server shutdown error:", err)
+		slog.Error("Server shutdown failed", pkg.Err(err))
 	}
 }
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/parrotmac/goutil"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/models"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+const (
+	defaultApplyNowMinutes = 60
+	maxApplyNowMinutes     = 24 * 60
+)
+
+// maintenanceWindowsFromColumn decodes a maintenance_windows column, which is NULL when the fleet or device has no
+// windows of its own.
+func maintenanceWindowsFromColumn(encoded []byte) *com.MaintenanceWindows {
+	if len(encoded) == 0 {
+		return nil
+	}
+	windows := &com.MaintenanceWindows{}
+	if err := protojson.Unmarshal(encoded, windows); err != nil {
+		// Only written by SetFleetMaintenance and SetDeviceMaintenance, so this is unexpected
+		slog.Error("Failed to decode maintenance windows", pkg.Err(err))
+		return nil
+	}
+	return windows
+}
+
+func maintenanceWindowsColumn(windows *com.MaintenanceWindows) ([]byte, error) {
+	if windows == nil {
+		return nil, nil
+	}
+	encoded, err := protojson.Marshal(windows)
+	return encoded, errors.Wrap(err, "failed to encode maintenance windows")
+}
+
+// maintenancePolicy resolves which maintenance windows apply to a device: its own if it has any, otherwise its
+// fleet's, laid out in the device's time zone or else the fleet's. Nil when the device may make disruptive
+// changes at any time.
+func maintenancePolicy(row models.GetMaintenanceForDeviceRow) *com.MaintenancePolicy {
+	windows := maintenanceWindowsFromColumn(row.DeviceMaintenanceWindows)
+	if windows == nil {
+		windows = maintenanceWindowsFromColumn(row.FleetMaintenanceWindows)
+	}
+	if len(windows.GetWindows()) == 0 {
+		return nil
+	}
+	policy := &com.MaintenancePolicy{
+		Windows:  windows.Windows,
+		TimeZone: goutil.UnwrapOr(row.DeviceTimeZone, goutil.UnwrapOr(row.FleetTimeZone, "")),
+	}
+	if row.ApplyNowUntil != nil {
+		policy.ApplyNowUntil = timestamppb.New(*row.ApplyNowUntil)
+	}
+	return policy
+}
+
+func (s *fleetServer) SetFleetMaintenance(ctx context.Context, req *connect.Request[com.SetFleetMaintenanceRequest]) (*connect.Response[com.SetFleetMaintenanceResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	fleetID, err := parseID("fleet", req.Msg.GetFleetId())
+	if err != nil {
+		return nil, err
+	}
+	timeZone := strings.TrimSpace(req.Msg.GetTimeZone())
+	if err := pkg.ValidateMaintenance(req.Msg.GetMaintenanceWindows().GetWindows(), timeZone); err != nil {
+		return nil, invalidArgument(err)
+	}
+	windows, err := maintenanceWindowsColumn(req.Msg.GetMaintenanceWindows())
+	if err != nil {
+		return nil, err
+	}
+
+	var fleet *com.Fleet
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		existing, err := loadFleet(ctx, q, fleetID, organizationID)
+		if err != nil {
+			return err
+		}
+		_, err = q.SetFleetMaintenance(ctx, models.SetFleetMaintenanceParams{
+			MaintenanceWindows: windows,
+			TimeZone:           &timeZone,
+			FleetID:            fleetID,
+			OrganizationID:     organizationID,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to set maintenance windows")
+		}
+		row, err := loadFleet(ctx, q, fleetID, organizationID)
+		if err != nil {
+			return err
+		}
+		fleet = fleetFromRow(row)
+		return audit(ctx, q, organizationID, "fleet.set_maintenance", fleet.Id, fleetFromRow(existing), fleet)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Set fleet maintenance windows", "fleet_id", fleetID, "windows", len(req.Msg.GetMaintenanceWindows().GetWindows()), "time_zone", timeZone)
+
+	return &connect.Response[com.SetFleetMaintenanceResponse]{
+		Msg: &com.SetFleetMaintenanceResponse{Fleet: fleet},
+	}, nil
+}
+
+func (s *fleetServer) SetDeviceMaintenance(ctx context.Context, req *connect.Request[com.SetDeviceMaintenanceRequest]) (*connect.Response[com.SetDeviceMaintenanceResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	deviceID, err := parseID("device", req.Msg.GetDeviceId())
+	if err != nil {
+		return nil, err
+	}
+	timeZone := strings.TrimSpace(req.Msg.GetTimeZone())
+	if err := pkg.ValidateMaintenance(req.Msg.GetMaintenanceWindows().GetWindows(), timeZone); err != nil {
+		return nil, invalidArgument(err)
+	}
+	windows, err := maintenanceWindowsColumn(req.Msg.GetMaintenanceWindows())
+	if err != nil {
+		return nil, err
+	}
+
+	var device *com.Device
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		existing, err := loadDevice(ctx, q, deviceID, organizationID)
+		if err != nil {
+			return err
+		}
+		_, err = q.SetDeviceMaintenance(ctx, models.SetDeviceMaintenanceParams{
+			MaintenanceWindows: windows,
+			TimeZone:           &timeZone,
+			DeviceID:           deviceID,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to set maintenance windows")
+		}
+		row, err := loadDevice(ctx, q, deviceID, organizationID)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		device = deviceFromRow(row, s.cfg, now)
+		return audit(ctx, q, organizationID, "device.set_maintenance", device.Id, deviceFromRow(existing, s.cfg, now), device)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Set device maintenance windows", pkg.LogKeyDeviceID, deviceID, "time_zone", timeZone)
+
+	return &connect.Response[com.SetDeviceMaintenanceResponse]{
+		Msg: &com.SetDeviceMaintenanceResponse{Device: device},
+	}, nil
+}
+
+// ApplyUpdatesNow lets devices make disruptive changes for a while as if a maintenance window were open. Devices
+// pick it up with their next schedule, so changes already deferred are applied within one scheduler tick.
+func (s *fleetServer) ApplyUpdatesNow(ctx context.Context, req *connect.Request[com.ApplyUpdatesNowRequest]) (*connect.Response[com.ApplyUpdatesNowResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	if (req.Msg.GetFleetId() == "") == (req.Msg.GetDeviceId() == "") {
+		return nil, invalidArgument(errors.New("exactly one of fleet_id and device_id is required"))
+	}
+	minutes := req.Msg.GetDurationMinutes()
+	if minutes == 0 {
+		minutes = defaultApplyNowMinutes
+	}
+	if minutes < 0 || minutes > maxApplyNowMinutes {
+		return nil, invalidArgument(errors.Errorf("duration_minutes must be between 1 and %d", maxApplyNowMinutes))
+	}
+
+	resp := &com.ApplyUpdatesNowResponse{}
+	var until *time.Time
+	if !req.Msg.GetCancel() {
+		until = goutil.Ptr(time.Now().UTC().Add(time.Duration(minutes) * time.Minute))
+		resp.Until = timestamppb.New(*until)
+	}
+
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		if req.Msg.GetDeviceId() != "" {
+			deviceID, err := parseID("device", req.Msg.GetDeviceId())
+			if err != nil {
+				return err
+			}
+			if _, err := loadDevice(ctx, q, deviceID, organizationID); err != nil {
+				return err
+			}
+			if _, err := q.SetDeviceApplyNowUntil(ctx, until, deviceID); err != nil {
+				return errors.Wrap(err, "failed to apply updates now")
+			}
+			resp.DeviceCount = 1
+			return audit(ctx, q, organizationID, "device.apply_updates_now", deviceID.String(), nil, resp)
+		}
+
+		fleetID, err := parseID("fleet", req.Msg.GetFleetId())
+		if err != nil {
+			return err
+		}
+		if _, err := loadFleet(ctx, q, fleetID, organizationID); err != nil {
+			return err
+		}
+		tag, err := q.SetFleetApplyNowUntil(ctx, until, fleetID)
+		if err != nil {
+			return errors.Wrap(err, "failed to apply updates now")
+		}
+		resp.DeviceCount = tag.RowsAffected()
+		return audit(ctx, q, organizationID, "fleet.apply_updates_now", fleetID.String(), nil, resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Applying updates now", "fleet_id", req.Msg.GetFleetId(), pkg.LogKeyDeviceID, req.Msg.GetDeviceId(),
+		"devices", resp.DeviceCount, "cancel", req.Msg.GetCancel())
+
+	return &connect.Response[com.ApplyUpdatesNowResponse]{
+		Msg: resp,
+	}, nil
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/models"
+	"github.com/uinta-labs/pando/pkg"
+	"github.com/uinta-labs/pando/pkg/manifest"
+)
+
+// findScheduleByName returns the organization's schedule with the given name, or nil if there is none.
+func findScheduleByName(ctx context.Context, q models.Querier, organizationID uuid.UUID, name string) (*com.Schedule, error) {
+	rows, err := q.ListSchedulesByNameForOrganization(ctx, &name, organizationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find schedule")
+	}
+	switch len(rows) {
+	case 0:
+		return nil, nil
+	case 1:
+		return loadSchedule(ctx, q, rows[0].ID, organizationID)
+	default:
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.Errorf("%d schedules are named %s; rename all but one", len(rows), name))
+	}
+}
+
+// applyChanges makes the changes Diff found between existing and desired, matching containers by name.
+func applyChanges(ctx context.Context, q models.Querier, organizationID uuid.UUID, existing *com.Schedule, desired *com.Schedule, changes []*com.ManifestChange) error {
+	scheduleID := uuid.MustParse(existing.Id)
+	existingContainers := map[string]*com.Container{}
+	for _, container := range existing.Containers {
+		existingContainers[container.Name] = container
+	}
+	desiredContainers := map[string]*com.Container{}
+	for _, container := range desired.Containers {
+		desiredContainers[container.Name] = container
+	}
+
+	for _, change := range changes {
+		switch {
+		case change.Container == "":
+			_, err := q.UpdateSchedule(ctx, models.UpdateScheduleParams{
+				Name:           &existing.Name,
+				State:          &desired.State,
+				ScheduleID:     scheduleID,
+				OrganizationID: organizationID,
+			})
+			if err != nil {
+				return errors.Wrap(err, "failed to update schedule")
+			}
+		case change.Action == manifest.ActionCreate:
+			if _, err := insertContainer(ctx, q, scheduleID, desiredContainers[change.Container]); err != nil {
+				return err
+			}
+		case change.Action == manifest.ActionUpdate:
+			params, err := containerColumns(desiredContainers[change.Container])
+			if err != nil {
+				return err
+			}
+			params.ContainerID = uuid.MustParse(existingContainers[change.Container].Id)
+			if _, err := q.UpdateContainer(ctx, params); err != nil {
+				return errors.Wrapf(err, "failed to update container %s", change.Container)
+			}
+		case change.Action == manifest.ActionDelete:
+			if _, err := q.DeleteContainer(ctx, uuid.MustParse(existingContainers[change.Container].Id)); err != nil {
+				return errors.Wrapf(err, "failed to delete container %s", change.Container)
+			}
+		}
+	}
+	_, err := q.TouchSchedule(ctx, scheduleID)
+	return errors.Wrap(err, "failed to touch schedule")
+}
+
+// findSchedule looks a schedule up by ID or name.
+func findSchedule(ctx context.Context, q models.Querier, organizationID uuid.UUID, schedule string) (*com.Schedule, error) {
+	if scheduleID, err := uuid.Parse(schedule); err == nil {
+		return loadSchedule(ctx, q, scheduleID, organizationID)
+	}
+	found, err := findScheduleByName(ctx, q, organizationID, schedule)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, connect.NewError(connect.CodeNotFound, errors.Errorf("schedule %s not found", schedule))
+	}
+	return found, nil
+}
+
+// applySchedule makes the organization's schedule named like desired match it, creating it if there is none,
+// and returns the schedule afterwards along with what changed. A dry run changes nothing, and returns the
+// schedule as it is, or nil if it would be created. desired must already be validated.
+func applySchedule(ctx context.Context, q models.Querier, organizationID uuid.UUID, desired *com.Schedule, dryRun bool) (*com.Schedule, []*com.ManifestChange, error) {
+	existing, err := findScheduleByName(ctx, q, organizationID, desired.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	changes := manifest.Diff(existing, desired)
+	if dryRun {
+		return existing, changes, nil
+	}
+
+	scheduleID := uuid.Nil
+	if existing == nil {
+		row, err := q.InsertSchedule(ctx, models.InsertScheduleParams{
+			Name:           &desired.Name,
+			State:          &desired.State,
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to insert schedule")
+		}
+		for _, container := range desired.Containers {
+			if _, err := insertContainer(ctx, q, row.ID, container); err != nil {
+				return nil, nil, err
+			}
+		}
+		scheduleID = row.ID
+	} else {
+		if len(changes) > 0 {
+			if err := applyChanges(ctx, q, organizationID, existing, desired, changes); err != nil {
+				return nil, nil, err
+			}
+		}
+		scheduleID = uuid.MustParse(existing.Id)
+	}
+
+	schedule, err := loadSchedule(ctx, q, scheduleID, organizationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(changes) > 0 {
+		if err := audit(ctx, q, organizationID, "schedule.apply", schedule.Id, existing, schedule); err != nil {
+			return nil, nil, err
+		}
+	}
+	return schedule, changes, nil
+}
+
+func (s *scheduleServer) ApplyManifest(ctx context.Context, req *connect.Request[com.ApplyManifestRequest]) (*connect.Response[com.ApplyManifestResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	m, err := manifest.Parse([]byte(req.Msg.GetManifest()))
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	desired, err := m.ToSchedule()
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	if err := pkg.ValidateSchedule(desired); err != nil {
+		return nil, validationError(err)
+	}
+
+	resp := &com.ApplyManifestResponse{}
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		resp.Schedule, resp.Changes, err = applySchedule(ctx, q, organizationID, desired, req.Msg.GetDryRun())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !req.Msg.GetDryRun() && len(resp.Changes) > 0 {
+		slog.InfoContext(ctx, "Applied manifest", pkg.LogKeyScheduleID, resp.Schedule.Id, "name", resp.Schedule.Name, "changes", len(resp.Changes))
+	}
+
+	return &connect.Response[com.ApplyManifestResponse]{
+		Msg: resp,
+	}, nil
+}
+
+func (s *scheduleServer) ExportSchedule(ctx context.Context, req *connect.Request[com.ExportScheduleRequest]) (*connect.Response[com.ExportScheduleResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := findSchedule(ctx, s.db.Q, organizationID, req.Msg.GetSchedule())
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := manifest.FromSchedule(schedule).Encode(req.Msg.GetFormat())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	return &connect.Response[com.ExportScheduleResponse]{
+		Msg: &com.ExportScheduleResponse{Manifest: string(encoded)},
+	}, nil
+}
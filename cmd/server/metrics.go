@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/parrotmac/goutil"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/uinta-labs/pando/models"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+// A scrape gives up on counting devices after this long, rather than holding the scrape open
+const deviceMetricsTimeout = 5 * time.Second
+
+// rpcMetrics counts every RPC the server handles, and how long each took, by procedure and result code.
+type rpcMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+var _ connect.Interceptor = &rpcMetrics{}
+
+func newRPCMetrics(registry prometheus.Registerer) *rpcMetrics {
+	m := &rpcMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pando_rpc_requests_total",
+			Help: "RPCs handled, by procedure and result code.",
+		}, []string{"procedure", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "pando_rpc_duration_seconds",
+			Help:    "Time taken to handle RPCs, by procedure and result code. Streams count from open to close.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"procedure", "code"}),
+	}
+	registry.MustRegister(m.requests, m.duration)
+	return m
+}
+
+func (m *rpcMetrics) observe(procedure string, startedAt time.Time, err error) {
+	code := "ok"
+	if err != nil {
+		code = connect.CodeOf(err).String()
+	}
+	m.requests.WithLabelValues(procedure, code).Inc()
+	m.duration.WithLabelValues(procedure, code).Observe(time.Since(startedAt).Seconds())
+}
+
+func (m *rpcMetrics) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		startedAt := time.Now()
+		resp, err := next(ctx, req)
+		m.observe(req.Spec().Procedure, startedAt, err)
+		return resp, err
+	}
+}
+
+func (m *rpcMetrics) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (m *rpcMetrics) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		startedAt := time.Now()
+		err := next(ctx, conn)
+		m.observe(conn.Spec().Procedure, startedAt, err)
+		return err
+	}
+}
+
+// deviceCollector counts devices by status across every organization, querying the database on each scrape.
+type deviceCollector struct {
+	cfg     pkg.Config
+	q       models.Querier
+	devices *prometheus.Desc
+}
+
+func newDeviceCollector(cfg pkg.Config, q models.Querier) *deviceCollector {
+	return &deviceCollector{
+		cfg: cfg,
+		q:   q,
+		devices: prometheus.NewDesc("pando_devices", "Devices by status: online, stale or offline.",
+			[]string{"status"}, nil),
+	}
+}
+
+func (c *deviceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.devices
+}
+
+func (c *deviceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), deviceMetricsTimeout)
+	defer cancel()
+	onlineAfter, offlineAfter := c.cfg.DeviceStatusCutoffs(time.Now().UTC())
+	counts, err := c.q.CountDevicesByStatus(ctx, &onlineAfter, &offlineAfter)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to count devices for metrics", pkg.Err(err))
+		ch <- prometheus.NewInvalidMetric(c.devices, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.devices, prometheus.GaugeValue, float64(goutil.UnwrapOr(counts.Online, 0)), pkg.DeviceOnline)
+	ch <- prometheus.MustNewConstMetric(c.devices, prometheus.GaugeValue, float64(goutil.UnwrapOr(counts.Stale, 0)), pkg.DeviceStale)
+	ch <- prometheus.MustNewConstMetric(c.devices, prometheus.GaugeValue, float64(goutil.UnwrapOr(counts.Offline, 0)), pkg.DeviceOffline)
+}
@@ -0,0 +1,371 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v4"
+	"github.com/parrotmac/goutil"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/models"
+	"github.com/uinta-labs/pando/pkg"
+	"github.com/uinta-labs/pando/pkg/db"
+)
+
+// organizationServer implements OrganizationService, which lets callers find their organizations, manage their
+// members, and manage the API keys that scripts and CI use in place of a web session.
+type organizationServer struct {
+	db   *db.DB
+	auth *pkg.Authenticator
+}
+
+type apiKeyRow = models.ListAPIKeysForOrganizationRow
+
+func apiKeyFromRow(row apiKeyRow) *com.APIKey {
+	apiKey := &com.APIKey{
+		Id:             row.ID.String(),
+		OrganizationId: row.OrganizationID.String(),
+		Name:           goutil.UnwrapOr(row.Name, ""),
+		Role:           goutil.UnwrapOr(row.Role, ""),
+	}
+	if row.CreatedAt != nil {
+		apiKey.CreatedAt = timestamppb.New(*row.CreatedAt)
+	}
+	if row.RevokedAt != nil {
+		apiKey.RevokedAt = timestamppb.New(*row.RevokedAt)
+	}
+	return apiKey
+}
+
+func (s *organizationServer) ListOrganizations(ctx context.Context, req *connect.Request[com.ListOrganizationsRequest]) (*connect.Response[com.ListOrganizationsResponse], error) {
+	principal, ok := pkg.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing credentials"))
+	}
+
+	organizations := []*com.Organization{}
+	if principal.IsAPIKey() {
+		// an API key only ever sees the organization it was created in
+		row, err := s.db.Q.GetOrganizationByID(ctx, principal.OrganizationID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get organization")
+		}
+		organizations = append(organizations, &com.Organization{
+			Id:   row.ID.String(),
+			Name: goutil.UnwrapOr(row.Name, ""),
+			Role: string(principal.Role),
+		})
+	} else {
+		if principal.Email != "" {
+			if _, err := s.db.Q.AcceptOrganizationInvitations(ctx, &principal.Email, principal.UserID); err != nil {
+				return nil, errors.Wrap(err, "failed to accept invitations")
+			}
+		}
+		rows, err := s.db.Q.ListOrganizationsForUser(ctx, principal.UserID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list organizations")
+		}
+		for _, row := range rows {
+			organizations = append(organizations, &com.Organization{
+				Id:   row.ID.String(),
+				Name: goutil.UnwrapOr(row.Name, ""),
+				Role: goutil.UnwrapOr(row.Role, ""),
+			})
+		}
+	}
+
+	return &connect.Response[com.ListOrganizationsResponse]{
+		Msg: &com.ListOrganizationsResponse{Organizations: organizations},
+	}, nil
+}
+
+func (s *organizationServer) CreateAPIKey(ctx context.Context, req *connect.Request[com.CreateAPIKeyRequest]) (*connect.Response[com.CreateAPIKeyResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Msg.GetName())
+	if name == "" {
+		return nil, invalidArgument(errors.New("name is required"))
+	}
+	role := pkg.Role(req.Msg.GetRole())
+	if role == "" {
+		role = pkg.RoleOperator
+	}
+	// uuid.Nil (no user) when an API key creates another one; stored as NULL
+	principal, _ := pkg.PrincipalFromContext(ctx)
+	if err := requireGrantableRole(principal, role); err != nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := pkg.NewAPIKeyToken()
+	if err != nil {
+		return nil, err
+	}
+	var apiKey *com.APIKey
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		row, err := q.InsertAPIKey(ctx, models.InsertAPIKeyParams{
+			OrganizationID:  organizationID,
+			Name:            &name,
+			TokenHash:       &tokenHash,
+			CreatedByUserID: principal.UserID,
+			Role:            (*string)(&role),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to insert API key")
+		}
+		apiKey = apiKeyFromRow(apiKeyRow(row))
+		return audit(ctx, q, organizationID, "api_key.create", apiKey.Id, nil, apiKey)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Created API key", "api_key_id", apiKey.Id, "name", name, "organization_id", organizationID)
+
+	return &connect.Response[com.CreateAPIKeyResponse]{
+		Msg: &com.CreateAPIKeyResponse{
+			ApiKey: apiKey,
+			Token:  token,
+		},
+	}, nil
+}
+
+func (s *organizationServer) ListAPIKeys(ctx context.Context, req *connect.Request[com.ListAPIKeysRequest]) (*connect.Response[com.ListAPIKeysResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Q.ListAPIKeysForOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list API keys")
+	}
+	apiKeys := make([]*com.APIKey, 0, len(rows))
+	for _, row := range rows {
+		apiKeys = append(apiKeys, apiKeyFromRow(row))
+	}
+
+	return &connect.Response[com.ListAPIKeysResponse]{
+		Msg: &com.ListAPIKeysResponse{ApiKeys: apiKeys},
+	}, nil
+}
+
+func (s *organizationServer) RevokeAPIKey(ctx context.Context, req *connect.Request[com.RevokeAPIKeyRequest]) (*connect.Response[com.RevokeAPIKeyResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	apiKeyID, err := parseID("API key", req.Msg.GetApiKeyId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		tag, err := q.RevokeAPIKey(ctx, apiKeyID, organizationID)
+		if err != nil {
+			return errors.Wrap(err, "failed to revoke API key")
+		}
+		if tag.RowsAffected() == 0 {
+			return connect.NewError(connect.CodeNotFound, errors.Errorf("active API key %s not found", apiKeyID))
+		}
+		return audit(ctx, q, organizationID, "api_key.revoke", apiKeyID.String(), nil, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Revoked API key", "api_key_id", apiKeyID)
+
+	return &connect.Response[com.RevokeAPIKeyResponse]{
+		Msg: &com.RevokeAPIKeyResponse{},
+	}, nil
+}
+
+// requireGrantableRole fails unless role exists and principal may hand it out, which needs at least that role.
+func requireGrantableRole(principal *pkg.Principal, role pkg.Role) error {
+	if !role.Valid() {
+		return invalidArgument(errors.Errorf("unknown role %q; use one of %v", role, pkg.Roles))
+	}
+	if !principal.Role.AtLeast(role) {
+		return connect.NewError(connect.CodePermissionDenied, errors.Errorf("role %s cannot grant role %s", principal.Role, role))
+	}
+	return nil
+}
+
+func invitationFromRow(row models.UpsertOrganizationInvitationRow) *com.Invitation {
+	invitation := &com.Invitation{
+		Id:    row.ID.String(),
+		Email: goutil.UnwrapOr(row.Email, ""),
+		Role:  goutil.UnwrapOr(row.Role, ""),
+	}
+	if row.CreatedAt != nil {
+		invitation.CreatedAt = timestamppb.New(*row.CreatedAt)
+	}
+	return invitation
+}
+
+func (s *organizationServer) ListMembers(ctx context.Context, req *connect.Request[com.ListMembersRequest]) (*connect.Response[com.ListMembersResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.db.Q.ListOrganizationMembers(ctx, organizationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list members")
+	}
+	invitations, err := s.db.Q.ListOrganizationInvitations(ctx, organizationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list invitations")
+	}
+	resp := &com.ListMembersResponse{
+		Members:     make([]*com.Member, 0, len(members)),
+		Invitations: make([]*com.Invitation, 0, len(invitations)),
+	}
+	for _, row := range members {
+		resp.Members = append(resp.Members, &com.Member{
+			UserId: row.UserID.String(),
+			Email:  goutil.UnwrapOr(row.Email, ""),
+			Role:   goutil.UnwrapOr(row.Role, ""),
+		})
+	}
+	for _, row := range invitations {
+		resp.Invitations = append(resp.Invitations, invitationFromRow(models.UpsertOrganizationInvitationRow(row)))
+	}
+
+	return &connect.Response[com.ListMembersResponse]{
+		Msg: resp,
+	}, nil
+}
+
+func (s *organizationServer) InviteUser(ctx context.Context, req *connect.Request[com.InviteUserRequest]) (*connect.Response[com.InviteUserResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	email := strings.TrimSpace(req.Msg.GetEmail())
+	if !strings.Contains(email, "@") {
+		return nil, invalidArgument(errors.Errorf("invalid email %q", email))
+	}
+	role := pkg.Role(req.Msg.GetRole())
+	principal, _ := pkg.PrincipalFromContext(ctx)
+	if err := requireGrantableRole(principal, role); err != nil {
+		return nil, err
+	}
+
+	resp := &com.InviteUserResponse{}
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		user, err := q.GetUserByEmail(ctx, &email)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return errors.Wrap(err, "failed to look up user")
+		}
+		if err != nil {
+			// no such user yet; they join when they first call the API after signing up
+			row, err := q.UpsertOrganizationInvitation(ctx, models.UpsertOrganizationInvitationParams{
+				OrganizationID:  organizationID,
+				Email:           &email,
+				Role:            (*string)(&role),
+				InvitedByUserID: principal.UserID,
+			})
+			if err != nil {
+				return errors.Wrap(err, "failed to record invitation")
+			}
+			resp.Invitation = invitationFromRow(row)
+			return audit(ctx, q, organizationID, "invitation.create", resp.Invitation.Id, nil, resp.Invitation)
+		}
+
+		if _, err := q.GetOrganizationRole(ctx, user.ID, organizationID); err == nil {
+			return connect.NewError(connect.CodeAlreadyExists, errors.Errorf("%s is already a member; change their role instead", email))
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			return errors.Wrap(err, "failed to look up membership")
+		}
+		_, err = q.InsertOrganizationUser(ctx, models.InsertOrganizationUserParams{
+			UserID:         user.ID,
+			OrganizationID: organizationID,
+			Role:           (*string)(&role),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to add member")
+		}
+		resp.Member = &com.Member{UserId: user.ID.String(), Email: goutil.UnwrapOr(user.Email, ""), Role: string(role)}
+		return audit(ctx, q, organizationID, "member.add", resp.Member.UserId, nil, resp.Member)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Invited user", "email", email, "role", role, "organization_id", organizationID)
+
+	return &connect.Response[com.InviteUserResponse]{
+		Msg: resp,
+	}, nil
+}
+
+func (s *organizationServer) SetMemberRole(ctx context.Context, req *connect.Request[com.SetMemberRoleRequest]) (*connect.Response[com.SetMemberRoleResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	userID, err := parseID("user", req.Msg.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	role := pkg.Role(req.Msg.GetRole())
+	principal, _ := pkg.PrincipalFromContext(ctx)
+	if err := requireGrantableRole(principal, role); err != nil {
+		return nil, err
+	}
+
+	var member *com.Member
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		current, err := q.GetOrganizationRole(ctx, userID, organizationID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return connect.NewError(connect.CodeNotFound, errors.Errorf("user %s is not a member", userID))
+			}
+			return errors.Wrap(err, "failed to look up membership")
+		}
+		currentRole := pkg.Role(goutil.UnwrapOr(current, ""))
+		// nor can anyone take away a role they could not have granted
+		if currentRole.Valid() && !principal.Role.AtLeast(currentRole) {
+			return connect.NewError(connect.CodePermissionDenied, errors.Errorf("role %s cannot change the role of a member with role %s", principal.Role, currentRole))
+		}
+
+		_, err = q.SetOrganizationUserRole(ctx, models.SetOrganizationUserRoleParams{
+			Role:           (*string)(&role),
+			UserID:         userID,
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to set role")
+		}
+		if currentRole == pkg.RoleOwner && role != pkg.RoleOwner {
+			owners, err := q.CountOrganizationOwners(ctx, organizationID)
+			if err != nil {
+				return errors.Wrap(err, "failed to count owners")
+			}
+			if goutil.UnwrapOr(owners, 0) == 0 {
+				return connect.NewError(connect.CodeFailedPrecondition, errors.New("an organization must keep at least one owner"))
+			}
+		}
+
+		user, err := q.GetUserByID(ctx, userID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get user")
+		}
+		before := &com.Member{UserId: userID.String(), Email: goutil.UnwrapOr(user.Email, ""), Role: string(currentRole)}
+		member = &com.Member{UserId: userID.String(), Email: goutil.UnwrapOr(user.Email, ""), Role: string(role)}
+		return audit(ctx, q, organizationID, "member.set_role", member.UserId, before, member)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Set member role", "user_id", userID, "role", role, "organization_id", organizationID)
+
+	return &connect.Response[com.SetMemberRoleResponse]{
+		Msg: &com.SetMemberRoleResponse{Member: member},
+	}, nil
+}
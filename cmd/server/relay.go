@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+)
+
+const (
+	// Agents' PollActions calls are answered within this, comfortably inside the HTTP server's write timeout
+	actionPollTimeout = 20 * time.Second
+	// Log lines kept per device for GetContainerLogs
+	logBufferSize = 5000
+)
+
+// relay hands operator actions to devices and device logs back to operators. It is kept in memory, so it
+// assumes a single server instance, and queued actions and buffered logs do not survive a restart.
+type relay struct {
+	mu      sync.Mutex
+	devices map[uuid.UUID]*deviceRelay
+}
+
+type deviceRelay struct {
+	actions []*com.DeviceAction
+	// closed, and replaced, whenever an action is queued, to wake a waiting PollActions
+	queued chan struct{}
+	// action ID -> where its result is delivered
+	results map[string]chan *com.ReportActionResultRequest
+
+	logs         []*com.LogEntry
+	nextSequence int64
+}
+
+func newRelay() *relay {
+	return &relay{
+		devices: map[uuid.UUID]*deviceRelay{},
+	}
+}
+
+// device must be called with r.mu held.
+func (r *relay) device(deviceID uuid.UUID) *deviceRelay {
+	d, ok := r.devices[deviceID]
+	if !ok {
+		d = &deviceRelay{
+			queued:       make(chan struct{}),
+			results:      map[string]chan *com.ReportActionResultRequest{},
+			nextSequence: 1,
+		}
+		r.devices[deviceID] = d
+	}
+	return d
+}
+
+// enqueue queues action for the device and returns where its result will be delivered.
+func (r *relay) enqueue(deviceID uuid.UUID, action *com.DeviceAction) <-chan *com.ReportActionResultRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.device(deviceID)
+	result := make(chan *com.ReportActionResultRequest, 1)
+	d.results[action.Id] = result
+	d.actions = append(d.actions, action)
+	close(d.queued)
+	d.queued = make(chan struct{})
+	return result
+}
+
+// cancel forgets an action whose caller stopped waiting, whether or not the device picked it up yet.
+func (r *relay) cancel(deviceID uuid.UUID, actionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.device(deviceID)
+	delete(d.results, actionID)
+	for i, action := range d.actions {
+		if action.Id == actionID {
+			d.actions = append(d.actions[:i], d.actions[i+1:]...)
+			break
+		}
+	}
+}
+
+// poll waits until at least one action is queued for the device, or until wait or ctx runs out, and takes
+// every queued action.
+func (r *relay) poll(ctx context.Context, deviceID uuid.UUID, wait time.Duration) []*com.DeviceAction {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		r.mu.Lock()
+		d := r.device(deviceID)
+		if len(d.actions) > 0 {
+			actions := d.actions
+			d.actions = nil
+			r.mu.Unlock()
+			return actions
+		}
+		queued := d.queued
+		r.mu.Unlock()
+
+		select {
+		case <-queued:
+		case <-timer.C:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// complete delivers an action's result, and reports false if nobody is waiting for it any more.
+func (r *relay) complete(deviceID uuid.UUID, result *com.ReportActionResultRequest) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.device(deviceID)
+	waiting, ok := d.results[result.ActionId]
+	if !ok {
+		return false
+	}
+	delete(d.results, result.ActionId)
+	waiting <- result
+	return true
+}
+
+func (r *relay) appendLogs(deviceID uuid.UUID, lines []*com.LogLine) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.device(deviceID)
+	for _, line := range lines {
+		d.logs = append(d.logs, &com.LogEntry{
+			Sequence:  d.nextSequence,
+			Container: line.Container,
+			Time:      line.Time,
+			Line:      line.Line,
+		})
+		d.nextSequence++
+	}
+	if overflow := len(d.logs) - logBufferSize; overflow > 0 {
+		d.logs = append([]*com.LogEntry(nil), d.logs[overflow:]...)
+	}
+}
+
+// logs returns the last tail buffered lines after afterSequence, optionally only from one container, and the
+// sequence to pass as afterSequence to follow on from them.
+func (r *relay) logs(deviceID uuid.UUID, container string, afterSequence int64, tail int) ([]*com.LogEntry, int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.device(deviceID)
+	entries := []*com.LogEntry{}
+	for _, entry := range d.logs {
+		if entry.Sequence <= afterSequence {
+			continue
+		}
+		if container != "" && entry.Container != container {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) > tail {
+		entries = entries[len(entries)-tail:]
+	}
+	return entries, d.nextSequence - 1
+}
@@ -0,0 +1,445 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/models"
+	"github.com/uinta-labs/pando/pkg"
+	"github.com/uinta-labs/pando/pkg/db"
+)
+
+const defaultScheduleState = "active"
+
+// scheduleServer implements ScheduleService, which manages an organization's schedules and their containers.
+type scheduleServer struct {
+	db   *db.DB
+	auth *pkg.Authenticator
+}
+
+func invalidArgument(err error) error {
+	return connect.NewError(connect.CodeInvalidArgument, err)
+}
+
+// validationError turns a failed pkg.ValidateSchedule into an InvalidArgument error.
+func validationError(err error) error {
+	var validationErr *pkg.ValidationError
+	if errors.As(err, &validationErr) {
+		return invalidArgument(validationErr)
+	}
+	return err
+}
+
+func parseID(kind string, id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, invalidArgument(errors.Wrapf(err, "invalid %s id", kind))
+	}
+	return parsed, nil
+}
+
+// loadSchedule returns the schedule with its containers, or NotFound if it doesn't belong to the organization.
+func loadSchedule(ctx context.Context, q models.Querier, scheduleID uuid.UUID, organizationID uuid.UUID) (*com.Schedule, error) {
+	row, err := q.GetScheduleForOrganization(ctx, scheduleID, organizationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.Errorf("schedule %s not found", scheduleID))
+		}
+		return nil, errors.Wrap(err, "failed to get schedule")
+	}
+	rows, err := q.GetContainersForSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get schedule containers")
+	}
+	containers := make([]*com.Container, 0, len(rows))
+	for _, row := range rows {
+		container, err := containerFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, container)
+	}
+	return scheduleFromRow(row, containers), nil
+}
+
+func insertContainer(ctx context.Context, q models.Querier, scheduleID uuid.UUID, container *com.Container) (*com.Container, error) {
+	columns, err := containerColumns(container)
+	if err != nil {
+		return nil, err
+	}
+	row, err := q.InsertContainer(ctx, models.InsertContainerParams{
+		ScheduleID:             scheduleID,
+		Name:                   columns.Name,
+		ContainerImage:         columns.ContainerImage,
+		Env:                    columns.Env,
+		Privileged:             columns.Privileged,
+		NetworkMode:            columns.NetworkMode,
+		Ports:                  columns.Ports,
+		BindDev:                columns.BindDev,
+		BindProc:               columns.BindProc,
+		BindSys:                columns.BindSys,
+		BindShm:                columns.BindShm,
+		BindCgroup:             columns.BindCgroup,
+		BindDockerSocket:       columns.BindDockerSocket,
+		BindBoot:               columns.BindBoot,
+		Command:                columns.Command,
+		Entrypoint:             columns.Entrypoint,
+		StopSignal:             columns.StopSignal,
+		StopGracePeriodSeconds: columns.StopGracePeriodSeconds,
+		UpdateStrategy:         columns.UpdateStrategy,
+		Job:                    columns.Job,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to insert container %s", container.Name)
+	}
+	return containerFromRow(containerRow(row))
+}
+
+func (s *scheduleServer) ListSchedules(ctx context.Context, req *connect.Request[com.ListSchedulesRequest]) (*connect.Response[com.ListSchedulesResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Q.ListSchedulesForOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list schedules")
+	}
+	scheduleIDs := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		scheduleIDs = append(scheduleIDs, row.ID)
+	}
+	containerRows, err := s.db.Q.GetContainersForSchedules(ctx, scheduleIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get schedule containers")
+	}
+	containers := map[uuid.UUID][]*com.Container{}
+	for _, row := range containerRows {
+		container, err := containerFromRow(containerRow(row))
+		if err != nil {
+			return nil, err
+		}
+		containers[row.ScheduleID] = append(containers[row.ScheduleID], container)
+	}
+
+	schedules := make([]*com.Schedule, 0, len(rows))
+	for _, row := range rows {
+		schedules = append(schedules, scheduleFromRow(scheduleRow(row), containers[row.ID]))
+	}
+
+	return &connect.Response[com.ListSchedulesResponse]{
+		Msg: &com.ListSchedulesResponse{Schedules: schedules},
+	}, nil
+}
+
+func (s *scheduleServer) DescribeSchedule(ctx context.Context, req *connect.Request[com.DescribeScheduleRequest]) (*connect.Response[com.DescribeScheduleResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	scheduleID, err := parseID("schedule", req.Msg.GetScheduleId())
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := loadSchedule(ctx, s.db.Q, scheduleID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &connect.Response[com.DescribeScheduleResponse]{
+		Msg: &com.DescribeScheduleResponse{Schedule: schedule},
+	}, nil
+}
+
+func (s *scheduleServer) CreateSchedule(ctx context.Context, req *connect.Request[com.CreateScheduleRequest]) (*connect.Response[com.CreateScheduleResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+
+	requested := &com.Schedule{
+		Name:       strings.TrimSpace(req.Msg.GetName()),
+		State:      req.Msg.GetState(),
+		Containers: req.Msg.GetContainers(),
+	}
+	if requested.State == "" {
+		requested.State = defaultScheduleState
+	}
+	if err := pkg.ValidateSchedule(requested); err != nil {
+		return nil, validationError(err)
+	}
+
+	var schedule *com.Schedule
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		row, err := q.InsertSchedule(ctx, models.InsertScheduleParams{
+			Name:           &requested.Name,
+			State:          &requested.State,
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to insert schedule")
+		}
+		containers := make([]*com.Container, 0, len(requested.Containers))
+		for _, container := range requested.Containers {
+			inserted, err := insertContainer(ctx, q, row.ID, container)
+			if err != nil {
+				return err
+			}
+			containers = append(containers, inserted)
+		}
+		schedule = scheduleFromRow(scheduleRow(row), containers)
+		return audit(ctx, q, organizationID, "schedule.create", schedule.Id, nil, schedule)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Created schedule", pkg.LogKeyScheduleID, schedule.Id, "name", schedule.Name, "containers", len(schedule.Containers))
+
+	return &connect.Response[com.CreateScheduleResponse]{
+		Msg: &com.CreateScheduleResponse{Schedule: schedule},
+	}, nil
+}
+
+func (s *scheduleServer) UpdateSchedule(ctx context.Context, req *connect.Request[com.UpdateScheduleRequest]) (*connect.Response[com.UpdateScheduleResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	scheduleID, err := parseID("schedule", req.Msg.GetScheduleId())
+	if err != nil {
+		return nil, err
+	}
+
+	var schedule *com.Schedule
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		existing, err := loadSchedule(ctx, q, scheduleID, organizationID)
+		if err != nil {
+			return err
+		}
+		before := proto.Clone(existing)
+		// empty fields are left unchanged
+		if name := strings.TrimSpace(req.Msg.GetName()); name != "" {
+			existing.Name = name
+		}
+		if state := req.Msg.GetState(); state != "" {
+			existing.State = state
+		}
+		if err := pkg.ValidateSchedule(existing); err != nil {
+			return validationError(err)
+		}
+
+		row, err := q.UpdateSchedule(ctx, models.UpdateScheduleParams{
+			Name:           &existing.Name,
+			State:          &existing.State,
+			ScheduleID:     scheduleID,
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to update schedule")
+		}
+		schedule = scheduleFromRow(scheduleRow(row), existing.Containers)
+		return audit(ctx, q, organizationID, "schedule.update", schedule.Id, before, schedule)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &connect.Response[com.UpdateScheduleResponse]{
+		Msg: &com.UpdateScheduleResponse{Schedule: schedule},
+	}, nil
+}
+
+func (s *scheduleServer) DeleteSchedule(ctx context.Context, req *connect.Request[com.DeleteScheduleRequest]) (*connect.Response[com.DeleteScheduleResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	scheduleID, err := parseID("schedule", req.Msg.GetScheduleId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		existing, err := loadSchedule(ctx, q, scheduleID, organizationID)
+		if err != nil {
+			return err
+		}
+		fleets, err := q.CountFleetsUsingSchedule(ctx, scheduleID)
+		if err != nil {
+			return errors.Wrap(err, "failed to count fleets using schedule")
+		}
+		if fleets != nil && *fleets > 0 {
+			return connect.NewError(connect.CodeFailedPrecondition, errors.Errorf("schedule is the default for %d fleet(s)", *fleets))
+		}
+		// containers only reference their schedule with ON DELETE SET NULL, so remove them explicitly
+		if _, err := q.DeleteContainersForSchedule(ctx, scheduleID); err != nil {
+			return errors.Wrap(err, "failed to delete schedule containers")
+		}
+		if _, err := q.DeleteSchedule(ctx, scheduleID, organizationID); err != nil {
+			return errors.Wrap(err, "failed to delete schedule")
+		}
+		return audit(ctx, q, organizationID, "schedule.delete", existing.Id, existing, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Deleted schedule", pkg.LogKeyScheduleID, scheduleID)
+
+	return &connect.Response[com.DeleteScheduleResponse]{
+		Msg: &com.DeleteScheduleResponse{},
+	}, nil
+}
+
+func (s *scheduleServer) CreateContainer(ctx context.Context, req *connect.Request[com.CreateContainerRequest]) (*connect.Response[com.CreateContainerResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	scheduleID, err := parseID("schedule", req.Msg.GetScheduleId())
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.GetContainer() == nil {
+		return nil, invalidArgument(errors.New("container is required"))
+	}
+
+	var container *com.Container
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		schedule, err := loadSchedule(ctx, q, scheduleID, organizationID)
+		if err != nil {
+			return err
+		}
+		schedule.Containers = append(schedule.Containers, req.Msg.GetContainer())
+		if err := pkg.ValidateSchedule(schedule); err != nil {
+			return validationError(err)
+		}
+
+		container, err = insertContainer(ctx, q, scheduleID, req.Msg.GetContainer())
+		if err != nil {
+			return err
+		}
+		if _, err := q.TouchSchedule(ctx, scheduleID); err != nil {
+			return errors.Wrap(err, "failed to touch schedule")
+		}
+		return audit(ctx, q, organizationID, "container.create", container.Id, nil, container)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &connect.Response[com.CreateContainerResponse]{
+		Msg: &com.CreateContainerResponse{Container: container},
+	}, nil
+}
+
+func (s *scheduleServer) UpdateContainer(ctx context.Context, req *connect.Request[com.UpdateContainerRequest]) (*connect.Response[com.UpdateContainerResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.GetContainer() == nil {
+		return nil, invalidArgument(errors.New("container is required"))
+	}
+	containerID, err := parseID("container", req.Msg.GetContainer().GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	var container *com.Container
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		existing, err := q.GetContainerForOrganization(ctx, containerID, organizationID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return connect.NewError(connect.CodeNotFound, errors.Errorf("container %s not found", containerID))
+			}
+			return errors.Wrap(err, "failed to get container")
+		}
+		schedule, err := loadSchedule(ctx, q, existing.ScheduleID, organizationID)
+		if err != nil {
+			return err
+		}
+		var before *com.Container
+		for i, sibling := range schedule.Containers {
+			if sibling.Id == containerID.String() {
+				before = sibling
+				schedule.Containers[i] = req.Msg.GetContainer()
+			}
+		}
+		if err := pkg.ValidateSchedule(schedule); err != nil {
+			return validationError(err)
+		}
+
+		params, err := containerColumns(req.Msg.GetContainer())
+		if err != nil {
+			return err
+		}
+		params.ContainerID = containerID
+		row, err := q.UpdateContainer(ctx, params)
+		if err != nil {
+			return errors.Wrap(err, "failed to update container")
+		}
+		if _, err := q.TouchSchedule(ctx, existing.ScheduleID); err != nil {
+			return errors.Wrap(err, "failed to touch schedule")
+		}
+		container, err = containerFromRow(containerRow(row))
+		if err != nil {
+			return err
+		}
+		return audit(ctx, q, organizationID, "container.update", container.Id, before, container)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &connect.Response[com.UpdateContainerResponse]{
+		Msg: &com.UpdateContainerResponse{Container: container},
+	}, nil
+}
+
+func (s *scheduleServer) DeleteContainer(ctx context.Context, req *connect.Request[com.DeleteContainerRequest]) (*connect.Response[com.DeleteContainerResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	containerID, err := parseID("container", req.Msg.GetContainerId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		existing, err := q.GetContainerForOrganization(ctx, containerID, organizationID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return connect.NewError(connect.CodeNotFound, errors.Errorf("container %s not found", containerID))
+			}
+			return errors.Wrap(err, "failed to get container")
+		}
+		before, err := containerFromRow(containerRow(existing))
+		if err != nil {
+			return err
+		}
+		if _, err := q.DeleteContainer(ctx, containerID); err != nil {
+			return errors.Wrap(err, "failed to delete container")
+		}
+		if _, err := q.TouchSchedule(ctx, existing.ScheduleID); err != nil {
+			return errors.Wrap(err, "failed to touch schedule")
+		}
+		return audit(ctx, q, organizationID, "container.delete", containerID.String(), before, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &connect.Response[com.DeleteContainerResponse]{
+		Msg: &com.DeleteContainerResponse{},
+	}, nil
+}
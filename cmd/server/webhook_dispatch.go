@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/parrotmac/goutil"
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/models"
+	"github.com/uinta-labs/pando/pkg"
+	"github.com/uinta-labs/pando/pkg/db"
+)
+
+const (
+	webhookDeliveryPending   = "pending"
+	webhookDeliverySucceeded = "succeeded"
+	webhookDeliveryFailed    = "failed"
+
+	webhookPollInterval = 5 * time.Second
+	webhookBatchSize    = 20
+	webhookTimeout      = 10 * time.Second
+	// Longer than a whole batch can take, so a claimed delivery is only retried by another server if this one died
+	webhookLease = time.Minute
+
+	// Retries back off exponentially from webhookRetryDelay up to webhookMaxRetryDelay, about 15 hours in all
+	webhookMaxAttempts   = 12
+	webhookRetryDelay    = 30 * time.Second
+	webhookMaxRetryDelay = 6 * time.Hour
+
+	webhookDeliveryRetention = 30 * 24 * time.Hour
+	webhookPurgeInterval     = time.Hour
+	maxWebhookErrorLength    = 500
+)
+
+// webhookDispatcher sends queued webhook deliveries, retrying failures, until its context is done. Deliveries are
+// leased rather than locked while being sent, so several servers can run dispatchers against the same database.
+type webhookDispatcher struct {
+	db     *db.DB
+	client *http.Client
+}
+
+func newWebhookDispatcher(db *db.DB) *webhookDispatcher {
+	return &webhookDispatcher{
+		db:     db,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (d *webhookDispatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+	lastPurge := time.Time{}
+	for {
+		// keep going while there is a backlog
+		for ctx.Err() == nil {
+			if d.dispatchBatch(ctx) < webhookBatchSize {
+				break
+			}
+		}
+		if time.Since(lastPurge) >= webhookPurgeInterval {
+			d.purge(ctx)
+			lastPurge = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchBatch claims due deliveries and sends them concurrently, returning how many it claimed.
+func (d *webhookDispatcher) dispatchBatch(ctx context.Context) int {
+	batchSize := webhookBatchSize
+	deliveries, err := d.db.Q.ClaimWebhookDeliveries(ctx, int32(webhookLease.Seconds()), &batchSize)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to claim webhook deliveries", pkg.Err(err))
+		return 0
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.deliver(ctx, delivery)
+		}()
+	}
+	wg.Wait()
+	return len(deliveries)
+}
+
+// webhookSignature signs a delivery's body the way the X-Pando-Signature header documents.
+func webhookSignature(secret string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t + "."))
+	mac.Write(body)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookRetryAfter is how long to wait after the given number of failed attempts.
+func webhookRetryAfter(attempts int32) time.Duration {
+	delay := webhookRetryDelay
+	for i := int32(1); i < attempts && delay < webhookMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, webhookMaxRetryDelay)
+}
+
+func (d *webhookDispatcher) deliver(ctx context.Context, delivery models.ClaimWebhookDeliveriesRow) {
+	responseStatus, err := d.send(ctx, delivery)
+	if ctx.Err() != nil {
+		// shutting down; the lease runs out and the delivery is sent again later
+		return
+	}
+
+	params := models.RecordWebhookDeliveryAttemptParams{
+		ResponseStatus: int32(responseStatus),
+		DeliveryID:     delivery.ID,
+	}
+	status := webhookDeliverySucceeded
+	if err != nil {
+		attempts := delivery.Attempts + 1
+		status = webhookDeliveryPending
+		if attempts >= webhookMaxAttempts {
+			status = webhookDeliveryFailed
+		}
+		lastError := err.Error()
+		if len(lastError) > maxWebhookErrorLength {
+			lastError = lastError[:maxWebhookErrorLength]
+		}
+		params.LastError = &lastError
+		params.RetryAfterSeconds = int32(webhookRetryAfter(attempts).Seconds())
+		slog.WarnContext(ctx, "Webhook delivery failed", "delivery_id", delivery.ID, "url", goutil.UnwrapOr(delivery.URL, ""), "attempt", attempts, pkg.Err(err))
+	}
+	params.Status = &status
+
+	if _, err := d.db.Q.RecordWebhookDeliveryAttempt(ctx, params); err != nil {
+		slog.ErrorContext(ctx, "Failed to record webhook delivery", "delivery_id", delivery.ID, pkg.Err(err))
+	}
+}
+
+// send POSTs the delivery, returning the endpoint's status code if it responded. Anything but a 2xx is an error.
+func (d *webhookDispatcher) send(ctx context.Context, delivery models.ClaimWebhookDeliveriesRow) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, goutil.UnwrapOr(delivery.URL, ""), bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "pando-webhooks")
+	req.Header.Set("X-Pando-Event", goutil.UnwrapOr(delivery.EventType, ""))
+	req.Header.Set("X-Pando-Delivery", delivery.ID.String())
+	req.Header.Set("X-Pando-Signature", webhookSignature(goutil.UnwrapOr(delivery.Secret, ""), time.Now(), delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// drain a little of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, errors.Errorf("endpoint responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// purge deletes finished deliveries older than webhookDeliveryRetention.
+func (d *webhookDispatcher) purge(ctx context.Context) {
+	cutoff := time.Now().UTC().Add(-webhookDeliveryRetention)
+	tag, err := d.db.Q.DeleteWebhookDeliveriesBefore(ctx, &cutoff)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to purge webhook deliveries", pkg.Err(err))
+	} else if tag.RowsAffected() > 0 {
+		slog.InfoContext(ctx, "Purged webhook deliveries", "deliveries", tag.RowsAffected(), "before", cutoff)
+	}
+}
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"log/slog"
+	"net/url"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/parrotmac/goutil"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/models"
+	"github.com/uinta-labs/pando/pkg"
+	"github.com/uinta-labs/pando/pkg/db"
+)
+
+const (
+	webhookSecretPrefix = "whsec_"
+
+	defaultWebhookDeliveryPageSize = 50
+	maxWebhookDeliveryPageSize     = 500
+)
+
+var webhookDeliveryStatuses = []string{webhookDeliveryPending, webhookDeliverySucceeded, webhookDeliveryFailed}
+
+// webhookServer implements WebhookService, which manages the endpoints an organization's events are sent to.
+type webhookServer struct {
+	db   *db.DB
+	auth *pkg.Authenticator
+}
+
+// webhookRow is the shape shared by every query returning a whole webhook.
+type webhookRow = models.GetWebhookForOrganizationRow
+
+func webhookFromRow(row webhookRow) *com.Webhook {
+	webhook := &com.Webhook{
+		Id:             row.ID.String(),
+		OrganizationId: row.OrganizationID.String(),
+		Url:            goutil.UnwrapOr(row.URL, ""),
+		Description:    goutil.UnwrapOr(row.Description, ""),
+		EventTypes:     row.EventTypes,
+		Enabled:        row.Enabled,
+	}
+	if row.CreatedAt != nil {
+		webhook.CreatedAt = timestamppb.New(*row.CreatedAt)
+	}
+	if row.UpdatedAt != nil {
+		webhook.UpdatedAt = timestamppb.New(*row.UpdatedAt)
+	}
+	return webhook
+}
+
+// webhookDeliveryRow is the shape shared by every query returning a whole delivery.
+type webhookDeliveryRow = models.ListWebhookDeliveriesRow
+
+func webhookDeliveryFromRow(row webhookDeliveryRow) *com.WebhookDelivery {
+	delivery := &com.WebhookDelivery{
+		Id:             row.ID.String(),
+		WebhookId:      row.WebhookID.String(),
+		EventId:        row.EventID.String(),
+		EventType:      goutil.UnwrapOr(row.EventType, ""),
+		Payload:        string(row.Payload),
+		Status:         goutil.UnwrapOr(row.Status, ""),
+		Attempts:       row.Attempts,
+		ResponseStatus: goutil.UnwrapOr(row.ResponseStatus, 0),
+		LastError:      goutil.UnwrapOr(row.LastError, ""),
+	}
+	if row.CreatedAt != nil {
+		delivery.CreatedAt = timestamppb.New(*row.CreatedAt)
+	}
+	if row.LastAttemptAt != nil {
+		delivery.LastAttemptAt = timestamppb.New(*row.LastAttemptAt)
+	}
+	if row.NextAttemptAt != nil && delivery.Status == webhookDeliveryPending {
+		delivery.NextAttemptAt = timestamppb.New(*row.NextAttemptAt)
+	}
+	return delivery
+}
+
+func loadWebhook(ctx context.Context, q models.Querier, webhookID uuid.UUID, organizationID uuid.UUID) (webhookRow, error) {
+	row, err := q.GetWebhookForOrganization(ctx, webhookID, organizationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return row, connect.NewError(connect.CodeNotFound, errors.Errorf("webhook %s not found", webhookID))
+		}
+		return row, errors.Wrap(err, "failed to get webhook")
+	}
+	return row, nil
+}
+
+// validateWebhook checks an endpoint URL and the event types it subscribes to.
+func validateWebhook(endpoint string, types []string) error {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return invalidArgument(errors.Wrap(err, "invalid url"))
+	}
+	if (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return invalidArgument(errors.Errorf("url %q must be an absolute http or https URL", endpoint))
+	}
+	for _, eventType := range types {
+		if !slices.Contains(eventTypes, eventType) {
+			return invalidArgument(errors.Errorf("unknown event type %q; use any of %v", eventType, eventTypes))
+		}
+	}
+	return nil
+}
+
+// subscribedEventTypes stores no event types as an empty array rather than NULL.
+func subscribedEventTypes(types []string) []string {
+	if types == nil {
+		return []string{}
+	}
+	return types
+}
+
+// newWebhookSecret returns a random secret to sign a webhook's deliveries with.
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.Wrap(err, "failed to generate webhook secret")
+	}
+	return webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+func (s *webhookServer) ListWebhooks(ctx context.Context, req *connect.Request[com.ListWebhooksRequest]) (*connect.Response[com.ListWebhooksResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Q.ListWebhooksForOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list webhooks")
+	}
+
+	webhooks := make([]*com.Webhook, 0, len(rows))
+	for _, row := range rows {
+		webhooks = append(webhooks, webhookFromRow(webhookRow(row)))
+	}
+
+	return &connect.Response[com.ListWebhooksResponse]{
+		Msg: &com.ListWebhooksResponse{Webhooks: webhooks},
+	}, nil
+}
+
+func (s *webhookServer) CreateWebhook(ctx context.Context, req *connect.Request[com.CreateWebhookRequest]) (*connect.Response[com.CreateWebhookResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	endpoint := req.Msg.GetUrl()
+	if err := validateWebhook(endpoint, req.Msg.GetEventTypes()); err != nil {
+		return nil, err
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	description := req.Msg.GetDescription()
+	var webhook *com.Webhook
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		row, err := q.InsertWebhook(ctx, models.InsertWebhookParams{
+			OrganizationID: organizationID,
+			URL:            &endpoint,
+			Description:    &description,
+			Secret:         &secret,
+			EventTypes:     subscribedEventTypes(req.Msg.GetEventTypes()),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to create webhook")
+		}
+		webhook = webhookFromRow(webhookRow(row))
+		return audit(ctx, q, organizationID, "webhook.create", webhook.Id, nil, webhook)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Created webhook", "webhook_id", webhook.Id, "url", endpoint)
+
+	return &connect.Response[com.CreateWebhookResponse]{
+		Msg: &com.CreateWebhookResponse{Webhook: webhook, Secret: secret},
+	}, nil
+}
+
+func (s *webhookServer) UpdateWebhook(ctx context.Context, req *connect.Request[com.UpdateWebhookRequest]) (*connect.Response[com.UpdateWebhookResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	webhookID, err := parseID("webhook", req.Msg.GetWebhookId())
+	if err != nil {
+		return nil, err
+	}
+	endpoint := req.Msg.GetUrl()
+	if err := validateWebhook(endpoint, req.Msg.GetEventTypes()); err != nil {
+		return nil, err
+	}
+
+	description := req.Msg.GetDescription()
+	var webhook *com.Webhook
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		before, err := loadWebhook(ctx, q, webhookID, organizationID)
+		if err != nil {
+			return err
+		}
+		row, err := q.UpdateWebhook(ctx, models.UpdateWebhookParams{
+			URL:            &endpoint,
+			Description:    &description,
+			EventTypes:     subscribedEventTypes(req.Msg.GetEventTypes()),
+			Enabled:        req.Msg.GetEnabled(),
+			WebhookID:      webhookID,
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to update webhook")
+		}
+		webhook = webhookFromRow(webhookRow(row))
+		return audit(ctx, q, organizationID, "webhook.update", webhook.Id, webhookFromRow(before), webhook)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Updated webhook", "webhook_id", webhookID)
+
+	return &connect.Response[com.UpdateWebhookResponse]{
+		Msg: &com.UpdateWebhookResponse{Webhook: webhook},
+	}, nil
+}
+
+func (s *webhookServer) DeleteWebhook(ctx context.Context, req *connect.Request[com.DeleteWebhookRequest]) (*connect.Response[com.DeleteWebhookResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	webhookID, err := parseID("webhook", req.Msg.GetWebhookId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		before, err := loadWebhook(ctx, q, webhookID, organizationID)
+		if err != nil {
+			return err
+		}
+		// its deliveries, pending ones included, go with it
+		if _, err := q.DeleteWebhook(ctx, webhookID, organizationID); err != nil {
+			return errors.Wrap(err, "failed to delete webhook")
+		}
+		return audit(ctx, q, organizationID, "webhook.delete", webhookID.String(), webhookFromRow(before), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Deleted webhook", "webhook_id", webhookID)
+
+	return &connect.Response[com.DeleteWebhookResponse]{
+		Msg: &com.DeleteWebhookResponse{},
+	}, nil
+}
+
+// Delivery page tokens are the creation time and ID of the last delivery on the previous page, like audit ones.
+func encodeWebhookDeliveryPageToken(row webhookDeliveryRow) string {
+	createdAt := goutil.UnwrapOr(row.CreatedAt, time.Time{})
+	return base64.RawURLEncoding.EncodeToString([]byte(row.ID.String() + createdAt.Format(time.RFC3339Nano)))
+}
+
+func (s *webhookServer) ListWebhookDeliveries(ctx context.Context, req *connect.Request[com.ListWebhookDeliveriesRequest]) (*connect.Response[com.ListWebhookDeliveriesResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	webhookID, err := parseID("webhook", req.Msg.GetWebhookId())
+	if err != nil {
+		return nil, err
+	}
+	status := req.Msg.GetStatus()
+	if status != "" && !slices.Contains(webhookDeliveryStatuses, status) {
+		return nil, invalidArgument(errors.Errorf("unknown status %q; use one of %v", status, webhookDeliveryStatuses))
+	}
+	if _, err := loadWebhook(ctx, s.db.Q, webhookID, organizationID); err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.Msg.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultWebhookDeliveryPageSize
+	}
+	if pageSize > maxWebhookDeliveryPageSize {
+		pageSize = maxWebhookDeliveryPageSize
+	}
+	beforeCreatedAt, beforeID, err := decodeAuditPageToken(req.Msg.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	// fetch one extra row to learn whether there is another page
+	limit := pageSize + 1
+	rows, err := s.db.Q.ListWebhookDeliveries(ctx, models.ListWebhookDeliveriesParams{
+		WebhookID:       webhookID,
+		Status:          &status,
+		BeforeCreatedAt: beforeCreatedAt,
+		BeforeID:        beforeID,
+		PageSize:        &limit,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list webhook deliveries")
+	}
+
+	resp := &com.ListWebhookDeliveriesResponse{}
+	if len(rows) > pageSize {
+		rows = rows[:pageSize]
+		resp.NextPageToken = encodeWebhookDeliveryPageToken(rows[len(rows)-1])
+	}
+	resp.Deliveries = make([]*com.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		resp.Deliveries = append(resp.Deliveries, webhookDeliveryFromRow(row))
+	}
+
+	return &connect.Response[com.ListWebhookDeliveriesResponse]{
+		Msg: resp,
+	}, nil
+}
+
+func (s *webhookServer) RedeliverWebhook(ctx context.Context, req *connect.Request[com.RedeliverWebhookRequest]) (*connect.Response[com.RedeliverWebhookResponse], error) {
+	organizationID, err := s.auth.RequireOrganization(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	webhookID, err := parseID("webhook", req.Msg.GetWebhookId())
+	if err != nil {
+		return nil, err
+	}
+	deliveryID, err := parseID("delivery", req.Msg.GetDeliveryId())
+	if err != nil {
+		return nil, err
+	}
+
+	var delivery *com.WebhookDelivery
+	err = s.db.InTx(ctx, func(q models.Querier) error {
+		if _, err := loadWebhook(ctx, q, webhookID, organizationID); err != nil {
+			return err
+		}
+		row, err := q.RedeliverWebhookDelivery(ctx, deliveryID, webhookID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return connect.NewError(connect.CodeNotFound, errors.Errorf("delivery %s not found", deliveryID))
+			}
+			return errors.Wrap(err, "failed to redeliver webhook delivery")
+		}
+		delivery = webhookDeliveryFromRow(webhookDeliveryRow(row))
+		return audit(ctx, q, organizationID, "webhook.redeliver", webhookID.String(), nil, delivery)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Queued webhook redelivery", "webhook_id", webhookID, "delivery_id", deliveryID)
+
+	return &connect.Response[com.RedeliverWebhookResponse]{
+		Msg: &com.RedeliverWebhookResponse{Delivery: delivery},
+	}, nil
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/uinta-labs/pando/pkg"
+	"github.com/uinta-labs/pando/pkg/agent"
+)
+
+// How long a simulated job's container runs before exiting successfully
+const simulatedJobDuration = time.Second
+
+// device is one simulated device: the agent itself, running against a FakeRuntime. Failures are injected around
+// it: pulls fail and containers crash in the runtime, and the device drops off the network by failing its calls.
+type device struct {
+	sim   *simulation
+	name  string
+	stats *stats
+	agent *agent.Agent
+
+	runtime *simulatedRuntime
+
+	mu sync.Mutex
+	// Draws the injected failures; seeded per device
+	rng *rand.Rand
+	// Whether calls to the server fail, for the current interval
+	offline bool
+}
+
+func newDevice(sim *simulation, name string, token string, stateRoot string, httpClient connect.HTTPClient, stats *stats, seed int64) (*device, error) {
+	d := &device{
+		sim:   sim,
+		name:  name,
+		stats: stats,
+		rng:   rand.New(rand.NewPCG(uint64(seed), 0)),
+	}
+	d.runtime = &simulatedRuntime{FakeRuntime: pkg.NewFakeRuntime(), device: d}
+	d.runtime.SetExecHandler(func(containerID string, options pkg.ExecOptions) (*pkg.ExecResult, error) {
+		return &pkg.ExecResult{Stdout: strings.Join(options.Command, " ") + "\n", Duration: time.Millisecond}, nil
+	})
+
+	// The agent's defaults, apart from what a simulated device has no use for: the status API, self-updates and
+	// checking the disk, which is this machine's rather than the device's
+	cfg := pkg.AgentConfig{
+		APIURL:              sim.server,
+		DeviceToken:         token,
+		GCDiskPath:          "/",
+		ScheduleInterval:    sim.interval,
+		StateDir:            filepath.Join(stateRoot, name),
+		RollbackSoakPeriod:  5 * time.Minute,
+		StartFirstTimeout:   2 * time.Minute,
+		StartFirstMinUptime: 10 * time.Second,
+		HandoverTimeout:     5 * time.Minute,
+	}
+	a, err := agent.New(cfg, agent.Options{
+		DeviceID:   name,
+		Runtime:    d.runtime,
+		HTTPClient: httpClient,
+		// offline calls never reach the server, so they are left out of the statistics
+		Interceptors: []connect.Interceptor{d.failOffline(), stats.record()},
+		Version:      "simulator-" + pkg.Version,
+		// Each agent registers the same metrics, so they are kept apart and not served
+		Registerer: prometheus.NewRegistry(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create agent for device %s", name)
+	}
+	d.agent = a
+	return d, nil
+}
+
+// chance reports true with probability rate.
+func (d *device) chance(rate float64) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return rate > 0 && d.rng.Float64() < rate
+}
+
+// run simulates the device until ctx is done.
+func (d *device) run(ctx context.Context) {
+	ctx = pkg.WithLogAttrs(ctx, slog.String(pkg.LogKeyDeviceID, d.name))
+	go d.injectFailures(ctx)
+	d.agent.Run(ctx)
+}
+
+// injectFailures takes the device offline for an interval, and crashes its containers, at the configured rates.
+func (d *device) injectFailures(ctx context.Context) {
+	ticker := time.NewTicker(d.sim.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		offline := d.chance(d.sim.offlineRate)
+		if offline {
+			d.stats.injected("offline")
+		}
+		d.mu.Lock()
+		d.offline = offline
+		d.mu.Unlock()
+		d.crash(ctx)
+	}
+}
+
+// failOffline fails every call while the device is offline, as if it had lost its network.
+func (d *device) failOffline() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			d.mu.Lock()
+			offline := d.offline
+			d.mu.Unlock()
+			if offline {
+				return nil, connect.NewError(connect.CodeUnavailable, errors.New("simulated network outage"))
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// crash makes each running container exit with an error at the configured rate.
+func (d *device) crash(ctx context.Context) {
+	if d.sim.crashRate == 0 {
+		return
+	}
+	containers, err := d.runtime.ListContainersMatchingLabel(ctx, pkg.LabelManaged, "true")
+	if err != nil {
+		return
+	}
+	for _, container := range containers {
+		if d.chance(d.sim.crashRate) {
+			d.stats.injected("crash")
+			_ = d.runtime.Exit(container.ID, 1)
+		}
+	}
+}
+
+// simulatedRuntime is a FakeRuntime whose registry has every image, though pulls fail at the configured rate, and
+// whose job containers exit successfully soon after starting.
+type simulatedRuntime struct {
+	*pkg.FakeRuntime
+	device *device
+}
+
+var _ pkg.ContainerRuntime = (*simulatedRuntime)(nil)
+
+func (r *simulatedRuntime) PullImage(ctx context.Context, imageReference string) error {
+	logs := make([]string, 0, r.device.sim.logLines)
+	for i := range r.device.sim.logLines {
+		logs = append(logs, fmt.Sprintf("simulated output line %d", i+1))
+	}
+	r.AddImage(imageReference, pkg.FakeImage{Logs: logs, LogEvery: r.device.sim.interval})
+	if r.device.chance(r.device.sim.pullFailureRate) {
+		r.device.stats.injected("pull_failure")
+		return &pkg.TimeoutError{Err: errors.Errorf("simulated timeout pulling %s", imageReference)}
+	}
+	return r.FakeRuntime.PullImage(ctx, imageReference)
+}
+
+func (r *simulatedRuntime) RunContainer(ctx context.Context, imageReference string, containerReference string, commands []string, environmentVariables []string, additionalLabels map[string]string, advancedOptions *pkg.AdvancedOptions, logs *pkg.LogChannels, waitOnContainer bool) (string, error) {
+	if additionalLabels[pkg.LabelJob] == "true" {
+		time.AfterFunc(simulatedJobDuration, func() {
+			_ = r.Exit(containerReference, 0)
+		})
+	}
+	return r.FakeRuntime.RunContainer(ctx, imageReference, containerReference, commands, environmentVariables, additionalLabels, advancedOptions, logs, waitOnContainer)
+}
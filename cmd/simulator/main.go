@@ -0,0 +1,293 @@
+// simulator runs many simulated devices against a real pando server, to test it end to end and to size it and its
+// database. Each device runs the agent itself, from pkg/agent, against its own in-memory container runtime, so it
+// polls for its schedule, applies it with rollbacks, maintenance windows, start-first updates and jobs, reports its
+// state and incidents, ships logs and answers actions just as a real device would. Failures are injected at the
+// configured rates. Latency and errors of every call are reported per procedure as it runs and when it finishes.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com/comconnect"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+const usage = `Usage: simulator --server URL --api-key KEY --fleet FLEET [flags]
+
+Devices named PREFIX-0001, PREFIX-0002 and so on are created in FLEET if they do not exist yet, then run until
+interrupted or until --duration has passed.
+
+Flags:
+`
+
+// simulation is the configuration shared by every simulated device.
+type simulation struct {
+	server         string
+	apiKey         string
+	organizationID string
+	fleet          string
+	prefix         string
+	devices        int
+
+	interval       time.Duration
+	rampUp         time.Duration
+	duration       time.Duration
+	reportInterval time.Duration
+	relay          bool
+	logLines       int
+
+	// Failure injection; each is a probability between 0 and 1
+	crashRate       float64
+	pullFailureRate float64
+	offlineRate     float64
+}
+
+func (s *simulation) validate() error {
+	if s.server == "" || s.apiKey == "" || s.fleet == "" {
+		return errors.New("--server, --api-key and --fleet are required")
+	}
+	if s.devices < 1 {
+		return errors.New("--devices must be at least 1")
+	}
+	if s.interval <= 0 {
+		return errors.New("--interval must be positive")
+	}
+	for name, rate := range map[string]float64{"--crash-rate": s.crashRate, "--pull-failure-rate": s.pullFailureRate, "--offline-rate": s.offlineRate} {
+		if rate < 0 || rate > 1 {
+			return errors.Errorf("%s must be between 0 and 1", name)
+		}
+	}
+	return nil
+}
+
+// bearerAuth sends the API key with every operator call.
+func bearerAuth(apiKey string) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			req.Header().Set("Authorization", "Bearer "+apiKey)
+			return next(ctx, req)
+		}
+	}
+}
+
+// enroll returns the names of the simulation's devices, creating any that the fleet does not have yet, and a new relay
+// token for each device when the simulation uses the relay.
+func enroll(ctx context.Context, sim *simulation, organizations comconnect.OrganizationServiceClient, fleets comconnect.FleetServiceClient, devices comconnect.DeviceServiceClient) ([]string, map[string]string, error) {
+	organizationID := sim.organizationID
+	if organizationID == "" {
+		resp, err := organizations.ListOrganizations(ctx, connect.NewRequest(&com.ListOrganizationsRequest{}))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to list organizations")
+		}
+		if len(resp.Msg.Organizations) != 1 {
+			return nil, nil, errors.Errorf("API key has access to %d organizations; choose one with --organization", len(resp.Msg.Organizations))
+		}
+		organizationID = resp.Msg.Organizations[0].Id
+	}
+
+	fleetsResp, err := fleets.ListFleets(ctx, connect.NewRequest(&com.ListFleetsRequest{OrganizationId: organizationID}))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list fleets")
+	}
+	var fleet *com.Fleet
+	for _, f := range fleetsResp.Msg.Fleets {
+		if f.Id == sim.fleet || f.Name == sim.fleet {
+			fleet = f
+		}
+	}
+	if fleet == nil {
+		return nil, nil, errors.Errorf("fleet %s not found", sim.fleet)
+	}
+
+	existing := map[string]bool{}
+	pageToken := ""
+	for {
+		resp, err := fleets.ListDevices(ctx, connect.NewRequest(&com.ListDevicesRequest{
+			OrganizationId: organizationID,
+			FleetId:        fleet.Id,
+			NameContains:   sim.prefix + "-",
+			PageSize:       500,
+			PageToken:      pageToken,
+		}))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to list devices")
+		}
+		for _, device := range resp.Msg.Devices {
+			existing[device.Name] = true
+		}
+		pageToken = resp.Msg.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+
+	names := make([]string, 0, sim.devices)
+	created := 0
+	for i := 1; i <= sim.devices; i++ {
+		name := fmt.Sprintf("%s-%04d", sim.prefix, i)
+		names = append(names, name)
+		if existing[name] {
+			continue
+		}
+		_, err := fleets.CreateDevice(ctx, connect.NewRequest(&com.CreateDeviceRequest{
+			OrganizationId: organizationID,
+			FleetId:        fleet.Id,
+			Name:           name,
+		}))
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to create device %s", name)
+		}
+		created++
+	}
+	slog.InfoContext(ctx, "Enrolled devices", "fleet", fleet.Name, "devices", len(names), "created", created)
+
+	tokens := map[string]string{}
+	if sim.relay {
+		for _, name := range names {
+			resp, err := devices.IssueDeviceRelayToken(ctx, connect.NewRequest(&com.IssueDeviceRelayTokenRequest{
+				OrganizationId: organizationID,
+				Device:         name,
+			}))
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to issue relay token for device %s", name)
+			}
+			tokens[name] = resp.Msg.Token
+		}
+	}
+	return names, tokens, nil
+}
+
+func main() {
+	sim := &simulation{}
+	var metricsAddress, logFormat, logLevel string
+	fs := flag.NewFlagSet("simulator", flag.ContinueOnError)
+	fs.StringVar(&sim.server, "server", "", "server URL")
+	fs.StringVar(&sim.apiKey, "api-key", os.Getenv("PANDO_API_KEY"), "API key used to enroll devices; defaults to $PANDO_API_KEY")
+	fs.StringVar(&sim.organizationID, "organization", "", "organization ID; only needed if the API key has access to several")
+	fs.StringVar(&sim.fleet, "fleet", "", "name or ID of the fleet to enroll devices in")
+	fs.StringVar(&sim.prefix, "prefix", "sim", "prefix of the simulated devices' names")
+	fs.IntVar(&sim.devices, "devices", 10, "number of devices to simulate")
+	fs.DurationVar(&sim.interval, "interval", 15*time.Second, "how often each device polls for its schedule and reports its state")
+	fs.DurationVar(&sim.rampUp, "ramp-up", 30*time.Second, "period over which devices are started, evenly spread")
+	fs.DurationVar(&sim.duration, "duration", 0, "stop after this long; zero runs until interrupted")
+	fs.DurationVar(&sim.reportInterval, "report-interval", time.Minute, "how often to print statistics; zero only prints them at the end")
+	fs.BoolVar(&sim.relay, "relay", true, "issue each device a relay token, so that it polls for actions and ships logs")
+	fs.IntVar(&sim.logLines, "log-lines", 5, "log lines each running container writes per interval")
+	fs.Float64Var(&sim.crashRate, "crash-rate", 0, "probability each interval that a running container crashes")
+	fs.Float64Var(&sim.pullFailureRate, "pull-failure-rate", 0, "probability that an image pull fails")
+	fs.Float64Var(&sim.offlineRate, "offline-rate", 0, "probability each interval that a device skips checking in")
+	fs.StringVar(&metricsAddress, "metrics-address", "", "serve the simulator's Prometheus metrics on this address")
+	fs.StringVar(&logFormat, "log-format", "text", "log format: text or json")
+	fs.StringVar(&logLevel, "log-level", "warn", "log level: debug, info, warn or error; the devices' agents log every step at info")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
+	if err := sim.validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "simulator: %v\n", err)
+		os.Exit(2)
+	}
+	if err := pkg.SetupLogging(logFormat, logLevel); err != nil {
+		log.Panicf("failed to set up logging: %+v\n", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if sim.duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, sim.duration)
+		defer cancel()
+	}
+
+	registry := pkg.NewMetricsRegistry()
+	stats := newStats(registry)
+	if metricsAddress != "" {
+		go pkg.ServeMetrics(ctx, metricsAddress, registry)
+	}
+
+	// Every device shares one connection pool, large enough that long polls do not starve the other calls
+	httpClient := &http.Client{Transport: &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		MaxIdleConnsPerHost: sim.devices * 2,
+		IdleConnTimeout:     90 * time.Second,
+	}}
+	sim.server = strings.TrimSuffix(sim.server, "/")
+	baseURL := sim.server
+	operatorOptions := connect.WithInterceptors(bearerAuth(sim.apiKey))
+	names, tokens, err := enroll(ctx,
+		sim,
+		comconnect.NewOrganizationServiceClient(httpClient, baseURL, operatorOptions),
+		comconnect.NewFleetServiceClient(httpClient, baseURL, operatorOptions),
+		comconnect.NewDeviceServiceClient(httpClient, baseURL, operatorOptions),
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "simulator: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Agents keep their state on disk, as on a device
+	stateRoot, err := os.MkdirTemp("", "pando-simulator-")
+	if err != nil {
+		log.Panicf("failed to create state directory: %+v\n", err)
+	}
+	defer os.RemoveAll(stateRoot)
+
+	startedAt := time.Now()
+	slog.InfoContext(ctx, "Starting devices", "devices", len(names), "ramp_up", sim.rampUp, "interval", sim.interval)
+	wg := sync.WaitGroup{}
+	for i, name := range names {
+		d, err := newDevice(sim, name, tokens[name], stateRoot, httpClient, stats, int64(i))
+		if err != nil {
+			log.Panicf("failed to create device: %+v\n", err)
+		}
+		delay := sim.rampUp * time.Duration(i) / time.Duration(len(names))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			d.run(ctx)
+		}()
+	}
+
+	if sim.reportInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(sim.reportInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					stats.print(os.Stdout, time.Since(startedAt))
+				}
+			}
+		}()
+	}
+
+	<-ctx.Done()
+	slog.Info("Stopping devices")
+	wg.Wait()
+	stats.print(os.Stdout, time.Since(startedAt))
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// stats records the latency and outcome of every call the devices make, and the failures injected, both for the
+// printed report and as Prometheus metrics.
+type stats struct {
+	mu         sync.Mutex
+	procedures map[string]*procedureStats
+	failures   map[string]int
+
+	rpcDuration      *prometheus.HistogramVec
+	injectedFailures *prometheus.CounterVec
+}
+
+type procedureStats struct {
+	latencies []time.Duration
+	// Failed calls by code
+	errors map[string]int
+}
+
+func newStats(registry prometheus.Registerer) *stats {
+	s := &stats{
+		procedures: map[string]*procedureStats{},
+		failures:   map[string]int{},
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "pando_simulator_rpc_duration_seconds",
+			Help:    "Time taken by calls from simulated devices, by procedure and code.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+		}, []string{"procedure", "code"}),
+		injectedFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pando_simulator_injected_failures_total",
+			Help: "Failures injected into simulated devices, by kind: crash, pull_failure or offline.",
+		}, []string{"kind"}),
+	}
+	registry.MustRegister(s.rpcDuration, s.injectedFailures)
+	return s
+}
+
+// record times every call. Calls abandoned because the simulation is stopping are left out.
+func (s *stats) record() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			startedAt := time.Now()
+			resp, err := next(ctx, req)
+			elapsed := time.Since(startedAt)
+			if err != nil && ctx.Err() != nil {
+				return resp, err
+			}
+
+			code := "ok"
+			if err != nil {
+				code = connect.CodeOf(err).String()
+			}
+			procedure := req.Spec().Procedure
+			s.rpcDuration.WithLabelValues(procedure, code).Observe(elapsed.Seconds())
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			p, ok := s.procedures[procedure]
+			if !ok {
+				p = &procedureStats{errors: map[string]int{}}
+				s.procedures[procedure] = p
+			}
+			p.latencies = append(p.latencies, elapsed)
+			if err != nil {
+				p.errors[code]++
+			}
+			return resp, err
+		}
+	}
+}
+
+func (s *stats) injected(kind string) {
+	s.injectedFailures.WithLabelValues(kind).Inc()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[kind]++
+}
+
+// print writes a table of every procedure's calls, errors and latency percentiles since the simulation started
+// elapsed ago, followed by the failures injected. PollActions is a long poll, so its latency mostly reflects the
+// server's poll timeout.
+func (s *stats) print(w io.Writer, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fmt.Fprintf(w, "\nAfter %s:\n", elapsed.Round(time.Second))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROCEDURE\tCALLS\tPER SECOND\tERRORS\tP50\tP90\tP99\tMAX\tERROR CODES")
+	procedures := make([]string, 0, len(s.procedures))
+	for procedure := range s.procedures {
+		procedures = append(procedures, procedure)
+	}
+	sort.Strings(procedures)
+	for _, procedure := range procedures {
+		p := s.procedures[procedure]
+		latencies := slices.Clone(p.latencies)
+		slices.Sort(latencies)
+		errorCount := 0
+		codes := []string{}
+		for code, count := range p.errors {
+			errorCount += count
+			codes = append(codes, fmt.Sprintf("%s=%d", code, count))
+		}
+		sort.Strings(codes)
+		name := procedure[strings.LastIndex(procedure, "/")+1:]
+		fmt.Fprintf(tw, "%s\t%d\t%.1f\t%d\t%s\t%s\t%s\t%s\t%s\n", name, len(latencies), float64(len(latencies))/elapsed.Seconds(), errorCount,
+			percentile(latencies, 0.5), percentile(latencies, 0.9), percentile(latencies, 0.99), percentile(latencies, 1), strings.Join(codes, " "))
+	}
+	_ = tw.Flush()
+
+	if len(s.failures) > 0 {
+		kinds := []string{}
+		for kind, count := range s.failures {
+			kinds = append(kinds, fmt.Sprintf("%s=%d", kind, count))
+		}
+		sort.Strings(kinds)
+		fmt.Fprintf(w, "Injected failures: %s\n", strings.Join(kinds, " "))
+	}
+}
+
+// percentile returns the qth quantile of sorted, rounded for display.
+func percentile(sorted []time.Duration, q float64) string {
+	if len(sorted) == 0 {
+		return "-"
+	}
+	return sorted[int(q*float64(len(sorted)-1))].Round(100 * time.Microsecond).String()
+}
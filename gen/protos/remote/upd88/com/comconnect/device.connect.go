@@ -0,0 +1,239 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: protos/remote/upd88/com/device.proto
+
+package comconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	com "github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// DeviceServiceName is the fully-qualified name of the DeviceService service.
+	DeviceServiceName = "remote.upd88.com.DeviceService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// DeviceServiceDescribeDeviceProcedure is the fully-qualified name of the DeviceService's
+	// DescribeDevice RPC.
+	DeviceServiceDescribeDeviceProcedure = "/remote.upd88.com.DeviceService/DescribeDevice"
+	// DeviceServiceGetContainerLogsProcedure is the fully-qualified name of the DeviceService's
+	// GetContainerLogs RPC.
+	DeviceServiceGetContainerLogsProcedure = "/remote.upd88.com.DeviceService/GetContainerLogs"
+	// DeviceServiceExecInContainerProcedure is the fully-qualified name of the DeviceService's
+	// ExecInContainer RPC.
+	DeviceServiceExecInContainerProcedure = "/remote.upd88.com.DeviceService/ExecInContainer"
+	// DeviceServiceListJobRunsProcedure is the fully-qualified name of the DeviceService's ListJobRuns
+	// RPC.
+	DeviceServiceListJobRunsProcedure = "/remote.upd88.com.DeviceService/ListJobRuns"
+	// DeviceServiceIssueDeviceRelayTokenProcedure is the fully-qualified name of the DeviceService's
+	// IssueDeviceRelayToken RPC.
+	DeviceServiceIssueDeviceRelayTokenProcedure = "/remote.upd88.com.DeviceService/IssueDeviceRelayToken"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	deviceServiceServiceDescriptor                     = com.File_protos_remote_upd88_com_device_proto.Services().ByName("DeviceService")
+	deviceServiceDescribeDeviceMethodDescriptor        = deviceServiceServiceDescriptor.Methods().ByName("DescribeDevice")
+	deviceServiceGetContainerLogsMethodDescriptor      = deviceServiceServiceDescriptor.Methods().ByName("GetContainerLogs")
+	deviceServiceExecInContainerMethodDescriptor       = deviceServiceServiceDescriptor.Methods().ByName("ExecInContainer")
+	deviceServiceListJobRunsMethodDescriptor           = deviceServiceServiceDescriptor.Methods().ByName("ListJobRuns")
+	deviceServiceIssueDeviceRelayTokenMethodDescriptor = deviceServiceServiceDescriptor.Methods().ByName("IssueDeviceRelayToken")
+)
+
+// DeviceServiceClient is a client for the remote.upd88.com.DeviceService service.
+type DeviceServiceClient interface {
+	DescribeDevice(context.Context, *connect.Request[com.DescribeDeviceRequest]) (*connect.Response[com.DescribeDeviceResponse], error)
+	GetContainerLogs(context.Context, *connect.Request[com.GetContainerLogsRequest]) (*connect.Response[com.GetContainerLogsResponse], error)
+	ExecInContainer(context.Context, *connect.Request[com.ExecInContainerRequest]) (*connect.Response[com.ExecInContainerResponse], error)
+	// Lists the history of a device's job containers
+	ListJobRuns(context.Context, *connect.Request[com.ListJobRunsRequest]) (*connect.Response[com.ListJobRunsResponse], error)
+	// Issues the token the device's agent authenticates relay calls with (PollActions, ReportActionResult and
+	// PushLogs), replacing any it had
+	IssueDeviceRelayToken(context.Context, *connect.Request[com.IssueDeviceRelayTokenRequest]) (*connect.Response[com.IssueDeviceRelayTokenResponse], error)
+}
+
+// NewDeviceServiceClient constructs a client for the remote.upd88.com.DeviceService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewDeviceServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) DeviceServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &deviceServiceClient{
+		describeDevice: connect.NewClient[com.DescribeDeviceRequest, com.DescribeDeviceResponse](
+			httpClient,
+			baseURL+DeviceServiceDescribeDeviceProcedure,
+			connect.WithSchema(deviceServiceDescribeDeviceMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getContainerLogs: connect.NewClient[com.GetContainerLogsRequest, com.GetContainerLogsResponse](
+			httpClient,
+			baseURL+DeviceServiceGetContainerLogsProcedure,
+			connect.WithSchema(deviceServiceGetContainerLogsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		execInContainer: connect.NewClient[com.ExecInContainerRequest, com.ExecInContainerResponse](
+			httpClient,
+			baseURL+DeviceServiceExecInContainerProcedure,
+			connect.WithSchema(deviceServiceExecInContainerMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listJobRuns: connect.NewClient[com.ListJobRunsRequest, com.ListJobRunsResponse](
+			httpClient,
+			baseURL+DeviceServiceListJobRunsProcedure,
+			connect.WithSchema(deviceServiceListJobRunsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		issueDeviceRelayToken: connect.NewClient[com.IssueDeviceRelayTokenRequest, com.IssueDeviceRelayTokenResponse](
+			httpClient,
+			baseURL+DeviceServiceIssueDeviceRelayTokenProcedure,
+			connect.WithSchema(deviceServiceIssueDeviceRelayTokenMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// deviceServiceClient implements DeviceServiceClient.
+type deviceServiceClient struct {
+	describeDevice        *connect.Client[com.DescribeDeviceRequest, com.DescribeDeviceResponse]
+	getContainerLogs      *connect.Client[com.GetContainerLogsRequest, com.GetContainerLogsResponse]
+	execInContainer       *connect.Client[com.ExecInContainerRequest, com.ExecInContainerResponse]
+	listJobRuns           *connect.Client[com.ListJobRunsRequest, com.ListJobRunsResponse]
+	issueDeviceRelayToken *connect.Client[com.IssueDeviceRelayTokenRequest, com.IssueDeviceRelayTokenResponse]
+}
+
+// DescribeDevice calls remote.upd88.com.DeviceService.DescribeDevice.
+func (c *deviceServiceClient) DescribeDevice(ctx context.Context, req *connect.Request[com.DescribeDeviceRequest]) (*connect.Response[com.DescribeDeviceResponse], error) {
+	return c.describeDevice.CallUnary(ctx, req)
+}
+
+// GetContainerLogs calls remote.upd88.com.DeviceService.GetContainerLogs.
+func (c *deviceServiceClient) GetContainerLogs(ctx context.Context, req *connect.Request[com.GetContainerLogsRequest]) (*connect.Response[com.GetContainerLogsResponse], error) {
+	return c.getContainerLogs.CallUnary(ctx, req)
+}
+
+// ExecInContainer calls remote.upd88.com.DeviceService.ExecInContainer.
+func (c *deviceServiceClient) ExecInContainer(ctx context.Context, req *connect.Request[com.ExecInContainerRequest]) (*connect.Response[com.ExecInContainerResponse], error) {
+	return c.execInContainer.CallUnary(ctx, req)
+}
+
+// ListJobRuns calls remote.upd88.com.DeviceService.ListJobRuns.
+func (c *deviceServiceClient) ListJobRuns(ctx context.Context, req *connect.Request[com.ListJobRunsRequest]) (*connect.Response[com.ListJobRunsResponse], error) {
+	return c.listJobRuns.CallUnary(ctx, req)
+}
+
+// IssueDeviceRelayToken calls remote.upd88.com.DeviceService.IssueDeviceRelayToken.
+func (c *deviceServiceClient) IssueDeviceRelayToken(ctx context.Context, req *connect.Request[com.IssueDeviceRelayTokenRequest]) (*connect.Response[com.IssueDeviceRelayTokenResponse], error) {
+	return c.issueDeviceRelayToken.CallUnary(ctx, req)
+}
+
+// DeviceServiceHandler is an implementation of the remote.upd88.com.DeviceService service.
+type DeviceServiceHandler interface {
+	DescribeDevice(context.Context, *connect.Request[com.DescribeDeviceRequest]) (*connect.Response[com.DescribeDeviceResponse], error)
+	GetContainerLogs(context.Context, *connect.Request[com.GetContainerLogsRequest]) (*connect.Response[com.GetContainerLogsResponse], error)
+	ExecInContainer(context.Context, *connect.Request[com.ExecInContainerRequest]) (*connect.Response[com.ExecInContainerResponse], error)
+	// Lists the history of a device's job containers
+	ListJobRuns(context.Context, *connect.Request[com.ListJobRunsRequest]) (*connect.Response[com.ListJobRunsResponse], error)
+	// Issues the token the device's agent authenticates relay calls with (PollActions, ReportActionResult and
+	// PushLogs), replacing any it had
+	IssueDeviceRelayToken(context.Context, *connect.Request[com.IssueDeviceRelayTokenRequest]) (*connect.Response[com.IssueDeviceRelayTokenResponse], error)
+}
+
+// NewDeviceServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewDeviceServiceHandler(svc DeviceServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	deviceServiceDescribeDeviceHandler := connect.NewUnaryHandler(
+		DeviceServiceDescribeDeviceProcedure,
+		svc.DescribeDevice,
+		connect.WithSchema(deviceServiceDescribeDeviceMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	deviceServiceGetContainerLogsHandler := connect.NewUnaryHandler(
+		DeviceServiceGetContainerLogsProcedure,
+		svc.GetContainerLogs,
+		connect.WithSchema(deviceServiceGetContainerLogsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	deviceServiceExecInContainerHandler := connect.NewUnaryHandler(
+		DeviceServiceExecInContainerProcedure,
+		svc.ExecInContainer,
+		connect.WithSchema(deviceServiceExecInContainerMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	deviceServiceListJobRunsHandler := connect.NewUnaryHandler(
+		DeviceServiceListJobRunsProcedure,
+		svc.ListJobRuns,
+		connect.WithSchema(deviceServiceListJobRunsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	deviceServiceIssueDeviceRelayTokenHandler := connect.NewUnaryHandler(
+		DeviceServiceIssueDeviceRelayTokenProcedure,
+		svc.IssueDeviceRelayToken,
+		connect.WithSchema(deviceServiceIssueDeviceRelayTokenMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/remote.upd88.com.DeviceService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case DeviceServiceDescribeDeviceProcedure:
+			deviceServiceDescribeDeviceHandler.ServeHTTP(w, r)
+		case DeviceServiceGetContainerLogsProcedure:
+			deviceServiceGetContainerLogsHandler.ServeHTTP(w, r)
+		case DeviceServiceExecInContainerProcedure:
+			deviceServiceExecInContainerHandler.ServeHTTP(w, r)
+		case DeviceServiceListJobRunsProcedure:
+			deviceServiceListJobRunsHandler.ServeHTTP(w, r)
+		case DeviceServiceIssueDeviceRelayTokenProcedure:
+			deviceServiceIssueDeviceRelayTokenHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedDeviceServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedDeviceServiceHandler struct{}
+
+func (UnimplementedDeviceServiceHandler) DescribeDevice(context.Context, *connect.Request[com.DescribeDeviceRequest]) (*connect.Response[com.DescribeDeviceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.DeviceService.DescribeDevice is not implemented"))
+}
+
+func (UnimplementedDeviceServiceHandler) GetContainerLogs(context.Context, *connect.Request[com.GetContainerLogsRequest]) (*connect.Response[com.GetContainerLogsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.DeviceService.GetContainerLogs is not implemented"))
+}
+
+func (UnimplementedDeviceServiceHandler) ExecInContainer(context.Context, *connect.Request[com.ExecInContainerRequest]) (*connect.Response[com.ExecInContainerResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.DeviceService.ExecInContainer is not implemented"))
+}
+
+func (UnimplementedDeviceServiceHandler) ListJobRuns(context.Context, *connect.Request[com.ListJobRunsRequest]) (*connect.Response[com.ListJobRunsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.DeviceService.ListJobRuns is not implemented"))
+}
+
+func (UnimplementedDeviceServiceHandler) IssueDeviceRelayToken(context.Context, *connect.Request[com.IssueDeviceRelayTokenRequest]) (*connect.Response[com.IssueDeviceRelayTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.DeviceService.IssueDeviceRelayToken is not implemented"))
+}
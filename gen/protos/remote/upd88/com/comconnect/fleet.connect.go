@@ -0,0 +1,507 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: protos/remote/upd88/com/fleet.proto
+
+package comconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	com "github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// FleetServiceName is the fully-qualified name of the FleetService service.
+	FleetServiceName = "remote.upd88.com.FleetService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// FleetServiceListFleetsProcedure is the fully-qualified name of the FleetService's ListFleets RPC.
+	FleetServiceListFleetsProcedure = "/remote.upd88.com.FleetService/ListFleets"
+	// FleetServiceCreateFleetProcedure is the fully-qualified name of the FleetService's CreateFleet
+	// RPC.
+	FleetServiceCreateFleetProcedure = "/remote.upd88.com.FleetService/CreateFleet"
+	// FleetServiceRenameFleetProcedure is the fully-qualified name of the FleetService's RenameFleet
+	// RPC.
+	FleetServiceRenameFleetProcedure = "/remote.upd88.com.FleetService/RenameFleet"
+	// FleetServiceDeleteFleetProcedure is the fully-qualified name of the FleetService's DeleteFleet
+	// RPC.
+	FleetServiceDeleteFleetProcedure = "/remote.upd88.com.FleetService/DeleteFleet"
+	// FleetServiceSetFleetDefaultScheduleProcedure is the fully-qualified name of the FleetService's
+	// SetFleetDefaultSchedule RPC.
+	FleetServiceSetFleetDefaultScheduleProcedure = "/remote.upd88.com.FleetService/SetFleetDefaultSchedule"
+	// FleetServiceSetFleetAgentImageProcedure is the fully-qualified name of the FleetService's
+	// SetFleetAgentImage RPC.
+	FleetServiceSetFleetAgentImageProcedure = "/remote.upd88.com.FleetService/SetFleetAgentImage"
+	// FleetServiceSetFleetMaintenanceProcedure is the fully-qualified name of the FleetService's
+	// SetFleetMaintenance RPC.
+	FleetServiceSetFleetMaintenanceProcedure = "/remote.upd88.com.FleetService/SetFleetMaintenance"
+	// FleetServiceSetDeviceMaintenanceProcedure is the fully-qualified name of the FleetService's
+	// SetDeviceMaintenance RPC.
+	FleetServiceSetDeviceMaintenanceProcedure = "/remote.upd88.com.FleetService/SetDeviceMaintenance"
+	// FleetServiceApplyUpdatesNowProcedure is the fully-qualified name of the FleetService's
+	// ApplyUpdatesNow RPC.
+	FleetServiceApplyUpdatesNowProcedure = "/remote.upd88.com.FleetService/ApplyUpdatesNow"
+	// FleetServiceListDevicesProcedure is the fully-qualified name of the FleetService's ListDevices
+	// RPC.
+	FleetServiceListDevicesProcedure = "/remote.upd88.com.FleetService/ListDevices"
+	// FleetServiceCreateDeviceProcedure is the fully-qualified name of the FleetService's CreateDevice
+	// RPC.
+	FleetServiceCreateDeviceProcedure = "/remote.upd88.com.FleetService/CreateDevice"
+	// FleetServiceRenameDeviceProcedure is the fully-qualified name of the FleetService's RenameDevice
+	// RPC.
+	FleetServiceRenameDeviceProcedure = "/remote.upd88.com.FleetService/RenameDevice"
+	// FleetServiceMoveDeviceProcedure is the fully-qualified name of the FleetService's MoveDevice RPC.
+	FleetServiceMoveDeviceProcedure = "/remote.upd88.com.FleetService/MoveDevice"
+	// FleetServiceDeleteDeviceProcedure is the fully-qualified name of the FleetService's DeleteDevice
+	// RPC.
+	FleetServiceDeleteDeviceProcedure = "/remote.upd88.com.FleetService/DeleteDevice"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	fleetServiceServiceDescriptor                       = com.File_protos_remote_upd88_com_fleet_proto.Services().ByName("FleetService")
+	fleetServiceListFleetsMethodDescriptor              = fleetServiceServiceDescriptor.Methods().ByName("ListFleets")
+	fleetServiceCreateFleetMethodDescriptor             = fleetServiceServiceDescriptor.Methods().ByName("CreateFleet")
+	fleetServiceRenameFleetMethodDescriptor             = fleetServiceServiceDescriptor.Methods().ByName("RenameFleet")
+	fleetServiceDeleteFleetMethodDescriptor             = fleetServiceServiceDescriptor.Methods().ByName("DeleteFleet")
+	fleetServiceSetFleetDefaultScheduleMethodDescriptor = fleetServiceServiceDescriptor.Methods().ByName("SetFleetDefaultSchedule")
+	fleetServiceSetFleetAgentImageMethodDescriptor      = fleetServiceServiceDescriptor.Methods().ByName("SetFleetAgentImage")
+	fleetServiceSetFleetMaintenanceMethodDescriptor     = fleetServiceServiceDescriptor.Methods().ByName("SetFleetMaintenance")
+	fleetServiceSetDeviceMaintenanceMethodDescriptor    = fleetServiceServiceDescriptor.Methods().ByName("SetDeviceMaintenance")
+	fleetServiceApplyUpdatesNowMethodDescriptor         = fleetServiceServiceDescriptor.Methods().ByName("ApplyUpdatesNow")
+	fleetServiceListDevicesMethodDescriptor             = fleetServiceServiceDescriptor.Methods().ByName("ListDevices")
+	fleetServiceCreateDeviceMethodDescriptor            = fleetServiceServiceDescriptor.Methods().ByName("CreateDevice")
+	fleetServiceRenameDeviceMethodDescriptor            = fleetServiceServiceDescriptor.Methods().ByName("RenameDevice")
+	fleetServiceMoveDeviceMethodDescriptor              = fleetServiceServiceDescriptor.Methods().ByName("MoveDevice")
+	fleetServiceDeleteDeviceMethodDescriptor            = fleetServiceServiceDescriptor.Methods().ByName("DeleteDevice")
+)
+
+// FleetServiceClient is a client for the remote.upd88.com.FleetService service.
+type FleetServiceClient interface {
+	ListFleets(context.Context, *connect.Request[com.ListFleetsRequest]) (*connect.Response[com.ListFleetsResponse], error)
+	CreateFleet(context.Context, *connect.Request[com.CreateFleetRequest]) (*connect.Response[com.CreateFleetResponse], error)
+	RenameFleet(context.Context, *connect.Request[com.RenameFleetRequest]) (*connect.Response[com.RenameFleetResponse], error)
+	DeleteFleet(context.Context, *connect.Request[com.DeleteFleetRequest]) (*connect.Response[com.DeleteFleetResponse], error)
+	SetFleetDefaultSchedule(context.Context, *connect.Request[com.SetFleetDefaultScheduleRequest]) (*connect.Response[com.SetFleetDefaultScheduleResponse], error)
+	// Devices whose agent runs a different image hand over to this one, falling back if it fails to check in
+	SetFleetAgentImage(context.Context, *connect.Request[com.SetFleetAgentImageRequest]) (*connect.Response[com.SetFleetAgentImageResponse], error)
+	// Devices defer disruptive changes, such as restarting containers, until one of these windows opens
+	SetFleetMaintenance(context.Context, *connect.Request[com.SetFleetMaintenanceRequest]) (*connect.Response[com.SetFleetMaintenanceResponse], error)
+	SetDeviceMaintenance(context.Context, *connect.Request[com.SetDeviceMaintenanceRequest]) (*connect.Response[com.SetDeviceMaintenanceResponse], error)
+	// Lets a device, or every device in a fleet, apply deferred changes without waiting for a maintenance window
+	ApplyUpdatesNow(context.Context, *connect.Request[com.ApplyUpdatesNowRequest]) (*connect.Response[com.ApplyUpdatesNowResponse], error)
+	ListDevices(context.Context, *connect.Request[com.ListDevicesRequest]) (*connect.Response[com.ListDevicesResponse], error)
+	CreateDevice(context.Context, *connect.Request[com.CreateDeviceRequest]) (*connect.Response[com.CreateDeviceResponse], error)
+	RenameDevice(context.Context, *connect.Request[com.RenameDeviceRequest]) (*connect.Response[com.RenameDeviceResponse], error)
+	MoveDevice(context.Context, *connect.Request[com.MoveDeviceRequest]) (*connect.Response[com.MoveDeviceResponse], error)
+	DeleteDevice(context.Context, *connect.Request[com.DeleteDeviceRequest]) (*connect.Response[com.DeleteDeviceResponse], error)
+}
+
+// NewFleetServiceClient constructs a client for the remote.upd88.com.FleetService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewFleetServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) FleetServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &fleetServiceClient{
+		listFleets: connect.NewClient[com.ListFleetsRequest, com.ListFleetsResponse](
+			httpClient,
+			baseURL+FleetServiceListFleetsProcedure,
+			connect.WithSchema(fleetServiceListFleetsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		createFleet: connect.NewClient[com.CreateFleetRequest, com.CreateFleetResponse](
+			httpClient,
+			baseURL+FleetServiceCreateFleetProcedure,
+			connect.WithSchema(fleetServiceCreateFleetMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		renameFleet: connect.NewClient[com.RenameFleetRequest, com.RenameFleetResponse](
+			httpClient,
+			baseURL+FleetServiceRenameFleetProcedure,
+			connect.WithSchema(fleetServiceRenameFleetMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		deleteFleet: connect.NewClient[com.DeleteFleetRequest, com.DeleteFleetResponse](
+			httpClient,
+			baseURL+FleetServiceDeleteFleetProcedure,
+			connect.WithSchema(fleetServiceDeleteFleetMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		setFleetDefaultSchedule: connect.NewClient[com.SetFleetDefaultScheduleRequest, com.SetFleetDefaultScheduleResponse](
+			httpClient,
+			baseURL+FleetServiceSetFleetDefaultScheduleProcedure,
+			connect.WithSchema(fleetServiceSetFleetDefaultScheduleMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		setFleetAgentImage: connect.NewClient[com.SetFleetAgentImageRequest, com.SetFleetAgentImageResponse](
+			httpClient,
+			baseURL+FleetServiceSetFleetAgentImageProcedure,
+			connect.WithSchema(fleetServiceSetFleetAgentImageMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		setFleetMaintenance: connect.NewClient[com.SetFleetMaintenanceRequest, com.SetFleetMaintenanceResponse](
+			httpClient,
+			baseURL+FleetServiceSetFleetMaintenanceProcedure,
+			connect.WithSchema(fleetServiceSetFleetMaintenanceMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		setDeviceMaintenance: connect.NewClient[com.SetDeviceMaintenanceRequest, com.SetDeviceMaintenanceResponse](
+			httpClient,
+			baseURL+FleetServiceSetDeviceMaintenanceProcedure,
+			connect.WithSchema(fleetServiceSetDeviceMaintenanceMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		applyUpdatesNow: connect.NewClient[com.ApplyUpdatesNowRequest, com.ApplyUpdatesNowResponse](
+			httpClient,
+			baseURL+FleetServiceApplyUpdatesNowProcedure,
+			connect.WithSchema(fleetServiceApplyUpdatesNowMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listDevices: connect.NewClient[com.ListDevicesRequest, com.ListDevicesResponse](
+			httpClient,
+			baseURL+FleetServiceListDevicesProcedure,
+			connect.WithSchema(fleetServiceListDevicesMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		createDevice: connect.NewClient[com.CreateDeviceRequest, com.CreateDeviceResponse](
+			httpClient,
+			baseURL+FleetServiceCreateDeviceProcedure,
+			connect.WithSchema(fleetServiceCreateDeviceMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		renameDevice: connect.NewClient[com.RenameDeviceRequest, com.RenameDeviceResponse](
+			httpClient,
+			baseURL+FleetServiceRenameDeviceProcedure,
+			connect.WithSchema(fleetServiceRenameDeviceMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		moveDevice: connect.NewClient[com.MoveDeviceRequest, com.MoveDeviceResponse](
+			httpClient,
+			baseURL+FleetServiceMoveDeviceProcedure,
+			connect.WithSchema(fleetServiceMoveDeviceMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		deleteDevice: connect.NewClient[com.DeleteDeviceRequest, com.DeleteDeviceResponse](
+			httpClient,
+			baseURL+FleetServiceDeleteDeviceProcedure,
+			connect.WithSchema(fleetServiceDeleteDeviceMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// fleetServiceClient implements FleetServiceClient.
+type fleetServiceClient struct {
+	listFleets              *connect.Client[com.ListFleetsRequest, com.ListFleetsResponse]
+	createFleet             *connect.Client[com.CreateFleetRequest, com.CreateFleetResponse]
+	renameFleet             *connect.Client[com.RenameFleetRequest, com.RenameFleetResponse]
+	deleteFleet             *connect.Client[com.DeleteFleetRequest, com.DeleteFleetResponse]
+	setFleetDefaultSchedule *connect.Client[com.SetFleetDefaultScheduleRequest, com.SetFleetDefaultScheduleResponse]
+	setFleetAgentImage      *connect.Client[com.SetFleetAgentImageRequest, com.SetFleetAgentImageResponse]
+	setFleetMaintenance     *connect.Client[com.SetFleetMaintenanceRequest, com.SetFleetMaintenanceResponse]
+	setDeviceMaintenance    *connect.Client[com.SetDeviceMaintenanceRequest, com.SetDeviceMaintenanceResponse]
+	applyUpdatesNow         *connect.Client[com.ApplyUpdatesNowRequest, com.ApplyUpdatesNowResponse]
+	listDevices             *connect.Client[com.ListDevicesRequest, com.ListDevicesResponse]
+	createDevice            *connect.Client[com.CreateDeviceRequest, com.CreateDeviceResponse]
+	renameDevice            *connect.Client[com.RenameDeviceRequest, com.RenameDeviceResponse]
+	moveDevice              *connect.Client[com.MoveDeviceRequest, com.MoveDeviceResponse]
+	deleteDevice            *connect.Client[com.DeleteDeviceRequest, com.DeleteDeviceResponse]
+}
+
+// ListFleets calls remote.upd88.com.FleetService.ListFleets.
+func (c *fleetServiceClient) ListFleets(ctx context.Context, req *connect.Request[com.ListFleetsRequest]) (*connect.Response[com.ListFleetsResponse], error) {
+	return c.listFleets.CallUnary(ctx, req)
+}
+
+// CreateFleet calls remote.upd88.com.FleetService.CreateFleet.
+func (c *fleetServiceClient) CreateFleet(ctx context.Context, req *connect.Request[com.CreateFleetRequest]) (*connect.Response[com.CreateFleetResponse], error) {
+	return c.createFleet.CallUnary(ctx, req)
+}
+
+// RenameFleet calls remote.upd88.com.FleetService.RenameFleet.
+func (c *fleetServiceClient) RenameFleet(ctx context.Context, req *connect.Request[com.RenameFleetRequest]) (*connect.Response[com.RenameFleetResponse], error) {
+	return c.renameFleet.CallUnary(ctx, req)
+}
+
+// DeleteFleet calls remote.upd88.com.FleetService.DeleteFleet.
+func (c *fleetServiceClient) DeleteFleet(ctx context.Context, req *connect.Request[com.DeleteFleetRequest]) (*connect.Response[com.DeleteFleetResponse], error) {
+	return c.deleteFleet.CallUnary(ctx, req)
+}
+
+// SetFleetDefaultSchedule calls remote.upd88.com.FleetService.SetFleetDefaultSchedule.
+func (c *fleetServiceClient) SetFleetDefaultSchedule(ctx context.Context, req *connect.Request[com.SetFleetDefaultScheduleRequest]) (*connect.Response[com.SetFleetDefaultScheduleResponse], error) {
+	return c.setFleetDefaultSchedule.CallUnary(ctx, req)
+}
+
+// SetFleetAgentImage calls remote.upd88.com.FleetService.SetFleetAgentImage.
+func (c *fleetServiceClient) SetFleetAgentImage(ctx context.Context, req *connect.Request[com.SetFleetAgentImageRequest]) (*connect.Response[com.SetFleetAgentImageResponse], error) {
+	return c.setFleetAgentImage.CallUnary(ctx, req)
+}
+
+// SetFleetMaintenance calls remote.upd88.com.FleetService.SetFleetMaintenance.
+func (c *fleetServiceClient) SetFleetMaintenance(ctx context.Context, req *connect.Request[com.SetFleetMaintenanceRequest]) (*connect.Response[com.SetFleetMaintenanceResponse], error) {
+	return c.setFleetMaintenance.CallUnary(ctx, req)
+}
+
+// SetDeviceMaintenance calls remote.upd88.com.FleetService.SetDeviceMaintenance.
+func (c *fleetServiceClient) SetDeviceMaintenance(ctx context.Context, req *connect.Request[com.SetDeviceMaintenanceRequest]) (*connect.Response[com.SetDeviceMaintenanceResponse], error) {
+	return c.setDeviceMaintenance.CallUnary(ctx, req)
+}
+
+// ApplyUpdatesNow calls remote.upd88.com.FleetService.ApplyUpdatesNow.
+func (c *fleetServiceClient) ApplyUpdatesNow(ctx context.Context, req *connect.Request[com.ApplyUpdatesNowRequest]) (*connect.Response[com.ApplyUpdatesNowResponse], error) {
+	return c.applyUpdatesNow.CallUnary(ctx, req)
+}
+
+// ListDevices calls remote.upd88.com.FleetService.ListDevices.
+func (c *fleetServiceClient) ListDevices(ctx context.Context, req *connect.Request[com.ListDevicesRequest]) (*connect.Response[com.ListDevicesResponse], error) {
+	return c.listDevices.CallUnary(ctx, req)
+}
+
+// CreateDevice calls remote.upd88.com.FleetService.CreateDevice.
+func (c *fleetServiceClient) CreateDevice(ctx context.Context, req *connect.Request[com.CreateDeviceRequest]) (*connect.Response[com.CreateDeviceResponse], error) {
+	return c.createDevice.CallUnary(ctx, req)
+}
+
+// RenameDevice calls remote.upd88.com.FleetService.RenameDevice.
+func (c *fleetServiceClient) RenameDevice(ctx context.Context, req *connect.Request[com.RenameDeviceRequest]) (*connect.Response[com.RenameDeviceResponse], error) {
+	return c.renameDevice.CallUnary(ctx, req)
+}
+
+// MoveDevice calls remote.upd88.com.FleetService.MoveDevice.
+func (c *fleetServiceClient) MoveDevice(ctx context.Context, req *connect.Request[com.MoveDeviceRequest]) (*connect.Response[com.MoveDeviceResponse], error) {
+	return c.moveDevice.CallUnary(ctx, req)
+}
+
+// DeleteDevice calls remote.upd88.com.FleetService.DeleteDevice.
+func (c *fleetServiceClient) DeleteDevice(ctx context.Context, req *connect.Request[com.DeleteDeviceRequest]) (*connect.Response[com.DeleteDeviceResponse], error) {
+	return c.deleteDevice.CallUnary(ctx, req)
+}
+
+// FleetServiceHandler is an implementation of the remote.upd88.com.FleetService service.
+type FleetServiceHandler interface {
+	ListFleets(context.Context, *connect.Request[com.ListFleetsRequest]) (*connect.Response[com.ListFleetsResponse], error)
+	CreateFleet(context.Context, *connect.Request[com.CreateFleetRequest]) (*connect.Response[com.CreateFleetResponse], error)
+	RenameFleet(context.Context, *connect.Request[com.RenameFleetRequest]) (*connect.Response[com.RenameFleetResponse], error)
+	DeleteFleet(context.Context, *connect.Request[com.DeleteFleetRequest]) (*connect.Response[com.DeleteFleetResponse], error)
+	SetFleetDefaultSchedule(context.Context, *connect.Request[com.SetFleetDefaultScheduleRequest]) (*connect.Response[com.SetFleetDefaultScheduleResponse], error)
+	// Devices whose agent runs a different image hand over to this one, falling back if it fails to check in
+	SetFleetAgentImage(context.Context, *connect.Request[com.SetFleetAgentImageRequest]) (*connect.Response[com.SetFleetAgentImageResponse], error)
+	// Devices defer disruptive changes, such as restarting containers, until one of these windows opens
+	SetFleetMaintenance(context.Context, *connect.Request[com.SetFleetMaintenanceRequest]) (*connect.Response[com.SetFleetMaintenanceResponse], error)
+	SetDeviceMaintenance(context.Context, *connect.Request[com.SetDeviceMaintenanceRequest]) (*connect.Response[com.SetDeviceMaintenanceResponse], error)
+	// Lets a device, or every device in a fleet, apply deferred changes without waiting for a maintenance window
+	ApplyUpdatesNow(context.Context, *connect.Request[com.ApplyUpdatesNowRequest]) (*connect.Response[com.ApplyUpdatesNowResponse], error)
+	ListDevices(context.Context, *connect.Request[com.ListDevicesRequest]) (*connect.Response[com.ListDevicesResponse], error)
+	CreateDevice(context.Context, *connect.Request[com.CreateDeviceRequest]) (*connect.Response[com.CreateDeviceResponse], error)
+	RenameDevice(context.Context, *connect.Request[com.RenameDeviceRequest]) (*connect.Response[com.RenameDeviceResponse], error)
+	MoveDevice(context.Context, *connect.Request[com.MoveDeviceRequest]) (*connect.Response[com.MoveDeviceResponse], error)
+	DeleteDevice(context.Context, *connect.Request[com.DeleteDeviceRequest]) (*connect.Response[com.DeleteDeviceResponse], error)
+}
+
+// NewFleetServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewFleetServiceHandler(svc FleetServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	fleetServiceListFleetsHandler := connect.NewUnaryHandler(
+		FleetServiceListFleetsProcedure,
+		svc.ListFleets,
+		connect.WithSchema(fleetServiceListFleetsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceCreateFleetHandler := connect.NewUnaryHandler(
+		FleetServiceCreateFleetProcedure,
+		svc.CreateFleet,
+		connect.WithSchema(fleetServiceCreateFleetMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceRenameFleetHandler := connect.NewUnaryHandler(
+		FleetServiceRenameFleetProcedure,
+		svc.RenameFleet,
+		connect.WithSchema(fleetServiceRenameFleetMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceDeleteFleetHandler := connect.NewUnaryHandler(
+		FleetServiceDeleteFleetProcedure,
+		svc.DeleteFleet,
+		connect.WithSchema(fleetServiceDeleteFleetMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceSetFleetDefaultScheduleHandler := connect.NewUnaryHandler(
+		FleetServiceSetFleetDefaultScheduleProcedure,
+		svc.SetFleetDefaultSchedule,
+		connect.WithSchema(fleetServiceSetFleetDefaultScheduleMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceSetFleetAgentImageHandler := connect.NewUnaryHandler(
+		FleetServiceSetFleetAgentImageProcedure,
+		svc.SetFleetAgentImage,
+		connect.WithSchema(fleetServiceSetFleetAgentImageMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceSetFleetMaintenanceHandler := connect.NewUnaryHandler(
+		FleetServiceSetFleetMaintenanceProcedure,
+		svc.SetFleetMaintenance,
+		connect.WithSchema(fleetServiceSetFleetMaintenanceMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceSetDeviceMaintenanceHandler := connect.NewUnaryHandler(
+		FleetServiceSetDeviceMaintenanceProcedure,
+		svc.SetDeviceMaintenance,
+		connect.WithSchema(fleetServiceSetDeviceMaintenanceMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceApplyUpdatesNowHandler := connect.NewUnaryHandler(
+		FleetServiceApplyUpdatesNowProcedure,
+		svc.ApplyUpdatesNow,
+		connect.WithSchema(fleetServiceApplyUpdatesNowMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceListDevicesHandler := connect.NewUnaryHandler(
+		FleetServiceListDevicesProcedure,
+		svc.ListDevices,
+		connect.WithSchema(fleetServiceListDevicesMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceCreateDeviceHandler := connect.NewUnaryHandler(
+		FleetServiceCreateDeviceProcedure,
+		svc.CreateDevice,
+		connect.WithSchema(fleetServiceCreateDeviceMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceRenameDeviceHandler := connect.NewUnaryHandler(
+		FleetServiceRenameDeviceProcedure,
+		svc.RenameDevice,
+		connect.WithSchema(fleetServiceRenameDeviceMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceMoveDeviceHandler := connect.NewUnaryHandler(
+		FleetServiceMoveDeviceProcedure,
+		svc.MoveDevice,
+		connect.WithSchema(fleetServiceMoveDeviceMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	fleetServiceDeleteDeviceHandler := connect.NewUnaryHandler(
+		FleetServiceDeleteDeviceProcedure,
+		svc.DeleteDevice,
+		connect.WithSchema(fleetServiceDeleteDeviceMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/remote.upd88.com.FleetService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case FleetServiceListFleetsProcedure:
+			fleetServiceListFleetsHandler.ServeHTTP(w, r)
+		case FleetServiceCreateFleetProcedure:
+			fleetServiceCreateFleetHandler.ServeHTTP(w, r)
+		case FleetServiceRenameFleetProcedure:
+			fleetServiceRenameFleetHandler.ServeHTTP(w, r)
+		case FleetServiceDeleteFleetProcedure:
+			fleetServiceDeleteFleetHandler.ServeHTTP(w, r)
+		case FleetServiceSetFleetDefaultScheduleProcedure:
+			fleetServiceSetFleetDefaultScheduleHandler.ServeHTTP(w, r)
+		case FleetServiceSetFleetAgentImageProcedure:
+			fleetServiceSetFleetAgentImageHandler.ServeHTTP(w, r)
+		case FleetServiceSetFleetMaintenanceProcedure:
+			fleetServiceSetFleetMaintenanceHandler.ServeHTTP(w, r)
+		case FleetServiceSetDeviceMaintenanceProcedure:
+			fleetServiceSetDeviceMaintenanceHandler.ServeHTTP(w, r)
+		case FleetServiceApplyUpdatesNowProcedure:
+			fleetServiceApplyUpdatesNowHandler.ServeHTTP(w, r)
+		case FleetServiceListDevicesProcedure:
+			fleetServiceListDevicesHandler.ServeHTTP(w, r)
+		case FleetServiceCreateDeviceProcedure:
+			fleetServiceCreateDeviceHandler.ServeHTTP(w, r)
+		case FleetServiceRenameDeviceProcedure:
+			fleetServiceRenameDeviceHandler.ServeHTTP(w, r)
+		case FleetServiceMoveDeviceProcedure:
+			fleetServiceMoveDeviceHandler.ServeHTTP(w, r)
+		case FleetServiceDeleteDeviceProcedure:
+			fleetServiceDeleteDeviceHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedFleetServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedFleetServiceHandler struct{}
+
+func (UnimplementedFleetServiceHandler) ListFleets(context.Context, *connect.Request[com.ListFleetsRequest]) (*connect.Response[com.ListFleetsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.FleetService.ListFleets is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) CreateFleet(context.Context, *connect.Request[com.CreateFleetRequest]) (*connect.Response[com.CreateFleetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.FleetService.CreateFleet is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) RenameFleet(context.Context, *connect.Request[com.RenameFleetRequest]) (*connect.Response[com.RenameFleetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.FleetService.RenameFleet is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) DeleteFleet(context.Context, *connect.Request[com.DeleteFleetRequest]) (*connect.Response[com.DeleteFleetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.FleetService.DeleteFleet is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) SetFleetDefaultSchedule(context.Context, *connect.Request[com.SetFleetDefaultScheduleRequest]) (*connect.Response[com.SetFleetDefaultScheduleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.FleetService.SetFleetDefaultSchedule is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) SetFleetAgentImage(context.Context, *connect.Request[com.SetFleetAgentImageRequest]) (*connect.Response[com.SetFleetAgentImageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.FleetService.SetFleetAgentImage is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) SetFleetMaintenance(context.Context, *connect.Request[com.SetFleetMaintenanceRequest]) (*connect.Response[com.SetFleetMaintenanceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.FleetService.SetFleetMaintenance is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) SetDeviceMaintenance(context.Context, *connect.Request[com.SetDeviceMaintenanceRequest]) (*connect.Response[com.SetDeviceMaintenanceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.FleetService.SetDeviceMaintenance is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) ApplyUpdatesNow(context.Context, *connect.Request[com.ApplyUpdatesNowRequest]) (*connect.Response[com.ApplyUpdatesNowResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.FleetService.ApplyUpdatesNow is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) ListDevices(context.Context, *connect.Request[com.ListDevicesRequest]) (*connect.Response[com.ListDevicesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.FleetService.ListDevices is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) CreateDevice(context.Context, *connect.Request[com.CreateDeviceRequest]) (*connect.Response[com.CreateDeviceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.FleetService.CreateDevice is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) RenameDevice(context.Context, *connect.Request[com.RenameDeviceRequest]) (*connect.Response[com.RenameDeviceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.FleetService.RenameDevice is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) MoveDevice(context.Context, *connect.Request[com.MoveDeviceRequest]) (*connect.Response[com.MoveDeviceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.FleetService.MoveDevice is not implemented"))
+}
+
+func (UnimplementedFleetServiceHandler) DeleteDevice(context.Context, *connect.Request[com.DeleteDeviceRequest]) (*connect.Response[com.DeleteDeviceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.FleetService.DeleteDevice is not implemented"))
+}
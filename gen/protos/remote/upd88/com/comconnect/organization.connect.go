@@ -0,0 +1,294 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: protos/remote/upd88/com/organization.proto
+
+package comconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	com "github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// OrganizationServiceName is the fully-qualified name of the OrganizationService service.
+	OrganizationServiceName = "remote.upd88.com.OrganizationService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// OrganizationServiceListOrganizationsProcedure is the fully-qualified name of the
+	// OrganizationService's ListOrganizations RPC.
+	OrganizationServiceListOrganizationsProcedure = "/remote.upd88.com.OrganizationService/ListOrganizations"
+	// OrganizationServiceCreateAPIKeyProcedure is the fully-qualified name of the OrganizationService's
+	// CreateAPIKey RPC.
+	OrganizationServiceCreateAPIKeyProcedure = "/remote.upd88.com.OrganizationService/CreateAPIKey"
+	// OrganizationServiceListAPIKeysProcedure is the fully-qualified name of the OrganizationService's
+	// ListAPIKeys RPC.
+	OrganizationServiceListAPIKeysProcedure = "/remote.upd88.com.OrganizationService/ListAPIKeys"
+	// OrganizationServiceRevokeAPIKeyProcedure is the fully-qualified name of the OrganizationService's
+	// RevokeAPIKey RPC.
+	OrganizationServiceRevokeAPIKeyProcedure = "/remote.upd88.com.OrganizationService/RevokeAPIKey"
+	// OrganizationServiceListMembersProcedure is the fully-qualified name of the OrganizationService's
+	// ListMembers RPC.
+	OrganizationServiceListMembersProcedure = "/remote.upd88.com.OrganizationService/ListMembers"
+	// OrganizationServiceInviteUserProcedure is the fully-qualified name of the OrganizationService's
+	// InviteUser RPC.
+	OrganizationServiceInviteUserProcedure = "/remote.upd88.com.OrganizationService/InviteUser"
+	// OrganizationServiceSetMemberRoleProcedure is the fully-qualified name of the
+	// OrganizationService's SetMemberRole RPC.
+	OrganizationServiceSetMemberRoleProcedure = "/remote.upd88.com.OrganizationService/SetMemberRole"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	organizationServiceServiceDescriptor                 = com.File_protos_remote_upd88_com_organization_proto.Services().ByName("OrganizationService")
+	organizationServiceListOrganizationsMethodDescriptor = organizationServiceServiceDescriptor.Methods().ByName("ListOrganizations")
+	organizationServiceCreateAPIKeyMethodDescriptor      = organizationServiceServiceDescriptor.Methods().ByName("CreateAPIKey")
+	organizationServiceListAPIKeysMethodDescriptor       = organizationServiceServiceDescriptor.Methods().ByName("ListAPIKeys")
+	organizationServiceRevokeAPIKeyMethodDescriptor      = organizationServiceServiceDescriptor.Methods().ByName("RevokeAPIKey")
+	organizationServiceListMembersMethodDescriptor       = organizationServiceServiceDescriptor.Methods().ByName("ListMembers")
+	organizationServiceInviteUserMethodDescriptor        = organizationServiceServiceDescriptor.Methods().ByName("InviteUser")
+	organizationServiceSetMemberRoleMethodDescriptor     = organizationServiceServiceDescriptor.Methods().ByName("SetMemberRole")
+)
+
+// OrganizationServiceClient is a client for the remote.upd88.com.OrganizationService service.
+type OrganizationServiceClient interface {
+	ListOrganizations(context.Context, *connect.Request[com.ListOrganizationsRequest]) (*connect.Response[com.ListOrganizationsResponse], error)
+	CreateAPIKey(context.Context, *connect.Request[com.CreateAPIKeyRequest]) (*connect.Response[com.CreateAPIKeyResponse], error)
+	ListAPIKeys(context.Context, *connect.Request[com.ListAPIKeysRequest]) (*connect.Response[com.ListAPIKeysResponse], error)
+	RevokeAPIKey(context.Context, *connect.Request[com.RevokeAPIKeyRequest]) (*connect.Response[com.RevokeAPIKeyResponse], error)
+	ListMembers(context.Context, *connect.Request[com.ListMembersRequest]) (*connect.Response[com.ListMembersResponse], error)
+	InviteUser(context.Context, *connect.Request[com.InviteUserRequest]) (*connect.Response[com.InviteUserResponse], error)
+	SetMemberRole(context.Context, *connect.Request[com.SetMemberRoleRequest]) (*connect.Response[com.SetMemberRoleResponse], error)
+}
+
+// NewOrganizationServiceClient constructs a client for the remote.upd88.com.OrganizationService
+// service. By default, it uses the Connect protocol with the binary Protobuf Codec, asks for
+// gzipped responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply
+// the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewOrganizationServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) OrganizationServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &organizationServiceClient{
+		listOrganizations: connect.NewClient[com.ListOrganizationsRequest, com.ListOrganizationsResponse](
+			httpClient,
+			baseURL+OrganizationServiceListOrganizationsProcedure,
+			connect.WithSchema(organizationServiceListOrganizationsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		createAPIKey: connect.NewClient[com.CreateAPIKeyRequest, com.CreateAPIKeyResponse](
+			httpClient,
+			baseURL+OrganizationServiceCreateAPIKeyProcedure,
+			connect.WithSchema(organizationServiceCreateAPIKeyMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listAPIKeys: connect.NewClient[com.ListAPIKeysRequest, com.ListAPIKeysResponse](
+			httpClient,
+			baseURL+OrganizationServiceListAPIKeysProcedure,
+			connect.WithSchema(organizationServiceListAPIKeysMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		revokeAPIKey: connect.NewClient[com.RevokeAPIKeyRequest, com.RevokeAPIKeyResponse](
+			httpClient,
+			baseURL+OrganizationServiceRevokeAPIKeyProcedure,
+			connect.WithSchema(organizationServiceRevokeAPIKeyMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listMembers: connect.NewClient[com.ListMembersRequest, com.ListMembersResponse](
+			httpClient,
+			baseURL+OrganizationServiceListMembersProcedure,
+			connect.WithSchema(organizationServiceListMembersMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		inviteUser: connect.NewClient[com.InviteUserRequest, com.InviteUserResponse](
+			httpClient,
+			baseURL+OrganizationServiceInviteUserProcedure,
+			connect.WithSchema(organizationServiceInviteUserMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		setMemberRole: connect.NewClient[com.SetMemberRoleRequest, com.SetMemberRoleResponse](
+			httpClient,
+			baseURL+OrganizationServiceSetMemberRoleProcedure,
+			connect.WithSchema(organizationServiceSetMemberRoleMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// organizationServiceClient implements OrganizationServiceClient.
+type organizationServiceClient struct {
+	listOrganizations *connect.Client[com.ListOrganizationsRequest, com.ListOrganizationsResponse]
+	createAPIKey      *connect.Client[com.CreateAPIKeyRequest, com.CreateAPIKeyResponse]
+	listAPIKeys       *connect.Client[com.ListAPIKeysRequest, com.ListAPIKeysResponse]
+	revokeAPIKey      *connect.Client[com.RevokeAPIKeyRequest, com.RevokeAPIKeyResponse]
+	listMembers       *connect.Client[com.ListMembersRequest, com.ListMembersResponse]
+	inviteUser        *connect.Client[com.InviteUserRequest, com.InviteUserResponse]
+	setMemberRole     *connect.Client[com.SetMemberRoleRequest, com.SetMemberRoleResponse]
+}
+
+// ListOrganizations calls remote.upd88.com.OrganizationService.ListOrganizations.
+func (c *organizationServiceClient) ListOrganizations(ctx context.Context, req *connect.Request[com.ListOrganizationsRequest]) (*connect.Response[com.ListOrganizationsResponse], error) {
+	return c.listOrganizations.CallUnary(ctx, req)
+}
+
+// CreateAPIKey calls remote.upd88.com.OrganizationService.CreateAPIKey.
+func (c *organizationServiceClient) CreateAPIKey(ctx context.Context, req *connect.Request[com.CreateAPIKeyRequest]) (*connect.Response[com.CreateAPIKeyResponse], error) {
+	return c.createAPIKey.CallUnary(ctx, req)
+}
+
+// ListAPIKeys calls remote.upd88.com.OrganizationService.ListAPIKeys.
+func (c *organizationServiceClient) ListAPIKeys(ctx context.Context, req *connect.Request[com.ListAPIKeysRequest]) (*connect.Response[com.ListAPIKeysResponse], error) {
+	return c.listAPIKeys.CallUnary(ctx, req)
+}
+
+// RevokeAPIKey calls remote.upd88.com.OrganizationService.RevokeAPIKey.
+func (c *organizationServiceClient) RevokeAPIKey(ctx context.Context, req *connect.Request[com.RevokeAPIKeyRequest]) (*connect.Response[com.RevokeAPIKeyResponse], error) {
+	return c.revokeAPIKey.CallUnary(ctx, req)
+}
+
+// ListMembers calls remote.upd88.com.OrganizationService.ListMembers.
+func (c *organizationServiceClient) ListMembers(ctx context.Context, req *connect.Request[com.ListMembersRequest]) (*connect.Response[com.ListMembersResponse], error) {
+	return c.listMembers.CallUnary(ctx, req)
+}
+
+// InviteUser calls remote.upd88.com.OrganizationService.InviteUser.
+func (c *organizationServiceClient) InviteUser(ctx context.Context, req *connect.Request[com.InviteUserRequest]) (*connect.Response[com.InviteUserResponse], error) {
+	return c.inviteUser.CallUnary(ctx, req)
+}
+
+// SetMemberRole calls remote.upd88.com.OrganizationService.SetMemberRole.
+func (c *organizationServiceClient) SetMemberRole(ctx context.Context, req *connect.Request[com.SetMemberRoleRequest]) (*connect.Response[com.SetMemberRoleResponse], error) {
+	return c.setMemberRole.CallUnary(ctx, req)
+}
+
+// OrganizationServiceHandler is an implementation of the remote.upd88.com.OrganizationService
+// service.
+type OrganizationServiceHandler interface {
+	ListOrganizations(context.Context, *connect.Request[com.ListOrganizationsRequest]) (*connect.Response[com.ListOrganizationsResponse], error)
+	CreateAPIKey(context.Context, *connect.Request[com.CreateAPIKeyRequest]) (*connect.Response[com.CreateAPIKeyResponse], error)
+	ListAPIKeys(context.Context, *connect.Request[com.ListAPIKeysRequest]) (*connect.Response[com.ListAPIKeysResponse], error)
+	RevokeAPIKey(context.Context, *connect.Request[com.RevokeAPIKeyRequest]) (*connect.Response[com.RevokeAPIKeyResponse], error)
+	ListMembers(context.Context, *connect.Request[com.ListMembersRequest]) (*connect.Response[com.ListMembersResponse], error)
+	InviteUser(context.Context, *connect.Request[com.InviteUserRequest]) (*connect.Response[com.InviteUserResponse], error)
+	SetMemberRole(context.Context, *connect.Request[com.SetMemberRoleRequest]) (*connect.Response[com.SetMemberRoleResponse], error)
+}
+
+// NewOrganizationServiceHandler builds an HTTP handler from the service implementation. It returns
+// the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewOrganizationServiceHandler(svc OrganizationServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	organizationServiceListOrganizationsHandler := connect.NewUnaryHandler(
+		OrganizationServiceListOrganizationsProcedure,
+		svc.ListOrganizations,
+		connect.WithSchema(organizationServiceListOrganizationsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	organizationServiceCreateAPIKeyHandler := connect.NewUnaryHandler(
+		OrganizationServiceCreateAPIKeyProcedure,
+		svc.CreateAPIKey,
+		connect.WithSchema(organizationServiceCreateAPIKeyMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	organizationServiceListAPIKeysHandler := connect.NewUnaryHandler(
+		OrganizationServiceListAPIKeysProcedure,
+		svc.ListAPIKeys,
+		connect.WithSchema(organizationServiceListAPIKeysMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	organizationServiceRevokeAPIKeyHandler := connect.NewUnaryHandler(
+		OrganizationServiceRevokeAPIKeyProcedure,
+		svc.RevokeAPIKey,
+		connect.WithSchema(organizationServiceRevokeAPIKeyMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	organizationServiceListMembersHandler := connect.NewUnaryHandler(
+		OrganizationServiceListMembersProcedure,
+		svc.ListMembers,
+		connect.WithSchema(organizationServiceListMembersMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	organizationServiceInviteUserHandler := connect.NewUnaryHandler(
+		OrganizationServiceInviteUserProcedure,
+		svc.InviteUser,
+		connect.WithSchema(organizationServiceInviteUserMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	organizationServiceSetMemberRoleHandler := connect.NewUnaryHandler(
+		OrganizationServiceSetMemberRoleProcedure,
+		svc.SetMemberRole,
+		connect.WithSchema(organizationServiceSetMemberRoleMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/remote.upd88.com.OrganizationService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case OrganizationServiceListOrganizationsProcedure:
+			organizationServiceListOrganizationsHandler.ServeHTTP(w, r)
+		case OrganizationServiceCreateAPIKeyProcedure:
+			organizationServiceCreateAPIKeyHandler.ServeHTTP(w, r)
+		case OrganizationServiceListAPIKeysProcedure:
+			organizationServiceListAPIKeysHandler.ServeHTTP(w, r)
+		case OrganizationServiceRevokeAPIKeyProcedure:
+			organizationServiceRevokeAPIKeyHandler.ServeHTTP(w, r)
+		case OrganizationServiceListMembersProcedure:
+			organizationServiceListMembersHandler.ServeHTTP(w, r)
+		case OrganizationServiceInviteUserProcedure:
+			organizationServiceInviteUserHandler.ServeHTTP(w, r)
+		case OrganizationServiceSetMemberRoleProcedure:
+			organizationServiceSetMemberRoleHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedOrganizationServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedOrganizationServiceHandler struct{}
+
+func (UnimplementedOrganizationServiceHandler) ListOrganizations(context.Context, *connect.Request[com.ListOrganizationsRequest]) (*connect.Response[com.ListOrganizationsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.OrganizationService.ListOrganizations is not implemented"))
+}
+
+func (UnimplementedOrganizationServiceHandler) CreateAPIKey(context.Context, *connect.Request[com.CreateAPIKeyRequest]) (*connect.Response[com.CreateAPIKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.OrganizationService.CreateAPIKey is not implemented"))
+}
+
+func (UnimplementedOrganizationServiceHandler) ListAPIKeys(context.Context, *connect.Request[com.ListAPIKeysRequest]) (*connect.Response[com.ListAPIKeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.OrganizationService.ListAPIKeys is not implemented"))
+}
+
+func (UnimplementedOrganizationServiceHandler) RevokeAPIKey(context.Context, *connect.Request[com.RevokeAPIKeyRequest]) (*connect.Response[com.RevokeAPIKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.OrganizationService.RevokeAPIKey is not implemented"))
+}
+
+func (UnimplementedOrganizationServiceHandler) ListMembers(context.Context, *connect.Request[com.ListMembersRequest]) (*connect.Response[com.ListMembersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.OrganizationService.ListMembers is not implemented"))
+}
+
+func (UnimplementedOrganizationServiceHandler) InviteUser(context.Context, *connect.Request[com.InviteUserRequest]) (*connect.Response[com.InviteUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.OrganizationService.InviteUser is not implemented"))
+}
+
+func (UnimplementedOrganizationServiceHandler) SetMemberRole(context.Context, *connect.Request[com.SetMemberRoleRequest]) (*connect.Response[com.SetMemberRoleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.OrganizationService.SetMemberRole is not implemented"))
+}
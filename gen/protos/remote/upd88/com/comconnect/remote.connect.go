@@ -39,6 +39,17 @@ const (
 	// RemoteServiceReportScheduleStateProcedure is the fully-qualified name of the RemoteService's
 	// ReportScheduleState RPC.
 	RemoteServiceReportScheduleStateProcedure = "/remote.upd88.com.RemoteService/ReportScheduleState"
+	// RemoteServiceReportRollbackProcedure is the fully-qualified name of the RemoteService's
+	// ReportRollback RPC.
+	RemoteServiceReportRollbackProcedure = "/remote.upd88.com.RemoteService/ReportRollback"
+	// RemoteServicePollActionsProcedure is the fully-qualified name of the RemoteService's PollActions
+	// RPC.
+	RemoteServicePollActionsProcedure = "/remote.upd88.com.RemoteService/PollActions"
+	// RemoteServiceReportActionResultProcedure is the fully-qualified name of the RemoteService's
+	// ReportActionResult RPC.
+	RemoteServiceReportActionResultProcedure = "/remote.upd88.com.RemoteService/ReportActionResult"
+	// RemoteServicePushLogsProcedure is the fully-qualified name of the RemoteService's PushLogs RPC.
+	RemoteServicePushLogsProcedure = "/remote.upd88.com.RemoteService/PushLogs"
 )
 
 // These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
@@ -46,12 +57,21 @@ var (
 	remoteServiceServiceDescriptor                   = com.File_protos_remote_upd88_com_remote_proto.Services().ByName("RemoteService")
 	remoteServiceGetScheduleMethodDescriptor         = remoteServiceServiceDescriptor.Methods().ByName("GetSchedule")
 	remoteServiceReportScheduleStateMethodDescriptor = remoteServiceServiceDescriptor.Methods().ByName("ReportScheduleState")
+	remoteServiceReportRollbackMethodDescriptor      = remoteServiceServiceDescriptor.Methods().ByName("ReportRollback")
+	remoteServicePollActionsMethodDescriptor         = remoteServiceServiceDescriptor.Methods().ByName("PollActions")
+	remoteServiceReportActionResultMethodDescriptor  = remoteServiceServiceDescriptor.Methods().ByName("ReportActionResult")
+	remoteServicePushLogsMethodDescriptor            = remoteServiceServiceDescriptor.Methods().ByName("PushLogs")
 )
 
 // RemoteServiceClient is a client for the remote.upd88.com.RemoteService service.
 type RemoteServiceClient interface {
 	GetSchedule(context.Context, *connect.Request[com.GetScheduleRequest]) (*connect.Response[com.GetScheduleResponse], error)
 	ReportScheduleState(context.Context, *connect.Request[com.ReportScheduleStateRequest]) (*connect.Response[com.ReportScheduleStateResponse], error)
+	ReportRollback(context.Context, *connect.Request[com.ReportRollbackRequest]) (*connect.Response[com.ReportRollbackResponse], error)
+	// Long-polls for operator actions queued for the device
+	PollActions(context.Context, *connect.Request[com.PollActionsRequest]) (*connect.Response[com.PollActionsResponse], error)
+	ReportActionResult(context.Context, *connect.Request[com.ReportActionResultRequest]) (*connect.Response[com.ReportActionResultResponse], error)
+	PushLogs(context.Context, *connect.Request[com.PushLogsRequest]) (*connect.Response[com.PushLogsResponse], error)
 }
 
 // NewRemoteServiceClient constructs a client for the remote.upd88.com.RemoteService service. By
@@ -76,6 +96,30 @@ func NewRemoteServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(remoteServiceReportScheduleStateMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		reportRollback: connect.NewClient[com.ReportRollbackRequest, com.ReportRollbackResponse](
+			httpClient,
+			baseURL+RemoteServiceReportRollbackProcedure,
+			connect.WithSchema(remoteServiceReportRollbackMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		pollActions: connect.NewClient[com.PollActionsRequest, com.PollActionsResponse](
+			httpClient,
+			baseURL+RemoteServicePollActionsProcedure,
+			connect.WithSchema(remoteServicePollActionsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		reportActionResult: connect.NewClient[com.ReportActionResultRequest, com.ReportActionResultResponse](
+			httpClient,
+			baseURL+RemoteServiceReportActionResultProcedure,
+			connect.WithSchema(remoteServiceReportActionResultMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		pushLogs: connect.NewClient[com.PushLogsRequest, com.PushLogsResponse](
+			httpClient,
+			baseURL+RemoteServicePushLogsProcedure,
+			connect.WithSchema(remoteServicePushLogsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -83,6 +127,10 @@ func NewRemoteServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 type remoteServiceClient struct {
 	getSchedule         *connect.Client[com.GetScheduleRequest, com.GetScheduleResponse]
 	reportScheduleState *connect.Client[com.ReportScheduleStateRequest, com.ReportScheduleStateResponse]
+	reportRollback      *connect.Client[com.ReportRollbackRequest, com.ReportRollbackResponse]
+	pollActions         *connect.Client[com.PollActionsRequest, com.PollActionsResponse]
+	reportActionResult  *connect.Client[com.ReportActionResultRequest, com.ReportActionResultResponse]
+	pushLogs            *connect.Client[com.PushLogsRequest, com.PushLogsResponse]
 }
 
 // GetSchedule calls remote.upd88.com.RemoteService.GetSchedule.
@@ -95,10 +143,35 @@ func (c *remoteServiceClient) ReportScheduleState(ctx context.Context, req *conn
 	return c.reportScheduleState.CallUnary(ctx, req)
 }
 
+// ReportRollback calls remote.upd88.com.RemoteService.ReportRollback.
+func (c *remoteServiceClient) ReportRollback(ctx context.Context, req *connect.Request[com.ReportRollbackRequest]) (*connect.Response[com.ReportRollbackResponse], error) {
+	return c.reportRollback.CallUnary(ctx, req)
+}
+
+// PollActions calls remote.upd88.com.RemoteService.PollActions.
+func (c *remoteServiceClient) PollActions(ctx context.Context, req *connect.Request[com.PollActionsRequest]) (*connect.Response[com.PollActionsResponse], error) {
+	return c.pollActions.CallUnary(ctx, req)
+}
+
+// ReportActionResult calls remote.upd88.com.RemoteService.ReportActionResult.
+func (c *remoteServiceClient) ReportActionResult(ctx context.Context, req *connect.Request[com.ReportActionResultRequest]) (*connect.Response[com.ReportActionResultResponse], error) {
+	return c.reportActionResult.CallUnary(ctx, req)
+}
+
+// PushLogs calls remote.upd88.com.RemoteService.PushLogs.
+func (c *remoteServiceClient) PushLogs(ctx context.Context, req *connect.Request[com.PushLogsRequest]) (*connect.Response[com.PushLogsResponse], error) {
+	return c.pushLogs.CallUnary(ctx, req)
+}
+
 // RemoteServiceHandler is an implementation of the remote.upd88.com.RemoteService service.
 type RemoteServiceHandler interface {
 	GetSchedule(context.Context, *connect.Request[com.GetScheduleRequest]) (*connect.Response[com.GetScheduleResponse], error)
 	ReportScheduleState(context.Context, *connect.Request[com.ReportScheduleStateRequest]) (*connect.Response[com.ReportScheduleStateResponse], error)
+	ReportRollback(context.Context, *connect.Request[com.ReportRollbackRequest]) (*connect.Response[com.ReportRollbackResponse], error)
+	// Long-polls for operator actions queued for the device
+	PollActions(context.Context, *connect.Request[com.PollActionsRequest]) (*connect.Response[com.PollActionsResponse], error)
+	ReportActionResult(context.Context, *connect.Request[com.ReportActionResultRequest]) (*connect.Response[com.ReportActionResultResponse], error)
+	PushLogs(context.Context, *connect.Request[com.PushLogsRequest]) (*connect.Response[com.PushLogsResponse], error)
 }
 
 // NewRemoteServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -119,12 +192,44 @@ func NewRemoteServiceHandler(svc RemoteServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(remoteServiceReportScheduleStateMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	remoteServiceReportRollbackHandler := connect.NewUnaryHandler(
+		RemoteServiceReportRollbackProcedure,
+		svc.ReportRollback,
+		connect.WithSchema(remoteServiceReportRollbackMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	remoteServicePollActionsHandler := connect.NewUnaryHandler(
+		RemoteServicePollActionsProcedure,
+		svc.PollActions,
+		connect.WithSchema(remoteServicePollActionsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	remoteServiceReportActionResultHandler := connect.NewUnaryHandler(
+		RemoteServiceReportActionResultProcedure,
+		svc.ReportActionResult,
+		connect.WithSchema(remoteServiceReportActionResultMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	remoteServicePushLogsHandler := connect.NewUnaryHandler(
+		RemoteServicePushLogsProcedure,
+		svc.PushLogs,
+		connect.WithSchema(remoteServicePushLogsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/remote.upd88.com.RemoteService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case RemoteServiceGetScheduleProcedure:
 			remoteServiceGetScheduleHandler.ServeHTTP(w, r)
 		case RemoteServiceReportScheduleStateProcedure:
 			remoteServiceReportScheduleStateHandler.ServeHTTP(w, r)
+		case RemoteServiceReportRollbackProcedure:
+			remoteServiceReportRollbackHandler.ServeHTTP(w, r)
+		case RemoteServicePollActionsProcedure:
+			remoteServicePollActionsHandler.ServeHTTP(w, r)
+		case RemoteServiceReportActionResultProcedure:
+			remoteServiceReportActionResultHandler.ServeHTTP(w, r)
+		case RemoteServicePushLogsProcedure:
+			remoteServicePushLogsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -141,3 +246,19 @@ func (UnimplementedRemoteServiceHandler) GetSchedule(context.Context, *connect.R
 func (UnimplementedRemoteServiceHandler) ReportScheduleState(context.Context, *connect.Request[com.ReportScheduleStateRequest]) (*connect.Response[com.ReportScheduleStateResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.RemoteService.ReportScheduleState is not implemented"))
 }
+
+func (UnimplementedRemoteServiceHandler) ReportRollback(context.Context, *connect.Request[com.ReportRollbackRequest]) (*connect.Response[com.ReportRollbackResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.RemoteService.ReportRollback is not implemented"))
+}
+
+func (UnimplementedRemoteServiceHandler) PollActions(context.Context, *connect.Request[com.PollActionsRequest]) (*connect.Response[com.PollActionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.RemoteService.PollActions is not implemented"))
+}
+
+func (UnimplementedRemoteServiceHandler) ReportActionResult(context.Context, *connect.Request[com.ReportActionResultRequest]) (*connect.Response[com.ReportActionResultResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.RemoteService.ReportActionResult is not implemented"))
+}
+
+func (UnimplementedRemoteServiceHandler) PushLogs(context.Context, *connect.Request[com.PushLogsRequest]) (*connect.Response[com.PushLogsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.RemoteService.PushLogs is not implemented"))
+}
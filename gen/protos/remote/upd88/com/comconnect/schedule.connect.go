@@ -0,0 +1,447 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: protos/remote/upd88/com/schedule.proto
+
+package comconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	com "github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// ScheduleServiceName is the fully-qualified name of the ScheduleService service.
+	ScheduleServiceName = "remote.upd88.com.ScheduleService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// ScheduleServiceListSchedulesProcedure is the fully-qualified name of the ScheduleService's
+	// ListSchedules RPC.
+	ScheduleServiceListSchedulesProcedure = "/remote.upd88.com.ScheduleService/ListSchedules"
+	// ScheduleServiceDescribeScheduleProcedure is the fully-qualified name of the ScheduleService's
+	// DescribeSchedule RPC.
+	ScheduleServiceDescribeScheduleProcedure = "/remote.upd88.com.ScheduleService/DescribeSchedule"
+	// ScheduleServiceCreateScheduleProcedure is the fully-qualified name of the ScheduleService's
+	// CreateSchedule RPC.
+	ScheduleServiceCreateScheduleProcedure = "/remote.upd88.com.ScheduleService/CreateSchedule"
+	// ScheduleServiceUpdateScheduleProcedure is the fully-qualified name of the ScheduleService's
+	// UpdateSchedule RPC.
+	ScheduleServiceUpdateScheduleProcedure = "/remote.upd88.com.ScheduleService/UpdateSchedule"
+	// ScheduleServiceDeleteScheduleProcedure is the fully-qualified name of the ScheduleService's
+	// DeleteSchedule RPC.
+	ScheduleServiceDeleteScheduleProcedure = "/remote.upd88.com.ScheduleService/DeleteSchedule"
+	// ScheduleServiceCreateContainerProcedure is the fully-qualified name of the ScheduleService's
+	// CreateContainer RPC.
+	ScheduleServiceCreateContainerProcedure = "/remote.upd88.com.ScheduleService/CreateContainer"
+	// ScheduleServiceUpdateContainerProcedure is the fully-qualified name of the ScheduleService's
+	// UpdateContainer RPC.
+	ScheduleServiceUpdateContainerProcedure = "/remote.upd88.com.ScheduleService/UpdateContainer"
+	// ScheduleServiceDeleteContainerProcedure is the fully-qualified name of the ScheduleService's
+	// DeleteContainer RPC.
+	ScheduleServiceDeleteContainerProcedure = "/remote.upd88.com.ScheduleService/DeleteContainer"
+	// ScheduleServiceApplyManifestProcedure is the fully-qualified name of the ScheduleService's
+	// ApplyManifest RPC.
+	ScheduleServiceApplyManifestProcedure = "/remote.upd88.com.ScheduleService/ApplyManifest"
+	// ScheduleServiceExportScheduleProcedure is the fully-qualified name of the ScheduleService's
+	// ExportSchedule RPC.
+	ScheduleServiceExportScheduleProcedure = "/remote.upd88.com.ScheduleService/ExportSchedule"
+	// ScheduleServiceImportComposeProcedure is the fully-qualified name of the ScheduleService's
+	// ImportCompose RPC.
+	ScheduleServiceImportComposeProcedure = "/remote.upd88.com.ScheduleService/ImportCompose"
+	// ScheduleServiceExportComposeProcedure is the fully-qualified name of the ScheduleService's
+	// ExportCompose RPC.
+	ScheduleServiceExportComposeProcedure = "/remote.upd88.com.ScheduleService/ExportCompose"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	scheduleServiceServiceDescriptor                = com.File_protos_remote_upd88_com_schedule_proto.Services().ByName("ScheduleService")
+	scheduleServiceListSchedulesMethodDescriptor    = scheduleServiceServiceDescriptor.Methods().ByName("ListSchedules")
+	scheduleServiceDescribeScheduleMethodDescriptor = scheduleServiceServiceDescriptor.Methods().ByName("DescribeSchedule")
+	scheduleServiceCreateScheduleMethodDescriptor   = scheduleServiceServiceDescriptor.Methods().ByName("CreateSchedule")
+	scheduleServiceUpdateScheduleMethodDescriptor   = scheduleServiceServiceDescriptor.Methods().ByName("UpdateSchedule")
+	scheduleServiceDeleteScheduleMethodDescriptor   = scheduleServiceServiceDescriptor.Methods().ByName("DeleteSchedule")
+	scheduleServiceCreateContainerMethodDescriptor  = scheduleServiceServiceDescriptor.Methods().ByName("CreateContainer")
+	scheduleServiceUpdateContainerMethodDescriptor  = scheduleServiceServiceDescriptor.Methods().ByName("UpdateContainer")
+	scheduleServiceDeleteContainerMethodDescriptor  = scheduleServiceServiceDescriptor.Methods().ByName("DeleteContainer")
+	scheduleServiceApplyManifestMethodDescriptor    = scheduleServiceServiceDescriptor.Methods().ByName("ApplyManifest")
+	scheduleServiceExportScheduleMethodDescriptor   = scheduleServiceServiceDescriptor.Methods().ByName("ExportSchedule")
+	scheduleServiceImportComposeMethodDescriptor    = scheduleServiceServiceDescriptor.Methods().ByName("ImportCompose")
+	scheduleServiceExportComposeMethodDescriptor    = scheduleServiceServiceDescriptor.Methods().ByName("ExportCompose")
+)
+
+// ScheduleServiceClient is a client for the remote.upd88.com.ScheduleService service.
+type ScheduleServiceClient interface {
+	ListSchedules(context.Context, *connect.Request[com.ListSchedulesRequest]) (*connect.Response[com.ListSchedulesResponse], error)
+	DescribeSchedule(context.Context, *connect.Request[com.DescribeScheduleRequest]) (*connect.Response[com.DescribeScheduleResponse], error)
+	CreateSchedule(context.Context, *connect.Request[com.CreateScheduleRequest]) (*connect.Response[com.CreateScheduleResponse], error)
+	UpdateSchedule(context.Context, *connect.Request[com.UpdateScheduleRequest]) (*connect.Response[com.UpdateScheduleResponse], error)
+	DeleteSchedule(context.Context, *connect.Request[com.DeleteScheduleRequest]) (*connect.Response[com.DeleteScheduleResponse], error)
+	CreateContainer(context.Context, *connect.Request[com.CreateContainerRequest]) (*connect.Response[com.CreateContainerResponse], error)
+	UpdateContainer(context.Context, *connect.Request[com.UpdateContainerRequest]) (*connect.Response[com.UpdateContainerResponse], error)
+	DeleteContainer(context.Context, *connect.Request[com.DeleteContainerRequest]) (*connect.Response[com.DeleteContainerResponse], error)
+	// Declarative management: ApplyManifest is idempotent, and ExportSchedule renders a schedule as a manifest
+	// that applies back to it unchanged
+	ApplyManifest(context.Context, *connect.Request[com.ApplyManifestRequest]) (*connect.Response[com.ApplyManifestResponse], error)
+	ExportSchedule(context.Context, *connect.Request[com.ExportScheduleRequest]) (*connect.Response[com.ExportScheduleResponse], error)
+	ImportCompose(context.Context, *connect.Request[com.ImportComposeRequest]) (*connect.Response[com.ImportComposeResponse], error)
+	ExportCompose(context.Context, *connect.Request[com.ExportComposeRequest]) (*connect.Response[com.ExportComposeResponse], error)
+}
+
+// NewScheduleServiceClient constructs a client for the remote.upd88.com.ScheduleService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewScheduleServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) ScheduleServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &scheduleServiceClient{
+		listSchedules: connect.NewClient[com.ListSchedulesRequest, com.ListSchedulesResponse](
+			httpClient,
+			baseURL+ScheduleServiceListSchedulesProcedure,
+			connect.WithSchema(scheduleServiceListSchedulesMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		describeSchedule: connect.NewClient[com.DescribeScheduleRequest, com.DescribeScheduleResponse](
+			httpClient,
+			baseURL+ScheduleServiceDescribeScheduleProcedure,
+			connect.WithSchema(scheduleServiceDescribeScheduleMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		createSchedule: connect.NewClient[com.CreateScheduleRequest, com.CreateScheduleResponse](
+			httpClient,
+			baseURL+ScheduleServiceCreateScheduleProcedure,
+			connect.WithSchema(scheduleServiceCreateScheduleMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		updateSchedule: connect.NewClient[com.UpdateScheduleRequest, com.UpdateScheduleResponse](
+			httpClient,
+			baseURL+ScheduleServiceUpdateScheduleProcedure,
+			connect.WithSchema(scheduleServiceUpdateScheduleMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		deleteSchedule: connect.NewClient[com.DeleteScheduleRequest, com.DeleteScheduleResponse](
+			httpClient,
+			baseURL+ScheduleServiceDeleteScheduleProcedure,
+			connect.WithSchema(scheduleServiceDeleteScheduleMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		createContainer: connect.NewClient[com.CreateContainerRequest, com.CreateContainerResponse](
+			httpClient,
+			baseURL+ScheduleServiceCreateContainerProcedure,
+			connect.WithSchema(scheduleServiceCreateContainerMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		updateContainer: connect.NewClient[com.UpdateContainerRequest, com.UpdateContainerResponse](
+			httpClient,
+			baseURL+ScheduleServiceUpdateContainerProcedure,
+			connect.WithSchema(scheduleServiceUpdateContainerMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		deleteContainer: connect.NewClient[com.DeleteContainerRequest, com.DeleteContainerResponse](
+			httpClient,
+			baseURL+ScheduleServiceDeleteContainerProcedure,
+			connect.WithSchema(scheduleServiceDeleteContainerMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		applyManifest: connect.NewClient[com.ApplyManifestRequest, com.ApplyManifestResponse](
+			httpClient,
+			baseURL+ScheduleServiceApplyManifestProcedure,
+			connect.WithSchema(scheduleServiceApplyManifestMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		exportSchedule: connect.NewClient[com.ExportScheduleRequest, com.ExportScheduleResponse](
+			httpClient,
+			baseURL+ScheduleServiceExportScheduleProcedure,
+			connect.WithSchema(scheduleServiceExportScheduleMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		importCompose: connect.NewClient[com.ImportComposeRequest, com.ImportComposeResponse](
+			httpClient,
+			baseURL+ScheduleServiceImportComposeProcedure,
+			connect.WithSchema(scheduleServiceImportComposeMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		exportCompose: connect.NewClient[com.ExportComposeRequest, com.ExportComposeResponse](
+			httpClient,
+			baseURL+ScheduleServiceExportComposeProcedure,
+			connect.WithSchema(scheduleServiceExportComposeMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// scheduleServiceClient implements ScheduleServiceClient.
+type scheduleServiceClient struct {
+	listSchedules    *connect.Client[com.ListSchedulesRequest, com.ListSchedulesResponse]
+	describeSchedule *connect.Client[com.DescribeScheduleRequest, com.DescribeScheduleResponse]
+	createSchedule   *connect.Client[com.CreateScheduleRequest, com.CreateScheduleResponse]
+	updateSchedule   *connect.Client[com.UpdateScheduleRequest, com.UpdateScheduleResponse]
+	deleteSchedule   *connect.Client[com.DeleteScheduleRequest, com.DeleteScheduleResponse]
+	createContainer  *connect.Client[com.CreateContainerRequest, com.CreateContainerResponse]
+	updateContainer  *connect.Client[com.UpdateContainerRequest, com.UpdateContainerResponse]
+	deleteContainer  *connect.Client[com.DeleteContainerRequest, com.DeleteContainerResponse]
+	applyManifest    *connect.Client[com.ApplyManifestRequest, com.ApplyManifestResponse]
+	exportSchedule   *connect.Client[com.ExportScheduleRequest, com.ExportScheduleResponse]
+	importCompose    *connect.Client[com.ImportComposeRequest, com.ImportComposeResponse]
+	exportCompose    *connect.Client[com.ExportComposeRequest, com.ExportComposeResponse]
+}
+
+// ListSchedules calls remote.upd88.com.ScheduleService.ListSchedules.
+func (c *scheduleServiceClient) ListSchedules(ctx context.Context, req *connect.Request[com.ListSchedulesRequest]) (*connect.Response[com.ListSchedulesResponse], error) {
+	return c.listSchedules.CallUnary(ctx, req)
+}
+
+// DescribeSchedule calls remote.upd88.com.ScheduleService.DescribeSchedule.
+func (c *scheduleServiceClient) DescribeSchedule(ctx context.Context, req *connect.Request[com.DescribeScheduleRequest]) (*connect.Response[com.DescribeScheduleResponse], error) {
+	return c.describeSchedule.CallUnary(ctx, req)
+}
+
+// CreateSchedule calls remote.upd88.com.ScheduleService.CreateSchedule.
+func (c *scheduleServiceClient) CreateSchedule(ctx context.Context, req *connect.Request[com.CreateScheduleRequest]) (*connect.Response[com.CreateScheduleResponse], error) {
+	return c.createSchedule.CallUnary(ctx, req)
+}
+
+// UpdateSchedule calls remote.upd88.com.ScheduleService.UpdateSchedule.
+func (c *scheduleServiceClient) UpdateSchedule(ctx context.Context, req *connect.Request[com.UpdateScheduleRequest]) (*connect.Response[com.UpdateScheduleResponse], error) {
+	return c.updateSchedule.CallUnary(ctx, req)
+}
+
+// DeleteSchedule calls remote.upd88.com.ScheduleService.DeleteSchedule.
+func (c *scheduleServiceClient) DeleteSchedule(ctx context.Context, req *connect.Request[com.DeleteScheduleRequest]) (*connect.Response[com.DeleteScheduleResponse], error) {
+	return c.deleteSchedule.CallUnary(ctx, req)
+}
+
+// CreateContainer calls remote.upd88.com.ScheduleService.CreateContainer.
+func (c *scheduleServiceClient) CreateContainer(ctx context.Context, req *connect.Request[com.CreateContainerRequest]) (*connect.Response[com.CreateContainerResponse], error) {
+	return c.createContainer.CallUnary(ctx, req)
+}
+
+// UpdateContainer calls remote.upd88.com.ScheduleService.UpdateContainer.
+func (c *scheduleServiceClient) UpdateContainer(ctx context.Context, req *connect.Request[com.UpdateContainerRequest]) (*connect.Response[com.UpdateContainerResponse], error) {
+	return c.updateContainer.CallUnary(ctx, req)
+}
+
+// DeleteContainer calls remote.upd88.com.ScheduleService.DeleteContainer.
+func (c *scheduleServiceClient) DeleteContainer(ctx context.Context, req *connect.Request[com.DeleteContainerRequest]) (*connect.Response[com.DeleteContainerResponse], error) {
+	return c.deleteContainer.CallUnary(ctx, req)
+}
+
+// ApplyManifest calls remote.upd88.com.ScheduleService.ApplyManifest.
+func (c *scheduleServiceClient) ApplyManifest(ctx context.Context, req *connect.Request[com.ApplyManifestRequest]) (*connect.Response[com.ApplyManifestResponse], error) {
+	return c.applyManifest.CallUnary(ctx, req)
+}
+
+// ExportSchedule calls remote.upd88.com.ScheduleService.ExportSchedule.
+func (c *scheduleServiceClient) ExportSchedule(ctx context.Context, req *connect.Request[com.ExportScheduleRequest]) (*connect.Response[com.ExportScheduleResponse], error) {
+	return c.exportSchedule.CallUnary(ctx, req)
+}
+
+// ImportCompose calls remote.upd88.com.ScheduleService.ImportCompose.
+func (c *scheduleServiceClient) ImportCompose(ctx context.Context, req *connect.Request[com.ImportComposeRequest]) (*connect.Response[com.ImportComposeResponse], error) {
+	return c.importCompose.CallUnary(ctx, req)
+}
+
+// ExportCompose calls remote.upd88.com.ScheduleService.ExportCompose.
+func (c *scheduleServiceClient) ExportCompose(ctx context.Context, req *connect.Request[com.ExportComposeRequest]) (*connect.Response[com.ExportComposeResponse], error) {
+	return c.exportCompose.CallUnary(ctx, req)
+}
+
+// ScheduleServiceHandler is an implementation of the remote.upd88.com.ScheduleService service.
+type ScheduleServiceHandler interface {
+	ListSchedules(context.Context, *connect.Request[com.ListSchedulesRequest]) (*connect.Response[com.ListSchedulesResponse], error)
+	DescribeSchedule(context.Context, *connect.Request[com.DescribeScheduleRequest]) (*connect.Response[com.DescribeScheduleResponse], error)
+	CreateSchedule(context.Context, *connect.Request[com.CreateScheduleRequest]) (*connect.Response[com.CreateScheduleResponse], error)
+	UpdateSchedule(context.Context, *connect.Request[com.UpdateScheduleRequest]) (*connect.Response[com.UpdateScheduleResponse], error)
+	DeleteSchedule(context.Context, *connect.Request[com.DeleteScheduleRequest]) (*connect.Response[com.DeleteScheduleResponse], error)
+	CreateContainer(context.Context, *connect.Request[com.CreateContainerRequest]) (*connect.Response[com.CreateContainerResponse], error)
+	UpdateContainer(context.Context, *connect.Request[com.UpdateContainerRequest]) (*connect.Response[com.UpdateContainerResponse], error)
+	DeleteContainer(context.Context, *connect.Request[com.DeleteContainerRequest]) (*connect.Response[com.DeleteContainerResponse], error)
+	// Declarative management: ApplyManifest is idempotent, and ExportSchedule renders a schedule as a manifest
+	// that applies back to it unchanged
+	ApplyManifest(context.Context, *connect.Request[com.ApplyManifestRequest]) (*connect.Response[com.ApplyManifestResponse], error)
+	ExportSchedule(context.Context, *connect.Request[com.ExportScheduleRequest]) (*connect.Response[com.ExportScheduleResponse], error)
+	ImportCompose(context.Context, *connect.Request[com.ImportComposeRequest]) (*connect.Response[com.ImportComposeResponse], error)
+	ExportCompose(context.Context, *connect.Request[com.ExportComposeRequest]) (*connect.Response[com.ExportComposeResponse], error)
+}
+
+// NewScheduleServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewScheduleServiceHandler(svc ScheduleServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	scheduleServiceListSchedulesHandler := connect.NewUnaryHandler(
+		ScheduleServiceListSchedulesProcedure,
+		svc.ListSchedules,
+		connect.WithSchema(scheduleServiceListSchedulesMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	scheduleServiceDescribeScheduleHandler := connect.NewUnaryHandler(
+		ScheduleServiceDescribeScheduleProcedure,
+		svc.DescribeSchedule,
+		connect.WithSchema(scheduleServiceDescribeScheduleMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	scheduleServiceCreateScheduleHandler := connect.NewUnaryHandler(
+		ScheduleServiceCreateScheduleProcedure,
+		svc.CreateSchedule,
+		connect.WithSchema(scheduleServiceCreateScheduleMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	scheduleServiceUpdateScheduleHandler := connect.NewUnaryHandler(
+		ScheduleServiceUpdateScheduleProcedure,
+		svc.UpdateSchedule,
+		connect.WithSchema(scheduleServiceUpdateScheduleMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	scheduleServiceDeleteScheduleHandler := connect.NewUnaryHandler(
+		ScheduleServiceDeleteScheduleProcedure,
+		svc.DeleteSchedule,
+		connect.WithSchema(scheduleServiceDeleteScheduleMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	scheduleServiceCreateContainerHandler := connect.NewUnaryHandler(
+		ScheduleServiceCreateContainerProcedure,
+		svc.CreateContainer,
+		connect.WithSchema(scheduleServiceCreateContainerMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	scheduleServiceUpdateContainerHandler := connect.NewUnaryHandler(
+		ScheduleServiceUpdateContainerProcedure,
+		svc.UpdateContainer,
+		connect.WithSchema(scheduleServiceUpdateContainerMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	scheduleServiceDeleteContainerHandler := connect.NewUnaryHandler(
+		ScheduleServiceDeleteContainerProcedure,
+		svc.DeleteContainer,
+		connect.WithSchema(scheduleServiceDeleteContainerMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	scheduleServiceApplyManifestHandler := connect.NewUnaryHandler(
+		ScheduleServiceApplyManifestProcedure,
+		svc.ApplyManifest,
+		connect.WithSchema(scheduleServiceApplyManifestMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	scheduleServiceExportScheduleHandler := connect.NewUnaryHandler(
+		ScheduleServiceExportScheduleProcedure,
+		svc.ExportSchedule,
+		connect.WithSchema(scheduleServiceExportScheduleMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	scheduleServiceImportComposeHandler := connect.NewUnaryHandler(
+		ScheduleServiceImportComposeProcedure,
+		svc.ImportCompose,
+		connect.WithSchema(scheduleServiceImportComposeMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	scheduleServiceExportComposeHandler := connect.NewUnaryHandler(
+		ScheduleServiceExportComposeProcedure,
+		svc.ExportCompose,
+		connect.WithSchema(scheduleServiceExportComposeMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/remote.upd88.com.ScheduleService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ScheduleServiceListSchedulesProcedure:
+			scheduleServiceListSchedulesHandler.ServeHTTP(w, r)
+		case ScheduleServiceDescribeScheduleProcedure:
+			scheduleServiceDescribeScheduleHandler.ServeHTTP(w, r)
+		case ScheduleServiceCreateScheduleProcedure:
+			scheduleServiceCreateScheduleHandler.ServeHTTP(w, r)
+		case ScheduleServiceUpdateScheduleProcedure:
+			scheduleServiceUpdateScheduleHandler.ServeHTTP(w, r)
+		case ScheduleServiceDeleteScheduleProcedure:
+			scheduleServiceDeleteScheduleHandler.ServeHTTP(w, r)
+		case ScheduleServiceCreateContainerProcedure:
+			scheduleServiceCreateContainerHandler.ServeHTTP(w, r)
+		case ScheduleServiceUpdateContainerProcedure:
+			scheduleServiceUpdateContainerHandler.ServeHTTP(w, r)
+		case ScheduleServiceDeleteContainerProcedure:
+			scheduleServiceDeleteContainerHandler.ServeHTTP(w, r)
+		case ScheduleServiceApplyManifestProcedure:
+			scheduleServiceApplyManifestHandler.ServeHTTP(w, r)
+		case ScheduleServiceExportScheduleProcedure:
+			scheduleServiceExportScheduleHandler.ServeHTTP(w, r)
+		case ScheduleServiceImportComposeProcedure:
+			scheduleServiceImportComposeHandler.ServeHTTP(w, r)
+		case ScheduleServiceExportComposeProcedure:
+			scheduleServiceExportComposeHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedScheduleServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedScheduleServiceHandler struct{}
+
+func (UnimplementedScheduleServiceHandler) ListSchedules(context.Context, *connect.Request[com.ListSchedulesRequest]) (*connect.Response[com.ListSchedulesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.ScheduleService.ListSchedules is not implemented"))
+}
+
+func (UnimplementedScheduleServiceHandler) DescribeSchedule(context.Context, *connect.Request[com.DescribeScheduleRequest]) (*connect.Response[com.DescribeScheduleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.ScheduleService.DescribeSchedule is not implemented"))
+}
+
+func (UnimplementedScheduleServiceHandler) CreateSchedule(context.Context, *connect.Request[com.CreateScheduleRequest]) (*connect.Response[com.CreateScheduleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.ScheduleService.CreateSchedule is not implemented"))
+}
+
+func (UnimplementedScheduleServiceHandler) UpdateSchedule(context.Context, *connect.Request[com.UpdateScheduleRequest]) (*connect.Response[com.UpdateScheduleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.ScheduleService.UpdateSchedule is not implemented"))
+}
+
+func (UnimplementedScheduleServiceHandler) DeleteSchedule(context.Context, *connect.Request[com.DeleteScheduleRequest]) (*connect.Response[com.DeleteScheduleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.ScheduleService.DeleteSchedule is not implemented"))
+}
+
+func (UnimplementedScheduleServiceHandler) CreateContainer(context.Context, *connect.Request[com.CreateContainerRequest]) (*connect.Response[com.CreateContainerResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.ScheduleService.CreateContainer is not implemented"))
+}
+
+func (UnimplementedScheduleServiceHandler) UpdateContainer(context.Context, *connect.Request[com.UpdateContainerRequest]) (*connect.Response[com.UpdateContainerResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.ScheduleService.UpdateContainer is not implemented"))
+}
+
+func (UnimplementedScheduleServiceHandler) DeleteContainer(context.Context, *connect.Request[com.DeleteContainerRequest]) (*connect.Response[com.DeleteContainerResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.ScheduleService.DeleteContainer is not implemented"))
+}
+
+func (UnimplementedScheduleServiceHandler) ApplyManifest(context.Context, *connect.Request[com.ApplyManifestRequest]) (*connect.Response[com.ApplyManifestResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.ScheduleService.ApplyManifest is not implemented"))
+}
+
+func (UnimplementedScheduleServiceHandler) ExportSchedule(context.Context, *connect.Request[com.ExportScheduleRequest]) (*connect.Response[com.ExportScheduleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.ScheduleService.ExportSchedule is not implemented"))
+}
+
+func (UnimplementedScheduleServiceHandler) ImportCompose(context.Context, *connect.Request[com.ImportComposeRequest]) (*connect.Response[com.ImportComposeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.ScheduleService.ImportCompose is not implemented"))
+}
+
+func (UnimplementedScheduleServiceHandler) ExportCompose(context.Context, *connect.Request[com.ExportComposeRequest]) (*connect.Response[com.ExportComposeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.ScheduleService.ExportCompose is not implemented"))
+}
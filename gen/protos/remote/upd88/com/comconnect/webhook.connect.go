@@ -0,0 +1,263 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: protos/remote/upd88/com/webhook.proto
+
+package comconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	com "github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// WebhookServiceName is the fully-qualified name of the WebhookService service.
+	WebhookServiceName = "remote.upd88.com.WebhookService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// WebhookServiceListWebhooksProcedure is the fully-qualified name of the WebhookService's
+	// ListWebhooks RPC.
+	WebhookServiceListWebhooksProcedure = "/remote.upd88.com.WebhookService/ListWebhooks"
+	// WebhookServiceCreateWebhookProcedure is the fully-qualified name of the WebhookService's
+	// CreateWebhook RPC.
+	WebhookServiceCreateWebhookProcedure = "/remote.upd88.com.WebhookService/CreateWebhook"
+	// WebhookServiceUpdateWebhookProcedure is the fully-qualified name of the WebhookService's
+	// UpdateWebhook RPC.
+	WebhookServiceUpdateWebhookProcedure = "/remote.upd88.com.WebhookService/UpdateWebhook"
+	// WebhookServiceDeleteWebhookProcedure is the fully-qualified name of the WebhookService's
+	// DeleteWebhook RPC.
+	WebhookServiceDeleteWebhookProcedure = "/remote.upd88.com.WebhookService/DeleteWebhook"
+	// WebhookServiceListWebhookDeliveriesProcedure is the fully-qualified name of the WebhookService's
+	// ListWebhookDeliveries RPC.
+	WebhookServiceListWebhookDeliveriesProcedure = "/remote.upd88.com.WebhookService/ListWebhookDeliveries"
+	// WebhookServiceRedeliverWebhookProcedure is the fully-qualified name of the WebhookService's
+	// RedeliverWebhook RPC.
+	WebhookServiceRedeliverWebhookProcedure = "/remote.upd88.com.WebhookService/RedeliverWebhook"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	webhookServiceServiceDescriptor                     = com.File_protos_remote_upd88_com_webhook_proto.Services().ByName("WebhookService")
+	webhookServiceListWebhooksMethodDescriptor          = webhookServiceServiceDescriptor.Methods().ByName("ListWebhooks")
+	webhookServiceCreateWebhookMethodDescriptor         = webhookServiceServiceDescriptor.Methods().ByName("CreateWebhook")
+	webhookServiceUpdateWebhookMethodDescriptor         = webhookServiceServiceDescriptor.Methods().ByName("UpdateWebhook")
+	webhookServiceDeleteWebhookMethodDescriptor         = webhookServiceServiceDescriptor.Methods().ByName("DeleteWebhook")
+	webhookServiceListWebhookDeliveriesMethodDescriptor = webhookServiceServiceDescriptor.Methods().ByName("ListWebhookDeliveries")
+	webhookServiceRedeliverWebhookMethodDescriptor      = webhookServiceServiceDescriptor.Methods().ByName("RedeliverWebhook")
+)
+
+// WebhookServiceClient is a client for the remote.upd88.com.WebhookService service.
+type WebhookServiceClient interface {
+	ListWebhooks(context.Context, *connect.Request[com.ListWebhooksRequest]) (*connect.Response[com.ListWebhooksResponse], error)
+	CreateWebhook(context.Context, *connect.Request[com.CreateWebhookRequest]) (*connect.Response[com.CreateWebhookResponse], error)
+	UpdateWebhook(context.Context, *connect.Request[com.UpdateWebhookRequest]) (*connect.Response[com.UpdateWebhookResponse], error)
+	DeleteWebhook(context.Context, *connect.Request[com.DeleteWebhookRequest]) (*connect.Response[com.DeleteWebhookResponse], error)
+	ListWebhookDeliveries(context.Context, *connect.Request[com.ListWebhookDeliveriesRequest]) (*connect.Response[com.ListWebhookDeliveriesResponse], error)
+	RedeliverWebhook(context.Context, *connect.Request[com.RedeliverWebhookRequest]) (*connect.Response[com.RedeliverWebhookResponse], error)
+}
+
+// NewWebhookServiceClient constructs a client for the remote.upd88.com.WebhookService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewWebhookServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) WebhookServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &webhookServiceClient{
+		listWebhooks: connect.NewClient[com.ListWebhooksRequest, com.ListWebhooksResponse](
+			httpClient,
+			baseURL+WebhookServiceListWebhooksProcedure,
+			connect.WithSchema(webhookServiceListWebhooksMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		createWebhook: connect.NewClient[com.CreateWebhookRequest, com.CreateWebhookResponse](
+			httpClient,
+			baseURL+WebhookServiceCreateWebhookProcedure,
+			connect.WithSchema(webhookServiceCreateWebhookMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		updateWebhook: connect.NewClient[com.UpdateWebhookRequest, com.UpdateWebhookResponse](
+			httpClient,
+			baseURL+WebhookServiceUpdateWebhookProcedure,
+			connect.WithSchema(webhookServiceUpdateWebhookMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		deleteWebhook: connect.NewClient[com.DeleteWebhookRequest, com.DeleteWebhookResponse](
+			httpClient,
+			baseURL+WebhookServiceDeleteWebhookProcedure,
+			connect.WithSchema(webhookServiceDeleteWebhookMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listWebhookDeliveries: connect.NewClient[com.ListWebhookDeliveriesRequest, com.ListWebhookDeliveriesResponse](
+			httpClient,
+			baseURL+WebhookServiceListWebhookDeliveriesProcedure,
+			connect.WithSchema(webhookServiceListWebhookDeliveriesMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		redeliverWebhook: connect.NewClient[com.RedeliverWebhookRequest, com.RedeliverWebhookResponse](
+			httpClient,
+			baseURL+WebhookServiceRedeliverWebhookProcedure,
+			connect.WithSchema(webhookServiceRedeliverWebhookMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// webhookServiceClient implements WebhookServiceClient.
+type webhookServiceClient struct {
+	listWebhooks          *connect.Client[com.ListWebhooksRequest, com.ListWebhooksResponse]
+	createWebhook         *connect.Client[com.CreateWebhookRequest, com.CreateWebhookResponse]
+	updateWebhook         *connect.Client[com.UpdateWebhookRequest, com.UpdateWebhookResponse]
+	deleteWebhook         *connect.Client[com.DeleteWebhookRequest, com.DeleteWebhookResponse]
+	listWebhookDeliveries *connect.Client[com.ListWebhookDeliveriesRequest, com.ListWebhookDeliveriesResponse]
+	redeliverWebhook      *connect.Client[com.RedeliverWebhookRequest, com.RedeliverWebhookResponse]
+}
+
+// ListWebhooks calls remote.upd88.com.WebhookService.ListWebhooks.
+func (c *webhookServiceClient) ListWebhooks(ctx context.Context, req *connect.Request[com.ListWebhooksRequest]) (*connect.Response[com.ListWebhooksResponse], error) {
+	return c.listWebhooks.CallUnary(ctx, req)
+}
+
+// CreateWebhook calls remote.upd88.com.WebhookService.CreateWebhook.
+func (c *webhookServiceClient) CreateWebhook(ctx context.Context, req *connect.Request[com.CreateWebhookRequest]) (*connect.Response[com.CreateWebhookResponse], error) {
+	return c.createWebhook.CallUnary(ctx, req)
+}
+
+// UpdateWebhook calls remote.upd88.com.WebhookService.UpdateWebhook.
+func (c *webhookServiceClient) UpdateWebhook(ctx context.Context, req *connect.Request[com.UpdateWebhookRequest]) (*connect.Response[com.UpdateWebhookResponse], error) {
+	return c.updateWebhook.CallUnary(ctx, req)
+}
+
+// DeleteWebhook calls remote.upd88.com.WebhookService.DeleteWebhook.
+func (c *webhookServiceClient) DeleteWebhook(ctx context.Context, req *connect.Request[com.DeleteWebhookRequest]) (*connect.Response[com.DeleteWebhookResponse], error) {
+	return c.deleteWebhook.CallUnary(ctx, req)
+}
+
+// ListWebhookDeliveries calls remote.upd88.com.WebhookService.ListWebhookDeliveries.
+func (c *webhookServiceClient) ListWebhookDeliveries(ctx context.Context, req *connect.Request[com.ListWebhookDeliveriesRequest]) (*connect.Response[com.ListWebhookDeliveriesResponse], error) {
+	return c.listWebhookDeliveries.CallUnary(ctx, req)
+}
+
+// RedeliverWebhook calls remote.upd88.com.WebhookService.RedeliverWebhook.
+func (c *webhookServiceClient) RedeliverWebhook(ctx context.Context, req *connect.Request[com.RedeliverWebhookRequest]) (*connect.Response[com.RedeliverWebhookResponse], error) {
+	return c.redeliverWebhook.CallUnary(ctx, req)
+}
+
+// WebhookServiceHandler is an implementation of the remote.upd88.com.WebhookService service.
+type WebhookServiceHandler interface {
+	ListWebhooks(context.Context, *connect.Request[com.ListWebhooksRequest]) (*connect.Response[com.ListWebhooksResponse], error)
+	CreateWebhook(context.Context, *connect.Request[com.CreateWebhookRequest]) (*connect.Response[com.CreateWebhookResponse], error)
+	UpdateWebhook(context.Context, *connect.Request[com.UpdateWebhookRequest]) (*connect.Response[com.UpdateWebhookResponse], error)
+	DeleteWebhook(context.Context, *connect.Request[com.DeleteWebhookRequest]) (*connect.Response[com.DeleteWebhookResponse], error)
+	ListWebhookDeliveries(context.Context, *connect.Request[com.ListWebhookDeliveriesRequest]) (*connect.Response[com.ListWebhookDeliveriesResponse], error)
+	RedeliverWebhook(context.Context, *connect.Request[com.RedeliverWebhookRequest]) (*connect.Response[com.RedeliverWebhookResponse], error)
+}
+
+// NewWebhookServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewWebhookServiceHandler(svc WebhookServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	webhookServiceListWebhooksHandler := connect.NewUnaryHandler(
+		WebhookServiceListWebhooksProcedure,
+		svc.ListWebhooks,
+		connect.WithSchema(webhookServiceListWebhooksMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhookServiceCreateWebhookHandler := connect.NewUnaryHandler(
+		WebhookServiceCreateWebhookProcedure,
+		svc.CreateWebhook,
+		connect.WithSchema(webhookServiceCreateWebhookMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhookServiceUpdateWebhookHandler := connect.NewUnaryHandler(
+		WebhookServiceUpdateWebhookProcedure,
+		svc.UpdateWebhook,
+		connect.WithSchema(webhookServiceUpdateWebhookMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhookServiceDeleteWebhookHandler := connect.NewUnaryHandler(
+		WebhookServiceDeleteWebhookProcedure,
+		svc.DeleteWebhook,
+		connect.WithSchema(webhookServiceDeleteWebhookMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhookServiceListWebhookDeliveriesHandler := connect.NewUnaryHandler(
+		WebhookServiceListWebhookDeliveriesProcedure,
+		svc.ListWebhookDeliveries,
+		connect.WithSchema(webhookServiceListWebhookDeliveriesMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	webhookServiceRedeliverWebhookHandler := connect.NewUnaryHandler(
+		WebhookServiceRedeliverWebhookProcedure,
+		svc.RedeliverWebhook,
+		connect.WithSchema(webhookServiceRedeliverWebhookMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/remote.upd88.com.WebhookService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case WebhookServiceListWebhooksProcedure:
+			webhookServiceListWebhooksHandler.ServeHTTP(w, r)
+		case WebhookServiceCreateWebhookProcedure:
+			webhookServiceCreateWebhookHandler.ServeHTTP(w, r)
+		case WebhookServiceUpdateWebhookProcedure:
+			webhookServiceUpdateWebhookHandler.ServeHTTP(w, r)
+		case WebhookServiceDeleteWebhookProcedure:
+			webhookServiceDeleteWebhookHandler.ServeHTTP(w, r)
+		case WebhookServiceListWebhookDeliveriesProcedure:
+			webhookServiceListWebhookDeliveriesHandler.ServeHTTP(w, r)
+		case WebhookServiceRedeliverWebhookProcedure:
+			webhookServiceRedeliverWebhookHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedWebhookServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedWebhookServiceHandler struct{}
+
+func (UnimplementedWebhookServiceHandler) ListWebhooks(context.Context, *connect.Request[com.ListWebhooksRequest]) (*connect.Response[com.ListWebhooksResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.WebhookService.ListWebhooks is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) CreateWebhook(context.Context, *connect.Request[com.CreateWebhookRequest]) (*connect.Response[com.CreateWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.WebhookService.CreateWebhook is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) UpdateWebhook(context.Context, *connect.Request[com.UpdateWebhookRequest]) (*connect.Response[com.UpdateWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.WebhookService.UpdateWebhook is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) DeleteWebhook(context.Context, *connect.Request[com.DeleteWebhookRequest]) (*connect.Response[com.DeleteWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.WebhookService.DeleteWebhook is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) ListWebhookDeliveries(context.Context, *connect.Request[com.ListWebhookDeliveriesRequest]) (*connect.Response[com.ListWebhookDeliveriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.WebhookService.ListWebhookDeliveries is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) RedeliverWebhook(context.Context, *connect.Request[com.RedeliverWebhookRequest]) (*connect.Response[com.RedeliverWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("remote.upd88.com.WebhookService.RedeliverWebhook is not implemented"))
+}
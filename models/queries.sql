@@ -2,8 +2,7 @@
 -- name: GetCurrentScheduleForDevice :one
 SELECT s.*
 FROM device AS d
-LEFT JOIN fleet_schedule AS fs ON fs.fleet_id = d.fleet_id
-LEFT JOIN fleet AS f ON f.id = fs.fleet_id
+JOIN fleet AS f ON f.id = d.fleet_id
 LEFT JOIN schedule AS s ON s.id = f.default_schedule_id
 WHERE d.id = pggen.arg('device_id');
 
@@ -15,4 +14,568 @@ WHERE c.schedule_id = pggen.arg('schedule_id');
 -- name: GetDeviceByName :one
 SELECT d.*
 FROM device AS d
-WHERE d.name = pggen.arg('name');
\ No newline at end of file
+WHERE d.name = pggen.arg('name');
+
+-- name: InsertDeviceRollback :exec
+INSERT INTO device_rollback (id, device_id, failed_schedule_id, failed_schedule_version, restored_schedule_id, reason)
+VALUES (gen_random_uuid(), pggen.arg('device_id'), pggen.arg('failed_schedule_id'), pggen.arg('failed_schedule_version'), pggen.arg('restored_schedule_id'), pggen.arg('reason'));
+
+-- name: ListSchedulesForOrganization :many
+SELECT s.*
+FROM schedule AS s
+WHERE s.organization_id = pggen.arg('organization_id')
+ORDER BY s.name, s.created_at;
+
+-- Schedule names are not unique, so manifests refuse to apply to a name used more than once.
+-- name: ListSchedulesByNameForOrganization :many
+SELECT s.*
+FROM schedule AS s
+WHERE s.name = pggen.arg('name')
+  AND s.organization_id = pggen.arg('organization_id')
+ORDER BY s.created_at;
+
+-- name: GetScheduleForOrganization :one
+SELECT s.*
+FROM schedule AS s
+WHERE s.id = pggen.arg('schedule_id')
+  AND s.organization_id = pggen.arg('organization_id');
+
+-- name: InsertSchedule :one
+INSERT INTO schedule (id, name, state, created_at, updated_at, organization_id)
+VALUES (gen_random_uuid(), pggen.arg('name'), pggen.arg('state'), now(), now(), pggen.arg('organization_id'))
+RETURNING *;
+
+-- name: UpdateSchedule :one
+UPDATE schedule
+SET name       = pggen.arg('name'),
+    state      = pggen.arg('state'),
+    updated_at = now()
+WHERE id = pggen.arg('schedule_id')
+  AND organization_id = pggen.arg('organization_id')
+RETURNING *;
+
+-- Bumps a schedule's updated_at after one of its containers changed.
+-- name: TouchSchedule :exec
+UPDATE schedule
+SET updated_at = now()
+WHERE id = pggen.arg('schedule_id');
+
+-- name: DeleteSchedule :exec
+DELETE FROM schedule
+WHERE id = pggen.arg('schedule_id')
+  AND organization_id = pggen.arg('organization_id');
+
+-- name: CountFleetsUsingSchedule :one
+SELECT count(*)
+FROM fleet AS f
+WHERE f.default_schedule_id = pggen.arg('schedule_id');
+
+-- name: GetContainersForSchedules :many
+SELECT c.*
+FROM container AS c
+WHERE c.schedule_id = ANY(pggen.arg('schedule_ids')::uuid[])
+ORDER BY c.name;
+
+-- name: GetContainerForOrganization :one
+SELECT c.*
+FROM container AS c
+JOIN schedule AS s ON s.id = c.schedule_id
+WHERE c.id = pggen.arg('container_id')
+  AND s.organization_id = pggen.arg('organization_id');
+
+-- name: InsertContainer :one
+INSERT INTO container (id, created_at, updated_at, schedule_id, name, container_image, env, privileged, network_mode, ports,
+                       bind_dev, bind_proc, bind_sys, bind_shm, bind_cgroup, bind_docker_socket, bind_boot,
+                       command, entrypoint, stop_signal, stop_grace_period_seconds, update_strategy, job)
+VALUES (gen_random_uuid(), now(), now(), pggen.arg('schedule_id'), pggen.arg('name'), pggen.arg('container_image'),
+        pggen.arg('env'), pggen.arg('privileged'), pggen.arg('network_mode'), pggen.arg('ports'),
+        pggen.arg('bind_dev'), pggen.arg('bind_proc'), pggen.arg('bind_sys'), pggen.arg('bind_shm'),
+        pggen.arg('bind_cgroup'), pggen.arg('bind_docker_socket'), pggen.arg('bind_boot'),
+        pggen.arg('command'), pggen.arg('entrypoint'), pggen.arg('stop_signal'), pggen.arg('stop_grace_period_seconds'),
+        pggen.arg('update_strategy'), pggen.arg('job'))
+RETURNING *;
+
+-- name: UpdateContainer :one
+UPDATE container
+SET name                      = pggen.arg('name'),
+    container_image           = pggen.arg('container_image'),
+    env                       = pggen.arg('env'),
+    privileged                = pggen.arg('privileged'),
+    network_mode              = pggen.arg('network_mode'),
+    ports                     = pggen.arg('ports'),
+    bind_dev                  = pggen.arg('bind_dev'),
+    bind_proc                 = pggen.arg('bind_proc'),
+    bind_sys                  = pggen.arg('bind_sys'),
+    bind_shm                  = pggen.arg('bind_shm'),
+    bind_cgroup               = pggen.arg('bind_cgroup'),
+    bind_docker_socket        = pggen.arg('bind_docker_socket'),
+    bind_boot                 = pggen.arg('bind_boot'),
+    command                   = pggen.arg('command'),
+    entrypoint                = pggen.arg('entrypoint'),
+    stop_signal               = pggen.arg('stop_signal'),
+    stop_grace_period_seconds = pggen.arg('stop_grace_period_seconds'),
+    update_strategy           = pggen.arg('update_strategy'),
+    job                       = pggen.arg('job'),
+    updated_at                = now()
+WHERE id = pggen.arg('container_id')
+RETURNING *;
+
+-- name: DeleteContainer :exec
+DELETE FROM container
+WHERE id = pggen.arg('container_id');
+
+-- name: DeleteContainersForSchedule :exec
+DELETE FROM container
+WHERE schedule_id = pggen.arg('schedule_id');
+
+-- name: GetUserByEmail :one
+SELECT u.*
+FROM "user" AS u
+WHERE u.email = pggen.arg('email');
+
+-- name: GetUserByID :one
+SELECT u.*
+FROM "user" AS u
+WHERE u.id = pggen.arg('user_id');
+
+-- name: GetOrganizationRole :one
+SELECT ou.role
+FROM organization_user AS ou
+WHERE ou.user_id = pggen.arg('user_id')
+  AND ou.organization_id = pggen.arg('organization_id');
+
+-- name: ListOrganizationsForUser :many
+SELECT o.id, o.name, ou.role
+FROM organization AS o
+JOIN organization_user AS ou ON ou.organization_id = o.id
+WHERE ou.user_id = pggen.arg('user_id')
+ORDER BY o.name;
+
+-- name: GetOrganizationByID :one
+SELECT o.*
+FROM organization AS o
+WHERE o.id = pggen.arg('organization_id');
+
+-- name: InsertAPIKey :one
+INSERT INTO api_key (id, organization_id, name, token_hash, created_by_user_id, role)
+VALUES (gen_random_uuid(), pggen.arg('organization_id'), pggen.arg('name'), pggen.arg('token_hash'),
+        NULLIF(pggen.arg('created_by_user_id'), '00000000-0000-0000-0000-000000000000'::uuid), pggen.arg('role'))
+RETURNING *;
+
+-- name: ListAPIKeysForOrganization :many
+SELECT k.*
+FROM api_key AS k
+WHERE k.organization_id = pggen.arg('organization_id')
+ORDER BY k.created_at DESC;
+
+-- name: RevokeAPIKey :exec
+UPDATE api_key
+SET revoked_at = now()
+WHERE id = pggen.arg('api_key_id')
+  AND organization_id = pggen.arg('organization_id')
+  AND revoked_at IS NULL;
+
+-- name: GetActiveAPIKeyByTokenHash :one
+SELECT k.*
+FROM api_key AS k
+WHERE k.token_hash = pggen.arg('token_hash')
+  AND k.revoked_at IS NULL;
+
+-- name: ListFleetsForOrganization :many
+SELECT f.*, (SELECT count(*) FROM device AS d WHERE d.fleet_id = f.id) AS device_count
+FROM fleet AS f
+WHERE f.organization_id = pggen.arg('organization_id')
+ORDER BY f.name, f.created_at;
+
+-- name: GetFleetForOrganization :one
+SELECT f.*, (SELECT count(*) FROM device AS d WHERE d.fleet_id = f.id) AS device_count
+FROM fleet AS f
+WHERE f.id = pggen.arg('fleet_id')
+  AND f.organization_id = pggen.arg('organization_id');
+
+-- name: InsertFleet :one
+INSERT INTO fleet (id, name, created_at, updated_at, organization_id)
+VALUES (gen_random_uuid(), pggen.arg('name'), now(), now(), pggen.arg('organization_id'))
+RETURNING *;
+
+-- name: RenameFleet :exec
+UPDATE fleet
+SET name       = pggen.arg('name'),
+    updated_at = now()
+WHERE id = pggen.arg('fleet_id')
+  AND organization_id = pggen.arg('organization_id');
+
+-- name: SetFleetDefaultSchedule :exec
+UPDATE fleet
+SET default_schedule_id = NULLIF(pggen.arg('schedule_id'), '00000000-0000-0000-0000-000000000000'::uuid),
+    updated_at          = now()
+WHERE id = pggen.arg('fleet_id')
+  AND organization_id = pggen.arg('organization_id');
+
+-- name: SetFleetAgentImage :exec
+UPDATE fleet
+SET agent_image = NULLIF(pggen.arg('agent_image'), ''),
+    updated_at  = now()
+WHERE id = pggen.arg('fleet_id')
+  AND organization_id = pggen.arg('organization_id');
+
+-- The agent image of the device's fleet; NULL when the fleet has none.
+-- name: GetAgentImageForDevice :one
+SELECT f.agent_image
+FROM device AS d
+JOIN fleet AS f ON f.id = d.fleet_id
+WHERE d.id = pggen.arg('device_id');
+
+-- maintenance_windows is a MaintenanceWindows message as JSON; NULL lets devices make disruptive changes at any time.
+-- name: SetFleetMaintenance :exec
+UPDATE fleet
+SET maintenance_windows = pggen.arg('maintenance_windows'),
+    time_zone           = NULLIF(pggen.arg('time_zone'), ''),
+    updated_at          = now()
+WHERE id = pggen.arg('fleet_id')
+  AND organization_id = pggen.arg('organization_id');
+
+-- NULL maintenance_windows and an empty time_zone fall back to the fleet's.
+-- name: SetDeviceMaintenance :exec
+UPDATE device
+SET maintenance_windows = pggen.arg('maintenance_windows'),
+    time_zone           = NULLIF(pggen.arg('time_zone'), ''),
+    updated_at          = now()
+WHERE id = pggen.arg('device_id');
+
+-- name: SetDeviceApplyNowUntil :exec
+UPDATE device
+SET apply_now_until = pggen.arg('apply_now_until')
+WHERE id = pggen.arg('device_id');
+
+-- name: SetFleetApplyNowUntil :exec
+UPDATE device
+SET apply_now_until = pggen.arg('apply_now_until')
+WHERE fleet_id = pggen.arg('fleet_id');
+
+-- The maintenance settings of the device and of its fleet, for the server to resolve which apply.
+-- name: GetMaintenanceForDevice :one
+SELECT f.maintenance_windows AS fleet_maintenance_windows,
+       f.time_zone           AS fleet_time_zone,
+       d.maintenance_windows AS device_maintenance_windows,
+       d.time_zone           AS device_time_zone,
+       d.apply_now_until
+FROM device AS d
+JOIN fleet AS f ON f.id = d.fleet_id
+WHERE d.id = pggen.arg('device_id');
+
+-- Closes out the fleet's current fleet_schedule history entry, if any.
+-- name: EndFleetSchedules :exec
+UPDATE fleet_schedule
+SET deleted_at = now()
+WHERE fleet_id = pggen.arg('fleet_id')
+  AND deleted_at IS NULL;
+
+-- name: InsertFleetSchedule :exec
+INSERT INTO fleet_schedule (id, fleet_id, schedule_id)
+VALUES (gen_random_uuid(), pggen.arg('fleet_id'), pggen.arg('schedule_id'));
+
+-- name: DeleteFleet :exec
+DELETE FROM fleet
+WHERE id = pggen.arg('fleet_id')
+  AND organization_id = pggen.arg('organization_id');
+
+-- Keyset pagination over (name, id): pass the last row of the previous page as after_name/after_id.
+-- status filters on last_seen_at, using the same cutoffs as pkg.Config.DeviceStatus.
+-- name: ListDevicesInFleet :many
+SELECT d.*
+FROM device AS d
+WHERE d.fleet_id = pggen.arg('fleet_id')
+  AND (pggen.arg('name_contains')::text = '' OR strpos(lower(d.name), lower(pggen.arg('name_contains')::text)) > 0)
+  AND (pggen.arg('status')::text = ''
+    OR (pggen.arg('status')::text = 'online' AND d.last_seen_at >= pggen.arg('online_after')::timestamp)
+    OR (pggen.arg('status')::text = 'stale' AND d.last_seen_at < pggen.arg('online_after')::timestamp
+                                         AND d.last_seen_at >= pggen.arg('offline_after')::timestamp)
+    OR (pggen.arg('status')::text = 'offline' AND (d.last_seen_at IS NULL OR d.last_seen_at < pggen.arg('offline_after')::timestamp)))
+  AND (d.name, d.id) > (pggen.arg('after_name')::text, pggen.arg('after_id')::uuid)
+ORDER BY d.name, d.id
+LIMIT pggen.arg('page_size');
+
+-- name: GetDeviceForOrganization :one
+SELECT d.*
+FROM device AS d
+JOIN fleet AS f ON f.id = d.fleet_id
+WHERE d.id = pggen.arg('device_id')
+  AND f.organization_id = pggen.arg('organization_id');
+
+-- name: InsertDevice :one
+INSERT INTO device (id, name, created_at, updated_at, fleet_id)
+VALUES (gen_random_uuid(), pggen.arg('name'), now(), now(), pggen.arg('fleet_id'))
+RETURNING *;
+
+-- name: RenameDevice :one
+UPDATE device
+SET name       = pggen.arg('name'),
+    updated_at = now()
+WHERE id = pggen.arg('device_id')
+RETURNING *;
+
+-- name: MoveDevice :one
+UPDATE device
+SET fleet_id   = pggen.arg('fleet_id'),
+    updated_at = now()
+WHERE id = pggen.arg('device_id')
+RETURNING *;
+
+-- name: DeleteDevice :exec
+DELETE FROM device
+WHERE id = pggen.arg('device_id');
+
+-- Also marks the device online, returning what it was before so the caller can announce the change.
+-- name: RecordDeviceHeartbeat :one
+UPDATE device AS d
+SET last_seen_at   = pggen.arg('seen_at'),
+    agent_version  = coalesce(nullif(pggen.arg('agent_version')::text, ''), d.agent_version),
+    remote_address = pggen.arg('remote_address'),
+    connectivity   = 'online'
+FROM device AS previous
+         JOIN fleet AS f ON f.id = previous.fleet_id
+WHERE d.id = pggen.arg('device_id')
+  AND previous.id = d.id
+RETURNING previous.connectivity AS previous_connectivity, d.name, d.fleet_id, f.organization_id;
+
+-- name: RecordDeviceReportedState :one
+UPDATE device AS d
+SET reported_state = pggen.arg('reported_state'),
+    reported_at    = pggen.arg('reported_at')
+FROM device AS previous
+         JOIN fleet AS f ON f.id = previous.fleet_id
+WHERE d.id = pggen.arg('device_id')
+  AND previous.id = d.id
+RETURNING previous.reported_state AS previous_reported_state, d.name, d.fleet_id, f.organization_id;
+
+-- name: MarkDevicesOffline :many
+UPDATE device AS d
+SET connectivity = 'offline'
+FROM fleet AS f
+WHERE f.id = d.fleet_id
+  AND d.connectivity = 'online'
+  AND d.last_seen_at < pggen.arg('offline_before')
+RETURNING d.id, d.name, d.fleet_id, f.organization_id;
+
+-- Devices in the fleet seen since seen_after that do not report running containers of schedule_id.
+-- name: CountFleetDevicesBehindSchedule :one
+SELECT count(*)
+FROM device AS d
+WHERE d.fleet_id = pggen.arg('fleet_id')
+  AND d.last_seen_at >= pggen.arg('seen_after')
+  AND (d.reported_state IS NULL
+    OR NOT d.reported_state -> 'containerStates' @> jsonb_build_array(jsonb_build_object('scheduleId', pggen.arg('schedule_id')::text)));
+
+-- Every device, by the status DeviceStatus would give it.
+-- name: CountDevicesByStatus :one
+SELECT count(*) FILTER (WHERE d.last_seen_at >= pggen.arg('online_after')::timestamp) AS online,
+       count(*) FILTER (WHERE d.last_seen_at < pggen.arg('online_after')::timestamp
+           AND d.last_seen_at >= pggen.arg('offline_after')::timestamp)              AS stale,
+       count(*) FILTER (WHERE d.last_seen_at IS NULL
+           OR d.last_seen_at < pggen.arg('offline_after')::timestamp)               AS offline
+FROM device AS d;
+
+-- name: InsertAuditLog :exec
+INSERT INTO audit_log (id, organization_id, created_at, actor_type, actor_id, actor_name, action, target_type, target_id, before, after)
+VALUES (gen_random_uuid(), pggen.arg('organization_id'), now(), pggen.arg('actor_type'), pggen.arg('actor_id'), pggen.arg('actor_name'),
+        pggen.arg('action'), pggen.arg('target_type'), pggen.arg('target_id'), pggen.arg('before'), pggen.arg('after'));
+
+-- Newest first; empty filters match everything, and a NULL before_created_at starts from the newest entry.
+-- name: ListAuditLog :many
+SELECT a.*
+FROM audit_log AS a
+WHERE a.organization_id = pggen.arg('organization_id')
+  AND (pggen.arg('actor_id')::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR a.actor_id = pggen.arg('actor_id')::uuid)
+  AND starts_with(a.action, pggen.arg('action_prefix')::text)
+  AND (pggen.arg('target_type')::text = '' OR a.target_type = pggen.arg('target_type')::text)
+  AND (pggen.arg('target_id')::text = '' OR a.target_id = pggen.arg('target_id')::text)
+  AND (pggen.arg('since')::timestamp IS NULL OR a.created_at >= pggen.arg('since')::timestamp)
+  AND (pggen.arg('until')::timestamp IS NULL OR a.created_at < pggen.arg('until')::timestamp)
+  AND (pggen.arg('before_created_at')::timestamp IS NULL
+    OR (a.created_at, a.id) < (pggen.arg('before_created_at')::timestamp, pggen.arg('before_id')::uuid))
+ORDER BY a.created_at DESC, a.id DESC
+LIMIT pggen.arg('page_size');
+
+-- name: DeleteAuditLogBefore :exec
+DELETE FROM audit_log
+WHERE created_at < pggen.arg('cutoff');
+
+-- name: GetDeviceOrganization :one
+SELECT f.organization_id, d.name
+FROM device AS d
+         JOIN fleet AS f ON f.id = d.fleet_id
+WHERE d.id = pggen.arg('device_id');
+
+-- name: ListOrganizationMembers :many
+SELECT u.id AS user_id, u.email, ou.role
+FROM organization_user AS ou
+         JOIN "user" AS u ON u.id = ou.user_id
+WHERE ou.organization_id = pggen.arg('organization_id')
+ORDER BY u.email;
+
+-- name: InsertOrganizationUser :exec
+INSERT INTO organization_user (id, user_id, organization_id, role)
+VALUES (gen_random_uuid(), pggen.arg('user_id'), pggen.arg('organization_id'), pggen.arg('role'));
+
+-- name: SetOrganizationUserRole :exec
+UPDATE organization_user
+SET role = pggen.arg('role')
+WHERE user_id = pggen.arg('user_id')
+  AND organization_id = pggen.arg('organization_id');
+
+-- name: CountOrganizationOwners :one
+SELECT count(*)
+FROM organization_user AS ou
+WHERE ou.organization_id = pggen.arg('organization_id')
+  AND ou.role = 'owner';
+
+-- Emails are stored lowercased; inviting an address again replaces its role.
+-- name: UpsertOrganizationInvitation :one
+INSERT INTO organization_invitation (id, organization_id, email, role, invited_by_user_id, created_at)
+VALUES (gen_random_uuid(), pggen.arg('organization_id'), lower(pggen.arg('email')), pggen.arg('role'),
+        NULLIF(pggen.arg('invited_by_user_id'), '00000000-0000-0000-0000-000000000000'::uuid), now())
+ON CONFLICT (organization_id, email) DO UPDATE SET role               = excluded.role,
+                                                   invited_by_user_id = excluded.invited_by_user_id,
+                                                   created_at         = excluded.created_at
+RETURNING *;
+
+-- name: ListOrganizationInvitations :many
+SELECT i.*
+FROM organization_invitation AS i
+WHERE i.organization_id = pggen.arg('organization_id')
+ORDER BY i.email;
+
+-- Turns every invitation for email into a membership for user_id, unless they already are a member.
+-- name: AcceptOrganizationInvitations :exec
+WITH accepted AS (
+    DELETE FROM organization_invitation
+        WHERE email = lower(pggen.arg('email'))
+        RETURNING organization_id, role)
+INSERT
+INTO organization_user (id, user_id, organization_id, role)
+SELECT gen_random_uuid(), pggen.arg('user_id'), a.organization_id, a.role
+FROM accepted AS a
+ON CONFLICT (user_id, organization_id) DO NOTHING;
+
+-- name: ListWebhooksForOrganization :many
+SELECT w.*
+FROM webhook AS w
+WHERE w.organization_id = pggen.arg('organization_id')
+ORDER BY w.created_at;
+
+-- name: GetWebhookForOrganization :one
+SELECT w.*
+FROM webhook AS w
+WHERE w.id = pggen.arg('webhook_id')
+  AND w.organization_id = pggen.arg('organization_id');
+
+-- name: InsertWebhook :one
+INSERT INTO webhook (id, organization_id, url, description, secret, event_types, enabled, created_at, updated_at)
+VALUES (gen_random_uuid(), pggen.arg('organization_id'), pggen.arg('url'), pggen.arg('description'), pggen.arg('secret'),
+        pggen.arg('event_types'), true, now(), now())
+RETURNING *;
+
+-- name: UpdateWebhook :one
+UPDATE webhook
+SET url         = pggen.arg('url'),
+    description = pggen.arg('description'),
+    event_types = pggen.arg('event_types'),
+    enabled     = pggen.arg('enabled'),
+    updated_at  = now()
+WHERE id = pggen.arg('webhook_id')
+  AND organization_id = pggen.arg('organization_id')
+RETURNING *;
+
+-- name: DeleteWebhook :exec
+DELETE FROM webhook
+WHERE id = pggen.arg('webhook_id')
+  AND organization_id = pggen.arg('organization_id');
+
+-- Queues an event for every enabled webhook of the organization subscribed to its type.
+-- name: EnqueueWebhookDeliveries :exec
+INSERT INTO webhook_delivery (id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at)
+SELECT gen_random_uuid(), w.id, pggen.arg('event_id'), pggen.arg('event_type')::text, pggen.arg('payload'), 'pending', 0, now(), now()
+FROM webhook AS w
+WHERE w.organization_id = pggen.arg('organization_id')
+  AND w.enabled
+  AND (coalesce(cardinality(w.event_types), 0) = 0 OR pggen.arg('event_type')::text = ANY (w.event_types));
+
+-- Leases due deliveries for lease_seconds, so other servers skip them while this one sends them.
+-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_delivery AS d
+SET next_attempt_at = now() + make_interval(secs => pggen.arg('lease_seconds')::int)
+FROM webhook AS w
+WHERE d.id IN (SELECT due.id
+               FROM webhook_delivery AS due
+               WHERE due.status = 'pending'
+                 AND due.next_attempt_at <= now()
+               ORDER BY due.next_attempt_at
+               LIMIT pggen.arg('batch_size') FOR UPDATE SKIP LOCKED)
+  AND w.id = d.webhook_id
+RETURNING d.id, d.event_id, d.event_type, d.payload, d.attempts, w.url, w.secret;
+
+-- name: RecordWebhookDeliveryAttempt :exec
+UPDATE webhook_delivery
+SET status          = pggen.arg('status'),
+    attempts        = attempts + 1,
+    last_attempt_at = now(),
+    response_status = nullif(pggen.arg('response_status')::int, 0),
+    last_error      = nullif(pggen.arg('last_error')::text, ''),
+    next_attempt_at = now() + make_interval(secs => pggen.arg('retry_after_seconds')::int)
+WHERE id = pggen.arg('delivery_id');
+
+-- Newest first; an empty status matches every delivery.
+-- name: ListWebhookDeliveries :many
+SELECT d.*
+FROM webhook_delivery AS d
+WHERE d.webhook_id = pggen.arg('webhook_id')
+  AND (pggen.arg('status')::text = '' OR d.status = pggen.arg('status')::text)
+  AND (pggen.arg('before_created_at')::timestamp IS NULL
+    OR (d.created_at, d.id) < (pggen.arg('before_created_at')::timestamp, pggen.arg('before_id')::uuid))
+ORDER BY d.created_at DESC, d.id DESC
+LIMIT pggen.arg('page_size');
+
+-- name: RedeliverWebhookDelivery :one
+UPDATE webhook_delivery
+SET status          = 'pending',
+    next_attempt_at = now()
+WHERE id = pggen.arg('delivery_id')
+  AND webhook_id = pggen.arg('webhook_id')
+RETURNING *;
+
+-- name: DeleteWebhookDeliveriesBefore :exec
+DELETE FROM webhook_delivery
+WHERE created_at < pggen.arg('cutoff')
+  AND status <> 'pending';
+
+-- name: InsertJobRun :exec
+INSERT INTO job_run (id, device_id, task_id, task_name, schedule_id, status, started_at, finished_at, exit_code, error, log_tail, created_at)
+VALUES (gen_random_uuid(), pggen.arg('device_id'), pggen.arg('task_id'), pggen.arg('task_name'), pggen.arg('schedule_id'),
+        pggen.arg('status'), pggen.arg('started_at'), pggen.arg('finished_at'), pggen.arg('exit_code'), pggen.arg('error'),
+        pggen.arg('log_tail'), now());
+
+-- Newest first; an empty job matches every job, by task ID or name, and a NULL before_started_at starts from the newest run.
+-- name: ListJobRuns :many
+SELECT r.*
+FROM job_run AS r
+WHERE r.device_id = pggen.arg('device_id')
+  AND (pggen.arg('job')::text = '' OR r.task_id = pggen.arg('job')::text OR r.task_name = pggen.arg('job')::text)
+  AND (pggen.arg('before_started_at')::timestamp IS NULL
+    OR (r.started_at, r.id) < (pggen.arg('before_started_at')::timestamp, pggen.arg('before_id')::uuid))
+ORDER BY r.started_at DESC, r.id DESC
+LIMIT pggen.arg('page_size');
+
+-- name: DeleteJobRunsBefore :exec
+DELETE FROM job_run
+WHERE created_at < pggen.arg('cutoff');
+
+-- name: SetDeviceRelayTokenHash :exec
+UPDATE device
+SET relay_token_hash = pggen.arg('relay_token_hash')
+WHERE id = pggen.arg('device_id');
+
+-- name: GetDeviceRelayTokenHash :one
+SELECT relay_token_hash
+FROM device
+WHERE id = pggen.arg('device_id');
@@ -39,6 +39,564 @@ type Querier interface {
 	GetDeviceByNameBatch(batch genericBatch, name *string)
 	// GetDeviceByNameScan scans the result of an executed GetDeviceByNameBatch query.
 	GetDeviceByNameScan(results pgx.BatchResults) (GetDeviceByNameRow, error)
+
+	InsertDeviceRollback(ctx context.Context, params InsertDeviceRollbackParams) (pgconn.CommandTag, error)
+	// InsertDeviceRollbackBatch enqueues a InsertDeviceRollback query into batch to be executed
+	// later by the batch.
+	InsertDeviceRollbackBatch(batch genericBatch, params InsertDeviceRollbackParams)
+	// InsertDeviceRollbackScan scans the result of an executed InsertDeviceRollbackBatch query.
+	InsertDeviceRollbackScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	ListSchedulesForOrganization(ctx context.Context, organizationID uuid.UUID) ([]ListSchedulesForOrganizationRow, error)
+	// ListSchedulesForOrganizationBatch enqueues a ListSchedulesForOrganization query into batch to be executed
+	// later by the batch.
+	ListSchedulesForOrganizationBatch(batch genericBatch, organizationID uuid.UUID)
+	// ListSchedulesForOrganizationScan scans the result of an executed ListSchedulesForOrganizationBatch query.
+	ListSchedulesForOrganizationScan(results pgx.BatchResults) ([]ListSchedulesForOrganizationRow, error)
+
+	// Schedule names are not unique, so manifests refuse to apply to a name used more than once.
+	ListSchedulesByNameForOrganization(ctx context.Context, name *string, organizationID uuid.UUID) ([]ListSchedulesByNameForOrganizationRow, error)
+	// ListSchedulesByNameForOrganizationBatch enqueues a ListSchedulesByNameForOrganization query into batch to be executed
+	// later by the batch.
+	ListSchedulesByNameForOrganizationBatch(batch genericBatch, name *string, organizationID uuid.UUID)
+	// ListSchedulesByNameForOrganizationScan scans the result of an executed ListSchedulesByNameForOrganizationBatch query.
+	ListSchedulesByNameForOrganizationScan(results pgx.BatchResults) ([]ListSchedulesByNameForOrganizationRow, error)
+
+	GetScheduleForOrganization(ctx context.Context, scheduleID uuid.UUID, organizationID uuid.UUID) (GetScheduleForOrganizationRow, error)
+	// GetScheduleForOrganizationBatch enqueues a GetScheduleForOrganization query into batch to be executed
+	// later by the batch.
+	GetScheduleForOrganizationBatch(batch genericBatch, scheduleID uuid.UUID, organizationID uuid.UUID)
+	// GetScheduleForOrganizationScan scans the result of an executed GetScheduleForOrganizationBatch query.
+	GetScheduleForOrganizationScan(results pgx.BatchResults) (GetScheduleForOrganizationRow, error)
+
+	InsertSchedule(ctx context.Context, params InsertScheduleParams) (InsertScheduleRow, error)
+	// InsertScheduleBatch enqueues a InsertSchedule query into batch to be executed
+	// later by the batch.
+	InsertScheduleBatch(batch genericBatch, params InsertScheduleParams)
+	// InsertScheduleScan scans the result of an executed InsertScheduleBatch query.
+	InsertScheduleScan(results pgx.BatchResults) (InsertScheduleRow, error)
+
+	UpdateSchedule(ctx context.Context, params UpdateScheduleParams) (UpdateScheduleRow, error)
+	// UpdateScheduleBatch enqueues a UpdateSchedule query into batch to be executed
+	// later by the batch.
+	UpdateScheduleBatch(batch genericBatch, params UpdateScheduleParams)
+	// UpdateScheduleScan scans the result of an executed UpdateScheduleBatch query.
+	UpdateScheduleScan(results pgx.BatchResults) (UpdateScheduleRow, error)
+
+	// Bumps a schedule's updated_at after one of its containers changed.
+	TouchSchedule(ctx context.Context, scheduleID uuid.UUID) (pgconn.CommandTag, error)
+	// TouchScheduleBatch enqueues a TouchSchedule query into batch to be executed
+	// later by the batch.
+	TouchScheduleBatch(batch genericBatch, scheduleID uuid.UUID)
+	// TouchScheduleScan scans the result of an executed TouchScheduleBatch query.
+	TouchScheduleScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteSchedule(ctx context.Context, scheduleID uuid.UUID, organizationID uuid.UUID) (pgconn.CommandTag, error)
+	// DeleteScheduleBatch enqueues a DeleteSchedule query into batch to be executed
+	// later by the batch.
+	DeleteScheduleBatch(batch genericBatch, scheduleID uuid.UUID, organizationID uuid.UUID)
+	// DeleteScheduleScan scans the result of an executed DeleteScheduleBatch query.
+	DeleteScheduleScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	CountFleetsUsingSchedule(ctx context.Context, scheduleID uuid.UUID) (*int, error)
+	// CountFleetsUsingScheduleBatch enqueues a CountFleetsUsingSchedule query into batch to be executed
+	// later by the batch.
+	CountFleetsUsingScheduleBatch(batch genericBatch, scheduleID uuid.UUID)
+	// CountFleetsUsingScheduleScan scans the result of an executed CountFleetsUsingScheduleBatch query.
+	CountFleetsUsingScheduleScan(results pgx.BatchResults) (*int, error)
+
+	GetContainersForSchedules(ctx context.Context, scheduleIDs []uuid.UUID) ([]GetContainersForSchedulesRow, error)
+	// GetContainersForSchedulesBatch enqueues a GetContainersForSchedules query into batch to be executed
+	// later by the batch.
+	GetContainersForSchedulesBatch(batch genericBatch, scheduleIDs []uuid.UUID)
+	// GetContainersForSchedulesScan scans the result of an executed GetContainersForSchedulesBatch query.
+	GetContainersForSchedulesScan(results pgx.BatchResults) ([]GetContainersForSchedulesRow, error)
+
+	GetContainerForOrganization(ctx context.Context, containerID uuid.UUID, organizationID uuid.UUID) (GetContainerForOrganizationRow, error)
+	// GetContainerForOrganizationBatch enqueues a GetContainerForOrganization query into batch to be executed
+	// later by the batch.
+	GetContainerForOrganizationBatch(batch genericBatch, containerID uuid.UUID, organizationID uuid.UUID)
+	// GetContainerForOrganizationScan scans the result of an executed GetContainerForOrganizationBatch query.
+	GetContainerForOrganizationScan(results pgx.BatchResults) (GetContainerForOrganizationRow, error)
+
+	InsertContainer(ctx context.Context, params InsertContainerParams) (InsertContainerRow, error)
+	// InsertContainerBatch enqueues a InsertContainer query into batch to be executed
+	// later by the batch.
+	InsertContainerBatch(batch genericBatch, params InsertContainerParams)
+	// InsertContainerScan scans the result of an executed InsertContainerBatch query.
+	InsertContainerScan(results pgx.BatchResults) (InsertContainerRow, error)
+
+	UpdateContainer(ctx context.Context, params UpdateContainerParams) (UpdateContainerRow, error)
+	// UpdateContainerBatch enqueues a UpdateContainer query into batch to be executed
+	// later by the batch.
+	UpdateContainerBatch(batch genericBatch, params UpdateContainerParams)
+	// UpdateContainerScan scans the result of an executed UpdateContainerBatch query.
+	UpdateContainerScan(results pgx.BatchResults) (UpdateContainerRow, error)
+
+	DeleteContainer(ctx context.Context, containerID uuid.UUID) (pgconn.CommandTag, error)
+	// DeleteContainerBatch enqueues a DeleteContainer query into batch to be executed
+	// later by the batch.
+	DeleteContainerBatch(batch genericBatch, containerID uuid.UUID)
+	// DeleteContainerScan scans the result of an executed DeleteContainerBatch query.
+	DeleteContainerScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteContainersForSchedule(ctx context.Context, scheduleID uuid.UUID) (pgconn.CommandTag, error)
+	// DeleteContainersForScheduleBatch enqueues a DeleteContainersForSchedule query into batch to be executed
+	// later by the batch.
+	DeleteContainersForScheduleBatch(batch genericBatch, scheduleID uuid.UUID)
+	// DeleteContainersForScheduleScan scans the result of an executed DeleteContainersForScheduleBatch query.
+	DeleteContainersForScheduleScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	GetUserByEmail(ctx context.Context, email *string) (GetUserByEmailRow, error)
+	// GetUserByEmailBatch enqueues a GetUserByEmail query into batch to be executed
+	// later by the batch.
+	GetUserByEmailBatch(batch genericBatch, email *string)
+	// GetUserByEmailScan scans the result of an executed GetUserByEmailBatch query.
+	GetUserByEmailScan(results pgx.BatchResults) (GetUserByEmailRow, error)
+
+	GetUserByID(ctx context.Context, userID uuid.UUID) (GetUserByIDRow, error)
+	// GetUserByIDBatch enqueues a GetUserByID query into batch to be executed
+	// later by the batch.
+	GetUserByIDBatch(batch genericBatch, userID uuid.UUID)
+	// GetUserByIDScan scans the result of an executed GetUserByIDBatch query.
+	GetUserByIDScan(results pgx.BatchResults) (GetUserByIDRow, error)
+
+	GetOrganizationRole(ctx context.Context, userID uuid.UUID, organizationID uuid.UUID) (*string, error)
+	// GetOrganizationRoleBatch enqueues a GetOrganizationRole query into batch to be executed
+	// later by the batch.
+	GetOrganizationRoleBatch(batch genericBatch, userID uuid.UUID, organizationID uuid.UUID)
+	// GetOrganizationRoleScan scans the result of an executed GetOrganizationRoleBatch query.
+	GetOrganizationRoleScan(results pgx.BatchResults) (*string, error)
+
+	ListOrganizationsForUser(ctx context.Context, userID uuid.UUID) ([]ListOrganizationsForUserRow, error)
+	// ListOrganizationsForUserBatch enqueues a ListOrganizationsForUser query into batch to be executed
+	// later by the batch.
+	ListOrganizationsForUserBatch(batch genericBatch, userID uuid.UUID)
+	// ListOrganizationsForUserScan scans the result of an executed ListOrganizationsForUserBatch query.
+	ListOrganizationsForUserScan(results pgx.BatchResults) ([]ListOrganizationsForUserRow, error)
+
+	GetOrganizationByID(ctx context.Context, organizationID uuid.UUID) (GetOrganizationByIDRow, error)
+	// GetOrganizationByIDBatch enqueues a GetOrganizationByID query into batch to be executed
+	// later by the batch.
+	GetOrganizationByIDBatch(batch genericBatch, organizationID uuid.UUID)
+	// GetOrganizationByIDScan scans the result of an executed GetOrganizationByIDBatch query.
+	GetOrganizationByIDScan(results pgx.BatchResults) (GetOrganizationByIDRow, error)
+
+	InsertAPIKey(ctx context.Context, params InsertAPIKeyParams) (InsertAPIKeyRow, error)
+	// InsertAPIKeyBatch enqueues a InsertAPIKey query into batch to be executed
+	// later by the batch.
+	InsertAPIKeyBatch(batch genericBatch, params InsertAPIKeyParams)
+	// InsertAPIKeyScan scans the result of an executed InsertAPIKeyBatch query.
+	InsertAPIKeyScan(results pgx.BatchResults) (InsertAPIKeyRow, error)
+
+	ListAPIKeysForOrganization(ctx context.Context, organizationID uuid.UUID) ([]ListAPIKeysForOrganizationRow, error)
+	// ListAPIKeysForOrganizationBatch enqueues a ListAPIKeysForOrganization query into batch to be executed
+	// later by the batch.
+	ListAPIKeysForOrganizationBatch(batch genericBatch, organizationID uuid.UUID)
+	// ListAPIKeysForOrganizationScan scans the result of an executed ListAPIKeysForOrganizationBatch query.
+	ListAPIKeysForOrganizationScan(results pgx.BatchResults) ([]ListAPIKeysForOrganizationRow, error)
+
+	RevokeAPIKey(ctx context.Context, apiKeyID uuid.UUID, organizationID uuid.UUID) (pgconn.CommandTag, error)
+	// RevokeAPIKeyBatch enqueues a RevokeAPIKey query into batch to be executed
+	// later by the batch.
+	RevokeAPIKeyBatch(batch genericBatch, apiKeyID uuid.UUID, organizationID uuid.UUID)
+	// RevokeAPIKeyScan scans the result of an executed RevokeAPIKeyBatch query.
+	RevokeAPIKeyScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	GetActiveAPIKeyByTokenHash(ctx context.Context, tokenHash *string) (GetActiveAPIKeyByTokenHashRow, error)
+	// GetActiveAPIKeyByTokenHashBatch enqueues a GetActiveAPIKeyByTokenHash query into batch to be executed
+	// later by the batch.
+	GetActiveAPIKeyByTokenHashBatch(batch genericBatch, tokenHash *string)
+	// GetActiveAPIKeyByTokenHashScan scans the result of an executed GetActiveAPIKeyByTokenHashBatch query.
+	GetActiveAPIKeyByTokenHashScan(results pgx.BatchResults) (GetActiveAPIKeyByTokenHashRow, error)
+
+	ListFleetsForOrganization(ctx context.Context, organizationID uuid.UUID) ([]ListFleetsForOrganizationRow, error)
+	// ListFleetsForOrganizationBatch enqueues a ListFleetsForOrganization query into batch to be executed
+	// later by the batch.
+	ListFleetsForOrganizationBatch(batch genericBatch, organizationID uuid.UUID)
+	// ListFleetsForOrganizationScan scans the result of an executed ListFleetsForOrganizationBatch query.
+	ListFleetsForOrganizationScan(results pgx.BatchResults) ([]ListFleetsForOrganizationRow, error)
+
+	GetFleetForOrganization(ctx context.Context, fleetID uuid.UUID, organizationID uuid.UUID) (GetFleetForOrganizationRow, error)
+	// GetFleetForOrganizationBatch enqueues a GetFleetForOrganization query into batch to be executed
+	// later by the batch.
+	GetFleetForOrganizationBatch(batch genericBatch, fleetID uuid.UUID, organizationID uuid.UUID)
+	// GetFleetForOrganizationScan scans the result of an executed GetFleetForOrganizationBatch query.
+	GetFleetForOrganizationScan(results pgx.BatchResults) (GetFleetForOrganizationRow, error)
+
+	InsertFleet(ctx context.Context, name *string, organizationID uuid.UUID) (InsertFleetRow, error)
+	// InsertFleetBatch enqueues a InsertFleet query into batch to be executed
+	// later by the batch.
+	InsertFleetBatch(batch genericBatch, name *string, organizationID uuid.UUID)
+	// InsertFleetScan scans the result of an executed InsertFleetBatch query.
+	InsertFleetScan(results pgx.BatchResults) (InsertFleetRow, error)
+
+	RenameFleet(ctx context.Context, params RenameFleetParams) (pgconn.CommandTag, error)
+	// RenameFleetBatch enqueues a RenameFleet query into batch to be executed
+	// later by the batch.
+	RenameFleetBatch(batch genericBatch, params RenameFleetParams)
+	// RenameFleetScan scans the result of an executed RenameFleetBatch query.
+	RenameFleetScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	SetFleetDefaultSchedule(ctx context.Context, params SetFleetDefaultScheduleParams) (pgconn.CommandTag, error)
+	// SetFleetDefaultScheduleBatch enqueues a SetFleetDefaultSchedule query into batch to be executed
+	// later by the batch.
+	SetFleetDefaultScheduleBatch(batch genericBatch, params SetFleetDefaultScheduleParams)
+	// SetFleetDefaultScheduleScan scans the result of an executed SetFleetDefaultScheduleBatch query.
+	SetFleetDefaultScheduleScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	SetFleetAgentImage(ctx context.Context, params SetFleetAgentImageParams) (pgconn.CommandTag, error)
+	// SetFleetAgentImageBatch enqueues a SetFleetAgentImage query into batch to be executed
+	// later by the batch.
+	SetFleetAgentImageBatch(batch genericBatch, params SetFleetAgentImageParams)
+	// SetFleetAgentImageScan scans the result of an executed SetFleetAgentImageBatch query.
+	SetFleetAgentImageScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	// The agent image of the device's fleet; NULL when the fleet has none.
+	GetAgentImageForDevice(ctx context.Context, deviceID uuid.UUID) (*string, error)
+	// GetAgentImageForDeviceBatch enqueues a GetAgentImageForDevice query into batch to be executed
+	// later by the batch.
+	GetAgentImageForDeviceBatch(batch genericBatch, deviceID uuid.UUID)
+	// GetAgentImageForDeviceScan scans the result of an executed GetAgentImageForDeviceBatch query.
+	GetAgentImageForDeviceScan(results pgx.BatchResults) (*string, error)
+
+	// maintenance_windows is a MaintenanceWindows message as JSON; NULL lets devices make disruptive changes at any time.
+	SetFleetMaintenance(ctx context.Context, params SetFleetMaintenanceParams) (pgconn.CommandTag, error)
+	// SetFleetMaintenanceBatch enqueues a SetFleetMaintenance query into batch to be executed
+	// later by the batch.
+	SetFleetMaintenanceBatch(batch genericBatch, params SetFleetMaintenanceParams)
+	// SetFleetMaintenanceScan scans the result of an executed SetFleetMaintenanceBatch query.
+	SetFleetMaintenanceScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	// NULL maintenance_windows and an empty time_zone fall back to the fleet's.
+	SetDeviceMaintenance(ctx context.Context, params SetDeviceMaintenanceParams) (pgconn.CommandTag, error)
+	// SetDeviceMaintenanceBatch enqueues a SetDeviceMaintenance query into batch to be executed
+	// later by the batch.
+	SetDeviceMaintenanceBatch(batch genericBatch, params SetDeviceMaintenanceParams)
+	// SetDeviceMaintenanceScan scans the result of an executed SetDeviceMaintenanceBatch query.
+	SetDeviceMaintenanceScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	SetDeviceApplyNowUntil(ctx context.Context, applyNowUntil *time.Time, deviceID uuid.UUID) (pgconn.CommandTag, error)
+	// SetDeviceApplyNowUntilBatch enqueues a SetDeviceApplyNowUntil query into batch to be executed
+	// later by the batch.
+	SetDeviceApplyNowUntilBatch(batch genericBatch, applyNowUntil *time.Time, deviceID uuid.UUID)
+	// SetDeviceApplyNowUntilScan scans the result of an executed SetDeviceApplyNowUntilBatch query.
+	SetDeviceApplyNowUntilScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	SetFleetApplyNowUntil(ctx context.Context, applyNowUntil *time.Time, fleetID uuid.UUID) (pgconn.CommandTag, error)
+	// SetFleetApplyNowUntilBatch enqueues a SetFleetApplyNowUntil query into batch to be executed
+	// later by the batch.
+	SetFleetApplyNowUntilBatch(batch genericBatch, applyNowUntil *time.Time, fleetID uuid.UUID)
+	// SetFleetApplyNowUntilScan scans the result of an executed SetFleetApplyNowUntilBatch query.
+	SetFleetApplyNowUntilScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	// The maintenance settings of the device and of its fleet, for the server to resolve which apply.
+	GetMaintenanceForDevice(ctx context.Context, deviceID uuid.UUID) (GetMaintenanceForDeviceRow, error)
+	// GetMaintenanceForDeviceBatch enqueues a GetMaintenanceForDevice query into batch to be executed
+	// later by the batch.
+	GetMaintenanceForDeviceBatch(batch genericBatch, deviceID uuid.UUID)
+	// GetMaintenanceForDeviceScan scans the result of an executed GetMaintenanceForDeviceBatch query.
+	GetMaintenanceForDeviceScan(results pgx.BatchResults) (GetMaintenanceForDeviceRow, error)
+
+	// Closes out the fleet's current fleet_schedule history entry, if any.
+	EndFleetSchedules(ctx context.Context, fleetID uuid.UUID) (pgconn.CommandTag, error)
+	// EndFleetSchedulesBatch enqueues a EndFleetSchedules query into batch to be executed
+	// later by the batch.
+	EndFleetSchedulesBatch(batch genericBatch, fleetID uuid.UUID)
+	// EndFleetSchedulesScan scans the result of an executed EndFleetSchedulesBatch query.
+	EndFleetSchedulesScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertFleetSchedule(ctx context.Context, fleetID uuid.UUID, scheduleID uuid.UUID) (pgconn.CommandTag, error)
+	// InsertFleetScheduleBatch enqueues a InsertFleetSchedule query into batch to be executed
+	// later by the batch.
+	InsertFleetScheduleBatch(batch genericBatch, fleetID uuid.UUID, scheduleID uuid.UUID)
+	// InsertFleetScheduleScan scans the result of an executed InsertFleetScheduleBatch query.
+	InsertFleetScheduleScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	DeleteFleet(ctx context.Context, fleetID uuid.UUID, organizationID uuid.UUID) (pgconn.CommandTag, error)
+	// DeleteFleetBatch enqueues a DeleteFleet query into batch to be executed
+	// later by the batch.
+	DeleteFleetBatch(batch genericBatch, fleetID uuid.UUID, organizationID uuid.UUID)
+	// DeleteFleetScan scans the result of an executed DeleteFleetBatch query.
+	DeleteFleetScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	// Keyset pagination over (name, id): pass the last row of the previous page as after_name/after_id.
+	// status filters on last_seen_at, using the same cutoffs as pkg.Config.DeviceStatus.
+	ListDevicesInFleet(ctx context.Context, params ListDevicesInFleetParams) ([]ListDevicesInFleetRow, error)
+	// ListDevicesInFleetBatch enqueues a ListDevicesInFleet query into batch to be executed
+	// later by the batch.
+	ListDevicesInFleetBatch(batch genericBatch, params ListDevicesInFleetParams)
+	// ListDevicesInFleetScan scans the result of an executed ListDevicesInFleetBatch query.
+	ListDevicesInFleetScan(results pgx.BatchResults) ([]ListDevicesInFleetRow, error)
+
+	GetDeviceForOrganization(ctx context.Context, deviceID uuid.UUID, organizationID uuid.UUID) (GetDeviceForOrganizationRow, error)
+	// GetDeviceForOrganizationBatch enqueues a GetDeviceForOrganization query into batch to be executed
+	// later by the batch.
+	GetDeviceForOrganizationBatch(batch genericBatch, deviceID uuid.UUID, organizationID uuid.UUID)
+	// GetDeviceForOrganizationScan scans the result of an executed GetDeviceForOrganizationBatch query.
+	GetDeviceForOrganizationScan(results pgx.BatchResults) (GetDeviceForOrganizationRow, error)
+
+	InsertDevice(ctx context.Context, name *string, fleetID uuid.UUID) (InsertDeviceRow, error)
+	// InsertDeviceBatch enqueues a InsertDevice query into batch to be executed
+	// later by the batch.
+	InsertDeviceBatch(batch genericBatch, name *string, fleetID uuid.UUID)
+	// InsertDeviceScan scans the result of an executed InsertDeviceBatch query.
+	InsertDeviceScan(results pgx.BatchResults) (InsertDeviceRow, error)
+
+	RenameDevice(ctx context.Context, name *string, deviceID uuid.UUID) (RenameDeviceRow, error)
+	// RenameDeviceBatch enqueues a RenameDevice query into batch to be executed
+	// later by the batch.
+	RenameDeviceBatch(batch genericBatch, name *string, deviceID uuid.UUID)
+	// RenameDeviceScan scans the result of an executed RenameDeviceBatch query.
+	RenameDeviceScan(results pgx.BatchResults) (RenameDeviceRow, error)
+
+	MoveDevice(ctx context.Context, fleetID uuid.UUID, deviceID uuid.UUID) (MoveDeviceRow, error)
+	// MoveDeviceBatch enqueues a MoveDevice query into batch to be executed
+	// later by the batch.
+	MoveDeviceBatch(batch genericBatch, fleetID uuid.UUID, deviceID uuid.UUID)
+	// MoveDeviceScan scans the result of an executed MoveDeviceBatch query.
+	MoveDeviceScan(results pgx.BatchResults) (MoveDeviceRow, error)
+
+	DeleteDevice(ctx context.Context, deviceID uuid.UUID) (pgconn.CommandTag, error)
+	// DeleteDeviceBatch enqueues a DeleteDevice query into batch to be executed
+	// later by the batch.
+	DeleteDeviceBatch(batch genericBatch, deviceID uuid.UUID)
+	// DeleteDeviceScan scans the result of an executed DeleteDeviceBatch query.
+	DeleteDeviceScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	// Also marks the device online, returning what it was before so the caller can announce the change.
+	RecordDeviceHeartbeat(ctx context.Context, params RecordDeviceHeartbeatParams) (RecordDeviceHeartbeatRow, error)
+	// RecordDeviceHeartbeatBatch enqueues a RecordDeviceHeartbeat query into batch to be executed
+	// later by the batch.
+	RecordDeviceHeartbeatBatch(batch genericBatch, params RecordDeviceHeartbeatParams)
+	// RecordDeviceHeartbeatScan scans the result of an executed RecordDeviceHeartbeatBatch query.
+	RecordDeviceHeartbeatScan(results pgx.BatchResults) (RecordDeviceHeartbeatRow, error)
+
+	RecordDeviceReportedState(ctx context.Context, params RecordDeviceReportedStateParams) (RecordDeviceReportedStateRow, error)
+	// RecordDeviceReportedStateBatch enqueues a RecordDeviceReportedState query into batch to be executed
+	// later by the batch.
+	RecordDeviceReportedStateBatch(batch genericBatch, params RecordDeviceReportedStateParams)
+	// RecordDeviceReportedStateScan scans the result of an executed RecordDeviceReportedStateBatch query.
+	RecordDeviceReportedStateScan(results pgx.BatchResults) (RecordDeviceReportedStateRow, error)
+
+	MarkDevicesOffline(ctx context.Context, offlineBefore *time.Time) ([]MarkDevicesOfflineRow, error)
+	// MarkDevicesOfflineBatch enqueues a MarkDevicesOffline query into batch to be executed
+	// later by the batch.
+	MarkDevicesOfflineBatch(batch genericBatch, offlineBefore *time.Time)
+	// MarkDevicesOfflineScan scans the result of an executed MarkDevicesOfflineBatch query.
+	MarkDevicesOfflineScan(results pgx.BatchResults) ([]MarkDevicesOfflineRow, error)
+
+	// Devices in the fleet seen since seen_after that do not report running containers of schedule_id.
+	CountFleetDevicesBehindSchedule(ctx context.Context, params CountFleetDevicesBehindScheduleParams) (*int, error)
+	// CountFleetDevicesBehindScheduleBatch enqueues a CountFleetDevicesBehindSchedule query into batch to be executed
+	// later by the batch.
+	CountFleetDevicesBehindScheduleBatch(batch genericBatch, params CountFleetDevicesBehindScheduleParams)
+	// CountFleetDevicesBehindScheduleScan scans the result of an executed CountFleetDevicesBehindScheduleBatch query.
+	CountFleetDevicesBehindScheduleScan(results pgx.BatchResults) (*int, error)
+
+	// Every device, by the status DeviceStatus would give it.
+	CountDevicesByStatus(ctx context.Context, onlineAfter *time.Time, offlineAfter *time.Time) (CountDevicesByStatusRow, error)
+	// CountDevicesByStatusBatch enqueues a CountDevicesByStatus query into batch to be executed
+	// later by the batch.
+	CountDevicesByStatusBatch(batch genericBatch, onlineAfter *time.Time, offlineAfter *time.Time)
+	// CountDevicesByStatusScan scans the result of an executed CountDevicesByStatusBatch query.
+	CountDevicesByStatusScan(results pgx.BatchResults) (CountDevicesByStatusRow, error)
+
+	InsertAuditLog(ctx context.Context, params InsertAuditLogParams) (pgconn.CommandTag, error)
+	// InsertAuditLogBatch enqueues a InsertAuditLog query into batch to be executed
+	// later by the batch.
+	InsertAuditLogBatch(batch genericBatch, params InsertAuditLogParams)
+	// InsertAuditLogScan scans the result of an executed InsertAuditLogBatch query.
+	InsertAuditLogScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	// Newest first; empty filters match everything, and a NULL before_created_at starts from the newest entry.
+	ListAuditLog(ctx context.Context, params ListAuditLogParams) ([]ListAuditLogRow, error)
+	// ListAuditLogBatch enqueues a ListAuditLog query into batch to be executed
+	// later by the batch.
+	ListAuditLogBatch(batch genericBatch, params ListAuditLogParams)
+	// ListAuditLogScan scans the result of an executed ListAuditLogBatch query.
+	ListAuditLogScan(results pgx.BatchResults) ([]ListAuditLogRow, error)
+
+	DeleteAuditLogBefore(ctx context.Context, cutoff *time.Time) (pgconn.CommandTag, error)
+	// DeleteAuditLogBeforeBatch enqueues a DeleteAuditLogBefore query into batch to be executed
+	// later by the batch.
+	DeleteAuditLogBeforeBatch(batch genericBatch, cutoff *time.Time)
+	// DeleteAuditLogBeforeScan scans the result of an executed DeleteAuditLogBeforeBatch query.
+	DeleteAuditLogBeforeScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	GetDeviceOrganization(ctx context.Context, deviceID uuid.UUID) (GetDeviceOrganizationRow, error)
+	// GetDeviceOrganizationBatch enqueues a GetDeviceOrganization query into batch to be executed
+	// later by the batch.
+	GetDeviceOrganizationBatch(batch genericBatch, deviceID uuid.UUID)
+	// GetDeviceOrganizationScan scans the result of an executed GetDeviceOrganizationBatch query.
+	GetDeviceOrganizationScan(results pgx.BatchResults) (GetDeviceOrganizationRow, error)
+
+	ListOrganizationMembers(ctx context.Context, organizationID uuid.UUID) ([]ListOrganizationMembersRow, error)
+	// ListOrganizationMembersBatch enqueues a ListOrganizationMembers query into batch to be executed
+	// later by the batch.
+	ListOrganizationMembersBatch(batch genericBatch, organizationID uuid.UUID)
+	// ListOrganizationMembersScan scans the result of an executed ListOrganizationMembersBatch query.
+	ListOrganizationMembersScan(results pgx.BatchResults) ([]ListOrganizationMembersRow, error)
+
+	InsertOrganizationUser(ctx context.Context, params InsertOrganizationUserParams) (pgconn.CommandTag, error)
+	// InsertOrganizationUserBatch enqueues a InsertOrganizationUser query into batch to be executed
+	// later by the batch.
+	InsertOrganizationUserBatch(batch genericBatch, params InsertOrganizationUserParams)
+	// InsertOrganizationUserScan scans the result of an executed InsertOrganizationUserBatch query.
+	InsertOrganizationUserScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	SetOrganizationUserRole(ctx context.Context, params SetOrganizationUserRoleParams) (pgconn.CommandTag, error)
+	// SetOrganizationUserRoleBatch enqueues a SetOrganizationUserRole query into batch to be executed
+	// later by the batch.
+	SetOrganizationUserRoleBatch(batch genericBatch, params SetOrganizationUserRoleParams)
+	// SetOrganizationUserRoleScan scans the result of an executed SetOrganizationUserRoleBatch query.
+	SetOrganizationUserRoleScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	CountOrganizationOwners(ctx context.Context, organizationID uuid.UUID) (*int, error)
+	// CountOrganizationOwnersBatch enqueues a CountOrganizationOwners query into batch to be executed
+	// later by the batch.
+	CountOrganizationOwnersBatch(batch genericBatch, organizationID uuid.UUID)
+	// CountOrganizationOwnersScan scans the result of an executed CountOrganizationOwnersBatch query.
+	CountOrganizationOwnersScan(results pgx.BatchResults) (*int, error)
+
+	// Emails are stored lowercased; inviting an address again replaces its role.
+	UpsertOrganizationInvitation(ctx context.Context, params UpsertOrganizationInvitationParams) (UpsertOrganizationInvitationRow, error)
+	// UpsertOrganizationInvitationBatch enqueues a UpsertOrganizationInvitation query into batch to be executed
+	// later by the batch.
+	UpsertOrganizationInvitationBatch(batch genericBatch, params UpsertOrganizationInvitationParams)
+	// UpsertOrganizationInvitationScan scans the result of an executed UpsertOrganizationInvitationBatch query.
+	UpsertOrganizationInvitationScan(results pgx.BatchResults) (UpsertOrganizationInvitationRow, error)
+
+	ListOrganizationInvitations(ctx context.Context, organizationID uuid.UUID) ([]ListOrganizationInvitationsRow, error)
+	// ListOrganizationInvitationsBatch enqueues a ListOrganizationInvitations query into batch to be executed
+	// later by the batch.
+	ListOrganizationInvitationsBatch(batch genericBatch, organizationID uuid.UUID)
+	// ListOrganizationInvitationsScan scans the result of an executed ListOrganizationInvitationsBatch query.
+	ListOrganizationInvitationsScan(results pgx.BatchResults) ([]ListOrganizationInvitationsRow, error)
+
+	// Turns every invitation for email into a membership for user_id, unless they already are a member.
+	AcceptOrganizationInvitations(ctx context.Context, email *string, userID uuid.UUID) (pgconn.CommandTag, error)
+	// AcceptOrganizationInvitationsBatch enqueues a AcceptOrganizationInvitations query into batch to be executed
+	// later by the batch.
+	AcceptOrganizationInvitationsBatch(batch genericBatch, email *string, userID uuid.UUID)
+	// AcceptOrganizationInvitationsScan scans the result of an executed AcceptOrganizationInvitationsBatch query.
+	AcceptOrganizationInvitationsScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	ListWebhooksForOrganization(ctx context.Context, organizationID uuid.UUID) ([]ListWebhooksForOrganizationRow, error)
+	// ListWebhooksForOrganizationBatch enqueues a ListWebhooksForOrganization query into batch to be executed
+	// later by the batch.
+	ListWebhooksForOrganizationBatch(batch genericBatch, organizationID uuid.UUID)
+	// ListWebhooksForOrganizationScan scans the result of an executed ListWebhooksForOrganizationBatch query.
+	ListWebhooksForOrganizationScan(results pgx.BatchResults) ([]ListWebhooksForOrganizationRow, error)
+
+	GetWebhookForOrganization(ctx context.Context, webhookID uuid.UUID, organizationID uuid.UUID) (GetWebhookForOrganizationRow, error)
+	// GetWebhookForOrganizationBatch enqueues a GetWebhookForOrganization query into batch to be executed
+	// later by the batch.
+	GetWebhookForOrganizationBatch(batch genericBatch, webhookID uuid.UUID, organizationID uuid.UUID)
+	// GetWebhookForOrganizationScan scans the result of an executed GetWebhookForOrganizationBatch query.
+	GetWebhookForOrganizationScan(results pgx.BatchResults) (GetWebhookForOrganizationRow, error)
+
+	InsertWebhook(ctx context.Context, params InsertWebhookParams) (InsertWebhookRow, error)
+	// InsertWebhookBatch enqueues a InsertWebhook query into batch to be executed
+	// later by the batch.
+	InsertWebhookBatch(batch genericBatch, params InsertWebhookParams)
+	// InsertWebhookScan scans the result of an executed InsertWebhookBatch query.
+	InsertWebhookScan(results pgx.BatchResults) (InsertWebhookRow, error)
+
+	UpdateWebhook(ctx context.Context, params UpdateWebhookParams) (UpdateWebhookRow, error)
+	// UpdateWebhookBatch enqueues a UpdateWebhook query into batch to be executed
+	// later by the batch.
+	UpdateWebhookBatch(batch genericBatch, params UpdateWebhookParams)
+	// UpdateWebhookScan scans the result of an executed UpdateWebhookBatch query.
+	UpdateWebhookScan(results pgx.BatchResults) (UpdateWebhookRow, error)
+
+	DeleteWebhook(ctx context.Context, webhookID uuid.UUID, organizationID uuid.UUID) (pgconn.CommandTag, error)
+	// DeleteWebhookBatch enqueues a DeleteWebhook query into batch to be executed
+	// later by the batch.
+	DeleteWebhookBatch(batch genericBatch, webhookID uuid.UUID, organizationID uuid.UUID)
+	// DeleteWebhookScan scans the result of an executed DeleteWebhookBatch query.
+	DeleteWebhookScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	// Queues an event for every enabled webhook of the organization subscribed to its type.
+	EnqueueWebhookDeliveries(ctx context.Context, params EnqueueWebhookDeliveriesParams) (pgconn.CommandTag, error)
+	// EnqueueWebhookDeliveriesBatch enqueues a EnqueueWebhookDeliveries query into batch to be executed
+	// later by the batch.
+	EnqueueWebhookDeliveriesBatch(batch genericBatch, params EnqueueWebhookDeliveriesParams)
+	// EnqueueWebhookDeliveriesScan scans the result of an executed EnqueueWebhookDeliveriesBatch query.
+	EnqueueWebhookDeliveriesScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	// Leases due deliveries for lease_seconds, so other servers skip them while this one sends them.
+	ClaimWebhookDeliveries(ctx context.Context, leaseSeconds int32, batchSize *int) ([]ClaimWebhookDeliveriesRow, error)
+	// ClaimWebhookDeliveriesBatch enqueues a ClaimWebhookDeliveries query into batch to be executed
+	// later by the batch.
+	ClaimWebhookDeliveriesBatch(batch genericBatch, leaseSeconds int32, batchSize *int)
+	// ClaimWebhookDeliveriesScan scans the result of an executed ClaimWebhookDeliveriesBatch query.
+	ClaimWebhookDeliveriesScan(results pgx.BatchResults) ([]ClaimWebhookDeliveriesRow, error)
+
+	RecordWebhookDeliveryAttempt(ctx context.Context, params RecordWebhookDeliveryAttemptParams) (pgconn.CommandTag, error)
+	// RecordWebhookDeliveryAttemptBatch enqueues a RecordWebhookDeliveryAttempt query into batch to be executed
+	// later by the batch.
+	RecordWebhookDeliveryAttemptBatch(batch genericBatch, params RecordWebhookDeliveryAttemptParams)
+	// RecordWebhookDeliveryAttemptScan scans the result of an executed RecordWebhookDeliveryAttemptBatch query.
+	RecordWebhookDeliveryAttemptScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	// Newest first; an empty status matches every delivery.
+	ListWebhookDeliveries(ctx context.Context, params ListWebhookDeliveriesParams) ([]ListWebhookDeliveriesRow, error)
+	// ListWebhookDeliveriesBatch enqueues a ListWebhookDeliveries query into batch to be executed
+	// later by the batch.
+	ListWebhookDeliveriesBatch(batch genericBatch, params ListWebhookDeliveriesParams)
+	// ListWebhookDeliveriesScan scans the result of an executed ListWebhookDeliveriesBatch query.
+	ListWebhookDeliveriesScan(results pgx.BatchResults) ([]ListWebhookDeliveriesRow, error)
+
+	RedeliverWebhookDelivery(ctx context.Context, deliveryID uuid.UUID, webhookID uuid.UUID) (RedeliverWebhookDeliveryRow, error)
+	// RedeliverWebhookDeliveryBatch enqueues a RedeliverWebhookDelivery query into batch to be executed
+	// later by the batch.
+	RedeliverWebhookDeliveryBatch(batch genericBatch, deliveryID uuid.UUID, webhookID uuid.UUID)
+	// RedeliverWebhookDeliveryScan scans the result of an executed RedeliverWebhookDeliveryBatch query.
+	RedeliverWebhookDeliveryScan(results pgx.BatchResults) (RedeliverWebhookDeliveryRow, error)
+
+	DeleteWebhookDeliveriesBefore(ctx context.Context, cutoff *time.Time) (pgconn.CommandTag, error)
+	// DeleteWebhookDeliveriesBeforeBatch enqueues a DeleteWebhookDeliveriesBefore query into batch to be executed
+	// later by the batch.
+	DeleteWebhookDeliveriesBeforeBatch(batch genericBatch, cutoff *time.Time)
+	// DeleteWebhookDeliveriesBeforeScan scans the result of an executed DeleteWebhookDeliveriesBeforeBatch query.
+	DeleteWebhookDeliveriesBeforeScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	InsertJobRun(ctx context.Context, params InsertJobRunParams) (pgconn.CommandTag, error)
+	// InsertJobRunBatch enqueues a InsertJobRun query into batch to be executed
+	// later by the batch.
+	InsertJobRunBatch(batch genericBatch, params InsertJobRunParams)
+	// InsertJobRunScan scans the result of an executed InsertJobRunBatch query.
+	InsertJobRunScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	// Newest first; an empty job matches every job, by task ID or name, and a NULL before_started_at starts from the newest run.
+	ListJobRuns(ctx context.Context, params ListJobRunsParams) ([]ListJobRunsRow, error)
+	// ListJobRunsBatch enqueues a ListJobRuns query into batch to be executed
+	// later by the batch.
+	ListJobRunsBatch(batch genericBatch, params ListJobRunsParams)
+	// ListJobRunsScan scans the result of an executed ListJobRunsBatch query.
+	ListJobRunsScan(results pgx.BatchResults) ([]ListJobRunsRow, error)
+
+	DeleteJobRunsBefore(ctx context.Context, cutoff *time.Time) (pgconn.CommandTag, error)
+	// DeleteJobRunsBeforeBatch enqueues a DeleteJobRunsBefore query into batch to be executed
+	// later by the batch.
+	DeleteJobRunsBeforeBatch(batch genericBatch, cutoff *time.Time)
+	// DeleteJobRunsBeforeScan scans the result of an executed DeleteJobRunsBeforeBatch query.
+	DeleteJobRunsBeforeScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	SetDeviceRelayTokenHash(ctx context.Context, relayTokenHash *string, deviceID uuid.UUID) (pgconn.CommandTag, error)
+	// SetDeviceRelayTokenHashBatch enqueues a SetDeviceRelayTokenHash query into batch to be executed
+	// later by the batch.
+	SetDeviceRelayTokenHashBatch(batch genericBatch, relayTokenHash *string, deviceID uuid.UUID)
+	// SetDeviceRelayTokenHashScan scans the result of an executed SetDeviceRelayTokenHashBatch query.
+	SetDeviceRelayTokenHashScan(results pgx.BatchResults) (pgconn.CommandTag, error)
+
+	GetDeviceRelayTokenHash(ctx context.Context, deviceID uuid.UUID) (*string, error)
+	// GetDeviceRelayTokenHashBatch enqueues a GetDeviceRelayTokenHash query into batch to be executed
+	// later by the batch.
+	GetDeviceRelayTokenHashBatch(batch genericBatch, deviceID uuid.UUID)
+	// GetDeviceRelayTokenHashScan scans the result of an executed GetDeviceRelayTokenHashBatch query.
+	GetDeviceRelayTokenHashScan(results pgx.BatchResults) (*string, error)
 }
 
 type DBQuerier struct {
@@ -125,6 +683,237 @@ func PrepareAllQueries(ctx context.Context, p preparer) error {
 	if _, err := p.Prepare(ctx, getDeviceByNameSQL, getDeviceByNameSQL); err != nil {
 		return fmt.Errorf("prepare query 'GetDeviceByName': %w", err)
 	}
+	if _, err := p.Prepare(ctx, insertDeviceRollbackSQL, insertDeviceRollbackSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertDeviceRollback': %w", err)
+	}
+	if _, err := p.Prepare(ctx, listSchedulesForOrganizationSQL, listSchedulesForOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'ListSchedulesForOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, listSchedulesByNameForOrganizationSQL, listSchedulesByNameForOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'ListSchedulesByNameForOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getScheduleForOrganizationSQL, getScheduleForOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetScheduleForOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertScheduleSQL, insertScheduleSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertSchedule': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateScheduleSQL, updateScheduleSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateSchedule': %w", err)
+	}
+	if _, err := p.Prepare(ctx, touchScheduleSQL, touchScheduleSQL); err != nil {
+		return fmt.Errorf("prepare query 'TouchSchedule': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteScheduleSQL, deleteScheduleSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteSchedule': %w", err)
+	}
+	if _, err := p.Prepare(ctx, countFleetsUsingScheduleSQL, countFleetsUsingScheduleSQL); err != nil {
+		return fmt.Errorf("prepare query 'CountFleetsUsingSchedule': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getContainersForSchedulesSQL, getContainersForSchedulesSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetContainersForSchedules': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getContainerForOrganizationSQL, getContainerForOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetContainerForOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertContainerSQL, insertContainerSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertContainer': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateContainerSQL, updateContainerSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateContainer': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteContainerSQL, deleteContainerSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteContainer': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteContainersForScheduleSQL, deleteContainersForScheduleSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteContainersForSchedule': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getUserByEmailSQL, getUserByEmailSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetUserByEmail': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getUserByIDSQL, getUserByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetUserByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getOrganizationRoleSQL, getOrganizationRoleSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetOrganizationRole': %w", err)
+	}
+	if _, err := p.Prepare(ctx, listOrganizationsForUserSQL, listOrganizationsForUserSQL); err != nil {
+		return fmt.Errorf("prepare query 'ListOrganizationsForUser': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getOrganizationByIDSQL, getOrganizationByIDSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetOrganizationByID': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertAPIKeySQL, insertAPIKeySQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertAPIKey': %w", err)
+	}
+	if _, err := p.Prepare(ctx, listAPIKeysForOrganizationSQL, listAPIKeysForOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'ListAPIKeysForOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, revokeAPIKeySQL, revokeAPIKeySQL); err != nil {
+		return fmt.Errorf("prepare query 'RevokeAPIKey': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getActiveAPIKeyByTokenHashSQL, getActiveAPIKeyByTokenHashSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetActiveAPIKeyByTokenHash': %w", err)
+	}
+	if _, err := p.Prepare(ctx, listFleetsForOrganizationSQL, listFleetsForOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'ListFleetsForOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getFleetForOrganizationSQL, getFleetForOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetFleetForOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertFleetSQL, insertFleetSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertFleet': %w", err)
+	}
+	if _, err := p.Prepare(ctx, renameFleetSQL, renameFleetSQL); err != nil {
+		return fmt.Errorf("prepare query 'RenameFleet': %w", err)
+	}
+	if _, err := p.Prepare(ctx, setFleetDefaultScheduleSQL, setFleetDefaultScheduleSQL); err != nil {
+		return fmt.Errorf("prepare query 'SetFleetDefaultSchedule': %w", err)
+	}
+	if _, err := p.Prepare(ctx, setFleetAgentImageSQL, setFleetAgentImageSQL); err != nil {
+		return fmt.Errorf("prepare query 'SetFleetAgentImage': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getAgentImageForDeviceSQL, getAgentImageForDeviceSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetAgentImageForDevice': %w", err)
+	}
+	if _, err := p.Prepare(ctx, setFleetMaintenanceSQL, setFleetMaintenanceSQL); err != nil {
+		return fmt.Errorf("prepare query 'SetFleetMaintenance': %w", err)
+	}
+	if _, err := p.Prepare(ctx, setDeviceMaintenanceSQL, setDeviceMaintenanceSQL); err != nil {
+		return fmt.Errorf("prepare query 'SetDeviceMaintenance': %w", err)
+	}
+	if _, err := p.Prepare(ctx, setDeviceApplyNowUntilSQL, setDeviceApplyNowUntilSQL); err != nil {
+		return fmt.Errorf("prepare query 'SetDeviceApplyNowUntil': %w", err)
+	}
+	if _, err := p.Prepare(ctx, setFleetApplyNowUntilSQL, setFleetApplyNowUntilSQL); err != nil {
+		return fmt.Errorf("prepare query 'SetFleetApplyNowUntil': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getMaintenanceForDeviceSQL, getMaintenanceForDeviceSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetMaintenanceForDevice': %w", err)
+	}
+	if _, err := p.Prepare(ctx, endFleetSchedulesSQL, endFleetSchedulesSQL); err != nil {
+		return fmt.Errorf("prepare query 'EndFleetSchedules': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertFleetScheduleSQL, insertFleetScheduleSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertFleetSchedule': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteFleetSQL, deleteFleetSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteFleet': %w", err)
+	}
+	if _, err := p.Prepare(ctx, listDevicesInFleetSQL, listDevicesInFleetSQL); err != nil {
+		return fmt.Errorf("prepare query 'ListDevicesInFleet': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getDeviceForOrganizationSQL, getDeviceForOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetDeviceForOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertDeviceSQL, insertDeviceSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertDevice': %w", err)
+	}
+	if _, err := p.Prepare(ctx, renameDeviceSQL, renameDeviceSQL); err != nil {
+		return fmt.Errorf("prepare query 'RenameDevice': %w", err)
+	}
+	if _, err := p.Prepare(ctx, moveDeviceSQL, moveDeviceSQL); err != nil {
+		return fmt.Errorf("prepare query 'MoveDevice': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteDeviceSQL, deleteDeviceSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteDevice': %w", err)
+	}
+	if _, err := p.Prepare(ctx, recordDeviceHeartbeatSQL, recordDeviceHeartbeatSQL); err != nil {
+		return fmt.Errorf("prepare query 'RecordDeviceHeartbeat': %w", err)
+	}
+	if _, err := p.Prepare(ctx, recordDeviceReportedStateSQL, recordDeviceReportedStateSQL); err != nil {
+		return fmt.Errorf("prepare query 'RecordDeviceReportedState': %w", err)
+	}
+	if _, err := p.Prepare(ctx, markDevicesOfflineSQL, markDevicesOfflineSQL); err != nil {
+		return fmt.Errorf("prepare query 'MarkDevicesOffline': %w", err)
+	}
+	if _, err := p.Prepare(ctx, countFleetDevicesBehindScheduleSQL, countFleetDevicesBehindScheduleSQL); err != nil {
+		return fmt.Errorf("prepare query 'CountFleetDevicesBehindSchedule': %w", err)
+	}
+	if _, err := p.Prepare(ctx, countDevicesByStatusSQL, countDevicesByStatusSQL); err != nil {
+		return fmt.Errorf("prepare query 'CountDevicesByStatus': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertAuditLogSQL, insertAuditLogSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertAuditLog': %w", err)
+	}
+	if _, err := p.Prepare(ctx, listAuditLogSQL, listAuditLogSQL); err != nil {
+		return fmt.Errorf("prepare query 'ListAuditLog': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteAuditLogBeforeSQL, deleteAuditLogBeforeSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteAuditLogBefore': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getDeviceOrganizationSQL, getDeviceOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetDeviceOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, listOrganizationMembersSQL, listOrganizationMembersSQL); err != nil {
+		return fmt.Errorf("prepare query 'ListOrganizationMembers': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertOrganizationUserSQL, insertOrganizationUserSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertOrganizationUser': %w", err)
+	}
+	if _, err := p.Prepare(ctx, setOrganizationUserRoleSQL, setOrganizationUserRoleSQL); err != nil {
+		return fmt.Errorf("prepare query 'SetOrganizationUserRole': %w", err)
+	}
+	if _, err := p.Prepare(ctx, countOrganizationOwnersSQL, countOrganizationOwnersSQL); err != nil {
+		return fmt.Errorf("prepare query 'CountOrganizationOwners': %w", err)
+	}
+	if _, err := p.Prepare(ctx, upsertOrganizationInvitationSQL, upsertOrganizationInvitationSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpsertOrganizationInvitation': %w", err)
+	}
+	if _, err := p.Prepare(ctx, listOrganizationInvitationsSQL, listOrganizationInvitationsSQL); err != nil {
+		return fmt.Errorf("prepare query 'ListOrganizationInvitations': %w", err)
+	}
+	if _, err := p.Prepare(ctx, acceptOrganizationInvitationsSQL, acceptOrganizationInvitationsSQL); err != nil {
+		return fmt.Errorf("prepare query 'AcceptOrganizationInvitations': %w", err)
+	}
+	if _, err := p.Prepare(ctx, listWebhooksForOrganizationSQL, listWebhooksForOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'ListWebhooksForOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getWebhookForOrganizationSQL, getWebhookForOrganizationSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetWebhookForOrganization': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertWebhookSQL, insertWebhookSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertWebhook': %w", err)
+	}
+	if _, err := p.Prepare(ctx, updateWebhookSQL, updateWebhookSQL); err != nil {
+		return fmt.Errorf("prepare query 'UpdateWebhook': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteWebhookSQL, deleteWebhookSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteWebhook': %w", err)
+	}
+	if _, err := p.Prepare(ctx, enqueueWebhookDeliveriesSQL, enqueueWebhookDeliveriesSQL); err != nil {
+		return fmt.Errorf("prepare query 'EnqueueWebhookDeliveries': %w", err)
+	}
+	if _, err := p.Prepare(ctx, claimWebhookDeliveriesSQL, claimWebhookDeliveriesSQL); err != nil {
+		return fmt.Errorf("prepare query 'ClaimWebhookDeliveries': %w", err)
+	}
+	if _, err := p.Prepare(ctx, recordWebhookDeliveryAttemptSQL, recordWebhookDeliveryAttemptSQL); err != nil {
+		return fmt.Errorf("prepare query 'RecordWebhookDeliveryAttempt': %w", err)
+	}
+	if _, err := p.Prepare(ctx, listWebhookDeliveriesSQL, listWebhookDeliveriesSQL); err != nil {
+		return fmt.Errorf("prepare query 'ListWebhookDeliveries': %w", err)
+	}
+	if _, err := p.Prepare(ctx, redeliverWebhookDeliverySQL, redeliverWebhookDeliverySQL); err != nil {
+		return fmt.Errorf("prepare query 'RedeliverWebhookDelivery': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteWebhookDeliveriesBeforeSQL, deleteWebhookDeliveriesBeforeSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteWebhookDeliveriesBefore': %w", err)
+	}
+	if _, err := p.Prepare(ctx, insertJobRunSQL, insertJobRunSQL); err != nil {
+		return fmt.Errorf("prepare query 'InsertJobRun': %w", err)
+	}
+	if _, err := p.Prepare(ctx, listJobRunsSQL, listJobRunsSQL); err != nil {
+		return fmt.Errorf("prepare query 'ListJobRuns': %w", err)
+	}
+	if _, err := p.Prepare(ctx, deleteJobRunsBeforeSQL, deleteJobRunsBeforeSQL); err != nil {
+		return fmt.Errorf("prepare query 'DeleteJobRunsBefore': %w", err)
+	}
+	if _, err := p.Prepare(ctx, setDeviceRelayTokenHashSQL, setDeviceRelayTokenHashSQL); err != nil {
+		return fmt.Errorf("prepare query 'SetDeviceRelayTokenHash': %w", err)
+	}
+	if _, err := p.Prepare(ctx, getDeviceRelayTokenHashSQL, getDeviceRelayTokenHashSQL); err != nil {
+		return fmt.Errorf("prepare query 'GetDeviceRelayTokenHash': %w", err)
+	}
 	return nil
 }
 
@@ -165,17 +954,17 @@ func (tr *typeResolver) setValue(vt pgtype.ValueTranscoder, val interface{}) pgt
 
 const getCurrentScheduleForDeviceSQL = `SELECT s.*
 FROM device AS d
-LEFT JOIN fleet_schedule AS fs ON fs.fleet_id = d.fleet_id
-LEFT JOIN fleet AS f ON f.id = fs.fleet_id
+JOIN fleet AS f ON f.id = d.fleet_id
 LEFT JOIN schedule AS s ON s.id = f.default_schedule_id
 WHERE d.id = $1;`
 
 type GetCurrentScheduleForDeviceRow struct {
-	ID        uuid.UUID  `json:"id"`
-	Name      *string    `json:"name"`
-	State     *string    `json:"state"`
-	CreatedAt *time.Time `json:"created_at"`
-	UpdatedAt *time.Time `json:"updated_at"`
+	ID             uuid.UUID  `json:"id"`
+	Name           *string    `json:"name"`
+	State          *string    `json:"state"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
 }
 
 // GetCurrentScheduleForDevice implements Querier.GetCurrentScheduleForDevice.
@@ -183,7 +972,7 @@ func (q *DBQuerier) GetCurrentScheduleForDevice(ctx context.Context, deviceID uu
 	ctx = context.WithValue(ctx, "pggen_query_name", "GetCurrentScheduleForDevice")
 	row := q.conn.QueryRow(ctx, getCurrentScheduleForDeviceSQL, deviceID)
 	var item GetCurrentScheduleForDeviceRow
-	if err := row.Scan(&item.ID, &item.Name, &item.State, &item.CreatedAt, &item.UpdatedAt); err != nil {
+	if err := row.Scan(&item.ID, &item.Name, &item.State, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID); err != nil {
 		return item, fmt.Errorf("query GetCurrentScheduleForDevice: %w", err)
 	}
 	return item, nil
@@ -198,7 +987,7 @@ func (q *DBQuerier) GetCurrentScheduleForDeviceBatch(batch genericBatch, deviceI
 func (q *DBQuerier) GetCurrentScheduleForDeviceScan(results pgx.BatchResults) (GetCurrentScheduleForDeviceRow, error) {
 	row := results.QueryRow()
 	var item GetCurrentScheduleForDeviceRow
-	if err := row.Scan(&item.ID, &item.Name, &item.State, &item.CreatedAt, &item.UpdatedAt); err != nil {
+	if err := row.Scan(&item.ID, &item.Name, &item.State, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID); err != nil {
 		return item, fmt.Errorf("scan GetCurrentScheduleForDeviceBatch row: %w", err)
 	}
 	return item, nil
@@ -209,25 +998,29 @@ FROM container AS c
 WHERE c.schedule_id = $1;`
 
 type GetContainersForScheduleRow struct {
-	ID               uuid.UUID  `json:"id"`
-	CreatedAt        *time.Time `json:"created_at"`
-	UpdatedAt        *time.Time `json:"updated_at"`
-	Name             *string    `json:"name"`
-	ContainerImage   *string    `json:"container_image"`
-	Env              []byte     `json:"env"`
-	Privileged       bool       `json:"privileged"`
-	NetworkMode      *string    `json:"network_mode"`
-	Ports            []byte     `json:"ports"`
-	BindDev          bool       `json:"bind_dev"`
-	BindProc         bool       `json:"bind_proc"`
-	BindSys          bool       `json:"bind_sys"`
-	BindShm          bool       `json:"bind_shm"`
-	BindCgroup       bool       `json:"bind_cgroup"`
-	BindDockerSocket bool       `json:"bind_docker_socket"`
-	BindBoot         bool       `json:"bind_boot"`
-	Command          *string    `json:"command"`
-	Entrypoint       *string    `json:"entrypoint"`
-	ScheduleID       uuid.UUID  `json:"schedule_id"`
+	ID                     uuid.UUID  `json:"id"`
+	CreatedAt              *time.Time `json:"created_at"`
+	UpdatedAt              *time.Time `json:"updated_at"`
+	Name                   *string    `json:"name"`
+	ContainerImage         *string    `json:"container_image"`
+	Env                    []byte     `json:"env"`
+	Privileged             bool       `json:"privileged"`
+	NetworkMode            *string    `json:"network_mode"`
+	Ports                  []byte     `json:"ports"`
+	BindDev                bool       `json:"bind_dev"`
+	BindProc               bool       `json:"bind_proc"`
+	BindSys                bool       `json:"bind_sys"`
+	BindShm                bool       `json:"bind_shm"`
+	BindCgroup             bool       `json:"bind_cgroup"`
+	BindDockerSocket       bool       `json:"bind_docker_socket"`
+	BindBoot               bool       `json:"bind_boot"`
+	Command                *string    `json:"command"`
+	Entrypoint             *string    `json:"entrypoint"`
+	ScheduleID             uuid.UUID  `json:"schedule_id"`
+	StopSignal             *string    `json:"stop_signal"`
+	StopGracePeriodSeconds int32      `json:"stop_grace_period_seconds"`
+	UpdateStrategy         *string    `json:"update_strategy"`
+	Job                    []byte     `json:"job"`
 }
 
 // GetContainersForSchedule implements Querier.GetContainersForSchedule.
@@ -241,7 +1034,7 @@ func (q *DBQuerier) GetContainersForSchedule(ctx context.Context, scheduleID uui
 	items := []GetContainersForScheduleRow{}
 	for rows.Next() {
 		var item GetContainersForScheduleRow
-		if err := rows.Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.ContainerImage, &item.Env, &item.Privileged, &item.NetworkMode, &item.Ports, &item.BindDev, &item.BindProc, &item.BindSys, &item.BindShm, &item.BindCgroup, &item.BindDockerSocket, &item.BindBoot, &item.Command, &item.Entrypoint, &item.ScheduleID); err != nil {
+		if err := rows.Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.ContainerImage, &item.Env, &item.Privileged, &item.NetworkMode, &item.Ports, &item.BindDev, &item.BindProc, &item.BindSys, &item.BindShm, &item.BindCgroup, &item.BindDockerSocket, &item.BindBoot, &item.Command, &item.Entrypoint, &item.ScheduleID, &item.StopSignal, &item.StopGracePeriodSeconds, &item.UpdateStrategy, &item.Job); err != nil {
 			return nil, fmt.Errorf("scan GetContainersForSchedule row: %w", err)
 		}
 		items = append(items, item)
@@ -267,7 +1060,7 @@ func (q *DBQuerier) GetContainersForScheduleScan(results pgx.BatchResults) ([]Ge
 	items := []GetContainersForScheduleRow{}
 	for rows.Next() {
 		var item GetContainersForScheduleRow
-		if err := rows.Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.ContainerImage, &item.Env, &item.Privileged, &item.NetworkMode, &item.Ports, &item.BindDev, &item.BindProc, &item.BindSys, &item.BindShm, &item.BindCgroup, &item.BindDockerSocket, &item.BindBoot, &item.Command, &item.Entrypoint, &item.ScheduleID); err != nil {
+		if err := rows.Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.ContainerImage, &item.Env, &item.Privileged, &item.NetworkMode, &item.Ports, &item.BindDev, &item.BindProc, &item.BindSys, &item.BindShm, &item.BindCgroup, &item.BindDockerSocket, &item.BindBoot, &item.Command, &item.Entrypoint, &item.ScheduleID, &item.StopSignal, &item.StopGracePeriodSeconds, &item.UpdateStrategy, &item.Job); err != nil {
 			return nil, fmt.Errorf("scan GetContainersForScheduleBatch row: %w", err)
 		}
 		items = append(items, item)
@@ -283,11 +1076,21 @@ FROM device AS d
 WHERE d.name = $1;`
 
 type GetDeviceByNameRow struct {
-	ID        uuid.UUID  `json:"id"`
-	Name      *string    `json:"name"`
-	CreatedAt *time.Time `json:"created_at"`
-	UpdatedAt *time.Time `json:"updated_at"`
-	FleetID   uuid.UUID  `json:"fleet_id"`
+	ID                 uuid.UUID  `json:"id"`
+	Name               *string    `json:"name"`
+	CreatedAt          *time.Time `json:"created_at"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+	FleetID            uuid.UUID  `json:"fleet_id"`
+	AgentVersion       *string    `json:"agent_version"`
+	LastSeenAt         *time.Time `json:"last_seen_at"`
+	RemoteAddress      *string    `json:"remote_address"`
+	ReportedAt         *time.Time `json:"reported_at"`
+	ReportedState      []byte     `json:"reported_state"`
+	Connectivity       *string    `json:"connectivity"`
+	MaintenanceWindows []byte     `json:"maintenance_windows"`
+	TimeZone           *string    `json:"time_zone"`
+	ApplyNowUntil      *time.Time `json:"apply_now_until"`
+	RelayTokenHash     *string    `json:"relay_token_hash"`
 }
 
 // GetDeviceByName implements Querier.GetDeviceByName.
@@ -295,7 +1098,7 @@ func (q *DBQuerier) GetDeviceByName(ctx context.Context, name *string) (GetDevic
 	ctx = context.WithValue(ctx, "pggen_query_name", "GetDeviceByName")
 	row := q.conn.QueryRow(ctx, getDeviceByNameSQL, name)
 	var item GetDeviceByNameRow
-	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.FleetID); err != nil {
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.FleetID, &item.AgentVersion, &item.LastSeenAt, &item.RemoteAddress, &item.ReportedAt, &item.ReportedState, &item.Connectivity, &item.MaintenanceWindows, &item.TimeZone, &item.ApplyNowUntil, &item.RelayTokenHash); err != nil {
 		return item, fmt.Errorf("query GetDeviceByName: %w", err)
 	}
 	return item, nil
@@ -310,12 +1113,3517 @@ func (q *DBQuerier) GetDeviceByNameBatch(batch genericBatch, name *string) {
 func (q *DBQuerier) GetDeviceByNameScan(results pgx.BatchResults) (GetDeviceByNameRow, error) {
 	row := results.QueryRow()
 	var item GetDeviceByNameRow
-	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.FleetID); err != nil {
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.FleetID, &item.AgentVersion, &item.LastSeenAt, &item.RemoteAddress, &item.ReportedAt, &item.ReportedState, &item.Connectivity, &item.MaintenanceWindows, &item.TimeZone, &item.ApplyNowUntil, &item.RelayTokenHash); err != nil {
 		return item, fmt.Errorf("scan GetDeviceByNameBatch row: %w", err)
 	}
 	return item, nil
 }
 
+const insertDeviceRollbackSQL = `INSERT INTO device_rollback (id, device_id, failed_schedule_id, failed_schedule_version, restored_schedule_id, reason)
+VALUES (gen_random_uuid(), $1, $2, $3, $4, $5);`
+
+type InsertDeviceRollbackParams struct {
+	DeviceID              uuid.UUID
+	FailedScheduleID      uuid.UUID
+	FailedScheduleVersion *string
+	RestoredScheduleID    uuid.UUID
+	Reason                *string
+}
+
+// InsertDeviceRollback implements Querier.InsertDeviceRollback.
+func (q *DBQuerier) InsertDeviceRollback(ctx context.Context, params InsertDeviceRollbackParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertDeviceRollback")
+	cmdTag, err := q.conn.Exec(ctx, insertDeviceRollbackSQL, params.DeviceID, params.FailedScheduleID, params.FailedScheduleVersion, params.RestoredScheduleID, params.Reason)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query InsertDeviceRollback: %w", err)
+	}
+	return cmdTag, err
+}
+
+// InsertDeviceRollbackBatch implements Querier.InsertDeviceRollbackBatch.
+func (q *DBQuerier) InsertDeviceRollbackBatch(batch genericBatch, params InsertDeviceRollbackParams) {
+	batch.Queue(insertDeviceRollbackSQL, params.DeviceID, params.FailedScheduleID, params.FailedScheduleVersion, params.RestoredScheduleID, params.Reason)
+}
+
+// InsertDeviceRollbackScan implements Querier.InsertDeviceRollbackScan.
+func (q *DBQuerier) InsertDeviceRollbackScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec InsertDeviceRollbackBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const listSchedulesForOrganizationSQL = `SELECT s.*
+FROM schedule AS s
+WHERE s.organization_id = $1
+ORDER BY s.name, s.created_at;`
+
+type ListSchedulesForOrganizationRow struct {
+	ID             uuid.UUID  `json:"id"`
+	Name           *string    `json:"name"`
+	State          *string    `json:"state"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+}
+
+// ListSchedulesForOrganization implements Querier.ListSchedulesForOrganization.
+func (q *DBQuerier) ListSchedulesForOrganization(ctx context.Context, organizationID uuid.UUID) ([]ListSchedulesForOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "ListSchedulesForOrganization")
+	rows, err := q.conn.Query(ctx, listSchedulesForOrganizationSQL, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("query ListSchedulesForOrganization: %w", err)
+	}
+	defer rows.Close()
+	items := []ListSchedulesForOrganizationRow{}
+	for rows.Next() {
+		var item ListSchedulesForOrganizationRow
+		if err := rows.Scan(&item.ID, &item.Name, &item.State, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID); err != nil {
+			return nil, fmt.Errorf("scan ListSchedulesForOrganization row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListSchedulesForOrganization rows: %w", err)
+	}
+	return items, err
+}
+
+// ListSchedulesForOrganizationBatch implements Querier.ListSchedulesForOrganizationBatch.
+func (q *DBQuerier) ListSchedulesForOrganizationBatch(batch genericBatch, organizationID uuid.UUID) {
+	batch.Queue(listSchedulesForOrganizationSQL, organizationID)
+}
+
+// ListSchedulesForOrganizationScan implements Querier.ListSchedulesForOrganizationScan.
+func (q *DBQuerier) ListSchedulesForOrganizationScan(results pgx.BatchResults) ([]ListSchedulesForOrganizationRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query ListSchedulesForOrganizationBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []ListSchedulesForOrganizationRow{}
+	for rows.Next() {
+		var item ListSchedulesForOrganizationRow
+		if err := rows.Scan(&item.ID, &item.Name, &item.State, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID); err != nil {
+			return nil, fmt.Errorf("scan ListSchedulesForOrganizationBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListSchedulesForOrganizationBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const listSchedulesByNameForOrganizationSQL = `SELECT s.*
+FROM schedule AS s
+WHERE s.name = $1
+  AND s.organization_id = $2
+ORDER BY s.created_at;`
+
+type ListSchedulesByNameForOrganizationRow struct {
+	ID             uuid.UUID  `json:"id"`
+	Name           *string    `json:"name"`
+	State          *string    `json:"state"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+}
+
+// ListSchedulesByNameForOrganization implements Querier.ListSchedulesByNameForOrganization.
+func (q *DBQuerier) ListSchedulesByNameForOrganization(ctx context.Context, name *string, organizationID uuid.UUID) ([]ListSchedulesByNameForOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "ListSchedulesByNameForOrganization")
+	rows, err := q.conn.Query(ctx, listSchedulesByNameForOrganizationSQL, name, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("query ListSchedulesByNameForOrganization: %w", err)
+	}
+	defer rows.Close()
+	items := []ListSchedulesByNameForOrganizationRow{}
+	for rows.Next() {
+		var item ListSchedulesByNameForOrganizationRow
+		if err := rows.Scan(&item.ID, &item.Name, &item.State, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID); err != nil {
+			return nil, fmt.Errorf("scan ListSchedulesByNameForOrganization row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListSchedulesByNameForOrganization rows: %w", err)
+	}
+	return items, err
+}
+
+// ListSchedulesByNameForOrganizationBatch implements Querier.ListSchedulesByNameForOrganizationBatch.
+func (q *DBQuerier) ListSchedulesByNameForOrganizationBatch(batch genericBatch, name *string, organizationID uuid.UUID) {
+	batch.Queue(listSchedulesByNameForOrganizationSQL, name, organizationID)
+}
+
+// ListSchedulesByNameForOrganizationScan implements Querier.ListSchedulesByNameForOrganizationScan.
+func (q *DBQuerier) ListSchedulesByNameForOrganizationScan(results pgx.BatchResults) ([]ListSchedulesByNameForOrganizationRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query ListSchedulesByNameForOrganizationBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []ListSchedulesByNameForOrganizationRow{}
+	for rows.Next() {
+		var item ListSchedulesByNameForOrganizationRow
+		if err := rows.Scan(&item.ID, &item.Name, &item.State, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID); err != nil {
+			return nil, fmt.Errorf("scan ListSchedulesByNameForOrganizationBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListSchedulesByNameForOrganizationBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const getScheduleForOrganizationSQL = `SELECT s.*
+FROM schedule AS s
+WHERE s.id = $1
+  AND s.organization_id = $2;`
+
+type GetScheduleForOrganizationRow struct {
+	ID             uuid.UUID  `json:"id"`
+	Name           *string    `json:"name"`
+	State          *string    `json:"state"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+}
+
+// GetScheduleForOrganization implements Querier.GetScheduleForOrganization.
+func (q *DBQuerier) GetScheduleForOrganization(ctx context.Context, scheduleID uuid.UUID, organizationID uuid.UUID) (GetScheduleForOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetScheduleForOrganization")
+	row := q.conn.QueryRow(ctx, getScheduleForOrganizationSQL, scheduleID, organizationID)
+	var item GetScheduleForOrganizationRow
+	if err := row.Scan(&item.ID, &item.Name, &item.State, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID); err != nil {
+		return item, fmt.Errorf("query GetScheduleForOrganization: %w", err)
+	}
+	return item, nil
+}
+
+// GetScheduleForOrganizationBatch implements Querier.GetScheduleForOrganizationBatch.
+func (q *DBQuerier) GetScheduleForOrganizationBatch(batch genericBatch, scheduleID uuid.UUID, organizationID uuid.UUID) {
+	batch.Queue(getScheduleForOrganizationSQL, scheduleID, organizationID)
+}
+
+// GetScheduleForOrganizationScan implements Querier.GetScheduleForOrganizationScan.
+func (q *DBQuerier) GetScheduleForOrganizationScan(results pgx.BatchResults) (GetScheduleForOrganizationRow, error) {
+	row := results.QueryRow()
+	var item GetScheduleForOrganizationRow
+	if err := row.Scan(&item.ID, &item.Name, &item.State, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID); err != nil {
+		return item, fmt.Errorf("scan GetScheduleForOrganizationBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const insertScheduleSQL = `INSERT INTO schedule (id, name, state, created_at, updated_at, organization_id)
+VALUES (gen_random_uuid(), $1, $2, now(), now(), $3)
+RETURNING *;`
+
+type InsertScheduleParams struct {
+	Name           *string
+	State          *string
+	OrganizationID uuid.UUID
+}
+
+type InsertScheduleRow struct {
+	ID             uuid.UUID  `json:"id"`
+	Name           *string    `json:"name"`
+	State          *string    `json:"state"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+}
+
+// InsertSchedule implements Querier.InsertSchedule.
+func (q *DBQuerier) InsertSchedule(ctx context.Context, params InsertScheduleParams) (InsertScheduleRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertSchedule")
+	row := q.conn.QueryRow(ctx, insertScheduleSQL, params.Name, params.State, params.OrganizationID)
+	var item InsertScheduleRow
+	if err := row.Scan(&item.ID, &item.Name, &item.State, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID); err != nil {
+		return item, fmt.Errorf("query InsertSchedule: %w", err)
+	}
+	return item, nil
+}
+
+// InsertScheduleBatch implements Querier.InsertScheduleBatch.
+func (q *DBQuerier) InsertScheduleBatch(batch genericBatch, params InsertScheduleParams) {
+	batch.Queue(insertScheduleSQL, params.Name, params.State, params.OrganizationID)
+}
+
+// InsertScheduleScan implements Querier.InsertScheduleScan.
+func (q *DBQuerier) InsertScheduleScan(results pgx.BatchResults) (InsertScheduleRow, error) {
+	row := results.QueryRow()
+	var item InsertScheduleRow
+	if err := row.Scan(&item.ID, &item.Name, &item.State, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID); err != nil {
+		return item, fmt.Errorf("scan InsertScheduleBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const updateScheduleSQL = `UPDATE schedule
+SET name       = $1,
+    state      = $2,
+    updated_at = now()
+WHERE id = $3
+  AND organization_id = $4
+RETURNING *;`
+
+type UpdateScheduleParams struct {
+	Name           *string
+	State          *string
+	ScheduleID     uuid.UUID
+	OrganizationID uuid.UUID
+}
+
+type UpdateScheduleRow struct {
+	ID             uuid.UUID  `json:"id"`
+	Name           *string    `json:"name"`
+	State          *string    `json:"state"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+}
+
+// UpdateSchedule implements Querier.UpdateSchedule.
+func (q *DBQuerier) UpdateSchedule(ctx context.Context, params UpdateScheduleParams) (UpdateScheduleRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateSchedule")
+	row := q.conn.QueryRow(ctx, updateScheduleSQL, params.Name, params.State, params.ScheduleID, params.OrganizationID)
+	var item UpdateScheduleRow
+	if err := row.Scan(&item.ID, &item.Name, &item.State, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID); err != nil {
+		return item, fmt.Errorf("query UpdateSchedule: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateScheduleBatch implements Querier.UpdateScheduleBatch.
+func (q *DBQuerier) UpdateScheduleBatch(batch genericBatch, params UpdateScheduleParams) {
+	batch.Queue(updateScheduleSQL, params.Name, params.State, params.ScheduleID, params.OrganizationID)
+}
+
+// UpdateScheduleScan implements Querier.UpdateScheduleScan.
+func (q *DBQuerier) UpdateScheduleScan(results pgx.BatchResults) (UpdateScheduleRow, error) {
+	row := results.QueryRow()
+	var item UpdateScheduleRow
+	if err := row.Scan(&item.ID, &item.Name, &item.State, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID); err != nil {
+		return item, fmt.Errorf("scan UpdateScheduleBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const touchScheduleSQL = `UPDATE schedule
+SET updated_at = now()
+WHERE id = $1;`
+
+// TouchSchedule implements Querier.TouchSchedule.
+func (q *DBQuerier) TouchSchedule(ctx context.Context, scheduleID uuid.UUID) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "TouchSchedule")
+	cmdTag, err := q.conn.Exec(ctx, touchScheduleSQL, scheduleID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query TouchSchedule: %w", err)
+	}
+	return cmdTag, err
+}
+
+// TouchScheduleBatch implements Querier.TouchScheduleBatch.
+func (q *DBQuerier) TouchScheduleBatch(batch genericBatch, scheduleID uuid.UUID) {
+	batch.Queue(touchScheduleSQL, scheduleID)
+}
+
+// TouchScheduleScan implements Querier.TouchScheduleScan.
+func (q *DBQuerier) TouchScheduleScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec TouchScheduleBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const deleteScheduleSQL = `DELETE FROM schedule
+WHERE id = $1
+  AND organization_id = $2;`
+
+// DeleteSchedule implements Querier.DeleteSchedule.
+func (q *DBQuerier) DeleteSchedule(ctx context.Context, scheduleID uuid.UUID, organizationID uuid.UUID) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteSchedule")
+	cmdTag, err := q.conn.Exec(ctx, deleteScheduleSQL, scheduleID, organizationID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query DeleteSchedule: %w", err)
+	}
+	return cmdTag, err
+}
+
+// DeleteScheduleBatch implements Querier.DeleteScheduleBatch.
+func (q *DBQuerier) DeleteScheduleBatch(batch genericBatch, scheduleID uuid.UUID, organizationID uuid.UUID) {
+	batch.Queue(deleteScheduleSQL, scheduleID, organizationID)
+}
+
+// DeleteScheduleScan implements Querier.DeleteScheduleScan.
+func (q *DBQuerier) DeleteScheduleScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec DeleteScheduleBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const countFleetsUsingScheduleSQL = `SELECT count(*)
+FROM fleet AS f
+WHERE f.default_schedule_id = $1;`
+
+// CountFleetsUsingSchedule implements Querier.CountFleetsUsingSchedule.
+func (q *DBQuerier) CountFleetsUsingSchedule(ctx context.Context, scheduleID uuid.UUID) (*int, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "CountFleetsUsingSchedule")
+	row := q.conn.QueryRow(ctx, countFleetsUsingScheduleSQL, scheduleID)
+	var item *int
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query CountFleetsUsingSchedule: %w", err)
+	}
+	return item, nil
+}
+
+// CountFleetsUsingScheduleBatch implements Querier.CountFleetsUsingScheduleBatch.
+func (q *DBQuerier) CountFleetsUsingScheduleBatch(batch genericBatch, scheduleID uuid.UUID) {
+	batch.Queue(countFleetsUsingScheduleSQL, scheduleID)
+}
+
+// CountFleetsUsingScheduleScan implements Querier.CountFleetsUsingScheduleScan.
+func (q *DBQuerier) CountFleetsUsingScheduleScan(results pgx.BatchResults) (*int, error) {
+	row := results.QueryRow()
+	var item *int
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan CountFleetsUsingScheduleBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const getContainersForSchedulesSQL = `SELECT c.*
+FROM container AS c
+WHERE c.schedule_id = ANY($1::uuid[])
+ORDER BY c.name;`
+
+type GetContainersForSchedulesRow struct {
+	ID                     uuid.UUID  `json:"id"`
+	CreatedAt              *time.Time `json:"created_at"`
+	UpdatedAt              *time.Time `json:"updated_at"`
+	Name                   *string    `json:"name"`
+	ContainerImage         *string    `json:"container_image"`
+	Env                    []byte     `json:"env"`
+	Privileged             bool       `json:"privileged"`
+	NetworkMode            *string    `json:"network_mode"`
+	Ports                  []byte     `json:"ports"`
+	BindDev                bool       `json:"bind_dev"`
+	BindProc               bool       `json:"bind_proc"`
+	BindSys                bool       `json:"bind_sys"`
+	BindShm                bool       `json:"bind_shm"`
+	BindCgroup             bool       `json:"bind_cgroup"`
+	BindDockerSocket       bool       `json:"bind_docker_socket"`
+	BindBoot               bool       `json:"bind_boot"`
+	Command                *string    `json:"command"`
+	Entrypoint             *string    `json:"entrypoint"`
+	ScheduleID             uuid.UUID  `json:"schedule_id"`
+	StopSignal             *string    `json:"stop_signal"`
+	StopGracePeriodSeconds int32      `json:"stop_grace_period_seconds"`
+	UpdateStrategy         *string    `json:"update_strategy"`
+	Job                    []byte     `json:"job"`
+}
+
+// GetContainersForSchedules implements Querier.GetContainersForSchedules.
+func (q *DBQuerier) GetContainersForSchedules(ctx context.Context, scheduleIDs []uuid.UUID) ([]GetContainersForSchedulesRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetContainersForSchedules")
+	rows, err := q.conn.Query(ctx, getContainersForSchedulesSQL, scheduleIDs)
+	if err != nil {
+		return nil, fmt.Errorf("query GetContainersForSchedules: %w", err)
+	}
+	defer rows.Close()
+	items := []GetContainersForSchedulesRow{}
+	for rows.Next() {
+		var item GetContainersForSchedulesRow
+		if err := rows.Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.ContainerImage, &item.Env, &item.Privileged, &item.NetworkMode, &item.Ports, &item.BindDev, &item.BindProc, &item.BindSys, &item.BindShm, &item.BindCgroup, &item.BindDockerSocket, &item.BindBoot, &item.Command, &item.Entrypoint, &item.ScheduleID, &item.StopSignal, &item.StopGracePeriodSeconds, &item.UpdateStrategy, &item.Job); err != nil {
+			return nil, fmt.Errorf("scan GetContainersForSchedules row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close GetContainersForSchedules rows: %w", err)
+	}
+	return items, err
+}
+
+// GetContainersForSchedulesBatch implements Querier.GetContainersForSchedulesBatch.
+func (q *DBQuerier) GetContainersForSchedulesBatch(batch genericBatch, scheduleIDs []uuid.UUID) {
+	batch.Queue(getContainersForSchedulesSQL, scheduleIDs)
+}
+
+// GetContainersForSchedulesScan implements Querier.GetContainersForSchedulesScan.
+func (q *DBQuerier) GetContainersForSchedulesScan(results pgx.BatchResults) ([]GetContainersForSchedulesRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query GetContainersForSchedulesBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []GetContainersForSchedulesRow{}
+	for rows.Next() {
+		var item GetContainersForSchedulesRow
+		if err := rows.Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.ContainerImage, &item.Env, &item.Privileged, &item.NetworkMode, &item.Ports, &item.BindDev, &item.BindProc, &item.BindSys, &item.BindShm, &item.BindCgroup, &item.BindDockerSocket, &item.BindBoot, &item.Command, &item.Entrypoint, &item.ScheduleID, &item.StopSignal, &item.StopGracePeriodSeconds, &item.UpdateStrategy, &item.Job); err != nil {
+			return nil, fmt.Errorf("scan GetContainersForSchedulesBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close GetContainersForSchedulesBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const getContainerForOrganizationSQL = `SELECT c.*
+FROM container AS c
+JOIN schedule AS s ON s.id = c.schedule_id
+WHERE c.id = $1
+  AND s.organization_id = $2;`
+
+type GetContainerForOrganizationRow struct {
+	ID                     uuid.UUID  `json:"id"`
+	CreatedAt              *time.Time `json:"created_at"`
+	UpdatedAt              *time.Time `json:"updated_at"`
+	Name                   *string    `json:"name"`
+	ContainerImage         *string    `json:"container_image"`
+	Env                    []byte     `json:"env"`
+	Privileged             bool       `json:"privileged"`
+	NetworkMode            *string    `json:"network_mode"`
+	Ports                  []byte     `json:"ports"`
+	BindDev                bool       `json:"bind_dev"`
+	BindProc               bool       `json:"bind_proc"`
+	BindSys                bool       `json:"bind_sys"`
+	BindShm                bool       `json:"bind_shm"`
+	BindCgroup             bool       `json:"bind_cgroup"`
+	BindDockerSocket       bool       `json:"bind_docker_socket"`
+	BindBoot               bool       `json:"bind_boot"`
+	Command                *string    `json:"command"`
+	Entrypoint             *string    `json:"entrypoint"`
+	ScheduleID             uuid.UUID  `json:"schedule_id"`
+	StopSignal             *string    `json:"stop_signal"`
+	StopGracePeriodSeconds int32      `json:"stop_grace_period_seconds"`
+	UpdateStrategy         *string    `json:"update_strategy"`
+	Job                    []byte     `json:"job"`
+}
+
+// GetContainerForOrganization implements Querier.GetContainerForOrganization.
+func (q *DBQuerier) GetContainerForOrganization(ctx context.Context, containerID uuid.UUID, organizationID uuid.UUID) (GetContainerForOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetContainerForOrganization")
+	row := q.conn.QueryRow(ctx, getContainerForOrganizationSQL, containerID, organizationID)
+	var item GetContainerForOrganizationRow
+	if err := row.Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.ContainerImage, &item.Env, &item.Privileged, &item.NetworkMode, &item.Ports, &item.BindDev, &item.BindProc, &item.BindSys, &item.BindShm, &item.BindCgroup, &item.BindDockerSocket, &item.BindBoot, &item.Command, &item.Entrypoint, &item.ScheduleID, &item.StopSignal, &item.StopGracePeriodSeconds, &item.UpdateStrategy, &item.Job); err != nil {
+		return item, fmt.Errorf("query GetContainerForOrganization: %w", err)
+	}
+	return item, nil
+}
+
+// GetContainerForOrganizationBatch implements Querier.GetContainerForOrganizationBatch.
+func (q *DBQuerier) GetContainerForOrganizationBatch(batch genericBatch, containerID uuid.UUID, organizationID uuid.UUID) {
+	batch.Queue(getContainerForOrganizationSQL, containerID, organizationID)
+}
+
+// GetContainerForOrganizationScan implements Querier.GetContainerForOrganizationScan.
+func (q *DBQuerier) GetContainerForOrganizationScan(results pgx.BatchResults) (GetContainerForOrganizationRow, error) {
+	row := results.QueryRow()
+	var item GetContainerForOrganizationRow
+	if err := row.Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.ContainerImage, &item.Env, &item.Privileged, &item.NetworkMode, &item.Ports, &item.BindDev, &item.BindProc, &item.BindSys, &item.BindShm, &item.BindCgroup, &item.BindDockerSocket, &item.BindBoot, &item.Command, &item.Entrypoint, &item.ScheduleID, &item.StopSignal, &item.StopGracePeriodSeconds, &item.UpdateStrategy, &item.Job); err != nil {
+		return item, fmt.Errorf("scan GetContainerForOrganizationBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const insertContainerSQL = `INSERT INTO container (id, created_at, updated_at, schedule_id, name, container_image, env, privileged, network_mode, ports,
+                       bind_dev, bind_proc, bind_sys, bind_shm, bind_cgroup, bind_docker_socket, bind_boot,
+                       command, entrypoint, stop_signal, stop_grace_period_seconds, update_strategy, job)
+VALUES (gen_random_uuid(), now(), now(), $1, $2, $3,
+        $4, $5, $6, $7,
+        $8, $9, $10, $11,
+        $12, $13, $14,
+        $15, $16, $17, $18,
+        $19, $20)
+RETURNING *;`
+
+type InsertContainerParams struct {
+	ScheduleID             uuid.UUID
+	Name                   *string
+	ContainerImage         *string
+	Env                    []byte
+	Privileged             bool
+	NetworkMode            *string
+	Ports                  []byte
+	BindDev                bool
+	BindProc               bool
+	BindSys                bool
+	BindShm                bool
+	BindCgroup             bool
+	BindDockerSocket       bool
+	BindBoot               bool
+	Command                *string
+	Entrypoint             *string
+	StopSignal             *string
+	StopGracePeriodSeconds int32
+	UpdateStrategy         *string
+	Job                    []byte
+}
+
+type InsertContainerRow struct {
+	ID                     uuid.UUID  `json:"id"`
+	CreatedAt              *time.Time `json:"created_at"`
+	UpdatedAt              *time.Time `json:"updated_at"`
+	Name                   *string    `json:"name"`
+	ContainerImage         *string    `json:"container_image"`
+	Env                    []byte     `json:"env"`
+	Privileged             bool       `json:"privileged"`
+	NetworkMode            *string    `json:"network_mode"`
+	Ports                  []byte     `json:"ports"`
+	BindDev                bool       `json:"bind_dev"`
+	BindProc               bool       `json:"bind_proc"`
+	BindSys                bool       `json:"bind_sys"`
+	BindShm                bool       `json:"bind_shm"`
+	BindCgroup             bool       `json:"bind_cgroup"`
+	BindDockerSocket       bool       `json:"bind_docker_socket"`
+	BindBoot               bool       `json:"bind_boot"`
+	Command                *string    `json:"command"`
+	Entrypoint             *string    `json:"entrypoint"`
+	ScheduleID             uuid.UUID  `json:"schedule_id"`
+	StopSignal             *string    `json:"stop_signal"`
+	StopGracePeriodSeconds int32      `json:"stop_grace_period_seconds"`
+	UpdateStrategy         *string    `json:"update_strategy"`
+	Job                    []byte     `json:"job"`
+}
+
+// InsertContainer implements Querier.InsertContainer.
+func (q *DBQuerier) InsertContainer(ctx context.Context, params InsertContainerParams) (InsertContainerRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertContainer")
+	row := q.conn.QueryRow(ctx, insertContainerSQL, params.ScheduleID, params.Name, params.ContainerImage, params.Env, params.Privileged, params.NetworkMode, params.Ports, params.BindDev, params.BindProc, params.BindSys, params.BindShm, params.BindCgroup, params.BindDockerSocket, params.BindBoot, params.Command, params.Entrypoint, params.StopSignal, params.StopGracePeriodSeconds, params.UpdateStrategy, params.Job)
+	var item InsertContainerRow
+	if err := row.Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.ContainerImage, &item.Env, &item.Privileged, &item.NetworkMode, &item.Ports, &item.BindDev, &item.BindProc, &item.BindSys, &item.BindShm, &item.BindCgroup, &item.BindDockerSocket, &item.BindBoot, &item.Command, &item.Entrypoint, &item.ScheduleID, &item.StopSignal, &item.StopGracePeriodSeconds, &item.UpdateStrategy, &item.Job); err != nil {
+		return item, fmt.Errorf("query InsertContainer: %w", err)
+	}
+	return item, nil
+}
+
+// InsertContainerBatch implements Querier.InsertContainerBatch.
+func (q *DBQuerier) InsertContainerBatch(batch genericBatch, params InsertContainerParams) {
+	batch.Queue(insertContainerSQL, params.ScheduleID, params.Name, params.ContainerImage, params.Env, params.Privileged, params.NetworkMode, params.Ports, params.BindDev, params.BindProc, params.BindSys, params.BindShm, params.BindCgroup, params.BindDockerSocket, params.BindBoot, params.Command, params.Entrypoint, params.StopSignal, params.StopGracePeriodSeconds, params.UpdateStrategy, params.Job)
+}
+
+// InsertContainerScan implements Querier.InsertContainerScan.
+func (q *DBQuerier) InsertContainerScan(results pgx.BatchResults) (InsertContainerRow, error) {
+	row := results.QueryRow()
+	var item InsertContainerRow
+	if err := row.Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.ContainerImage, &item.Env, &item.Privileged, &item.NetworkMode, &item.Ports, &item.BindDev, &item.BindProc, &item.BindSys, &item.BindShm, &item.BindCgroup, &item.BindDockerSocket, &item.BindBoot, &item.Command, &item.Entrypoint, &item.ScheduleID, &item.StopSignal, &item.StopGracePeriodSeconds, &item.UpdateStrategy, &item.Job); err != nil {
+		return item, fmt.Errorf("scan InsertContainerBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const updateContainerSQL = `UPDATE container
+SET name                      = $1,
+    container_image           = $2,
+    env                       = $3,
+    privileged                = $4,
+    network_mode              = $5,
+    ports                     = $6,
+    bind_dev                  = $7,
+    bind_proc                 = $8,
+    bind_sys                  = $9,
+    bind_shm                  = $10,
+    bind_cgroup               = $11,
+    bind_docker_socket        = $12,
+    bind_boot                 = $13,
+    command                   = $14,
+    entrypoint                = $15,
+    stop_signal               = $16,
+    stop_grace_period_seconds = $17,
+    update_strategy           = $18,
+    job                       = $19,
+    updated_at                = now()
+WHERE id = $20
+RETURNING *;`
+
+type UpdateContainerParams struct {
+	Name                   *string
+	ContainerImage         *string
+	Env                    []byte
+	Privileged             bool
+	NetworkMode            *string
+	Ports                  []byte
+	BindDev                bool
+	BindProc               bool
+	BindSys                bool
+	BindShm                bool
+	BindCgroup             bool
+	BindDockerSocket       bool
+	BindBoot               bool
+	Command                *string
+	Entrypoint             *string
+	StopSignal             *string
+	StopGracePeriodSeconds int32
+	UpdateStrategy         *string
+	Job                    []byte
+	ContainerID            uuid.UUID
+}
+
+type UpdateContainerRow struct {
+	ID                     uuid.UUID  `json:"id"`
+	CreatedAt              *time.Time `json:"created_at"`
+	UpdatedAt              *time.Time `json:"updated_at"`
+	Name                   *string    `json:"name"`
+	ContainerImage         *string    `json:"container_image"`
+	Env                    []byte     `json:"env"`
+	Privileged             bool       `json:"privileged"`
+	NetworkMode            *string    `json:"network_mode"`
+	Ports                  []byte     `json:"ports"`
+	BindDev                bool       `json:"bind_dev"`
+	BindProc               bool       `json:"bind_proc"`
+	BindSys                bool       `json:"bind_sys"`
+	BindShm                bool       `json:"bind_shm"`
+	BindCgroup             bool       `json:"bind_cgroup"`
+	BindDockerSocket       bool       `json:"bind_docker_socket"`
+	BindBoot               bool       `json:"bind_boot"`
+	Command                *string    `json:"command"`
+	Entrypoint             *string    `json:"entrypoint"`
+	ScheduleID             uuid.UUID  `json:"schedule_id"`
+	StopSignal             *string    `json:"stop_signal"`
+	StopGracePeriodSeconds int32      `json:"stop_grace_period_seconds"`
+	UpdateStrategy         *string    `json:"update_strategy"`
+	Job                    []byte     `json:"job"`
+}
+
+// UpdateContainer implements Querier.UpdateContainer.
+func (q *DBQuerier) UpdateContainer(ctx context.Context, params UpdateContainerParams) (UpdateContainerRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateContainer")
+	row := q.conn.QueryRow(ctx, updateContainerSQL, params.Name, params.ContainerImage, params.Env, params.Privileged, params.NetworkMode, params.Ports, params.BindDev, params.BindProc, params.BindSys, params.BindShm, params.BindCgroup, params.BindDockerSocket, params.BindBoot, params.Command, params.Entrypoint, params.StopSignal, params.StopGracePeriodSeconds, params.UpdateStrategy, params.Job, params.ContainerID)
+	var item UpdateContainerRow
+	if err := row.Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.ContainerImage, &item.Env, &item.Privileged, &item.NetworkMode, &item.Ports, &item.BindDev, &item.BindProc, &item.BindSys, &item.BindShm, &item.BindCgroup, &item.BindDockerSocket, &item.BindBoot, &item.Command, &item.Entrypoint, &item.ScheduleID, &item.StopSignal, &item.StopGracePeriodSeconds, &item.UpdateStrategy, &item.Job); err != nil {
+		return item, fmt.Errorf("query UpdateContainer: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateContainerBatch implements Querier.UpdateContainerBatch.
+func (q *DBQuerier) UpdateContainerBatch(batch genericBatch, params UpdateContainerParams) {
+	batch.Queue(updateContainerSQL, params.Name, params.ContainerImage, params.Env, params.Privileged, params.NetworkMode, params.Ports, params.BindDev, params.BindProc, params.BindSys, params.BindShm, params.BindCgroup, params.BindDockerSocket, params.BindBoot, params.Command, params.Entrypoint, params.StopSignal, params.StopGracePeriodSeconds, params.UpdateStrategy, params.Job, params.ContainerID)
+}
+
+// UpdateContainerScan implements Querier.UpdateContainerScan.
+func (q *DBQuerier) UpdateContainerScan(results pgx.BatchResults) (UpdateContainerRow, error) {
+	row := results.QueryRow()
+	var item UpdateContainerRow
+	if err := row.Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt, &item.Name, &item.ContainerImage, &item.Env, &item.Privileged, &item.NetworkMode, &item.Ports, &item.BindDev, &item.BindProc, &item.BindSys, &item.BindShm, &item.BindCgroup, &item.BindDockerSocket, &item.BindBoot, &item.Command, &item.Entrypoint, &item.ScheduleID, &item.StopSignal, &item.StopGracePeriodSeconds, &item.UpdateStrategy, &item.Job); err != nil {
+		return item, fmt.Errorf("scan UpdateContainerBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const deleteContainerSQL = `DELETE FROM container
+WHERE id = $1;`
+
+// DeleteContainer implements Querier.DeleteContainer.
+func (q *DBQuerier) DeleteContainer(ctx context.Context, containerID uuid.UUID) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteContainer")
+	cmdTag, err := q.conn.Exec(ctx, deleteContainerSQL, containerID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query DeleteContainer: %w", err)
+	}
+	return cmdTag, err
+}
+
+// DeleteContainerBatch implements Querier.DeleteContainerBatch.
+func (q *DBQuerier) DeleteContainerBatch(batch genericBatch, containerID uuid.UUID) {
+	batch.Queue(deleteContainerSQL, containerID)
+}
+
+// DeleteContainerScan implements Querier.DeleteContainerScan.
+func (q *DBQuerier) DeleteContainerScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec DeleteContainerBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const deleteContainersForScheduleSQL = `DELETE FROM container
+WHERE schedule_id = $1;`
+
+// DeleteContainersForSchedule implements Querier.DeleteContainersForSchedule.
+func (q *DBQuerier) DeleteContainersForSchedule(ctx context.Context, scheduleID uuid.UUID) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteContainersForSchedule")
+	cmdTag, err := q.conn.Exec(ctx, deleteContainersForScheduleSQL, scheduleID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query DeleteContainersForSchedule: %w", err)
+	}
+	return cmdTag, err
+}
+
+// DeleteContainersForScheduleBatch implements Querier.DeleteContainersForScheduleBatch.
+func (q *DBQuerier) DeleteContainersForScheduleBatch(batch genericBatch, scheduleID uuid.UUID) {
+	batch.Queue(deleteContainersForScheduleSQL, scheduleID)
+}
+
+// DeleteContainersForScheduleScan implements Querier.DeleteContainersForScheduleScan.
+func (q *DBQuerier) DeleteContainersForScheduleScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec DeleteContainersForScheduleBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const getUserByEmailSQL = `SELECT u.*
+FROM "user" AS u
+WHERE u.email = $1;`
+
+type GetUserByEmailRow struct {
+	ID         uuid.UUID  `json:"id"`
+	Email      *string    `json:"email"`
+	GivenName  *string    `json:"given_name"`
+	FamilyName *string    `json:"family_name"`
+	CreatedAt  *time.Time `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
+
+// GetUserByEmail implements Querier.GetUserByEmail.
+func (q *DBQuerier) GetUserByEmail(ctx context.Context, email *string) (GetUserByEmailRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetUserByEmail")
+	row := q.conn.QueryRow(ctx, getUserByEmailSQL, email)
+	var item GetUserByEmailRow
+	if err := row.Scan(&item.ID, &item.Email, &item.GivenName, &item.FamilyName, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		return item, fmt.Errorf("query GetUserByEmail: %w", err)
+	}
+	return item, nil
+}
+
+// GetUserByEmailBatch implements Querier.GetUserByEmailBatch.
+func (q *DBQuerier) GetUserByEmailBatch(batch genericBatch, email *string) {
+	batch.Queue(getUserByEmailSQL, email)
+}
+
+// GetUserByEmailScan implements Querier.GetUserByEmailScan.
+func (q *DBQuerier) GetUserByEmailScan(results pgx.BatchResults) (GetUserByEmailRow, error) {
+	row := results.QueryRow()
+	var item GetUserByEmailRow
+	if err := row.Scan(&item.ID, &item.Email, &item.GivenName, &item.FamilyName, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		return item, fmt.Errorf("scan GetUserByEmailBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const getUserByIDSQL = `SELECT u.*
+FROM "user" AS u
+WHERE u.id = $1;`
+
+type GetUserByIDRow struct {
+	ID         uuid.UUID  `json:"id"`
+	Email      *string    `json:"email"`
+	GivenName  *string    `json:"given_name"`
+	FamilyName *string    `json:"family_name"`
+	CreatedAt  *time.Time `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
+
+// GetUserByID implements Querier.GetUserByID.
+func (q *DBQuerier) GetUserByID(ctx context.Context, userID uuid.UUID) (GetUserByIDRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetUserByID")
+	row := q.conn.QueryRow(ctx, getUserByIDSQL, userID)
+	var item GetUserByIDRow
+	if err := row.Scan(&item.ID, &item.Email, &item.GivenName, &item.FamilyName, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		return item, fmt.Errorf("query GetUserByID: %w", err)
+	}
+	return item, nil
+}
+
+// GetUserByIDBatch implements Querier.GetUserByIDBatch.
+func (q *DBQuerier) GetUserByIDBatch(batch genericBatch, userID uuid.UUID) {
+	batch.Queue(getUserByIDSQL, userID)
+}
+
+// GetUserByIDScan implements Querier.GetUserByIDScan.
+func (q *DBQuerier) GetUserByIDScan(results pgx.BatchResults) (GetUserByIDRow, error) {
+	row := results.QueryRow()
+	var item GetUserByIDRow
+	if err := row.Scan(&item.ID, &item.Email, &item.GivenName, &item.FamilyName, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		return item, fmt.Errorf("scan GetUserByIDBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const getOrganizationRoleSQL = `SELECT ou.role
+FROM organization_user AS ou
+WHERE ou.user_id = $1
+  AND ou.organization_id = $2;`
+
+// GetOrganizationRole implements Querier.GetOrganizationRole.
+func (q *DBQuerier) GetOrganizationRole(ctx context.Context, userID uuid.UUID, organizationID uuid.UUID) (*string, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetOrganizationRole")
+	row := q.conn.QueryRow(ctx, getOrganizationRoleSQL, userID, organizationID)
+	var item *string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query GetOrganizationRole: %w", err)
+	}
+	return item, nil
+}
+
+// GetOrganizationRoleBatch implements Querier.GetOrganizationRoleBatch.
+func (q *DBQuerier) GetOrganizationRoleBatch(batch genericBatch, userID uuid.UUID, organizationID uuid.UUID) {
+	batch.Queue(getOrganizationRoleSQL, userID, organizationID)
+}
+
+// GetOrganizationRoleScan implements Querier.GetOrganizationRoleScan.
+func (q *DBQuerier) GetOrganizationRoleScan(results pgx.BatchResults) (*string, error) {
+	row := results.QueryRow()
+	var item *string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan GetOrganizationRoleBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const listOrganizationsForUserSQL = `SELECT o.id, o.name, ou.role
+FROM organization AS o
+JOIN organization_user AS ou ON ou.organization_id = o.id
+WHERE ou.user_id = $1
+ORDER BY o.name;`
+
+type ListOrganizationsForUserRow struct {
+	ID   uuid.UUID `json:"id"`
+	Name *string   `json:"name"`
+	Role *string   `json:"role"`
+}
+
+// ListOrganizationsForUser implements Querier.ListOrganizationsForUser.
+func (q *DBQuerier) ListOrganizationsForUser(ctx context.Context, userID uuid.UUID) ([]ListOrganizationsForUserRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "ListOrganizationsForUser")
+	rows, err := q.conn.Query(ctx, listOrganizationsForUserSQL, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query ListOrganizationsForUser: %w", err)
+	}
+	defer rows.Close()
+	items := []ListOrganizationsForUserRow{}
+	for rows.Next() {
+		var item ListOrganizationsForUserRow
+		if err := rows.Scan(&item.ID, &item.Name, &item.Role); err != nil {
+			return nil, fmt.Errorf("scan ListOrganizationsForUser row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListOrganizationsForUser rows: %w", err)
+	}
+	return items, err
+}
+
+// ListOrganizationsForUserBatch implements Querier.ListOrganizationsForUserBatch.
+func (q *DBQuerier) ListOrganizationsForUserBatch(batch genericBatch, userID uuid.UUID) {
+	batch.Queue(listOrganizationsForUserSQL, userID)
+}
+
+// ListOrganizationsForUserScan implements Querier.ListOrganizationsForUserScan.
+func (q *DBQuerier) ListOrganizationsForUserScan(results pgx.BatchResults) ([]ListOrganizationsForUserRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query ListOrganizationsForUserBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []ListOrganizationsForUserRow{}
+	for rows.Next() {
+		var item ListOrganizationsForUserRow
+		if err := rows.Scan(&item.ID, &item.Name, &item.Role); err != nil {
+			return nil, fmt.Errorf("scan ListOrganizationsForUserBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListOrganizationsForUserBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const getOrganizationByIDSQL = `SELECT o.*
+FROM organization AS o
+WHERE o.id = $1;`
+
+type GetOrganizationByIDRow struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      *string    `json:"name"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// GetOrganizationByID implements Querier.GetOrganizationByID.
+func (q *DBQuerier) GetOrganizationByID(ctx context.Context, organizationID uuid.UUID) (GetOrganizationByIDRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetOrganizationByID")
+	row := q.conn.QueryRow(ctx, getOrganizationByIDSQL, organizationID)
+	var item GetOrganizationByIDRow
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		return item, fmt.Errorf("query GetOrganizationByID: %w", err)
+	}
+	return item, nil
+}
+
+// GetOrganizationByIDBatch implements Querier.GetOrganizationByIDBatch.
+func (q *DBQuerier) GetOrganizationByIDBatch(batch genericBatch, organizationID uuid.UUID) {
+	batch.Queue(getOrganizationByIDSQL, organizationID)
+}
+
+// GetOrganizationByIDScan implements Querier.GetOrganizationByIDScan.
+func (q *DBQuerier) GetOrganizationByIDScan(results pgx.BatchResults) (GetOrganizationByIDRow, error) {
+	row := results.QueryRow()
+	var item GetOrganizationByIDRow
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		return item, fmt.Errorf("scan GetOrganizationByIDBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const insertAPIKeySQL = `INSERT INTO api_key (id, organization_id, name, token_hash, created_by_user_id, role)
+VALUES (gen_random_uuid(), $1, $2, $3,
+        NULLIF($4, '00000000-0000-0000-0000-000000000000'::uuid), $5)
+RETURNING *;`
+
+type InsertAPIKeyParams struct {
+	OrganizationID  uuid.UUID
+	Name            *string
+	TokenHash       *string
+	CreatedByUserID uuid.UUID
+	Role            *string
+}
+
+type InsertAPIKeyRow struct {
+	ID              uuid.UUID  `json:"id"`
+	OrganizationID  uuid.UUID  `json:"organization_id"`
+	Name            *string    `json:"name"`
+	TokenHash       *string    `json:"token_hash"`
+	CreatedByUserID uuid.UUID  `json:"created_by_user_id"`
+	CreatedAt       *time.Time `json:"created_at"`
+	RevokedAt       *time.Time `json:"revoked_at"`
+	Role            *string    `json:"role"`
+}
+
+// InsertAPIKey implements Querier.InsertAPIKey.
+func (q *DBQuerier) InsertAPIKey(ctx context.Context, params InsertAPIKeyParams) (InsertAPIKeyRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertAPIKey")
+	row := q.conn.QueryRow(ctx, insertAPIKeySQL, params.OrganizationID, params.Name, params.TokenHash, params.CreatedByUserID, params.Role)
+	var item InsertAPIKeyRow
+	if err := row.Scan(&item.ID, &item.OrganizationID, &item.Name, &item.TokenHash, &item.CreatedByUserID, &item.CreatedAt, &item.RevokedAt, &item.Role); err != nil {
+		return item, fmt.Errorf("query InsertAPIKey: %w", err)
+	}
+	return item, nil
+}
+
+// InsertAPIKeyBatch implements Querier.InsertAPIKeyBatch.
+func (q *DBQuerier) InsertAPIKeyBatch(batch genericBatch, params InsertAPIKeyParams) {
+	batch.Queue(insertAPIKeySQL, params.OrganizationID, params.Name, params.TokenHash, params.CreatedByUserID, params.Role)
+}
+
+// InsertAPIKeyScan implements Querier.InsertAPIKeyScan.
+func (q *DBQuerier) InsertAPIKeyScan(results pgx.BatchResults) (InsertAPIKeyRow, error) {
+	row := results.QueryRow()
+	var item InsertAPIKeyRow
+	if err := row.Scan(&item.ID, &item.OrganizationID, &item.Name, &item.TokenHash, &item.CreatedByUserID, &item.CreatedAt, &item.RevokedAt, &item.Role); err != nil {
+		return item, fmt.Errorf("scan InsertAPIKeyBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const listAPIKeysForOrganizationSQL = `SELECT k.*
+FROM api_key AS k
+WHERE k.organization_id = $1
+ORDER BY k.created_at DESC;`
+
+type ListAPIKeysForOrganizationRow struct {
+	ID              uuid.UUID  `json:"id"`
+	OrganizationID  uuid.UUID  `json:"organization_id"`
+	Name            *string    `json:"name"`
+	TokenHash       *string    `json:"token_hash"`
+	CreatedByUserID uuid.UUID  `json:"created_by_user_id"`
+	CreatedAt       *time.Time `json:"created_at"`
+	RevokedAt       *time.Time `json:"revoked_at"`
+	Role            *string    `json:"role"`
+}
+
+// ListAPIKeysForOrganization implements Querier.ListAPIKeysForOrganization.
+func (q *DBQuerier) ListAPIKeysForOrganization(ctx context.Context, organizationID uuid.UUID) ([]ListAPIKeysForOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "ListAPIKeysForOrganization")
+	rows, err := q.conn.Query(ctx, listAPIKeysForOrganizationSQL, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("query ListAPIKeysForOrganization: %w", err)
+	}
+	defer rows.Close()
+	items := []ListAPIKeysForOrganizationRow{}
+	for rows.Next() {
+		var item ListAPIKeysForOrganizationRow
+		if err := rows.Scan(&item.ID, &item.OrganizationID, &item.Name, &item.TokenHash, &item.CreatedByUserID, &item.CreatedAt, &item.RevokedAt, &item.Role); err != nil {
+			return nil, fmt.Errorf("scan ListAPIKeysForOrganization row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListAPIKeysForOrganization rows: %w", err)
+	}
+	return items, err
+}
+
+// ListAPIKeysForOrganizationBatch implements Querier.ListAPIKeysForOrganizationBatch.
+func (q *DBQuerier) ListAPIKeysForOrganizationBatch(batch genericBatch, organizationID uuid.UUID) {
+	batch.Queue(listAPIKeysForOrganizationSQL, organizationID)
+}
+
+// ListAPIKeysForOrganizationScan implements Querier.ListAPIKeysForOrganizationScan.
+func (q *DBQuerier) ListAPIKeysForOrganizationScan(results pgx.BatchResults) ([]ListAPIKeysForOrganizationRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query ListAPIKeysForOrganizationBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []ListAPIKeysForOrganizationRow{}
+	for rows.Next() {
+		var item ListAPIKeysForOrganizationRow
+		if err := rows.Scan(&item.ID, &item.OrganizationID, &item.Name, &item.TokenHash, &item.CreatedByUserID, &item.CreatedAt, &item.RevokedAt, &item.Role); err != nil {
+			return nil, fmt.Errorf("scan ListAPIKeysForOrganizationBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListAPIKeysForOrganizationBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const revokeAPIKeySQL = `UPDATE api_key
+SET revoked_at = now()
+WHERE id = $1
+  AND organization_id = $2
+  AND revoked_at IS NULL;`
+
+// RevokeAPIKey implements Querier.RevokeAPIKey.
+func (q *DBQuerier) RevokeAPIKey(ctx context.Context, apiKeyID uuid.UUID, organizationID uuid.UUID) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "RevokeAPIKey")
+	cmdTag, err := q.conn.Exec(ctx, revokeAPIKeySQL, apiKeyID, organizationID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query RevokeAPIKey: %w", err)
+	}
+	return cmdTag, err
+}
+
+// RevokeAPIKeyBatch implements Querier.RevokeAPIKeyBatch.
+func (q *DBQuerier) RevokeAPIKeyBatch(batch genericBatch, apiKeyID uuid.UUID, organizationID uuid.UUID) {
+	batch.Queue(revokeAPIKeySQL, apiKeyID, organizationID)
+}
+
+// RevokeAPIKeyScan implements Querier.RevokeAPIKeyScan.
+func (q *DBQuerier) RevokeAPIKeyScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec RevokeAPIKeyBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const getActiveAPIKeyByTokenHashSQL = `SELECT k.*
+FROM api_key AS k
+WHERE k.token_hash = $1
+  AND k.revoked_at IS NULL;`
+
+type GetActiveAPIKeyByTokenHashRow struct {
+	ID              uuid.UUID  `json:"id"`
+	OrganizationID  uuid.UUID  `json:"organization_id"`
+	Name            *string    `json:"name"`
+	TokenHash       *string    `json:"token_hash"`
+	CreatedByUserID uuid.UUID  `json:"created_by_user_id"`
+	CreatedAt       *time.Time `json:"created_at"`
+	RevokedAt       *time.Time `json:"revoked_at"`
+	Role            *string    `json:"role"`
+}
+
+// GetActiveAPIKeyByTokenHash implements Querier.GetActiveAPIKeyByTokenHash.
+func (q *DBQuerier) GetActiveAPIKeyByTokenHash(ctx context.Context, tokenHash *string) (GetActiveAPIKeyByTokenHashRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetActiveAPIKeyByTokenHash")
+	row := q.conn.QueryRow(ctx, getActiveAPIKeyByTokenHashSQL, tokenHash)
+	var item GetActiveAPIKeyByTokenHashRow
+	if err := row.Scan(&item.ID, &item.OrganizationID, &item.Name, &item.TokenHash, &item.CreatedByUserID, &item.CreatedAt, &item.RevokedAt, &item.Role); err != nil {
+		return item, fmt.Errorf("query GetActiveAPIKeyByTokenHash: %w", err)
+	}
+	return item, nil
+}
+
+// GetActiveAPIKeyByTokenHashBatch implements Querier.GetActiveAPIKeyByTokenHashBatch.
+func (q *DBQuerier) GetActiveAPIKeyByTokenHashBatch(batch genericBatch, tokenHash *string) {
+	batch.Queue(getActiveAPIKeyByTokenHashSQL, tokenHash)
+}
+
+// GetActiveAPIKeyByTokenHashScan implements Querier.GetActiveAPIKeyByTokenHashScan.
+func (q *DBQuerier) GetActiveAPIKeyByTokenHashScan(results pgx.BatchResults) (GetActiveAPIKeyByTokenHashRow, error) {
+	row := results.QueryRow()
+	var item GetActiveAPIKeyByTokenHashRow
+	if err := row.Scan(&item.ID, &item.OrganizationID, &item.Name, &item.TokenHash, &item.CreatedByUserID, &item.CreatedAt, &item.RevokedAt, &item.Role); err != nil {
+		return item, fmt.Errorf("scan GetActiveAPIKeyByTokenHashBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const listFleetsForOrganizationSQL = `SELECT f.*, (SELECT count(*) FROM device AS d WHERE d.fleet_id = f.id) AS device_count
+FROM fleet AS f
+WHERE f.organization_id = $1
+ORDER BY f.name, f.created_at;`
+
+type ListFleetsForOrganizationRow struct {
+	ID                 uuid.UUID  `json:"id"`
+	Name               *string    `json:"name"`
+	CreatedAt          *time.Time `json:"created_at"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+	OrganizationID     uuid.UUID  `json:"organization_id"`
+	DefaultScheduleID  uuid.UUID  `json:"default_schedule_id"`
+	AgentImage         *string    `json:"agent_image"`
+	MaintenanceWindows []byte     `json:"maintenance_windows"`
+	TimeZone           *string    `json:"time_zone"`
+	DeviceCount        *int       `json:"device_count"`
+}
+
+// ListFleetsForOrganization implements Querier.ListFleetsForOrganization.
+func (q *DBQuerier) ListFleetsForOrganization(ctx context.Context, organizationID uuid.UUID) ([]ListFleetsForOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "ListFleetsForOrganization")
+	rows, err := q.conn.Query(ctx, listFleetsForOrganizationSQL, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("query ListFleetsForOrganization: %w", err)
+	}
+	defer rows.Close()
+	items := []ListFleetsForOrganizationRow{}
+	for rows.Next() {
+		var item ListFleetsForOrganizationRow
+		if err := rows.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID, &item.DefaultScheduleID, &item.AgentImage, &item.MaintenanceWindows, &item.TimeZone, &item.DeviceCount); err != nil {
+			return nil, fmt.Errorf("scan ListFleetsForOrganization row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListFleetsForOrganization rows: %w", err)
+	}
+	return items, err
+}
+
+// ListFleetsForOrganizationBatch implements Querier.ListFleetsForOrganizationBatch.
+func (q *DBQuerier) ListFleetsForOrganizationBatch(batch genericBatch, organizationID uuid.UUID) {
+	batch.Queue(listFleetsForOrganizationSQL, organizationID)
+}
+
+// ListFleetsForOrganizationScan implements Querier.ListFleetsForOrganizationScan.
+func (q *DBQuerier) ListFleetsForOrganizationScan(results pgx.BatchResults) ([]ListFleetsForOrganizationRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query ListFleetsForOrganizationBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []ListFleetsForOrganizationRow{}
+	for rows.Next() {
+		var item ListFleetsForOrganizationRow
+		if err := rows.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID, &item.DefaultScheduleID, &item.AgentImage, &item.MaintenanceWindows, &item.TimeZone, &item.DeviceCount); err != nil {
+			return nil, fmt.Errorf("scan ListFleetsForOrganizationBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListFleetsForOrganizationBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const getFleetForOrganizationSQL = `SELECT f.*, (SELECT count(*) FROM device AS d WHERE d.fleet_id = f.id) AS device_count
+FROM fleet AS f
+WHERE f.id = $1
+  AND f.organization_id = $2;`
+
+type GetFleetForOrganizationRow struct {
+	ID                 uuid.UUID  `json:"id"`
+	Name               *string    `json:"name"`
+	CreatedAt          *time.Time `json:"created_at"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+	OrganizationID     uuid.UUID  `json:"organization_id"`
+	DefaultScheduleID  uuid.UUID  `json:"default_schedule_id"`
+	AgentImage         *string    `json:"agent_image"`
+	MaintenanceWindows []byte     `json:"maintenance_windows"`
+	TimeZone           *string    `json:"time_zone"`
+	DeviceCount        *int       `json:"device_count"`
+}
+
+// GetFleetForOrganization implements Querier.GetFleetForOrganization.
+func (q *DBQuerier) GetFleetForOrganization(ctx context.Context, fleetID uuid.UUID, organizationID uuid.UUID) (GetFleetForOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetFleetForOrganization")
+	row := q.conn.QueryRow(ctx, getFleetForOrganizationSQL, fleetID, organizationID)
+	var item GetFleetForOrganizationRow
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID, &item.DefaultScheduleID, &item.AgentImage, &item.MaintenanceWindows, &item.TimeZone, &item.DeviceCount); err != nil {
+		return item, fmt.Errorf("query GetFleetForOrganization: %w", err)
+	}
+	return item, nil
+}
+
+// GetFleetForOrganizationBatch implements Querier.GetFleetForOrganizationBatch.
+func (q *DBQuerier) GetFleetForOrganizationBatch(batch genericBatch, fleetID uuid.UUID, organizationID uuid.UUID) {
+	batch.Queue(getFleetForOrganizationSQL, fleetID, organizationID)
+}
+
+// GetFleetForOrganizationScan implements Querier.GetFleetForOrganizationScan.
+func (q *DBQuerier) GetFleetForOrganizationScan(results pgx.BatchResults) (GetFleetForOrganizationRow, error) {
+	row := results.QueryRow()
+	var item GetFleetForOrganizationRow
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID, &item.DefaultScheduleID, &item.AgentImage, &item.MaintenanceWindows, &item.TimeZone, &item.DeviceCount); err != nil {
+		return item, fmt.Errorf("scan GetFleetForOrganizationBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const insertFleetSQL = `INSERT INTO fleet (id, name, created_at, updated_at, organization_id)
+VALUES (gen_random_uuid(), $1, now(), now(), $2)
+RETURNING *;`
+
+type InsertFleetRow struct {
+	ID                 uuid.UUID  `json:"id"`
+	Name               *string    `json:"name"`
+	CreatedAt          *time.Time `json:"created_at"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+	OrganizationID     uuid.UUID  `json:"organization_id"`
+	DefaultScheduleID  uuid.UUID  `json:"default_schedule_id"`
+	AgentImage         *string    `json:"agent_image"`
+	MaintenanceWindows []byte     `json:"maintenance_windows"`
+	TimeZone           *string    `json:"time_zone"`
+}
+
+// InsertFleet implements Querier.InsertFleet.
+func (q *DBQuerier) InsertFleet(ctx context.Context, name *string, organizationID uuid.UUID) (InsertFleetRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertFleet")
+	row := q.conn.QueryRow(ctx, insertFleetSQL, name, organizationID)
+	var item InsertFleetRow
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID, &item.DefaultScheduleID, &item.AgentImage, &item.MaintenanceWindows, &item.TimeZone); err != nil {
+		return item, fmt.Errorf("query InsertFleet: %w", err)
+	}
+	return item, nil
+}
+
+// InsertFleetBatch implements Querier.InsertFleetBatch.
+func (q *DBQuerier) InsertFleetBatch(batch genericBatch, name *string, organizationID uuid.UUID) {
+	batch.Queue(insertFleetSQL, name, organizationID)
+}
+
+// InsertFleetScan implements Querier.InsertFleetScan.
+func (q *DBQuerier) InsertFleetScan(results pgx.BatchResults) (InsertFleetRow, error) {
+	row := results.QueryRow()
+	var item InsertFleetRow
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.OrganizationID, &item.DefaultScheduleID, &item.AgentImage, &item.MaintenanceWindows, &item.TimeZone); err != nil {
+		return item, fmt.Errorf("scan InsertFleetBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const renameFleetSQL = `UPDATE fleet
+SET name       = $1,
+    updated_at = now()
+WHERE id = $2
+  AND organization_id = $3;`
+
+type RenameFleetParams struct {
+	Name           *string
+	FleetID        uuid.UUID
+	OrganizationID uuid.UUID
+}
+
+// RenameFleet implements Querier.RenameFleet.
+func (q *DBQuerier) RenameFleet(ctx context.Context, params RenameFleetParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "RenameFleet")
+	cmdTag, err := q.conn.Exec(ctx, renameFleetSQL, params.Name, params.FleetID, params.OrganizationID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query RenameFleet: %w", err)
+	}
+	return cmdTag, err
+}
+
+// RenameFleetBatch implements Querier.RenameFleetBatch.
+func (q *DBQuerier) RenameFleetBatch(batch genericBatch, params RenameFleetParams) {
+	batch.Queue(renameFleetSQL, params.Name, params.FleetID, params.OrganizationID)
+}
+
+// RenameFleetScan implements Querier.RenameFleetScan.
+func (q *DBQuerier) RenameFleetScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec RenameFleetBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const setFleetDefaultScheduleSQL = `UPDATE fleet
+SET default_schedule_id = NULLIF($1, '00000000-0000-0000-0000-000000000000'::uuid),
+    updated_at          = now()
+WHERE id = $2
+  AND organization_id = $3;`
+
+type SetFleetDefaultScheduleParams struct {
+	ScheduleID     uuid.UUID
+	FleetID        uuid.UUID
+	OrganizationID uuid.UUID
+}
+
+// SetFleetDefaultSchedule implements Querier.SetFleetDefaultSchedule.
+func (q *DBQuerier) SetFleetDefaultSchedule(ctx context.Context, params SetFleetDefaultScheduleParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "SetFleetDefaultSchedule")
+	cmdTag, err := q.conn.Exec(ctx, setFleetDefaultScheduleSQL, params.ScheduleID, params.FleetID, params.OrganizationID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query SetFleetDefaultSchedule: %w", err)
+	}
+	return cmdTag, err
+}
+
+// SetFleetDefaultScheduleBatch implements Querier.SetFleetDefaultScheduleBatch.
+func (q *DBQuerier) SetFleetDefaultScheduleBatch(batch genericBatch, params SetFleetDefaultScheduleParams) {
+	batch.Queue(setFleetDefaultScheduleSQL, params.ScheduleID, params.FleetID, params.OrganizationID)
+}
+
+// SetFleetDefaultScheduleScan implements Querier.SetFleetDefaultScheduleScan.
+func (q *DBQuerier) SetFleetDefaultScheduleScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec SetFleetDefaultScheduleBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const setFleetAgentImageSQL = `UPDATE fleet
+SET agent_image = NULLIF($1, ''),
+    updated_at  = now()
+WHERE id = $2
+  AND organization_id = $3;`
+
+type SetFleetAgentImageParams struct {
+	AgentImage     *string
+	FleetID        uuid.UUID
+	OrganizationID uuid.UUID
+}
+
+// SetFleetAgentImage implements Querier.SetFleetAgentImage.
+func (q *DBQuerier) SetFleetAgentImage(ctx context.Context, params SetFleetAgentImageParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "SetFleetAgentImage")
+	cmdTag, err := q.conn.Exec(ctx, setFleetAgentImageSQL, params.AgentImage, params.FleetID, params.OrganizationID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query SetFleetAgentImage: %w", err)
+	}
+	return cmdTag, err
+}
+
+// SetFleetAgentImageBatch implements Querier.SetFleetAgentImageBatch.
+func (q *DBQuerier) SetFleetAgentImageBatch(batch genericBatch, params SetFleetAgentImageParams) {
+	batch.Queue(setFleetAgentImageSQL, params.AgentImage, params.FleetID, params.OrganizationID)
+}
+
+// SetFleetAgentImageScan implements Querier.SetFleetAgentImageScan.
+func (q *DBQuerier) SetFleetAgentImageScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec SetFleetAgentImageBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const getAgentImageForDeviceSQL = `SELECT f.agent_image
+FROM device AS d
+JOIN fleet AS f ON f.id = d.fleet_id
+WHERE d.id = $1;`
+
+// GetAgentImageForDevice implements Querier.GetAgentImageForDevice.
+func (q *DBQuerier) GetAgentImageForDevice(ctx context.Context, deviceID uuid.UUID) (*string, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetAgentImageForDevice")
+	row := q.conn.QueryRow(ctx, getAgentImageForDeviceSQL, deviceID)
+	var item *string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query GetAgentImageForDevice: %w", err)
+	}
+	return item, nil
+}
+
+// GetAgentImageForDeviceBatch implements Querier.GetAgentImageForDeviceBatch.
+func (q *DBQuerier) GetAgentImageForDeviceBatch(batch genericBatch, deviceID uuid.UUID) {
+	batch.Queue(getAgentImageForDeviceSQL, deviceID)
+}
+
+// GetAgentImageForDeviceScan implements Querier.GetAgentImageForDeviceScan.
+func (q *DBQuerier) GetAgentImageForDeviceScan(results pgx.BatchResults) (*string, error) {
+	row := results.QueryRow()
+	var item *string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan GetAgentImageForDeviceBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const setFleetMaintenanceSQL = `UPDATE fleet
+SET maintenance_windows = $1,
+    time_zone           = NULLIF($2, ''),
+    updated_at          = now()
+WHERE id = $3
+  AND organization_id = $4;`
+
+type SetFleetMaintenanceParams struct {
+	MaintenanceWindows []byte
+	TimeZone           *string
+	FleetID            uuid.UUID
+	OrganizationID     uuid.UUID
+}
+
+// SetFleetMaintenance implements Querier.SetFleetMaintenance.
+func (q *DBQuerier) SetFleetMaintenance(ctx context.Context, params SetFleetMaintenanceParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "SetFleetMaintenance")
+	cmdTag, err := q.conn.Exec(ctx, setFleetMaintenanceSQL, params.MaintenanceWindows, params.TimeZone, params.FleetID, params.OrganizationID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query SetFleetMaintenance: %w", err)
+	}
+	return cmdTag, err
+}
+
+// SetFleetMaintenanceBatch implements Querier.SetFleetMaintenanceBatch.
+func (q *DBQuerier) SetFleetMaintenanceBatch(batch genericBatch, params SetFleetMaintenanceParams) {
+	batch.Queue(setFleetMaintenanceSQL, params.MaintenanceWindows, params.TimeZone, params.FleetID, params.OrganizationID)
+}
+
+// SetFleetMaintenanceScan implements Querier.SetFleetMaintenanceScan.
+func (q *DBQuerier) SetFleetMaintenanceScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec SetFleetMaintenanceBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const setDeviceMaintenanceSQL = `UPDATE device
+SET maintenance_windows = $1,
+    time_zone           = NULLIF($2, ''),
+    updated_at          = now()
+WHERE id = $3;`
+
+type SetDeviceMaintenanceParams struct {
+	MaintenanceWindows []byte
+	TimeZone           *string
+	DeviceID           uuid.UUID
+}
+
+// SetDeviceMaintenance implements Querier.SetDeviceMaintenance.
+func (q *DBQuerier) SetDeviceMaintenance(ctx context.Context, params SetDeviceMaintenanceParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "SetDeviceMaintenance")
+	cmdTag, err := q.conn.Exec(ctx, setDeviceMaintenanceSQL, params.MaintenanceWindows, params.TimeZone, params.DeviceID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query SetDeviceMaintenance: %w", err)
+	}
+	return cmdTag, err
+}
+
+// SetDeviceMaintenanceBatch implements Querier.SetDeviceMaintenanceBatch.
+func (q *DBQuerier) SetDeviceMaintenanceBatch(batch genericBatch, params SetDeviceMaintenanceParams) {
+	batch.Queue(setDeviceMaintenanceSQL, params.MaintenanceWindows, params.TimeZone, params.DeviceID)
+}
+
+// SetDeviceMaintenanceScan implements Querier.SetDeviceMaintenanceScan.
+func (q *DBQuerier) SetDeviceMaintenanceScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec SetDeviceMaintenanceBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const setDeviceApplyNowUntilSQL = `UPDATE device
+SET apply_now_until = $1
+WHERE id = $2;`
+
+// SetDeviceApplyNowUntil implements Querier.SetDeviceApplyNowUntil.
+func (q *DBQuerier) SetDeviceApplyNowUntil(ctx context.Context, applyNowUntil *time.Time, deviceID uuid.UUID) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "SetDeviceApplyNowUntil")
+	cmdTag, err := q.conn.Exec(ctx, setDeviceApplyNowUntilSQL, applyNowUntil, deviceID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query SetDeviceApplyNowUntil: %w", err)
+	}
+	return cmdTag, err
+}
+
+// SetDeviceApplyNowUntilBatch implements Querier.SetDeviceApplyNowUntilBatch.
+func (q *DBQuerier) SetDeviceApplyNowUntilBatch(batch genericBatch, applyNowUntil *time.Time, deviceID uuid.UUID) {
+	batch.Queue(setDeviceApplyNowUntilSQL, applyNowUntil, deviceID)
+}
+
+// SetDeviceApplyNowUntilScan implements Querier.SetDeviceApplyNowUntilScan.
+func (q *DBQuerier) SetDeviceApplyNowUntilScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec SetDeviceApplyNowUntilBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const setFleetApplyNowUntilSQL = `UPDATE device
+SET apply_now_until = $1
+WHERE fleet_id = $2;`
+
+// SetFleetApplyNowUntil implements Querier.SetFleetApplyNowUntil.
+func (q *DBQuerier) SetFleetApplyNowUntil(ctx context.Context, applyNowUntil *time.Time, fleetID uuid.UUID) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "SetFleetApplyNowUntil")
+	cmdTag, err := q.conn.Exec(ctx, setFleetApplyNowUntilSQL, applyNowUntil, fleetID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query SetFleetApplyNowUntil: %w", err)
+	}
+	return cmdTag, err
+}
+
+// SetFleetApplyNowUntilBatch implements Querier.SetFleetApplyNowUntilBatch.
+func (q *DBQuerier) SetFleetApplyNowUntilBatch(batch genericBatch, applyNowUntil *time.Time, fleetID uuid.UUID) {
+	batch.Queue(setFleetApplyNowUntilSQL, applyNowUntil, fleetID)
+}
+
+// SetFleetApplyNowUntilScan implements Querier.SetFleetApplyNowUntilScan.
+func (q *DBQuerier) SetFleetApplyNowUntilScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec SetFleetApplyNowUntilBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const getMaintenanceForDeviceSQL = `SELECT f.maintenance_windows AS fleet_maintenance_windows,
+       f.time_zone           AS fleet_time_zone,
+       d.maintenance_windows AS device_maintenance_windows,
+       d.time_zone           AS device_time_zone,
+       d.apply_now_until
+FROM device AS d
+JOIN fleet AS f ON f.id = d.fleet_id
+WHERE d.id = $1;`
+
+type GetMaintenanceForDeviceRow struct {
+	FleetMaintenanceWindows  []byte     `json:"fleet_maintenance_windows"`
+	FleetTimeZone            *string    `json:"fleet_time_zone"`
+	DeviceMaintenanceWindows []byte     `json:"device_maintenance_windows"`
+	DeviceTimeZone           *string    `json:"device_time_zone"`
+	ApplyNowUntil            *time.Time `json:"apply_now_until"`
+}
+
+// GetMaintenanceForDevice implements Querier.GetMaintenanceForDevice.
+func (q *DBQuerier) GetMaintenanceForDevice(ctx context.Context, deviceID uuid.UUID) (GetMaintenanceForDeviceRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetMaintenanceForDevice")
+	row := q.conn.QueryRow(ctx, getMaintenanceForDeviceSQL, deviceID)
+	var item GetMaintenanceForDeviceRow
+	if err := row.Scan(&item.FleetMaintenanceWindows, &item.FleetTimeZone, &item.DeviceMaintenanceWindows, &item.DeviceTimeZone, &item.ApplyNowUntil); err != nil {
+		return item, fmt.Errorf("query GetMaintenanceForDevice: %w", err)
+	}
+	return item, nil
+}
+
+// GetMaintenanceForDeviceBatch implements Querier.GetMaintenanceForDeviceBatch.
+func (q *DBQuerier) GetMaintenanceForDeviceBatch(batch genericBatch, deviceID uuid.UUID) {
+	batch.Queue(getMaintenanceForDeviceSQL, deviceID)
+}
+
+// GetMaintenanceForDeviceScan implements Querier.GetMaintenanceForDeviceScan.
+func (q *DBQuerier) GetMaintenanceForDeviceScan(results pgx.BatchResults) (GetMaintenanceForDeviceRow, error) {
+	row := results.QueryRow()
+	var item GetMaintenanceForDeviceRow
+	if err := row.Scan(&item.FleetMaintenanceWindows, &item.FleetTimeZone, &item.DeviceMaintenanceWindows, &item.DeviceTimeZone, &item.ApplyNowUntil); err != nil {
+		return item, fmt.Errorf("scan GetMaintenanceForDeviceBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const endFleetSchedulesSQL = `UPDATE fleet_schedule
+SET deleted_at = now()
+WHERE fleet_id = $1
+  AND deleted_at IS NULL;`
+
+// EndFleetSchedules implements Querier.EndFleetSchedules.
+func (q *DBQuerier) EndFleetSchedules(ctx context.Context, fleetID uuid.UUID) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "EndFleetSchedules")
+	cmdTag, err := q.conn.Exec(ctx, endFleetSchedulesSQL, fleetID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query EndFleetSchedules: %w", err)
+	}
+	return cmdTag, err
+}
+
+// EndFleetSchedulesBatch implements Querier.EndFleetSchedulesBatch.
+func (q *DBQuerier) EndFleetSchedulesBatch(batch genericBatch, fleetID uuid.UUID) {
+	batch.Queue(endFleetSchedulesSQL, fleetID)
+}
+
+// EndFleetSchedulesScan implements Querier.EndFleetSchedulesScan.
+func (q *DBQuerier) EndFleetSchedulesScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec EndFleetSchedulesBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const insertFleetScheduleSQL = `INSERT INTO fleet_schedule (id, fleet_id, schedule_id)
+VALUES (gen_random_uuid(), $1, $2);`
+
+// InsertFleetSchedule implements Querier.InsertFleetSchedule.
+func (q *DBQuerier) InsertFleetSchedule(ctx context.Context, fleetID uuid.UUID, scheduleID uuid.UUID) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertFleetSchedule")
+	cmdTag, err := q.conn.Exec(ctx, insertFleetScheduleSQL, fleetID, scheduleID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query InsertFleetSchedule: %w", err)
+	}
+	return cmdTag, err
+}
+
+// InsertFleetScheduleBatch implements Querier.InsertFleetScheduleBatch.
+func (q *DBQuerier) InsertFleetScheduleBatch(batch genericBatch, fleetID uuid.UUID, scheduleID uuid.UUID) {
+	batch.Queue(insertFleetScheduleSQL, fleetID, scheduleID)
+}
+
+// InsertFleetScheduleScan implements Querier.InsertFleetScheduleScan.
+func (q *DBQuerier) InsertFleetScheduleScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec InsertFleetScheduleBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const deleteFleetSQL = `DELETE FROM fleet
+WHERE id = $1
+  AND organization_id = $2;`
+
+// DeleteFleet implements Querier.DeleteFleet.
+func (q *DBQuerier) DeleteFleet(ctx context.Context, fleetID uuid.UUID, organizationID uuid.UUID) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteFleet")
+	cmdTag, err := q.conn.Exec(ctx, deleteFleetSQL, fleetID, organizationID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query DeleteFleet: %w", err)
+	}
+	return cmdTag, err
+}
+
+// DeleteFleetBatch implements Querier.DeleteFleetBatch.
+func (q *DBQuerier) DeleteFleetBatch(batch genericBatch, fleetID uuid.UUID, organizationID uuid.UUID) {
+	batch.Queue(deleteFleetSQL, fleetID, organizationID)
+}
+
+// DeleteFleetScan implements Querier.DeleteFleetScan.
+func (q *DBQuerier) DeleteFleetScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec DeleteFleetBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const listDevicesInFleetSQL = `SELECT d.*
+FROM device AS d
+WHERE d.fleet_id = $1
+  AND ($2::text = '' OR strpos(lower(d.name), lower($2::text)) > 0)
+  AND ($3::text = ''
+    OR ($3::text = 'online' AND d.last_seen_at >= $4::timestamp)
+    OR ($3::text = 'stale' AND d.last_seen_at < $4::timestamp
+                                         AND d.last_seen_at >= $5::timestamp)
+    OR ($3::text = 'offline' AND (d.last_seen_at IS NULL OR d.last_seen_at < $5::timestamp)))
+  AND (d.name, d.id) > ($6::text, $7::uuid)
+ORDER BY d.name, d.id
+LIMIT $8;`
+
+type ListDevicesInFleetParams struct {
+	FleetID      uuid.UUID
+	NameContains *string
+	Status       *string
+	OnlineAfter  *time.Time
+	OfflineAfter *time.Time
+	AfterName    *string
+	AfterID      uuid.UUID
+	PageSize     *int
+}
+
+type ListDevicesInFleetRow struct {
+	ID                 uuid.UUID  `json:"id"`
+	Name               *string    `json:"name"`
+	CreatedAt          *time.Time `json:"created_at"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+	FleetID            uuid.UUID  `json:"fleet_id"`
+	AgentVersion       *string    `json:"agent_version"`
+	LastSeenAt         *time.Time `json:"last_seen_at"`
+	RemoteAddress      *string    `json:"remote_address"`
+	ReportedAt         *time.Time `json:"reported_at"`
+	ReportedState      []byte     `json:"reported_state"`
+	Connectivity       *string    `json:"connectivity"`
+	MaintenanceWindows []byte     `json:"maintenance_windows"`
+	TimeZone           *string    `json:"time_zone"`
+	ApplyNowUntil      *time.Time `json:"apply_now_until"`
+	RelayTokenHash     *string    `json:"relay_token_hash"`
+}
+
+// ListDevicesInFleet implements Querier.ListDevicesInFleet.
+func (q *DBQuerier) ListDevicesInFleet(ctx context.Context, params ListDevicesInFleetParams) ([]ListDevicesInFleetRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "ListDevicesInFleet")
+	rows, err := q.conn.Query(ctx, listDevicesInFleetSQL, params.FleetID, params.NameContains, params.Status, params.OnlineAfter, params.OfflineAfter, params.AfterName, params.AfterID, params.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("query ListDevicesInFleet: %w", err)
+	}
+	defer rows.Close()
+	items := []ListDevicesInFleetRow{}
+	for rows.Next() {
+		var item ListDevicesInFleetRow
+		if err := rows.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.FleetID, &item.AgentVersion, &item.LastSeenAt, &item.RemoteAddress, &item.ReportedAt, &item.ReportedState, &item.Connectivity, &item.MaintenanceWindows, &item.TimeZone, &item.ApplyNowUntil, &item.RelayTokenHash); err != nil {
+			return nil, fmt.Errorf("scan ListDevicesInFleet row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListDevicesInFleet rows: %w", err)
+	}
+	return items, err
+}
+
+// ListDevicesInFleetBatch implements Querier.ListDevicesInFleetBatch.
+func (q *DBQuerier) ListDevicesInFleetBatch(batch genericBatch, params ListDevicesInFleetParams) {
+	batch.Queue(listDevicesInFleetSQL, params.FleetID, params.NameContains, params.Status, params.OnlineAfter, params.OfflineAfter, params.AfterName, params.AfterID, params.PageSize)
+}
+
+// ListDevicesInFleetScan implements Querier.ListDevicesInFleetScan.
+func (q *DBQuerier) ListDevicesInFleetScan(results pgx.BatchResults) ([]ListDevicesInFleetRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query ListDevicesInFleetBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []ListDevicesInFleetRow{}
+	for rows.Next() {
+		var item ListDevicesInFleetRow
+		if err := rows.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.FleetID, &item.AgentVersion, &item.LastSeenAt, &item.RemoteAddress, &item.ReportedAt, &item.ReportedState, &item.Connectivity, &item.MaintenanceWindows, &item.TimeZone, &item.ApplyNowUntil, &item.RelayTokenHash); err != nil {
+			return nil, fmt.Errorf("scan ListDevicesInFleetBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListDevicesInFleetBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const getDeviceForOrganizationSQL = `SELECT d.*
+FROM device AS d
+JOIN fleet AS f ON f.id = d.fleet_id
+WHERE d.id = $1
+  AND f.organization_id = $2;`
+
+type GetDeviceForOrganizationRow struct {
+	ID                 uuid.UUID  `json:"id"`
+	Name               *string    `json:"name"`
+	CreatedAt          *time.Time `json:"created_at"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+	FleetID            uuid.UUID  `json:"fleet_id"`
+	AgentVersion       *string    `json:"agent_version"`
+	LastSeenAt         *time.Time `json:"last_seen_at"`
+	RemoteAddress      *string    `json:"remote_address"`
+	ReportedAt         *time.Time `json:"reported_at"`
+	ReportedState      []byte     `json:"reported_state"`
+	Connectivity       *string    `json:"connectivity"`
+	MaintenanceWindows []byte     `json:"maintenance_windows"`
+	TimeZone           *string    `json:"time_zone"`
+	ApplyNowUntil      *time.Time `json:"apply_now_until"`
+	RelayTokenHash     *string    `json:"relay_token_hash"`
+}
+
+// GetDeviceForOrganization implements Querier.GetDeviceForOrganization.
+func (q *DBQuerier) GetDeviceForOrganization(ctx context.Context, deviceID uuid.UUID, organizationID uuid.UUID) (GetDeviceForOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetDeviceForOrganization")
+	row := q.conn.QueryRow(ctx, getDeviceForOrganizationSQL, deviceID, organizationID)
+	var item GetDeviceForOrganizationRow
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.FleetID, &item.AgentVersion, &item.LastSeenAt, &item.RemoteAddress, &item.ReportedAt, &item.ReportedState, &item.Connectivity, &item.MaintenanceWindows, &item.TimeZone, &item.ApplyNowUntil, &item.RelayTokenHash); err != nil {
+		return item, fmt.Errorf("query GetDeviceForOrganization: %w", err)
+	}
+	return item, nil
+}
+
+// GetDeviceForOrganizationBatch implements Querier.GetDeviceForOrganizationBatch.
+func (q *DBQuerier) GetDeviceForOrganizationBatch(batch genericBatch, deviceID uuid.UUID, organizationID uuid.UUID) {
+	batch.Queue(getDeviceForOrganizationSQL, deviceID, organizationID)
+}
+
+// GetDeviceForOrganizationScan implements Querier.GetDeviceForOrganizationScan.
+func (q *DBQuerier) GetDeviceForOrganizationScan(results pgx.BatchResults) (GetDeviceForOrganizationRow, error) {
+	row := results.QueryRow()
+	var item GetDeviceForOrganizationRow
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.FleetID, &item.AgentVersion, &item.LastSeenAt, &item.RemoteAddress, &item.ReportedAt, &item.ReportedState, &item.Connectivity, &item.MaintenanceWindows, &item.TimeZone, &item.ApplyNowUntil, &item.RelayTokenHash); err != nil {
+		return item, fmt.Errorf("scan GetDeviceForOrganizationBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const insertDeviceSQL = `INSERT INTO device (id, name, created_at, updated_at, fleet_id)
+VALUES (gen_random_uuid(), $1, now(), now(), $2)
+RETURNING *;`
+
+type InsertDeviceRow struct {
+	ID                 uuid.UUID  `json:"id"`
+	Name               *string    `json:"name"`
+	CreatedAt          *time.Time `json:"created_at"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+	FleetID            uuid.UUID  `json:"fleet_id"`
+	AgentVersion       *string    `json:"agent_version"`
+	LastSeenAt         *time.Time `json:"last_seen_at"`
+	RemoteAddress      *string    `json:"remote_address"`
+	ReportedAt         *time.Time `json:"reported_at"`
+	ReportedState      []byte     `json:"reported_state"`
+	Connectivity       *string    `json:"connectivity"`
+	MaintenanceWindows []byte     `json:"maintenance_windows"`
+	TimeZone           *string    `json:"time_zone"`
+	ApplyNowUntil      *time.Time `json:"apply_now_until"`
+	RelayTokenHash     *string    `json:"relay_token_hash"`
+}
+
+// InsertDevice implements Querier.InsertDevice.
+func (q *DBQuerier) InsertDevice(ctx context.Context, name *string, fleetID uuid.UUID) (InsertDeviceRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertDevice")
+	row := q.conn.QueryRow(ctx, insertDeviceSQL, name, fleetID)
+	var item InsertDeviceRow
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.FleetID, &item.AgentVersion, &item.LastSeenAt, &item.RemoteAddress, &item.ReportedAt, &item.ReportedState, &item.Connectivity, &item.MaintenanceWindows, &item.TimeZone, &item.ApplyNowUntil, &item.RelayTokenHash); err != nil {
+		return item, fmt.Errorf("query InsertDevice: %w", err)
+	}
+	return item, nil
+}
+
+// InsertDeviceBatch implements Querier.InsertDeviceBatch.
+func (q *DBQuerier) InsertDeviceBatch(batch genericBatch, name *string, fleetID uuid.UUID) {
+	batch.Queue(insertDeviceSQL, name, fleetID)
+}
+
+// InsertDeviceScan implements Querier.InsertDeviceScan.
+func (q *DBQuerier) InsertDeviceScan(results pgx.BatchResults) (InsertDeviceRow, error) {
+	row := results.QueryRow()
+	var item InsertDeviceRow
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.FleetID, &item.AgentVersion, &item.LastSeenAt, &item.RemoteAddress, &item.ReportedAt, &item.ReportedState, &item.Connectivity, &item.MaintenanceWindows, &item.TimeZone, &item.ApplyNowUntil, &item.RelayTokenHash); err != nil {
+		return item, fmt.Errorf("scan InsertDeviceBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const renameDeviceSQL = `UPDATE device
+SET name       = $1,
+    updated_at = now()
+WHERE id = $2
+RETURNING *;`
+
+type RenameDeviceRow struct {
+	ID                 uuid.UUID  `json:"id"`
+	Name               *string    `json:"name"`
+	CreatedAt          *time.Time `json:"created_at"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+	FleetID            uuid.UUID  `json:"fleet_id"`
+	AgentVersion       *string    `json:"agent_version"`
+	LastSeenAt         *time.Time `json:"last_seen_at"`
+	RemoteAddress      *string    `json:"remote_address"`
+	ReportedAt         *time.Time `json:"reported_at"`
+	ReportedState      []byte     `json:"reported_state"`
+	Connectivity       *string    `json:"connectivity"`
+	MaintenanceWindows []byte     `json:"maintenance_windows"`
+	TimeZone           *string    `json:"time_zone"`
+	ApplyNowUntil      *time.Time `json:"apply_now_until"`
+	RelayTokenHash     *string    `json:"relay_token_hash"`
+}
+
+// RenameDevice implements Querier.RenameDevice.
+func (q *DBQuerier) RenameDevice(ctx context.Context, name *string, deviceID uuid.UUID) (RenameDeviceRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "RenameDevice")
+	row := q.conn.QueryRow(ctx, renameDeviceSQL, name, deviceID)
+	var item RenameDeviceRow
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.FleetID, &item.AgentVersion, &item.LastSeenAt, &item.RemoteAddress, &item.ReportedAt, &item.ReportedState, &item.Connectivity, &item.MaintenanceWindows, &item.TimeZone, &item.ApplyNowUntil, &item.RelayTokenHash); err != nil {
+		return item, fmt.Errorf("query RenameDevice: %w", err)
+	}
+	return item, nil
+}
+
+// RenameDeviceBatch implements Querier.RenameDeviceBatch.
+func (q *DBQuerier) RenameDeviceBatch(batch genericBatch, name *string, deviceID uuid.UUID) {
+	batch.Queue(renameDeviceSQL, name, deviceID)
+}
+
+// RenameDeviceScan implements Querier.RenameDeviceScan.
+func (q *DBQuerier) RenameDeviceScan(results pgx.BatchResults) (RenameDeviceRow, error) {
+	row := results.QueryRow()
+	var item RenameDeviceRow
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.FleetID, &item.AgentVersion, &item.LastSeenAt, &item.RemoteAddress, &item.ReportedAt, &item.ReportedState, &item.Connectivity, &item.MaintenanceWindows, &item.TimeZone, &item.ApplyNowUntil, &item.RelayTokenHash); err != nil {
+		return item, fmt.Errorf("scan RenameDeviceBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const moveDeviceSQL = `UPDATE device
+SET fleet_id   = $1,
+    updated_at = now()
+WHERE id = $2
+RETURNING *;`
+
+type MoveDeviceRow struct {
+	ID                 uuid.UUID  `json:"id"`
+	Name               *string    `json:"name"`
+	CreatedAt          *time.Time `json:"created_at"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+	FleetID            uuid.UUID  `json:"fleet_id"`
+	AgentVersion       *string    `json:"agent_version"`
+	LastSeenAt         *time.Time `json:"last_seen_at"`
+	RemoteAddress      *string    `json:"remote_address"`
+	ReportedAt         *time.Time `json:"reported_at"`
+	ReportedState      []byte     `json:"reported_state"`
+	Connectivity       *string    `json:"connectivity"`
+	MaintenanceWindows []byte     `json:"maintenance_windows"`
+	TimeZone           *string    `json:"time_zone"`
+	ApplyNowUntil      *time.Time `json:"apply_now_until"`
+	RelayTokenHash     *string    `json:"relay_token_hash"`
+}
+
+// MoveDevice implements Querier.MoveDevice.
+func (q *DBQuerier) MoveDevice(ctx context.Context, fleetID uuid.UUID, deviceID uuid.UUID) (MoveDeviceRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "MoveDevice")
+	row := q.conn.QueryRow(ctx, moveDeviceSQL, fleetID, deviceID)
+	var item MoveDeviceRow
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.FleetID, &item.AgentVersion, &item.LastSeenAt, &item.RemoteAddress, &item.ReportedAt, &item.ReportedState, &item.Connectivity, &item.MaintenanceWindows, &item.TimeZone, &item.ApplyNowUntil, &item.RelayTokenHash); err != nil {
+		return item, fmt.Errorf("query MoveDevice: %w", err)
+	}
+	return item, nil
+}
+
+// MoveDeviceBatch implements Querier.MoveDeviceBatch.
+func (q *DBQuerier) MoveDeviceBatch(batch genericBatch, fleetID uuid.UUID, deviceID uuid.UUID) {
+	batch.Queue(moveDeviceSQL, fleetID, deviceID)
+}
+
+// MoveDeviceScan implements Querier.MoveDeviceScan.
+func (q *DBQuerier) MoveDeviceScan(results pgx.BatchResults) (MoveDeviceRow, error) {
+	row := results.QueryRow()
+	var item MoveDeviceRow
+	if err := row.Scan(&item.ID, &item.Name, &item.CreatedAt, &item.UpdatedAt, &item.FleetID, &item.AgentVersion, &item.LastSeenAt, &item.RemoteAddress, &item.ReportedAt, &item.ReportedState, &item.Connectivity, &item.MaintenanceWindows, &item.TimeZone, &item.ApplyNowUntil, &item.RelayTokenHash); err != nil {
+		return item, fmt.Errorf("scan MoveDeviceBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const deleteDeviceSQL = `DELETE FROM device
+WHERE id = $1;`
+
+// DeleteDevice implements Querier.DeleteDevice.
+func (q *DBQuerier) DeleteDevice(ctx context.Context, deviceID uuid.UUID) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteDevice")
+	cmdTag, err := q.conn.Exec(ctx, deleteDeviceSQL, deviceID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query DeleteDevice: %w", err)
+	}
+	return cmdTag, err
+}
+
+// DeleteDeviceBatch implements Querier.DeleteDeviceBatch.
+func (q *DBQuerier) DeleteDeviceBatch(batch genericBatch, deviceID uuid.UUID) {
+	batch.Queue(deleteDeviceSQL, deviceID)
+}
+
+// DeleteDeviceScan implements Querier.DeleteDeviceScan.
+func (q *DBQuerier) DeleteDeviceScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec DeleteDeviceBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const recordDeviceHeartbeatSQL = `UPDATE device AS d
+SET last_seen_at   = $1,
+    agent_version  = coalesce(nullif($2::text, ''), d.agent_version),
+    remote_address = $3,
+    connectivity   = 'online'
+FROM device AS previous
+         JOIN fleet AS f ON f.id = previous.fleet_id
+WHERE d.id = $4
+  AND previous.id = d.id
+RETURNING previous.connectivity AS previous_connectivity, d.name, d.fleet_id, f.organization_id;`
+
+type RecordDeviceHeartbeatParams struct {
+	SeenAt        *time.Time
+	AgentVersion  *string
+	RemoteAddress *string
+	DeviceID      uuid.UUID
+}
+
+type RecordDeviceHeartbeatRow struct {
+	PreviousConnectivity *string   `json:"previous_connectivity"`
+	Name                 *string   `json:"name"`
+	FleetID              uuid.UUID `json:"fleet_id"`
+	OrganizationID       uuid.UUID `json:"organization_id"`
+}
+
+// RecordDeviceHeartbeat implements Querier.RecordDeviceHeartbeat.
+func (q *DBQuerier) RecordDeviceHeartbeat(ctx context.Context, params RecordDeviceHeartbeatParams) (RecordDeviceHeartbeatRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "RecordDeviceHeartbeat")
+	row := q.conn.QueryRow(ctx, recordDeviceHeartbeatSQL, params.SeenAt, params.AgentVersion, params.RemoteAddress, params.DeviceID)
+	var item RecordDeviceHeartbeatRow
+	if err := row.Scan(&item.PreviousConnectivity, &item.Name, &item.FleetID, &item.OrganizationID); err != nil {
+		return item, fmt.Errorf("query RecordDeviceHeartbeat: %w", err)
+	}
+	return item, nil
+}
+
+// RecordDeviceHeartbeatBatch implements Querier.RecordDeviceHeartbeatBatch.
+func (q *DBQuerier) RecordDeviceHeartbeatBatch(batch genericBatch, params RecordDeviceHeartbeatParams) {
+	batch.Queue(recordDeviceHeartbeatSQL, params.SeenAt, params.AgentVersion, params.RemoteAddress, params.DeviceID)
+}
+
+// RecordDeviceHeartbeatScan implements Querier.RecordDeviceHeartbeatScan.
+func (q *DBQuerier) RecordDeviceHeartbeatScan(results pgx.BatchResults) (RecordDeviceHeartbeatRow, error) {
+	row := results.QueryRow()
+	var item RecordDeviceHeartbeatRow
+	if err := row.Scan(&item.PreviousConnectivity, &item.Name, &item.FleetID, &item.OrganizationID); err != nil {
+		return item, fmt.Errorf("scan RecordDeviceHeartbeatBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const recordDeviceReportedStateSQL = `UPDATE device AS d
+SET reported_state = $1,
+    reported_at    = $2
+FROM device AS previous
+         JOIN fleet AS f ON f.id = previous.fleet_id
+WHERE d.id = $3
+  AND previous.id = d.id
+RETURNING previous.reported_state AS previous_reported_state, d.name, d.fleet_id, f.organization_id;`
+
+type RecordDeviceReportedStateParams struct {
+	ReportedState []byte
+	ReportedAt    *time.Time
+	DeviceID      uuid.UUID
+}
+
+type RecordDeviceReportedStateRow struct {
+	PreviousReportedState []byte    `json:"previous_reported_state"`
+	Name                  *string   `json:"name"`
+	FleetID               uuid.UUID `json:"fleet_id"`
+	OrganizationID        uuid.UUID `json:"organization_id"`
+}
+
+// RecordDeviceReportedState implements Querier.RecordDeviceReportedState.
+func (q *DBQuerier) RecordDeviceReportedState(ctx context.Context, params RecordDeviceReportedStateParams) (RecordDeviceReportedStateRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "RecordDeviceReportedState")
+	row := q.conn.QueryRow(ctx, recordDeviceReportedStateSQL, params.ReportedState, params.ReportedAt, params.DeviceID)
+	var item RecordDeviceReportedStateRow
+	if err := row.Scan(&item.PreviousReportedState, &item.Name, &item.FleetID, &item.OrganizationID); err != nil {
+		return item, fmt.Errorf("query RecordDeviceReportedState: %w", err)
+	}
+	return item, nil
+}
+
+// RecordDeviceReportedStateBatch implements Querier.RecordDeviceReportedStateBatch.
+func (q *DBQuerier) RecordDeviceReportedStateBatch(batch genericBatch, params RecordDeviceReportedStateParams) {
+	batch.Queue(recordDeviceReportedStateSQL, params.ReportedState, params.ReportedAt, params.DeviceID)
+}
+
+// RecordDeviceReportedStateScan implements Querier.RecordDeviceReportedStateScan.
+func (q *DBQuerier) RecordDeviceReportedStateScan(results pgx.BatchResults) (RecordDeviceReportedStateRow, error) {
+	row := results.QueryRow()
+	var item RecordDeviceReportedStateRow
+	if err := row.Scan(&item.PreviousReportedState, &item.Name, &item.FleetID, &item.OrganizationID); err != nil {
+		return item, fmt.Errorf("scan RecordDeviceReportedStateBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const markDevicesOfflineSQL = `UPDATE device AS d
+SET connectivity = 'offline'
+FROM fleet AS f
+WHERE f.id = d.fleet_id
+  AND d.connectivity = 'online'
+  AND d.last_seen_at < $1
+RETURNING d.id, d.name, d.fleet_id, f.organization_id;`
+
+type MarkDevicesOfflineRow struct {
+	ID             uuid.UUID `json:"id"`
+	Name           *string   `json:"name"`
+	FleetID        uuid.UUID `json:"fleet_id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+}
+
+// MarkDevicesOffline implements Querier.MarkDevicesOffline.
+func (q *DBQuerier) MarkDevicesOffline(ctx context.Context, offlineBefore *time.Time) ([]MarkDevicesOfflineRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "MarkDevicesOffline")
+	rows, err := q.conn.Query(ctx, markDevicesOfflineSQL, offlineBefore)
+	if err != nil {
+		return nil, fmt.Errorf("query MarkDevicesOffline: %w", err)
+	}
+	defer rows.Close()
+	items := []MarkDevicesOfflineRow{}
+	for rows.Next() {
+		var item MarkDevicesOfflineRow
+		if err := rows.Scan(&item.ID, &item.Name, &item.FleetID, &item.OrganizationID); err != nil {
+			return nil, fmt.Errorf("scan MarkDevicesOffline row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close MarkDevicesOffline rows: %w", err)
+	}
+	return items, err
+}
+
+// MarkDevicesOfflineBatch implements Querier.MarkDevicesOfflineBatch.
+func (q *DBQuerier) MarkDevicesOfflineBatch(batch genericBatch, offlineBefore *time.Time) {
+	batch.Queue(markDevicesOfflineSQL, offlineBefore)
+}
+
+// MarkDevicesOfflineScan implements Querier.MarkDevicesOfflineScan.
+func (q *DBQuerier) MarkDevicesOfflineScan(results pgx.BatchResults) ([]MarkDevicesOfflineRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query MarkDevicesOfflineBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []MarkDevicesOfflineRow{}
+	for rows.Next() {
+		var item MarkDevicesOfflineRow
+		if err := rows.Scan(&item.ID, &item.Name, &item.FleetID, &item.OrganizationID); err != nil {
+			return nil, fmt.Errorf("scan MarkDevicesOfflineBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close MarkDevicesOfflineBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const countFleetDevicesBehindScheduleSQL = `SELECT count(*)
+FROM device AS d
+WHERE d.fleet_id = $1
+  AND d.last_seen_at >= $2
+  AND (d.reported_state IS NULL
+    OR NOT d.reported_state -> 'containerStates' @> jsonb_build_array(jsonb_build_object('scheduleId', $3::text)));`
+
+type CountFleetDevicesBehindScheduleParams struct {
+	FleetID    uuid.UUID
+	SeenAfter  *time.Time
+	ScheduleID *string
+}
+
+// CountFleetDevicesBehindSchedule implements Querier.CountFleetDevicesBehindSchedule.
+func (q *DBQuerier) CountFleetDevicesBehindSchedule(ctx context.Context, params CountFleetDevicesBehindScheduleParams) (*int, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "CountFleetDevicesBehindSchedule")
+	row := q.conn.QueryRow(ctx, countFleetDevicesBehindScheduleSQL, params.FleetID, params.SeenAfter, params.ScheduleID)
+	var item *int
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query CountFleetDevicesBehindSchedule: %w", err)
+	}
+	return item, nil
+}
+
+// CountFleetDevicesBehindScheduleBatch implements Querier.CountFleetDevicesBehindScheduleBatch.
+func (q *DBQuerier) CountFleetDevicesBehindScheduleBatch(batch genericBatch, params CountFleetDevicesBehindScheduleParams) {
+	batch.Queue(countFleetDevicesBehindScheduleSQL, params.FleetID, params.SeenAfter, params.ScheduleID)
+}
+
+// CountFleetDevicesBehindScheduleScan implements Querier.CountFleetDevicesBehindScheduleScan.
+func (q *DBQuerier) CountFleetDevicesBehindScheduleScan(results pgx.BatchResults) (*int, error) {
+	row := results.QueryRow()
+	var item *int
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan CountFleetDevicesBehindScheduleBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const countDevicesByStatusSQL = `SELECT count(*) FILTER (WHERE d.last_seen_at >= $1::timestamp) AS online,
+       count(*) FILTER (WHERE d.last_seen_at < $1::timestamp
+           AND d.last_seen_at >= $2::timestamp)              AS stale,
+       count(*) FILTER (WHERE d.last_seen_at IS NULL
+           OR d.last_seen_at < $2::timestamp)               AS offline
+FROM device AS d;`
+
+type CountDevicesByStatusRow struct {
+	Online  *int `json:"online"`
+	Stale   *int `json:"stale"`
+	Offline *int `json:"offline"`
+}
+
+// CountDevicesByStatus implements Querier.CountDevicesByStatus.
+func (q *DBQuerier) CountDevicesByStatus(ctx context.Context, onlineAfter *time.Time, offlineAfter *time.Time) (CountDevicesByStatusRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "CountDevicesByStatus")
+	row := q.conn.QueryRow(ctx, countDevicesByStatusSQL, onlineAfter, offlineAfter)
+	var item CountDevicesByStatusRow
+	if err := row.Scan(&item.Online, &item.Stale, &item.Offline); err != nil {
+		return item, fmt.Errorf("query CountDevicesByStatus: %w", err)
+	}
+	return item, nil
+}
+
+// CountDevicesByStatusBatch implements Querier.CountDevicesByStatusBatch.
+func (q *DBQuerier) CountDevicesByStatusBatch(batch genericBatch, onlineAfter *time.Time, offlineAfter *time.Time) {
+	batch.Queue(countDevicesByStatusSQL, onlineAfter, offlineAfter)
+}
+
+// CountDevicesByStatusScan implements Querier.CountDevicesByStatusScan.
+func (q *DBQuerier) CountDevicesByStatusScan(results pgx.BatchResults) (CountDevicesByStatusRow, error) {
+	row := results.QueryRow()
+	var item CountDevicesByStatusRow
+	if err := row.Scan(&item.Online, &item.Stale, &item.Offline); err != nil {
+		return item, fmt.Errorf("scan CountDevicesByStatusBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const insertAuditLogSQL = `INSERT INTO audit_log (id, organization_id, created_at, actor_type, actor_id, actor_name, action, target_type, target_id, before, after)
+VALUES (gen_random_uuid(), $1, now(), $2, $3, $4,
+        $5, $6, $7, $8, $9);`
+
+type InsertAuditLogParams struct {
+	OrganizationID uuid.UUID
+	ActorType      *string
+	ActorID        uuid.UUID
+	ActorName      *string
+	Action         *string
+	TargetType     *string
+	TargetID       *string
+	Before         []byte
+	After          []byte
+}
+
+// InsertAuditLog implements Querier.InsertAuditLog.
+func (q *DBQuerier) InsertAuditLog(ctx context.Context, params InsertAuditLogParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertAuditLog")
+	cmdTag, err := q.conn.Exec(ctx, insertAuditLogSQL, params.OrganizationID, params.ActorType, params.ActorID, params.ActorName, params.Action, params.TargetType, params.TargetID, params.Before, params.After)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query InsertAuditLog: %w", err)
+	}
+	return cmdTag, err
+}
+
+// InsertAuditLogBatch implements Querier.InsertAuditLogBatch.
+func (q *DBQuerier) InsertAuditLogBatch(batch genericBatch, params InsertAuditLogParams) {
+	batch.Queue(insertAuditLogSQL, params.OrganizationID, params.ActorType, params.ActorID, params.ActorName, params.Action, params.TargetType, params.TargetID, params.Before, params.After)
+}
+
+// InsertAuditLogScan implements Querier.InsertAuditLogScan.
+func (q *DBQuerier) InsertAuditLogScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec InsertAuditLogBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const listAuditLogSQL = `SELECT a.*
+FROM audit_log AS a
+WHERE a.organization_id = $1
+  AND ($2::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR a.actor_id = $2::uuid)
+  AND starts_with(a.action, $3::text)
+  AND ($4::text = '' OR a.target_type = $4::text)
+  AND ($5::text = '' OR a.target_id = $5::text)
+  AND ($6::timestamp IS NULL OR a.created_at >= $6::timestamp)
+  AND ($7::timestamp IS NULL OR a.created_at < $7::timestamp)
+  AND ($8::timestamp IS NULL
+    OR (a.created_at, a.id) < ($8::timestamp, $9::uuid))
+ORDER BY a.created_at DESC, a.id DESC
+LIMIT $10;`
+
+type ListAuditLogParams struct {
+	OrganizationID  uuid.UUID
+	ActorID         uuid.UUID
+	ActionPrefix    *string
+	TargetType      *string
+	TargetID        *string
+	Since           *time.Time
+	Until           *time.Time
+	BeforeCreatedAt *time.Time
+	BeforeID        uuid.UUID
+	PageSize        *int
+}
+
+type ListAuditLogRow struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	CreatedAt      *time.Time `json:"created_at"`
+	ActorType      *string    `json:"actor_type"`
+	ActorID        uuid.UUID  `json:"actor_id"`
+	ActorName      *string    `json:"actor_name"`
+	Action         *string    `json:"action"`
+	TargetType     *string    `json:"target_type"`
+	TargetID       *string    `json:"target_id"`
+	Before         []byte     `json:"before"`
+	After          []byte     `json:"after"`
+}
+
+// ListAuditLog implements Querier.ListAuditLog.
+func (q *DBQuerier) ListAuditLog(ctx context.Context, params ListAuditLogParams) ([]ListAuditLogRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "ListAuditLog")
+	rows, err := q.conn.Query(ctx, listAuditLogSQL, params.OrganizationID, params.ActorID, params.ActionPrefix, params.TargetType, params.TargetID, params.Since, params.Until, params.BeforeCreatedAt, params.BeforeID, params.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("query ListAuditLog: %w", err)
+	}
+	defer rows.Close()
+	items := []ListAuditLogRow{}
+	for rows.Next() {
+		var item ListAuditLogRow
+		if err := rows.Scan(&item.ID, &item.OrganizationID, &item.CreatedAt, &item.ActorType, &item.ActorID, &item.ActorName, &item.Action, &item.TargetType, &item.TargetID, &item.Before, &item.After); err != nil {
+			return nil, fmt.Errorf("scan ListAuditLog row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListAuditLog rows: %w", err)
+	}
+	return items, err
+}
+
+// ListAuditLogBatch implements Querier.ListAuditLogBatch.
+func (q *DBQuerier) ListAuditLogBatch(batch genericBatch, params ListAuditLogParams) {
+	batch.Queue(listAuditLogSQL, params.OrganizationID, params.ActorID, params.ActionPrefix, params.TargetType, params.TargetID, params.Since, params.Until, params.BeforeCreatedAt, params.BeforeID, params.PageSize)
+}
+
+// ListAuditLogScan implements Querier.ListAuditLogScan.
+func (q *DBQuerier) ListAuditLogScan(results pgx.BatchResults) ([]ListAuditLogRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query ListAuditLogBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []ListAuditLogRow{}
+	for rows.Next() {
+		var item ListAuditLogRow
+		if err := rows.Scan(&item.ID, &item.OrganizationID, &item.CreatedAt, &item.ActorType, &item.ActorID, &item.ActorName, &item.Action, &item.TargetType, &item.TargetID, &item.Before, &item.After); err != nil {
+			return nil, fmt.Errorf("scan ListAuditLogBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListAuditLogBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const deleteAuditLogBeforeSQL = `DELETE FROM audit_log
+WHERE created_at < $1;`
+
+// DeleteAuditLogBefore implements Querier.DeleteAuditLogBefore.
+func (q *DBQuerier) DeleteAuditLogBefore(ctx context.Context, cutoff *time.Time) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteAuditLogBefore")
+	cmdTag, err := q.conn.Exec(ctx, deleteAuditLogBeforeSQL, cutoff)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query DeleteAuditLogBefore: %w", err)
+	}
+	return cmdTag, err
+}
+
+// DeleteAuditLogBeforeBatch implements Querier.DeleteAuditLogBeforeBatch.
+func (q *DBQuerier) DeleteAuditLogBeforeBatch(batch genericBatch, cutoff *time.Time) {
+	batch.Queue(deleteAuditLogBeforeSQL, cutoff)
+}
+
+// DeleteAuditLogBeforeScan implements Querier.DeleteAuditLogBeforeScan.
+func (q *DBQuerier) DeleteAuditLogBeforeScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec DeleteAuditLogBeforeBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const getDeviceOrganizationSQL = `SELECT f.organization_id, d.name
+FROM device AS d
+         JOIN fleet AS f ON f.id = d.fleet_id
+WHERE d.id = $1;`
+
+type GetDeviceOrganizationRow struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Name           *string   `json:"name"`
+}
+
+// GetDeviceOrganization implements Querier.GetDeviceOrganization.
+func (q *DBQuerier) GetDeviceOrganization(ctx context.Context, deviceID uuid.UUID) (GetDeviceOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetDeviceOrganization")
+	row := q.conn.QueryRow(ctx, getDeviceOrganizationSQL, deviceID)
+	var item GetDeviceOrganizationRow
+	if err := row.Scan(&item.OrganizationID, &item.Name); err != nil {
+		return item, fmt.Errorf("query GetDeviceOrganization: %w", err)
+	}
+	return item, nil
+}
+
+// GetDeviceOrganizationBatch implements Querier.GetDeviceOrganizationBatch.
+func (q *DBQuerier) GetDeviceOrganizationBatch(batch genericBatch, deviceID uuid.UUID) {
+	batch.Queue(getDeviceOrganizationSQL, deviceID)
+}
+
+// GetDeviceOrganizationScan implements Querier.GetDeviceOrganizationScan.
+func (q *DBQuerier) GetDeviceOrganizationScan(results pgx.BatchResults) (GetDeviceOrganizationRow, error) {
+	row := results.QueryRow()
+	var item GetDeviceOrganizationRow
+	if err := row.Scan(&item.OrganizationID, &item.Name); err != nil {
+		return item, fmt.Errorf("scan GetDeviceOrganizationBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const listOrganizationMembersSQL = `SELECT u.id AS user_id, u.email, ou.role
+FROM organization_user AS ou
+         JOIN "user" AS u ON u.id = ou.user_id
+WHERE ou.organization_id = $1
+ORDER BY u.email;`
+
+type ListOrganizationMembersRow struct {
+	UserID uuid.UUID `json:"user_id"`
+	Email  *string   `json:"email"`
+	Role   *string   `json:"role"`
+}
+
+// ListOrganizationMembers implements Querier.ListOrganizationMembers.
+func (q *DBQuerier) ListOrganizationMembers(ctx context.Context, organizationID uuid.UUID) ([]ListOrganizationMembersRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "ListOrganizationMembers")
+	rows, err := q.conn.Query(ctx, listOrganizationMembersSQL, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("query ListOrganizationMembers: %w", err)
+	}
+	defer rows.Close()
+	items := []ListOrganizationMembersRow{}
+	for rows.Next() {
+		var item ListOrganizationMembersRow
+		if err := rows.Scan(&item.UserID, &item.Email, &item.Role); err != nil {
+			return nil, fmt.Errorf("scan ListOrganizationMembers row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListOrganizationMembers rows: %w", err)
+	}
+	return items, err
+}
+
+// ListOrganizationMembersBatch implements Querier.ListOrganizationMembersBatch.
+func (q *DBQuerier) ListOrganizationMembersBatch(batch genericBatch, organizationID uuid.UUID) {
+	batch.Queue(listOrganizationMembersSQL, organizationID)
+}
+
+// ListOrganizationMembersScan implements Querier.ListOrganizationMembersScan.
+func (q *DBQuerier) ListOrganizationMembersScan(results pgx.BatchResults) ([]ListOrganizationMembersRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query ListOrganizationMembersBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []ListOrganizationMembersRow{}
+	for rows.Next() {
+		var item ListOrganizationMembersRow
+		if err := rows.Scan(&item.UserID, &item.Email, &item.Role); err != nil {
+			return nil, fmt.Errorf("scan ListOrganizationMembersBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListOrganizationMembersBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const insertOrganizationUserSQL = `INSERT INTO organization_user (id, user_id, organization_id, role)
+VALUES (gen_random_uuid(), $1, $2, $3);`
+
+type InsertOrganizationUserParams struct {
+	UserID         uuid.UUID
+	OrganizationID uuid.UUID
+	Role           *string
+}
+
+// InsertOrganizationUser implements Querier.InsertOrganizationUser.
+func (q *DBQuerier) InsertOrganizationUser(ctx context.Context, params InsertOrganizationUserParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertOrganizationUser")
+	cmdTag, err := q.conn.Exec(ctx, insertOrganizationUserSQL, params.UserID, params.OrganizationID, params.Role)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query InsertOrganizationUser: %w", err)
+	}
+	return cmdTag, err
+}
+
+// InsertOrganizationUserBatch implements Querier.InsertOrganizationUserBatch.
+func (q *DBQuerier) InsertOrganizationUserBatch(batch genericBatch, params InsertOrganizationUserParams) {
+	batch.Queue(insertOrganizationUserSQL, params.UserID, params.OrganizationID, params.Role)
+}
+
+// InsertOrganizationUserScan implements Querier.InsertOrganizationUserScan.
+func (q *DBQuerier) InsertOrganizationUserScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec InsertOrganizationUserBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const setOrganizationUserRoleSQL = `UPDATE organization_user
+SET role = $1
+WHERE user_id = $2
+  AND organization_id = $3;`
+
+type SetOrganizationUserRoleParams struct {
+	Role           *string
+	UserID         uuid.UUID
+	OrganizationID uuid.UUID
+}
+
+// SetOrganizationUserRole implements Querier.SetOrganizationUserRole.
+func (q *DBQuerier) SetOrganizationUserRole(ctx context.Context, params SetOrganizationUserRoleParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "SetOrganizationUserRole")
+	cmdTag, err := q.conn.Exec(ctx, setOrganizationUserRoleSQL, params.Role, params.UserID, params.OrganizationID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query SetOrganizationUserRole: %w", err)
+	}
+	return cmdTag, err
+}
+
+// SetOrganizationUserRoleBatch implements Querier.SetOrganizationUserRoleBatch.
+func (q *DBQuerier) SetOrganizationUserRoleBatch(batch genericBatch, params SetOrganizationUserRoleParams) {
+	batch.Queue(setOrganizationUserRoleSQL, params.Role, params.UserID, params.OrganizationID)
+}
+
+// SetOrganizationUserRoleScan implements Querier.SetOrganizationUserRoleScan.
+func (q *DBQuerier) SetOrganizationUserRoleScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec SetOrganizationUserRoleBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const countOrganizationOwnersSQL = `SELECT count(*)
+FROM organization_user AS ou
+WHERE ou.organization_id = $1
+  AND ou.role = 'owner';`
+
+// CountOrganizationOwners implements Querier.CountOrganizationOwners.
+func (q *DBQuerier) CountOrganizationOwners(ctx context.Context, organizationID uuid.UUID) (*int, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "CountOrganizationOwners")
+	row := q.conn.QueryRow(ctx, countOrganizationOwnersSQL, organizationID)
+	var item *int
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query CountOrganizationOwners: %w", err)
+	}
+	return item, nil
+}
+
+// CountOrganizationOwnersBatch implements Querier.CountOrganizationOwnersBatch.
+func (q *DBQuerier) CountOrganizationOwnersBatch(batch genericBatch, organizationID uuid.UUID) {
+	batch.Queue(countOrganizationOwnersSQL, organizationID)
+}
+
+// CountOrganizationOwnersScan implements Querier.CountOrganizationOwnersScan.
+func (q *DBQuerier) CountOrganizationOwnersScan(results pgx.BatchResults) (*int, error) {
+	row := results.QueryRow()
+	var item *int
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan CountOrganizationOwnersBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const upsertOrganizationInvitationSQL = `INSERT INTO organization_invitation (id, organization_id, email, role, invited_by_user_id, created_at)
+VALUES (gen_random_uuid(), $1, lower($2), $3,
+        NULLIF($4, '00000000-0000-0000-0000-000000000000'::uuid), now())
+ON CONFLICT (organization_id, email) DO UPDATE SET role               = excluded.role,
+                                                   invited_by_user_id = excluded.invited_by_user_id,
+                                                   created_at         = excluded.created_at
+RETURNING *;`
+
+type UpsertOrganizationInvitationParams struct {
+	OrganizationID  uuid.UUID
+	Email           *string
+	Role            *string
+	InvitedByUserID uuid.UUID
+}
+
+type UpsertOrganizationInvitationRow struct {
+	ID              uuid.UUID  `json:"id"`
+	OrganizationID  uuid.UUID  `json:"organization_id"`
+	Email           *string    `json:"email"`
+	Role            *string    `json:"role"`
+	InvitedByUserID uuid.UUID  `json:"invited_by_user_id"`
+	CreatedAt       *time.Time `json:"created_at"`
+}
+
+// UpsertOrganizationInvitation implements Querier.UpsertOrganizationInvitation.
+func (q *DBQuerier) UpsertOrganizationInvitation(ctx context.Context, params UpsertOrganizationInvitationParams) (UpsertOrganizationInvitationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpsertOrganizationInvitation")
+	row := q.conn.QueryRow(ctx, upsertOrganizationInvitationSQL, params.OrganizationID, params.Email, params.Role, params.InvitedByUserID)
+	var item UpsertOrganizationInvitationRow
+	if err := row.Scan(&item.ID, &item.OrganizationID, &item.Email, &item.Role, &item.InvitedByUserID, &item.CreatedAt); err != nil {
+		return item, fmt.Errorf("query UpsertOrganizationInvitation: %w", err)
+	}
+	return item, nil
+}
+
+// UpsertOrganizationInvitationBatch implements Querier.UpsertOrganizationInvitationBatch.
+func (q *DBQuerier) UpsertOrganizationInvitationBatch(batch genericBatch, params UpsertOrganizationInvitationParams) {
+	batch.Queue(upsertOrganizationInvitationSQL, params.OrganizationID, params.Email, params.Role, params.InvitedByUserID)
+}
+
+// UpsertOrganizationInvitationScan implements Querier.UpsertOrganizationInvitationScan.
+func (q *DBQuerier) UpsertOrganizationInvitationScan(results pgx.BatchResults) (UpsertOrganizationInvitationRow, error) {
+	row := results.QueryRow()
+	var item UpsertOrganizationInvitationRow
+	if err := row.Scan(&item.ID, &item.OrganizationID, &item.Email, &item.Role, &item.InvitedByUserID, &item.CreatedAt); err != nil {
+		return item, fmt.Errorf("scan UpsertOrganizationInvitationBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const listOrganizationInvitationsSQL = `SELECT i.*
+FROM organization_invitation AS i
+WHERE i.organization_id = $1
+ORDER BY i.email;`
+
+type ListOrganizationInvitationsRow struct {
+	ID              uuid.UUID  `json:"id"`
+	OrganizationID  uuid.UUID  `json:"organization_id"`
+	Email           *string    `json:"email"`
+	Role            *string    `json:"role"`
+	InvitedByUserID uuid.UUID  `json:"invited_by_user_id"`
+	CreatedAt       *time.Time `json:"created_at"`
+}
+
+// ListOrganizationInvitations implements Querier.ListOrganizationInvitations.
+func (q *DBQuerier) ListOrganizationInvitations(ctx context.Context, organizationID uuid.UUID) ([]ListOrganizationInvitationsRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "ListOrganizationInvitations")
+	rows, err := q.conn.Query(ctx, listOrganizationInvitationsSQL, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("query ListOrganizationInvitations: %w", err)
+	}
+	defer rows.Close()
+	items := []ListOrganizationInvitationsRow{}
+	for rows.Next() {
+		var item ListOrganizationInvitationsRow
+		if err := rows.Scan(&item.ID, &item.OrganizationID, &item.Email, &item.Role, &item.InvitedByUserID, &item.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan ListOrganizationInvitations row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListOrganizationInvitations rows: %w", err)
+	}
+	return items, err
+}
+
+// ListOrganizationInvitationsBatch implements Querier.ListOrganizationInvitationsBatch.
+func (q *DBQuerier) ListOrganizationInvitationsBatch(batch genericBatch, organizationID uuid.UUID) {
+	batch.Queue(listOrganizationInvitationsSQL, organizationID)
+}
+
+// ListOrganizationInvitationsScan implements Querier.ListOrganizationInvitationsScan.
+func (q *DBQuerier) ListOrganizationInvitationsScan(results pgx.BatchResults) ([]ListOrganizationInvitationsRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query ListOrganizationInvitationsBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []ListOrganizationInvitationsRow{}
+	for rows.Next() {
+		var item ListOrganizationInvitationsRow
+		if err := rows.Scan(&item.ID, &item.OrganizationID, &item.Email, &item.Role, &item.InvitedByUserID, &item.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan ListOrganizationInvitationsBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListOrganizationInvitationsBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const acceptOrganizationInvitationsSQL = `WITH accepted AS (
+    DELETE FROM organization_invitation
+        WHERE email = lower($1)
+        RETURNING organization_id, role)
+INSERT
+INTO organization_user (id, user_id, organization_id, role)
+SELECT gen_random_uuid(), $2, a.organization_id, a.role
+FROM accepted AS a
+ON CONFLICT (user_id, organization_id) DO NOTHING;`
+
+// AcceptOrganizationInvitations implements Querier.AcceptOrganizationInvitations.
+func (q *DBQuerier) AcceptOrganizationInvitations(ctx context.Context, email *string, userID uuid.UUID) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "AcceptOrganizationInvitations")
+	cmdTag, err := q.conn.Exec(ctx, acceptOrganizationInvitationsSQL, email, userID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query AcceptOrganizationInvitations: %w", err)
+	}
+	return cmdTag, err
+}
+
+// AcceptOrganizationInvitationsBatch implements Querier.AcceptOrganizationInvitationsBatch.
+func (q *DBQuerier) AcceptOrganizationInvitationsBatch(batch genericBatch, email *string, userID uuid.UUID) {
+	batch.Queue(acceptOrganizationInvitationsSQL, email, userID)
+}
+
+// AcceptOrganizationInvitationsScan implements Querier.AcceptOrganizationInvitationsScan.
+func (q *DBQuerier) AcceptOrganizationInvitationsScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec AcceptOrganizationInvitationsBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const listWebhooksForOrganizationSQL = `SELECT w.*
+FROM webhook AS w
+WHERE w.organization_id = $1
+ORDER BY w.created_at;`
+
+type ListWebhooksForOrganizationRow struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	URL            *string    `json:"url"`
+	Description    *string    `json:"description"`
+	Secret         *string    `json:"secret"`
+	EventTypes     []string   `json:"event_types"`
+	Enabled        bool       `json:"enabled"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+// ListWebhooksForOrganization implements Querier.ListWebhooksForOrganization.
+func (q *DBQuerier) ListWebhooksForOrganization(ctx context.Context, organizationID uuid.UUID) ([]ListWebhooksForOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "ListWebhooksForOrganization")
+	rows, err := q.conn.Query(ctx, listWebhooksForOrganizationSQL, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("query ListWebhooksForOrganization: %w", err)
+	}
+	defer rows.Close()
+	items := []ListWebhooksForOrganizationRow{}
+	for rows.Next() {
+		var item ListWebhooksForOrganizationRow
+		if err := rows.Scan(&item.ID, &item.OrganizationID, &item.URL, &item.Description, &item.Secret, &item.EventTypes, &item.Enabled, &item.CreatedAt, &item.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan ListWebhooksForOrganization row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListWebhooksForOrganization rows: %w", err)
+	}
+	return items, err
+}
+
+// ListWebhooksForOrganizationBatch implements Querier.ListWebhooksForOrganizationBatch.
+func (q *DBQuerier) ListWebhooksForOrganizationBatch(batch genericBatch, organizationID uuid.UUID) {
+	batch.Queue(listWebhooksForOrganizationSQL, organizationID)
+}
+
+// ListWebhooksForOrganizationScan implements Querier.ListWebhooksForOrganizationScan.
+func (q *DBQuerier) ListWebhooksForOrganizationScan(results pgx.BatchResults) ([]ListWebhooksForOrganizationRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query ListWebhooksForOrganizationBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []ListWebhooksForOrganizationRow{}
+	for rows.Next() {
+		var item ListWebhooksForOrganizationRow
+		if err := rows.Scan(&item.ID, &item.OrganizationID, &item.URL, &item.Description, &item.Secret, &item.EventTypes, &item.Enabled, &item.CreatedAt, &item.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan ListWebhooksForOrganizationBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListWebhooksForOrganizationBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const getWebhookForOrganizationSQL = `SELECT w.*
+FROM webhook AS w
+WHERE w.id = $1
+  AND w.organization_id = $2;`
+
+type GetWebhookForOrganizationRow struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	URL            *string    `json:"url"`
+	Description    *string    `json:"description"`
+	Secret         *string    `json:"secret"`
+	EventTypes     []string   `json:"event_types"`
+	Enabled        bool       `json:"enabled"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+// GetWebhookForOrganization implements Querier.GetWebhookForOrganization.
+func (q *DBQuerier) GetWebhookForOrganization(ctx context.Context, webhookID uuid.UUID, organizationID uuid.UUID) (GetWebhookForOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetWebhookForOrganization")
+	row := q.conn.QueryRow(ctx, getWebhookForOrganizationSQL, webhookID, organizationID)
+	var item GetWebhookForOrganizationRow
+	if err := row.Scan(&item.ID, &item.OrganizationID, &item.URL, &item.Description, &item.Secret, &item.EventTypes, &item.Enabled, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		return item, fmt.Errorf("query GetWebhookForOrganization: %w", err)
+	}
+	return item, nil
+}
+
+// GetWebhookForOrganizationBatch implements Querier.GetWebhookForOrganizationBatch.
+func (q *DBQuerier) GetWebhookForOrganizationBatch(batch genericBatch, webhookID uuid.UUID, organizationID uuid.UUID) {
+	batch.Queue(getWebhookForOrganizationSQL, webhookID, organizationID)
+}
+
+// GetWebhookForOrganizationScan implements Querier.GetWebhookForOrganizationScan.
+func (q *DBQuerier) GetWebhookForOrganizationScan(results pgx.BatchResults) (GetWebhookForOrganizationRow, error) {
+	row := results.QueryRow()
+	var item GetWebhookForOrganizationRow
+	if err := row.Scan(&item.ID, &item.OrganizationID, &item.URL, &item.Description, &item.Secret, &item.EventTypes, &item.Enabled, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		return item, fmt.Errorf("scan GetWebhookForOrganizationBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const insertWebhookSQL = `INSERT INTO webhook (id, organization_id, url, description, secret, event_types, enabled, created_at, updated_at)
+VALUES (gen_random_uuid(), $1, $2, $3, $4,
+        $5, true, now(), now())
+RETURNING *;`
+
+type InsertWebhookParams struct {
+	OrganizationID uuid.UUID
+	URL            *string
+	Description    *string
+	Secret         *string
+	EventTypes     []string
+}
+
+type InsertWebhookRow struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	URL            *string    `json:"url"`
+	Description    *string    `json:"description"`
+	Secret         *string    `json:"secret"`
+	EventTypes     []string   `json:"event_types"`
+	Enabled        bool       `json:"enabled"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+// InsertWebhook implements Querier.InsertWebhook.
+func (q *DBQuerier) InsertWebhook(ctx context.Context, params InsertWebhookParams) (InsertWebhookRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertWebhook")
+	row := q.conn.QueryRow(ctx, insertWebhookSQL, params.OrganizationID, params.URL, params.Description, params.Secret, params.EventTypes)
+	var item InsertWebhookRow
+	if err := row.Scan(&item.ID, &item.OrganizationID, &item.URL, &item.Description, &item.Secret, &item.EventTypes, &item.Enabled, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		return item, fmt.Errorf("query InsertWebhook: %w", err)
+	}
+	return item, nil
+}
+
+// InsertWebhookBatch implements Querier.InsertWebhookBatch.
+func (q *DBQuerier) InsertWebhookBatch(batch genericBatch, params InsertWebhookParams) {
+	batch.Queue(insertWebhookSQL, params.OrganizationID, params.URL, params.Description, params.Secret, params.EventTypes)
+}
+
+// InsertWebhookScan implements Querier.InsertWebhookScan.
+func (q *DBQuerier) InsertWebhookScan(results pgx.BatchResults) (InsertWebhookRow, error) {
+	row := results.QueryRow()
+	var item InsertWebhookRow
+	if err := row.Scan(&item.ID, &item.OrganizationID, &item.URL, &item.Description, &item.Secret, &item.EventTypes, &item.Enabled, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		return item, fmt.Errorf("scan InsertWebhookBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const updateWebhookSQL = `UPDATE webhook
+SET url         = $1,
+    description = $2,
+    event_types = $3,
+    enabled     = $4,
+    updated_at  = now()
+WHERE id = $5
+  AND organization_id = $6
+RETURNING *;`
+
+type UpdateWebhookParams struct {
+	URL            *string
+	Description    *string
+	EventTypes     []string
+	Enabled        bool
+	WebhookID      uuid.UUID
+	OrganizationID uuid.UUID
+}
+
+type UpdateWebhookRow struct {
+	ID             uuid.UUID  `json:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	URL            *string    `json:"url"`
+	Description    *string    `json:"description"`
+	Secret         *string    `json:"secret"`
+	EventTypes     []string   `json:"event_types"`
+	Enabled        bool       `json:"enabled"`
+	CreatedAt      *time.Time `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at"`
+}
+
+// UpdateWebhook implements Querier.UpdateWebhook.
+func (q *DBQuerier) UpdateWebhook(ctx context.Context, params UpdateWebhookParams) (UpdateWebhookRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateWebhook")
+	row := q.conn.QueryRow(ctx, updateWebhookSQL, params.URL, params.Description, params.EventTypes, params.Enabled, params.WebhookID, params.OrganizationID)
+	var item UpdateWebhookRow
+	if err := row.Scan(&item.ID, &item.OrganizationID, &item.URL, &item.Description, &item.Secret, &item.EventTypes, &item.Enabled, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		return item, fmt.Errorf("query UpdateWebhook: %w", err)
+	}
+	return item, nil
+}
+
+// UpdateWebhookBatch implements Querier.UpdateWebhookBatch.
+func (q *DBQuerier) UpdateWebhookBatch(batch genericBatch, params UpdateWebhookParams) {
+	batch.Queue(updateWebhookSQL, params.URL, params.Description, params.EventTypes, params.Enabled, params.WebhookID, params.OrganizationID)
+}
+
+// UpdateWebhookScan implements Querier.UpdateWebhookScan.
+func (q *DBQuerier) UpdateWebhookScan(results pgx.BatchResults) (UpdateWebhookRow, error) {
+	row := results.QueryRow()
+	var item UpdateWebhookRow
+	if err := row.Scan(&item.ID, &item.OrganizationID, &item.URL, &item.Description, &item.Secret, &item.EventTypes, &item.Enabled, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		return item, fmt.Errorf("scan UpdateWebhookBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const deleteWebhookSQL = `DELETE FROM webhook
+WHERE id = $1
+  AND organization_id = $2;`
+
+// DeleteWebhook implements Querier.DeleteWebhook.
+func (q *DBQuerier) DeleteWebhook(ctx context.Context, webhookID uuid.UUID, organizationID uuid.UUID) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteWebhook")
+	cmdTag, err := q.conn.Exec(ctx, deleteWebhookSQL, webhookID, organizationID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query DeleteWebhook: %w", err)
+	}
+	return cmdTag, err
+}
+
+// DeleteWebhookBatch implements Querier.DeleteWebhookBatch.
+func (q *DBQuerier) DeleteWebhookBatch(batch genericBatch, webhookID uuid.UUID, organizationID uuid.UUID) {
+	batch.Queue(deleteWebhookSQL, webhookID, organizationID)
+}
+
+// DeleteWebhookScan implements Querier.DeleteWebhookScan.
+func (q *DBQuerier) DeleteWebhookScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec DeleteWebhookBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const enqueueWebhookDeliveriesSQL = `INSERT INTO webhook_delivery (id, webhook_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at)
+SELECT gen_random_uuid(), w.id, $1, $2::text, $3, 'pending', 0, now(), now()
+FROM webhook AS w
+WHERE w.organization_id = $4
+  AND w.enabled
+  AND (coalesce(cardinality(w.event_types), 0) = 0 OR $2::text = ANY (w.event_types));`
+
+type EnqueueWebhookDeliveriesParams struct {
+	EventID        uuid.UUID
+	EventType      *string
+	Payload        []byte
+	OrganizationID uuid.UUID
+}
+
+// EnqueueWebhookDeliveries implements Querier.EnqueueWebhookDeliveries.
+func (q *DBQuerier) EnqueueWebhookDeliveries(ctx context.Context, params EnqueueWebhookDeliveriesParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "EnqueueWebhookDeliveries")
+	cmdTag, err := q.conn.Exec(ctx, enqueueWebhookDeliveriesSQL, params.EventID, params.EventType, params.Payload, params.OrganizationID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query EnqueueWebhookDeliveries: %w", err)
+	}
+	return cmdTag, err
+}
+
+// EnqueueWebhookDeliveriesBatch implements Querier.EnqueueWebhookDeliveriesBatch.
+func (q *DBQuerier) EnqueueWebhookDeliveriesBatch(batch genericBatch, params EnqueueWebhookDeliveriesParams) {
+	batch.Queue(enqueueWebhookDeliveriesSQL, params.EventID, params.EventType, params.Payload, params.OrganizationID)
+}
+
+// EnqueueWebhookDeliveriesScan implements Querier.EnqueueWebhookDeliveriesScan.
+func (q *DBQuerier) EnqueueWebhookDeliveriesScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec EnqueueWebhookDeliveriesBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const claimWebhookDeliveriesSQL = `UPDATE webhook_delivery AS d
+SET next_attempt_at = now() + make_interval(secs => $1::int)
+FROM webhook AS w
+WHERE d.id IN (SELECT due.id
+               FROM webhook_delivery AS due
+               WHERE due.status = 'pending'
+                 AND due.next_attempt_at <= now()
+               ORDER BY due.next_attempt_at
+               LIMIT $2 FOR UPDATE SKIP LOCKED)
+  AND w.id = d.webhook_id
+RETURNING d.id, d.event_id, d.event_type, d.payload, d.attempts, w.url, w.secret;`
+
+type ClaimWebhookDeliveriesRow struct {
+	ID        uuid.UUID `json:"id"`
+	EventID   uuid.UUID `json:"event_id"`
+	EventType *string   `json:"event_type"`
+	Payload   []byte    `json:"payload"`
+	Attempts  int32     `json:"attempts"`
+	URL       *string   `json:"url"`
+	Secret    *string   `json:"secret"`
+}
+
+// ClaimWebhookDeliveries implements Querier.ClaimWebhookDeliveries.
+func (q *DBQuerier) ClaimWebhookDeliveries(ctx context.Context, leaseSeconds int32, batchSize *int) ([]ClaimWebhookDeliveriesRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "ClaimWebhookDeliveries")
+	rows, err := q.conn.Query(ctx, claimWebhookDeliveriesSQL, leaseSeconds, batchSize)
+	if err != nil {
+		return nil, fmt.Errorf("query ClaimWebhookDeliveries: %w", err)
+	}
+	defer rows.Close()
+	items := []ClaimWebhookDeliveriesRow{}
+	for rows.Next() {
+		var item ClaimWebhookDeliveriesRow
+		if err := rows.Scan(&item.ID, &item.EventID, &item.EventType, &item.Payload, &item.Attempts, &item.URL, &item.Secret); err != nil {
+			return nil, fmt.Errorf("scan ClaimWebhookDeliveries row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ClaimWebhookDeliveries rows: %w", err)
+	}
+	return items, err
+}
+
+// ClaimWebhookDeliveriesBatch implements Querier.ClaimWebhookDeliveriesBatch.
+func (q *DBQuerier) ClaimWebhookDeliveriesBatch(batch genericBatch, leaseSeconds int32, batchSize *int) {
+	batch.Queue(claimWebhookDeliveriesSQL, leaseSeconds, batchSize)
+}
+
+// ClaimWebhookDeliveriesScan implements Querier.ClaimWebhookDeliveriesScan.
+func (q *DBQuerier) ClaimWebhookDeliveriesScan(results pgx.BatchResults) ([]ClaimWebhookDeliveriesRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query ClaimWebhookDeliveriesBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []ClaimWebhookDeliveriesRow{}
+	for rows.Next() {
+		var item ClaimWebhookDeliveriesRow
+		if err := rows.Scan(&item.ID, &item.EventID, &item.EventType, &item.Payload, &item.Attempts, &item.URL, &item.Secret); err != nil {
+			return nil, fmt.Errorf("scan ClaimWebhookDeliveriesBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ClaimWebhookDeliveriesBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const recordWebhookDeliveryAttemptSQL = `UPDATE webhook_delivery
+SET status          = $1,
+    attempts        = attempts + 1,
+    last_attempt_at = now(),
+    response_status = nullif($2::int, 0),
+    last_error      = nullif($3::text, ''),
+    next_attempt_at = now() + make_interval(secs => $4::int)
+WHERE id = $5;`
+
+type RecordWebhookDeliveryAttemptParams struct {
+	Status            *string
+	ResponseStatus    int32
+	LastError         *string
+	RetryAfterSeconds int32
+	DeliveryID        uuid.UUID
+}
+
+// RecordWebhookDeliveryAttempt implements Querier.RecordWebhookDeliveryAttempt.
+func (q *DBQuerier) RecordWebhookDeliveryAttempt(ctx context.Context, params RecordWebhookDeliveryAttemptParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "RecordWebhookDeliveryAttempt")
+	cmdTag, err := q.conn.Exec(ctx, recordWebhookDeliveryAttemptSQL, params.Status, params.ResponseStatus, params.LastError, params.RetryAfterSeconds, params.DeliveryID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query RecordWebhookDeliveryAttempt: %w", err)
+	}
+	return cmdTag, err
+}
+
+// RecordWebhookDeliveryAttemptBatch implements Querier.RecordWebhookDeliveryAttemptBatch.
+func (q *DBQuerier) RecordWebhookDeliveryAttemptBatch(batch genericBatch, params RecordWebhookDeliveryAttemptParams) {
+	batch.Queue(recordWebhookDeliveryAttemptSQL, params.Status, params.ResponseStatus, params.LastError, params.RetryAfterSeconds, params.DeliveryID)
+}
+
+// RecordWebhookDeliveryAttemptScan implements Querier.RecordWebhookDeliveryAttemptScan.
+func (q *DBQuerier) RecordWebhookDeliveryAttemptScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec RecordWebhookDeliveryAttemptBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const listWebhookDeliveriesSQL = `SELECT d.*
+FROM webhook_delivery AS d
+WHERE d.webhook_id = $1
+  AND ($2::text = '' OR d.status = $2::text)
+  AND ($3::timestamp IS NULL
+    OR (d.created_at, d.id) < ($3::timestamp, $4::uuid))
+ORDER BY d.created_at DESC, d.id DESC
+LIMIT $5;`
+
+type ListWebhookDeliveriesParams struct {
+	WebhookID       uuid.UUID
+	Status          *string
+	BeforeCreatedAt *time.Time
+	BeforeID        uuid.UUID
+	PageSize        *int
+}
+
+type ListWebhookDeliveriesRow struct {
+	ID             uuid.UUID  `json:"id"`
+	WebhookID      uuid.UUID  `json:"webhook_id"`
+	EventID        uuid.UUID  `json:"event_id"`
+	EventType      *string    `json:"event_type"`
+	Payload        []byte     `json:"payload"`
+	Status         *string    `json:"status"`
+	Attempts       int32      `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at"`
+	ResponseStatus *int32     `json:"response_status"`
+	LastError      *string    `json:"last_error"`
+	CreatedAt      *time.Time `json:"created_at"`
+}
+
+// ListWebhookDeliveries implements Querier.ListWebhookDeliveries.
+func (q *DBQuerier) ListWebhookDeliveries(ctx context.Context, params ListWebhookDeliveriesParams) ([]ListWebhookDeliveriesRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "ListWebhookDeliveries")
+	rows, err := q.conn.Query(ctx, listWebhookDeliveriesSQL, params.WebhookID, params.Status, params.BeforeCreatedAt, params.BeforeID, params.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("query ListWebhookDeliveries: %w", err)
+	}
+	defer rows.Close()
+	items := []ListWebhookDeliveriesRow{}
+	for rows.Next() {
+		var item ListWebhookDeliveriesRow
+		if err := rows.Scan(&item.ID, &item.WebhookID, &item.EventID, &item.EventType, &item.Payload, &item.Status, &item.Attempts, &item.NextAttemptAt, &item.LastAttemptAt, &item.ResponseStatus, &item.LastError, &item.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan ListWebhookDeliveries row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListWebhookDeliveries rows: %w", err)
+	}
+	return items, err
+}
+
+// ListWebhookDeliveriesBatch implements Querier.ListWebhookDeliveriesBatch.
+func (q *DBQuerier) ListWebhookDeliveriesBatch(batch genericBatch, params ListWebhookDeliveriesParams) {
+	batch.Queue(listWebhookDeliveriesSQL, params.WebhookID, params.Status, params.BeforeCreatedAt, params.BeforeID, params.PageSize)
+}
+
+// ListWebhookDeliveriesScan implements Querier.ListWebhookDeliveriesScan.
+func (q *DBQuerier) ListWebhookDeliveriesScan(results pgx.BatchResults) ([]ListWebhookDeliveriesRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query ListWebhookDeliveriesBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []ListWebhookDeliveriesRow{}
+	for rows.Next() {
+		var item ListWebhookDeliveriesRow
+		if err := rows.Scan(&item.ID, &item.WebhookID, &item.EventID, &item.EventType, &item.Payload, &item.Status, &item.Attempts, &item.NextAttemptAt, &item.LastAttemptAt, &item.ResponseStatus, &item.LastError, &item.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan ListWebhookDeliveriesBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListWebhookDeliveriesBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const redeliverWebhookDeliverySQL = `UPDATE webhook_delivery
+SET status          = 'pending',
+    next_attempt_at = now()
+WHERE id = $1
+  AND webhook_id = $2
+RETURNING *;`
+
+type RedeliverWebhookDeliveryRow struct {
+	ID             uuid.UUID  `json:"id"`
+	WebhookID      uuid.UUID  `json:"webhook_id"`
+	EventID        uuid.UUID  `json:"event_id"`
+	EventType      *string    `json:"event_type"`
+	Payload        []byte     `json:"payload"`
+	Status         *string    `json:"status"`
+	Attempts       int32      `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at"`
+	ResponseStatus *int32     `json:"response_status"`
+	LastError      *string    `json:"last_error"`
+	CreatedAt      *time.Time `json:"created_at"`
+}
+
+// RedeliverWebhookDelivery implements Querier.RedeliverWebhookDelivery.
+func (q *DBQuerier) RedeliverWebhookDelivery(ctx context.Context, deliveryID uuid.UUID, webhookID uuid.UUID) (RedeliverWebhookDeliveryRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "RedeliverWebhookDelivery")
+	row := q.conn.QueryRow(ctx, redeliverWebhookDeliverySQL, deliveryID, webhookID)
+	var item RedeliverWebhookDeliveryRow
+	if err := row.Scan(&item.ID, &item.WebhookID, &item.EventID, &item.EventType, &item.Payload, &item.Status, &item.Attempts, &item.NextAttemptAt, &item.LastAttemptAt, &item.ResponseStatus, &item.LastError, &item.CreatedAt); err != nil {
+		return item, fmt.Errorf("query RedeliverWebhookDelivery: %w", err)
+	}
+	return item, nil
+}
+
+// RedeliverWebhookDeliveryBatch implements Querier.RedeliverWebhookDeliveryBatch.
+func (q *DBQuerier) RedeliverWebhookDeliveryBatch(batch genericBatch, deliveryID uuid.UUID, webhookID uuid.UUID) {
+	batch.Queue(redeliverWebhookDeliverySQL, deliveryID, webhookID)
+}
+
+// RedeliverWebhookDeliveryScan implements Querier.RedeliverWebhookDeliveryScan.
+func (q *DBQuerier) RedeliverWebhookDeliveryScan(results pgx.BatchResults) (RedeliverWebhookDeliveryRow, error) {
+	row := results.QueryRow()
+	var item RedeliverWebhookDeliveryRow
+	if err := row.Scan(&item.ID, &item.WebhookID, &item.EventID, &item.EventType, &item.Payload, &item.Status, &item.Attempts, &item.NextAttemptAt, &item.LastAttemptAt, &item.ResponseStatus, &item.LastError, &item.CreatedAt); err != nil {
+		return item, fmt.Errorf("scan RedeliverWebhookDeliveryBatch row: %w", err)
+	}
+	return item, nil
+}
+
+const deleteWebhookDeliveriesBeforeSQL = `DELETE FROM webhook_delivery
+WHERE created_at < $1
+  AND status <> 'pending';`
+
+// DeleteWebhookDeliveriesBefore implements Querier.DeleteWebhookDeliveriesBefore.
+func (q *DBQuerier) DeleteWebhookDeliveriesBefore(ctx context.Context, cutoff *time.Time) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteWebhookDeliveriesBefore")
+	cmdTag, err := q.conn.Exec(ctx, deleteWebhookDeliveriesBeforeSQL, cutoff)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query DeleteWebhookDeliveriesBefore: %w", err)
+	}
+	return cmdTag, err
+}
+
+// DeleteWebhookDeliveriesBeforeBatch implements Querier.DeleteWebhookDeliveriesBeforeBatch.
+func (q *DBQuerier) DeleteWebhookDeliveriesBeforeBatch(batch genericBatch, cutoff *time.Time) {
+	batch.Queue(deleteWebhookDeliveriesBeforeSQL, cutoff)
+}
+
+// DeleteWebhookDeliveriesBeforeScan implements Querier.DeleteWebhookDeliveriesBeforeScan.
+func (q *DBQuerier) DeleteWebhookDeliveriesBeforeScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec DeleteWebhookDeliveriesBeforeBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const insertJobRunSQL = `INSERT INTO job_run (id, device_id, task_id, task_name, schedule_id, status, started_at, finished_at, exit_code, error, log_tail, created_at)
+VALUES (gen_random_uuid(), $1, $2, $3, $4,
+        $5, $6, $7, $8, $9,
+        $10, now());`
+
+type InsertJobRunParams struct {
+	DeviceID   uuid.UUID
+	TaskID     *string
+	TaskName   *string
+	ScheduleID *string
+	Status     *string
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	ExitCode   int32
+	Error      *string
+	LogTail    *string
+}
+
+// InsertJobRun implements Querier.InsertJobRun.
+func (q *DBQuerier) InsertJobRun(ctx context.Context, params InsertJobRunParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertJobRun")
+	cmdTag, err := q.conn.Exec(ctx, insertJobRunSQL, params.DeviceID, params.TaskID, params.TaskName, params.ScheduleID, params.Status, params.StartedAt, params.FinishedAt, params.ExitCode, params.Error, params.LogTail)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query InsertJobRun: %w", err)
+	}
+	return cmdTag, err
+}
+
+// InsertJobRunBatch implements Querier.InsertJobRunBatch.
+func (q *DBQuerier) InsertJobRunBatch(batch genericBatch, params InsertJobRunParams) {
+	batch.Queue(insertJobRunSQL, params.DeviceID, params.TaskID, params.TaskName, params.ScheduleID, params.Status, params.StartedAt, params.FinishedAt, params.ExitCode, params.Error, params.LogTail)
+}
+
+// InsertJobRunScan implements Querier.InsertJobRunScan.
+func (q *DBQuerier) InsertJobRunScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec InsertJobRunBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const listJobRunsSQL = `SELECT r.*
+FROM job_run AS r
+WHERE r.device_id = $1
+  AND ($2::text = '' OR r.task_id = $2::text OR r.task_name = $2::text)
+  AND ($3::timestamp IS NULL
+    OR (r.started_at, r.id) < ($3::timestamp, $4::uuid))
+ORDER BY r.started_at DESC, r.id DESC
+LIMIT $5;`
+
+type ListJobRunsParams struct {
+	DeviceID        uuid.UUID
+	Job             *string
+	BeforeStartedAt *time.Time
+	BeforeID        uuid.UUID
+	PageSize        *int
+}
+
+type ListJobRunsRow struct {
+	ID         uuid.UUID  `json:"id"`
+	DeviceID   uuid.UUID  `json:"device_id"`
+	TaskID     *string    `json:"task_id"`
+	TaskName   *string    `json:"task_name"`
+	ScheduleID *string    `json:"schedule_id"`
+	Status     *string    `json:"status"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	ExitCode   int32      `json:"exit_code"`
+	Error      *string    `json:"error"`
+	LogTail    *string    `json:"log_tail"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
+
+// ListJobRuns implements Querier.ListJobRuns.
+func (q *DBQuerier) ListJobRuns(ctx context.Context, params ListJobRunsParams) ([]ListJobRunsRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "ListJobRuns")
+	rows, err := q.conn.Query(ctx, listJobRunsSQL, params.DeviceID, params.Job, params.BeforeStartedAt, params.BeforeID, params.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("query ListJobRuns: %w", err)
+	}
+	defer rows.Close()
+	items := []ListJobRunsRow{}
+	for rows.Next() {
+		var item ListJobRunsRow
+		if err := rows.Scan(&item.ID, &item.DeviceID, &item.TaskID, &item.TaskName, &item.ScheduleID, &item.Status, &item.StartedAt, &item.FinishedAt, &item.ExitCode, &item.Error, &item.LogTail, &item.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan ListJobRuns row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListJobRuns rows: %w", err)
+	}
+	return items, err
+}
+
+// ListJobRunsBatch implements Querier.ListJobRunsBatch.
+func (q *DBQuerier) ListJobRunsBatch(batch genericBatch, params ListJobRunsParams) {
+	batch.Queue(listJobRunsSQL, params.DeviceID, params.Job, params.BeforeStartedAt, params.BeforeID, params.PageSize)
+}
+
+// ListJobRunsScan implements Querier.ListJobRunsScan.
+func (q *DBQuerier) ListJobRunsScan(results pgx.BatchResults) ([]ListJobRunsRow, error) {
+	rows, err := results.Query()
+	if err != nil {
+		return nil, fmt.Errorf("query ListJobRunsBatch: %w", err)
+	}
+	defer rows.Close()
+	items := []ListJobRunsRow{}
+	for rows.Next() {
+		var item ListJobRunsRow
+		if err := rows.Scan(&item.ID, &item.DeviceID, &item.TaskID, &item.TaskName, &item.ScheduleID, &item.Status, &item.StartedAt, &item.FinishedAt, &item.ExitCode, &item.Error, &item.LogTail, &item.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan ListJobRunsBatch row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("close ListJobRunsBatch rows: %w", err)
+	}
+	return items, err
+}
+
+const deleteJobRunsBeforeSQL = `DELETE FROM job_run
+WHERE created_at < $1;`
+
+// DeleteJobRunsBefore implements Querier.DeleteJobRunsBefore.
+func (q *DBQuerier) DeleteJobRunsBefore(ctx context.Context, cutoff *time.Time) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteJobRunsBefore")
+	cmdTag, err := q.conn.Exec(ctx, deleteJobRunsBeforeSQL, cutoff)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query DeleteJobRunsBefore: %w", err)
+	}
+	return cmdTag, err
+}
+
+// DeleteJobRunsBeforeBatch implements Querier.DeleteJobRunsBeforeBatch.
+func (q *DBQuerier) DeleteJobRunsBeforeBatch(batch genericBatch, cutoff *time.Time) {
+	batch.Queue(deleteJobRunsBeforeSQL, cutoff)
+}
+
+// DeleteJobRunsBeforeScan implements Querier.DeleteJobRunsBeforeScan.
+func (q *DBQuerier) DeleteJobRunsBeforeScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec DeleteJobRunsBeforeBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const setDeviceRelayTokenHashSQL = `UPDATE device
+SET relay_token_hash = $1
+WHERE id = $2;`
+
+// SetDeviceRelayTokenHash implements Querier.SetDeviceRelayTokenHash.
+func (q *DBQuerier) SetDeviceRelayTokenHash(ctx context.Context, relayTokenHash *string, deviceID uuid.UUID) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "SetDeviceRelayTokenHash")
+	cmdTag, err := q.conn.Exec(ctx, setDeviceRelayTokenHashSQL, relayTokenHash, deviceID)
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec query SetDeviceRelayTokenHash: %w", err)
+	}
+	return cmdTag, err
+}
+
+// SetDeviceRelayTokenHashBatch implements Querier.SetDeviceRelayTokenHashBatch.
+func (q *DBQuerier) SetDeviceRelayTokenHashBatch(batch genericBatch, relayTokenHash *string, deviceID uuid.UUID) {
+	batch.Queue(setDeviceRelayTokenHashSQL, relayTokenHash, deviceID)
+}
+
+// SetDeviceRelayTokenHashScan implements Querier.SetDeviceRelayTokenHashScan.
+func (q *DBQuerier) SetDeviceRelayTokenHashScan(results pgx.BatchResults) (pgconn.CommandTag, error) {
+	cmdTag, err := results.Exec()
+	if err != nil {
+		return cmdTag, fmt.Errorf("exec SetDeviceRelayTokenHashBatch: %w", err)
+	}
+	return cmdTag, err
+}
+
+const getDeviceRelayTokenHashSQL = `SELECT relay_token_hash
+FROM device
+WHERE id = $1;`
+
+// GetDeviceRelayTokenHash implements Querier.GetDeviceRelayTokenHash.
+func (q *DBQuerier) GetDeviceRelayTokenHash(ctx context.Context, deviceID uuid.UUID) (*string, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetDeviceRelayTokenHash")
+	row := q.conn.QueryRow(ctx, getDeviceRelayTokenHashSQL, deviceID)
+	var item *string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("query GetDeviceRelayTokenHash: %w", err)
+	}
+	return item, nil
+}
+
+// GetDeviceRelayTokenHashBatch implements Querier.GetDeviceRelayTokenHashBatch.
+func (q *DBQuerier) GetDeviceRelayTokenHashBatch(batch genericBatch, deviceID uuid.UUID) {
+	batch.Queue(getDeviceRelayTokenHashSQL, deviceID)
+}
+
+// GetDeviceRelayTokenHashScan implements Querier.GetDeviceRelayTokenHashScan.
+func (q *DBQuerier) GetDeviceRelayTokenHashScan(results pgx.BatchResults) (*string, error) {
+	row := results.QueryRow()
+	var item *string
+	if err := row.Scan(&item); err != nil {
+		return item, fmt.Errorf("scan GetDeviceRelayTokenHashBatch row: %w", err)
+	}
+	return item, nil
+}
+
 // textPreferrer wraps a pgtype.ValueTranscoder and sets the preferred encoding
 // format to text instead binary (the default). pggen uses the text format
 // when the OID is unknownOID because the binary format requires the OID.
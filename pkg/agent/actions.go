@@ -0,0 +1,147 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+const (
+	actionRetryDelay = 5 * time.Second
+	// When the server refuses the relay, as it is disabled or the device token is wrong, which takes an operator to fix
+	actionRefusedRetryDelay = 5 * time.Minute
+
+	logFlushInterval = 2 * time.Second
+	// Lines waiting to be pushed; further lines are dropped while the server is unreachable
+	logQueueSize = 1000
+	logBatchSize = 500
+)
+
+// runActions long-polls the server for operator actions and runs each one as it arrives.
+func (a *Agent) runActions(ctx context.Context) {
+	for ctx.Err() == nil {
+		resp, err := a.client.PollActions(ctx, &connect.Request[com.PollActionsRequest]{
+			Msg: &com.PollActionsRequest{DeviceId: a.deviceID},
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.ErrorContext(ctx, "Failed to poll for actions", pkg.Err(err))
+			delay := actionRetryDelay
+			switch connect.CodeOf(err) {
+			case connect.CodeFailedPrecondition, connect.CodePermissionDenied, connect.CodeUnauthenticated:
+				delay = actionRefusedRetryDelay
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			continue
+		}
+		for _, action := range resp.Msg.GetActions() {
+			go a.runAction(ctx, action)
+		}
+	}
+}
+
+// findTaskContainer finds the managed container running the named task, also accepting the task's ID.
+func (a *Agent) findTaskContainer(ctx context.Context, task string) (string, error) {
+	existingContainers, err := a.runner.ListContainersMatchingLabel(ctx, pkg.LabelManaged, "true")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list containers")
+	}
+	for _, container := range existingContainers {
+		if container.Labels[pkg.LabelTaskName] == task || container.Labels[pkg.LabelTaskID] == task {
+			return container.ID, nil
+		}
+	}
+	return "", errors.Errorf("no container is running task %s", task)
+}
+
+func (a *Agent) runAction(ctx context.Context, action *com.DeviceAction) {
+	ctx = pkg.WithLogAttrs(ctx, slog.String("action_id", action.Id))
+	report := &com.ReportActionResultRequest{
+		DeviceId: a.deviceID,
+		ActionId: action.Id,
+	}
+	switch action := action.Action.(type) {
+	case *com.DeviceAction_Exec:
+		report.Result = &com.ReportActionResultRequest_Exec{Exec: a.exec(ctx, action.Exec)}
+	default:
+		slog.WarnContext(ctx, "Ignoring unsupported action", "type", fmt.Sprintf("%T", action))
+		return
+	}
+
+	_, err := a.client.ReportActionResult(ctx, &connect.Request[com.ReportActionResultRequest]{Msg: report})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to report action result", pkg.Err(err))
+	}
+}
+
+func (a *Agent) exec(ctx context.Context, action *com.ExecAction) *com.ExecResult {
+	slog.InfoContext(ctx, "Running command", "task", action.Container, "command", action.Command)
+	containerID, err := a.findTaskContainer(ctx, action.Container)
+	if err != nil {
+		return &com.ExecResult{Error: err.Error()}
+	}
+	result, err := a.runner.Exec(ctx, containerID, pkg.ExecOptions{
+		Command: action.Command,
+		Timeout: time.Duration(action.TimeoutSeconds) * time.Second,
+	})
+	if err != nil {
+		return &com.ExecResult{Error: err.Error()}
+	}
+	return &com.ExecResult{
+		Stdout:     result.Stdout,
+		Stderr:     result.Stderr,
+		ExitCode:   int32(result.ExitCode),
+		DurationMs: result.Duration.Milliseconds(),
+		Truncated:  result.Truncated,
+	}
+}
+
+// shipLog queues a line of a task's output for the server, without ever blocking the container's log reader.
+func (a *Agent) shipLog(task string, line string) {
+	select {
+	case a.logs <- &com.LogLine{Container: task, Time: timestamppb.Now(), Line: line}:
+	default:
+	}
+}
+
+// runLogShipper pushes queued log lines to the server in batches.
+func (a *Agent) runLogShipper(ctx context.Context) {
+	ticker := time.NewTicker(logFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for len(a.logs) > 0 {
+			batch := make([]*com.LogLine, 0, logBatchSize)
+			for len(batch) < logBatchSize && len(a.logs) > 0 {
+				batch = append(batch, <-a.logs)
+			}
+			_, err := a.client.PushLogs(ctx, &connect.Request[com.PushLogsRequest]{
+				Msg: &com.PushLogsRequest{DeviceId: a.deviceID, Lines: batch},
+			})
+			if err != nil {
+				// the batch is dropped; logs are best-effort
+				slog.ErrorContext(ctx, "Failed to push logs", "lines", len(batch), pkg.Err(err))
+				break
+			}
+		}
+	}
+}
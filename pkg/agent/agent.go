@@ -0,0 +1,678 @@
+// Package agent is the on-device agent: it applies the schedule the server sends to the container engine, rolls
+// back schedules that fail, runs jobs, reports state and incidents, and relays logs and exec. cmd/remote runs it
+// against the device's engine, and cmd/simulator runs many of them against pkg.FakeRuntime.
+package agent
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com/comconnect"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+// tracer follows each scheduler tick from fetching the schedule through applying and reporting it.
+var tracer = otel.Tracer("github.com/uinta-labs/pando/pkg/agent")
+
+// Agent applies one device's schedules; create it with New and start it with Run.
+type Agent struct {
+	cfg      pkg.AgentConfig
+	deviceID string
+	client   comconnect.RemoteServiceClient
+	runner   pkg.ContainerRuntime
+	rollback *rollbackTracker
+
+	// Task output waiting to be pushed to the server
+	logs chan *com.LogLine
+	// Crashes and failed health checks waiting to be reported
+	incidents *incidents
+	metrics   *agentMetrics
+	updates   *selfUpdater
+	status    *agentStatus
+
+	// Requests from the local status API, handled by the scheduler between ticks
+	reconcileRequests chan struct{}
+	restartRequests   chan restartRequest
+
+	// Images used by these schedules are protected from garbage collection
+	currentSchedule  *com.Schedule
+	previousSchedule *com.Schedule
+
+	// When disruptive changes may be made, as last sent by the server; nil allows them at any time
+	maintenance *com.MaintenancePolicy
+	// The schedule part way applied while its disruptive changes wait for a maintenance window, and what waits
+	pendingSchedule *com.Schedule
+	pendingUpdate   *com.PendingUpdate
+
+	// Job containers of the applied schedule, and the history of their runs
+	jobs *jobs
+	// Images the agent pulled, which garbage collection may remove
+	pulled *pulledImages
+}
+
+// schedulePlan is the set of changes needed to move the engine from its current state to a schedule.
+type schedulePlan struct {
+	toStop  []types.Container
+	toStart []*com.Container
+}
+
+func (p *schedulePlan) empty() bool {
+	return len(p.toStop) == 0 && len(p.toStart) == 0
+}
+
+// images lists, without duplicates, every image the plan needs before it can start its containers.
+func (p *schedulePlan) images() []string {
+	seen := map[string]bool{}
+	images := []string{}
+	for _, task := range p.toStart {
+		if seen[task.ContainerImage] {
+			continue
+		}
+		seen[task.ContainerImage] = true
+		images = append(images, task.ContainerImage)
+	}
+	return images
+}
+
+func (a *Agent) planSchedule(ctx context.Context, schedule *com.Schedule) (*schedulePlan, error) {
+	existingContainers, err := a.runner.ListContainersMatchingLabel(ctx, pkg.LabelManaged, "true")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list containers")
+	}
+
+	tasks := map[string]*com.Container{}
+	for _, task := range schedule.Containers {
+		if task.Job == nil {
+			tasks[task.Id] = task
+		}
+	}
+
+	plan := &schedulePlan{}
+	upToDate := map[string]bool{}
+	for _, container := range existingContainers {
+		if container.Labels[pkg.LabelJob] == "true" {
+			// job runs are left to finish or time out, whatever the schedule
+			continue
+		}
+		task, ok := tasks[container.Labels[pkg.LabelTaskID]]
+		if ok && container.Labels[pkg.LabelTaskHash] == pkg.TaskHash(task) {
+			upToDate[task.Id] = true
+			continue
+		}
+		plan.toStop = append(plan.toStop, container)
+	}
+	for _, task := range schedule.Containers {
+		if task.Job != nil {
+			continue
+		}
+		if upToDate[task.Id] {
+			slog.DebugContext(ctx, "Task already running", pkg.LogKeyTaskID, task.Id)
+			continue
+		}
+		plan.toStart = append(plan.toStart, task)
+	}
+	return plan, nil
+}
+
+// downloadImages pulls every image the plan needs while the current containers keep running.
+// It stops at the first failure so that a schedule is never half-applied because of a missing image.
+func (a *Agent) downloadImages(ctx context.Context, plan *schedulePlan) error {
+	for _, imageReference := range plan.images() {
+		slog.InfoContext(ctx, "Pulling image", "image", imageReference)
+		if err := a.pullImage(ctx, imageReference); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *Agent) startTask(ctx context.Context, schedule *com.Schedule, task *com.Container) (string, error) {
+	return a.startTaskAs(ctx, schedule, task, task.Id)
+}
+
+// startTaskAs starts task in a container named name, which is only something other than the task's ID while the
+// container is started alongside the one it replaces.
+func (a *Agent) startTaskAs(ctx context.Context, schedule *com.Schedule, task *com.Container, name string) (string, error) {
+	return a.runTask(ctx, schedule, task, name, nil)
+}
+
+// runTask starts task in a container named name. Given a job run's output, it also keeps the container's last
+// lines of output there and waits for the container to exit.
+func (a *Agent) runTask(ctx context.Context, schedule *com.Schedule, task *com.Container, name string, output *logTail) (string, error) {
+	ctx = pkg.WithLogAttrs(ctx, slog.String(pkg.LogKeyTaskID, task.Id))
+	startImageCtx := context.WithValue(ctx, "task", task)
+	slog.InfoContext(ctx, "Running task", "name", task.Name)
+
+	logChannels := pkg.NewLogChannels(ctx)
+	go func() {
+		// for now, leak a goroutine and just log the output
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-logChannels.ChannelClosed:
+				return
+			case <-logChannels.Finished:
+				if output != nil {
+					output.finish()
+				}
+				return
+			case line := <-logChannels.Mixed:
+				// by name, as output can arrive before the container's ID is known
+				slog.InfoContext(ctx, "Container output", pkg.LogKeyContainer, name, "line", line)
+				a.shipLog(task.Name, line)
+				if output != nil {
+					output.add(line)
+				}
+			}
+		}
+	}()
+	environmentVariables := []string{}
+	for k, v := range task.Env {
+		environmentVariables = append(environmentVariables, fmt.Sprintf("%s=%s", k, v))
+	}
+	commandLine := []string{}
+	if task.Command != "" {
+		commandLine = append(commandLine, task.Command)
+	}
+
+	labels := map[string]string{
+		pkg.LabelTaskID:     task.Id,
+		pkg.LabelTaskHash:   pkg.TaskHash(task),
+		pkg.LabelTaskName:   task.Name,
+		pkg.LabelScheduleID: schedule.Id,
+	}
+	if task.Job != nil {
+		labels[pkg.LabelJob] = "true"
+	}
+
+	return a.runner.RunContainer(startImageCtx, task.ContainerImage, name, commandLine, environmentVariables, labels, &pkg.AdvancedOptions{
+		BindMountDockerSocket: task.BindDockerSocket,
+		//NetworkModeContainer:  "",
+		NetworkModeHost:            task.NetworkMode == com.Container_HOST,
+		DockerEngineSocketOverride: a.cfg.DockerHost,
+		StopSignal:                 task.StopSignal,
+		StopGracePeriod:            time.Duration(task.StopGracePeriodSeconds) * time.Second,
+	}, logChannels, output != nil)
+}
+
+// stopping is a container being stopped in the background during a switchover.
+type stopping struct {
+	container types.Container
+	done      chan struct{}
+}
+
+// conflictsWith reports whether task has to wait for the stopping container to be gone before it can start,
+// because it needs the same container name or both use host networking.
+func (s *stopping) conflictsWith(task *com.Container) bool {
+	if s.container.Labels[pkg.LabelTaskID] == task.Id {
+		return true
+	}
+	for _, name := range s.container.Names {
+		if strings.TrimPrefix(name, "/") == task.Id {
+			return true
+		}
+	}
+	return hostNetworkConflict(s.container, task)
+}
+
+// hostNetworkConflict reports whether task and container cannot run side by side because both use host
+// networking, which binds ports directly, so which ports each takes is unknown. Clashes over the host ports in
+// task.Ports are not detected: the agent does not publish them, so a container on bridge networking holds no host
+// ports.
+func hostNetworkConflict(container types.Container, task *com.Container) bool {
+	return task.NetworkMode == com.Container_HOST && container.HostConfig.NetworkMode == "host"
+}
+
+// switchover stops containers that are no longer wanted and starts the plan's tasks. It only runs once
+// downloadImages has succeeded, so every image is already present. Stops run concurrently, and each task
+// only waits for the stops it conflicts with. Containers of start-first tasks are only stopped once their
+// replacement is healthy.
+func (a *Agent) switchover(ctx context.Context, schedule *com.Schedule, plan *schedulePlan) error {
+	replacing := a.startFirstReplacements(ctx, plan)
+	stops := make([]*stopping, 0, len(plan.toStop))
+	for _, container := range plan.toStop {
+		if old, ok := replacing[container.Labels[pkg.LabelTaskID]]; ok && old.ID == container.ID {
+			continue
+		}
+		s := &stopping{container: container, done: make(chan struct{})}
+		stops = append(stops, s)
+		go func() {
+			defer close(s.done)
+			slog.InfoContext(ctx, "Stopping container", pkg.LogKeyContainer, container.ID)
+			a.incidents.expectExit(container.ID)
+			if err := a.runner.StopContainer(ctx, container.ID); err != nil {
+				slog.ErrorContext(ctx, "Failed to stop container", pkg.LogKeyContainer, container.ID, pkg.Err(err))
+			}
+		}()
+	}
+
+	failures := &startError{}
+	for _, task := range plan.toStart {
+		for _, s := range stops {
+			if s.conflictsWith(task) {
+				<-s.done
+			}
+		}
+		var containerID string
+		var err error
+		if old, ok := replacing[task.Id]; ok {
+			containerID, err = a.startFirst(ctx, schedule, task, old)
+		} else {
+			containerID, err = a.startTask(ctx, schedule, task)
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to run task", pkg.LogKeyTaskID, task.Id, "retryable", retryable(err), pkg.Err(err))
+			failures.failed++
+			if !retryable(err) {
+				failures.permanent++
+			}
+			continue
+		}
+		slog.InfoContext(ctx, "Task started", pkg.LogKeyTaskID, task.Id, pkg.LogKeyContainer, containerID)
+	}
+
+	for _, s := range stops {
+		<-s.done
+	}
+
+	if failures.failed > 0 {
+		return failures
+	}
+	return nil
+}
+
+// countRestarts counts the tasks the plan starts that were already running as part of the applied schedule,
+// which can only be starting again because their container went away.
+func (a *Agent) countRestarts(plan *schedulePlan) int {
+	if a.currentSchedule == nil {
+		return 0
+	}
+	applied := map[string]string{}
+	for _, task := range a.currentSchedule.Containers {
+		applied[task.Id] = pkg.TaskHash(task)
+	}
+	restarts := 0
+	for _, task := range plan.toStart {
+		if hash, ok := applied[task.Id]; ok && hash == pkg.TaskHash(task) {
+			restarts++
+		}
+	}
+	return restarts
+}
+
+// applySchedule brings the engine in line with schedule, returning whether any containers were started or removed.
+// Applying happens in two phases: every required image is downloaded while the old containers keep running,
+// and only then are containers switched over. A failed download leaves the engine untouched.
+// Unless disruptive, running containers are left alone, and the number of them still to be stopped or replaced
+// is returned.
+func (a *Agent) applySchedule(ctx context.Context, schedule *com.Schedule, disruptive bool) (bool, int, error) {
+	ctx = pkg.WithLogAttrs(ctx, slog.String(pkg.LogKeyScheduleID, schedule.Id))
+	slog.InfoContext(ctx, "Applying schedule")
+
+	plan, err := a.planSchedule(ctx, schedule)
+	if err != nil {
+		return false, 0, err
+	}
+	if plan.empty() {
+		return false, 0, nil
+	}
+
+	// Deferred tasks' images are downloaded too, so that the window is only spent switching over
+	if err := a.downloadImages(ctx, plan); err != nil {
+		return false, 0, errors.Wrap(err, "download phase failed, keeping current containers")
+	}
+	held := &schedulePlan{}
+	if !disruptive {
+		held = plan.holdBack()
+		if plan.empty() {
+			return false, len(held.toStop), nil
+		}
+	}
+	a.metrics.containerRestarts.Add(float64(a.countRestarts(plan)))
+
+	return true, len(held.toStop), a.switchover(ctx, schedule, plan)
+}
+
+// keptImages lists the images of the current, previous, known-good and pending schedules, which garbage collection
+// must not remove.
+func (a *Agent) keptImages() []string {
+	images := []string{}
+	for _, schedule := range []*com.Schedule{a.currentSchedule, a.previousSchedule, a.rollback.lastKnownGood, a.pendingSchedule} {
+		if schedule == nil {
+			continue
+		}
+		for _, task := range schedule.Containers {
+			images = append(images, task.ContainerImage)
+		}
+	}
+	return images
+}
+
+func (a *Agent) collectGarbage(ctx context.Context) {
+	kept := a.keptImages()
+	// Schedule images count as pulled, as the agent pulls every image it starts a task from; this also covers
+	// images pulled before the agent kept a record
+	if err := a.pulled.add(kept...); err != nil {
+		slog.ErrorContext(ctx, "Failed to record pulled images", pkg.Err(err))
+	}
+	report, err := a.runner.PruneUnreferenced(ctx, a.pulled.list(), kept)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to collect garbage", pkg.Err(err))
+	}
+	if report != nil {
+		if err := a.pulled.remove(report.PulledImagesGone); err != nil {
+			slog.ErrorContext(ctx, "Failed to record removed images", pkg.Err(err))
+		}
+		slog.InfoContext(ctx, "Collected garbage", "containers", len(report.ContainersDeleted),
+			"images", len(report.ImagesDeleted), "volumes", len(report.VolumesDeleted), "bytes_reclaimed", report.SpaceReclaimed)
+	}
+}
+
+// checkDiskPressure collects garbage when free space on the engine's filesystem drops below the configured threshold.
+func (a *Agent) checkDiskPressure(ctx context.Context) {
+	freePercent, err := pkg.FreeDiskPercent(a.cfg.GCDiskPath)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to check free disk space", pkg.Err(err))
+		return
+	}
+	if freePercent >= a.cfg.GCMinFreePercent {
+		return
+	}
+	slog.WarnContext(ctx, "Free disk space is low, collecting garbage", "free_percent", freePercent, "min_free_percent", a.cfg.GCMinFreePercent)
+	a.collectGarbage(ctx)
+}
+
+// recordApplied tracks schedule as the one now running on the engine.
+func (a *Agent) recordApplied(schedule *com.Schedule) {
+	if !proto.Equal(a.currentSchedule, schedule) {
+		a.previousSchedule = a.currentSchedule
+		a.currentSchedule = schedule
+	}
+}
+
+// reconcile applies schedule, or the last known-good schedule if this version of it already failed here, and
+// rolls back if a schedule on probation fails to start or goes unhealthy. Outside maintenance windows, only changes
+// that interrupt nothing are made, and the schedule is not counted as applied until the rest are.
+func (a *Agent) reconcile(ctx context.Context, schedule *com.Schedule) {
+	ctx, span := tracer.Start(ctx, "reconcile", trace.WithAttributes(attribute.String(pkg.LogKeyScheduleID, schedule.Id)))
+	defer span.End()
+
+	target := schedule
+	if a.rollback.refused(schedule) {
+		slog.WarnContext(ctx, "Schedule failed on this device before; not applying it again until it changes", pkg.LogKeyScheduleID, schedule.Id)
+		target = a.rollback.lastKnownGood
+		if target == nil {
+			return
+		}
+	}
+	// A rollback that failed to apply is retried without waiting for a maintenance window, unless the server has
+	// since sent a schedule to replace the failed one
+	rollingBack := false
+	if unreported := a.rollback.unreported; unreported != nil {
+		if target == a.rollback.lastKnownGood {
+			rollingBack = true
+		} else {
+			slog.WarnContext(ctx, "Abandoning rollback, as the failed schedule has been replaced", pkg.LogKeyScheduleID, unreported.FailedScheduleId)
+			a.rollback.unreported = nil
+		}
+	}
+
+	// Check before applying, as applying would quietly restart any task that has died since the last tick
+	if a.rollback.onProbation(target) {
+		reason, err := a.checkScheduleHealth(ctx, target)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to check schedule health", pkg.LogKeyScheduleID, target.Id, pkg.Err(err))
+			return
+		}
+		// With nothing to roll back to, the schedule is applied as usual, which restarts whatever has died
+		if reason != "" && a.rollbackFrom(ctx, target, reason) {
+			return
+		}
+	}
+
+	// Jobs interrupt nothing when they change, so they follow the schedule whatever the maintenance windows
+	a.jobs.update(ctx, target)
+	open, nextWindow := a.maintenanceWindowOpen(ctx)
+	changed, deferred, err := a.applySchedule(ctx, target, open || rollingBack)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to apply schedule", pkg.LogKeyScheduleID, target.Id, "retryable", retryable(err), pkg.Err(err))
+		// Download failures leave the engine untouched, and the next tick retries anything that failed for a
+		// passing reason; only roll back once containers failed to start for good
+		if changed && !retryable(err) {
+			a.rollbackFrom(ctx, target, err.Error())
+		}
+		return
+	}
+	if deferred > 0 {
+		a.deferUpdate(ctx, target, deferred, nextWindow)
+		return
+	}
+	a.clearPendingUpdate(ctx)
+	a.recordApplied(target)
+	a.reportRollback(ctx)
+	a.rollback.observeApplied(target)
+	if changed {
+		a.collectGarbage(ctx)
+	}
+}
+
+// reportState tells the server which tasks are running here; the call also serves as the device's heartbeat.
+func (a *Agent) reportState(ctx context.Context) {
+	existingContainers, err := a.runner.ListContainersMatchingLabel(ctx, pkg.LabelManaged, "true")
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list containers to report", pkg.Err(err))
+		return
+	}
+
+	states := make([]*com.ContainerState, 0, len(existingContainers))
+	for _, container := range existingContainers {
+		if container.Labels[pkg.LabelJob] == "true" {
+			// reported as job runs once they finish
+			continue
+		}
+		states = append(states, &com.ContainerState{
+			Id:         container.Labels[pkg.LabelTaskID],
+			Name:       container.Labels[pkg.LabelTaskName],
+			Status:     container.State,
+			ScheduleId: container.Labels[pkg.LabelScheduleID],
+		})
+	}
+	incidents := a.incidents.take()
+	jobRuns := a.jobs.takeRuns()
+	_, err = a.client.ReportScheduleState(ctx, &connect.Request[com.ReportScheduleStateRequest]{
+		Msg: &com.ReportScheduleStateRequest{
+			DeviceId:        a.deviceID,
+			ContainerStates: states,
+			Incidents:       incidents,
+			PendingUpdate:   a.pendingUpdate,
+			JobRuns:         jobRuns,
+		},
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to report state", pkg.Err(err))
+		a.incidents.restore(incidents)
+		a.jobs.restoreRuns(jobRuns)
+	}
+}
+
+func (a *Agent) runSchedulerTick(ctx context.Context) {
+	ctx, span := tracer.Start(ctx, "scheduler.tick", trace.WithAttributes(attribute.String(pkg.LogKeyDeviceID, a.deviceID)))
+	defer span.End()
+
+	slog.DebugContext(ctx, "Running scheduler")
+	schedule, err := a.client.GetSchedule(ctx, &connect.Request[com.GetScheduleRequest]{
+		Msg: &com.GetScheduleRequest{
+			DeviceId: a.deviceID,
+		},
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get schedule", pkg.Err(err))
+	}
+
+	if schedule != nil && schedule.Msg != nil {
+		a.maintenance = schedule.Msg.Maintenance
+	}
+	if schedule != nil && schedule.Msg != nil && schedule.Msg.Schedule != nil {
+		a.status.recordDesired(schedule.Msg.Schedule)
+		startedAt := time.Now()
+		a.reconcile(ctx, schedule.Msg.Schedule)
+		a.metrics.reconcileDuration.Observe(time.Since(startedAt).Seconds())
+		a.status.recordReconciled(a.currentSchedule, a.pendingUpdate)
+	} else {
+		slog.DebugContext(ctx, "Received empty schedule")
+	}
+
+	a.reportState(ctx)
+	a.checkDiskPressure(ctx)
+	if schedule != nil && schedule.Msg != nil {
+		a.updateAgent(ctx, schedule.Msg.AgentImage)
+	}
+}
+
+func (a *Agent) runScheduler(ctx context.Context) {
+	a.runSchedulerTick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(a.cfg.ScheduleInterval):
+			a.runSchedulerTick(ctx)
+		case <-a.reconcileRequests:
+			slog.InfoContext(ctx, "Reconciling on request")
+			a.runSchedulerTick(ctx)
+		case req := <-a.restartRequests:
+			req.done <- a.restartTask(ctx, req.task)
+		}
+	}
+}
+
+// withAgentVersion tags every call with the agent's version, which the server records for the device.
+func withAgentVersion(version string) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			req.Header().Set(pkg.AgentVersionHeader, version)
+			return next(ctx, req)
+		}
+	}
+}
+
+// withDeviceToken presents the device's relay token, which the server requires on calls to the action and log relay.
+func withDeviceToken(token string) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if token != "" {
+				req.Header().Set(pkg.DeviceTokenHeader, token)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// Options are what an Agent is given besides its configuration.
+type Options struct {
+	// Identifies the device to the server
+	DeviceID string
+	Runtime  pkg.ContainerRuntime
+	// Calls the server at cfg.APIURL
+	HTTPClient connect.HTTPClient
+	// Run ahead of the agent's own interceptors on every call to the server
+	Interceptors []connect.Interceptor
+	// Reported to the server as the agent's version; pkg.Version when empty
+	Version string
+	// The agent's metrics are registered here
+	Registerer prometheus.Registerer
+}
+
+// New returns an agent for cfg, picking up the state an earlier agent left in cfg.StateDir.
+func New(cfg pkg.AgentConfig, options Options) (*Agent, error) {
+	rollback, err := loadRollbackTracker(cfg.StateDir, cfg.RollbackSoakPeriod)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load rollback state")
+	}
+	updates, err := loadSelfUpdater(cfg.StateDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load agent update state")
+	}
+	jobs, err := loadJobs(cfg.StateDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load job state")
+	}
+	pulled, err := loadPulledImages(cfg.StateDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load pulled images")
+	}
+
+	version := options.Version
+	if version == "" {
+		version = pkg.Version
+	}
+	status := &agentStatus{}
+	metrics := newAgentMetrics(options.Registerer)
+	interceptors := append(slices.Clone(options.Interceptors), withAgentVersion(version), withDeviceToken(cfg.DeviceToken), metrics.countServerErrors(), status.observeServer())
+	client := comconnect.NewRemoteServiceClient(options.HTTPClient, cfg.APIURL, connect.WithClientOptions(connect.WithSendGzip()), connect.WithInterceptors(interceptors...))
+
+	return &Agent{
+		cfg:       cfg,
+		deviceID:  options.DeviceID,
+		client:    client,
+		runner:    options.Runtime,
+		rollback:  rollback,
+		logs:      make(chan *com.LogLine, logQueueSize),
+		incidents: newIncidents(),
+		metrics:   metrics,
+		updates:   updates,
+		status:    status,
+		jobs:      jobs,
+		pulled:    pulled,
+
+		reconcileRequests: make(chan struct{}, 1),
+		restartRequests:   make(chan restartRequest),
+	}, nil
+}
+
+// RecordErrors wraps handler so that error-level records are also kept for the local status API.
+func (a *Agent) RecordErrors(handler slog.Handler) slog.Handler {
+	return &errorRecorder{Handler: handler, status: a.status}
+}
+
+// Run runs the agent until ctx is done.
+func (a *Agent) Run(ctx context.Context) {
+	if a.cfg.HandoverFrom != "" {
+		if err := a.takeOver(ctx); err != nil {
+			// the old agent is still in charge, and removes this one once the deadline has passed
+			slog.ErrorContext(ctx, "Failed to take over from the old agent", pkg.LogKeyContainer, a.cfg.HandoverFrom, pkg.Err(err))
+			<-ctx.Done()
+			return
+		}
+	}
+	a.resumeHandover(ctx)
+
+	go a.runScheduler(ctx)
+	go a.runJobs(ctx)
+	if a.cfg.DeviceToken != "" {
+		go a.runActions(ctx)
+		go a.runLogShipper(ctx)
+	} else {
+		slog.InfoContext(ctx, "No DEVICE_TOKEN set; remote exec and log shipping are disabled")
+	}
+	go a.watchIncidents(ctx)
+	// Only once this agent is in charge, as one it hands over to listens on the same socket
+	a.serveStatus(ctx)
+
+	<-ctx.Done()
+}
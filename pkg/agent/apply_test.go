@@ -0,0 +1,396 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com/comconnect"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+// fakeServer records the rollbacks the agent reports; the agent makes no other calls while applying a schedule.
+type fakeServer struct {
+	comconnect.RemoteServiceClient
+	rollbacks []*com.ReportRollbackRequest
+}
+
+func (s *fakeServer) ReportRollback(ctx context.Context, req *connect.Request[com.ReportRollbackRequest]) (*connect.Response[com.ReportRollbackResponse], error) {
+	s.rollbacks = append(s.rollbacks, req.Msg)
+	return &connect.Response[com.ReportRollbackResponse]{Msg: &com.ReportRollbackResponse{}}, nil
+}
+
+// newTestAgent returns an agent running containers on a FakeRuntime, with its state kept in a temporary directory.
+// Schedules become known-good as soon as they have been applied twice.
+func newTestAgent(t *testing.T) (*Agent, *pkg.FakeRuntime, *fakeServer) {
+	t.Helper()
+	stateDir := t.TempDir()
+	rollback, err := loadRollbackTracker(stateDir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs, err := loadJobs(stateDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pulled, err := loadPulledImages(stateDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runtime := pkg.NewFakeRuntime()
+	server := &fakeServer{}
+	a := &Agent{
+		deviceID:  "device",
+		client:    server,
+		runner:    runtime,
+		rollback:  rollback,
+		logs:      make(chan *com.LogLine, logQueueSize),
+		incidents: newIncidents(),
+		metrics:   newAgentMetrics(prometheus.NewRegistry()),
+		jobs:      jobs,
+		pulled:    pulled,
+	}
+	return a, runtime, server
+}
+
+func testSchedule(id string, tasks ...*com.Container) *com.Schedule {
+	return &com.Schedule{Id: id, Containers: tasks}
+}
+
+func testTask(id string, image string) *com.Container {
+	return &com.Container{Id: id, Name: id, ContainerImage: image}
+}
+
+// runningTasks returns the ID of the container running each task.
+func runningTasks(t *testing.T, runtime *pkg.FakeRuntime) map[string]string {
+	t.Helper()
+	containers, err := runtime.ListContainersMatchingLabel(t.Context(), pkg.LabelManaged, "true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	running := map[string]string{}
+	for _, container := range containers {
+		running[container.Labels[pkg.LabelTaskID]] = container.ID
+	}
+	return running
+}
+
+func TestApplyScheduleFailedPullStopsNothing(t *testing.T) {
+	ctx := t.Context()
+	a, runtime, _ := newTestAgent(t)
+	runtime.AddImage("app:1", pkg.FakeImage{})
+	runtime.AddImage("db:1", pkg.FakeImage{})
+	runtime.AddImage("app:2", pkg.FakeImage{})
+	runtime.FailPulls("app:2", errors.New("registry unavailable"))
+
+	if _, _, err := a.applySchedule(ctx, testSchedule("v1", testTask("app", "app:1"), testTask("db", "db:1")), true); err != nil {
+		t.Fatalf("applying v1: %v", err)
+	}
+	before := runningTasks(t, runtime)
+
+	changed, _, err := a.applySchedule(ctx, testSchedule("v2", testTask("app", "app:2"), testTask("db", "db:1")), true)
+	if err == nil {
+		t.Fatal("applying v2 succeeded, although its image could not be pulled")
+	}
+	if changed {
+		t.Error("applying v2 reported changes, although its image could not be pulled")
+	}
+	after := runningTasks(t, runtime)
+	if len(after) != len(before) {
+		t.Fatalf("running tasks changed from %v to %v", before, after)
+	}
+	for task, containerID := range before {
+		if after[task] != containerID {
+			t.Errorf("task %s moved from container %s to %s", task, containerID, after[task])
+		}
+	}
+}
+
+func TestApplyScheduleReplacesOnlyChangedTasks(t *testing.T) {
+	ctx := t.Context()
+	a, runtime, _ := newTestAgent(t)
+	runtime.AddImage("app:1", pkg.FakeImage{})
+	runtime.AddImage("db:1", pkg.FakeImage{})
+
+	if _, _, err := a.applySchedule(ctx, testSchedule("v1", testTask("app", "app:1"), testTask("db", "db:1")), true); err != nil {
+		t.Fatalf("applying v1: %v", err)
+	}
+	before := runningTasks(t, runtime)
+
+	app := testTask("app", "app:1")
+	app.Env = map[string]string{"LOG_LEVEL": "debug"}
+	changed, deferred, err := a.applySchedule(ctx, testSchedule("v2", app, testTask("db", "db:1")), true)
+	if err != nil {
+		t.Fatalf("applying v2: %v", err)
+	}
+	if !changed || deferred != 0 {
+		t.Errorf("applying v2 returned changed %t and %d deferred, want true and 0", changed, deferred)
+	}
+
+	after := runningTasks(t, runtime)
+	if after["db"] != before["db"] {
+		t.Errorf("unchanged task db was replaced")
+	}
+	if after["app"] == "" || after["app"] == before["app"] {
+		t.Fatalf("changed task app was not replaced: before %q, after %q", before["app"], after["app"])
+	}
+	inspect, err := runtime.InspectContainer(ctx, after["app"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash := inspect.Config.Labels[pkg.LabelTaskHash]; hash != pkg.TaskHash(app) {
+		t.Errorf("app's container has task hash %s, want %s", hash, pkg.TaskHash(app))
+	}
+}
+
+func TestReconcileRollsBackWhenTaskFailsToStart(t *testing.T) {
+	ctx := t.Context()
+	a, runtime, server := newTestAgent(t)
+	runtime.AddImage("app:1", pkg.FakeImage{})
+	runtime.AddImage("app:2", pkg.FakeImage{StartError: errors.New("exec format error")})
+
+	good := testSchedule("schedule", testTask("app", "app:1"))
+	// the first reconcile puts the schedule on probation, and the second, with no soak period, makes it known-good
+	a.reconcile(ctx, good)
+	a.reconcile(ctx, good)
+	if a.rollback.lastKnownGood != good {
+		t.Fatal("schedule did not become known-good")
+	}
+
+	broken := testSchedule("schedule", testTask("app", "app:2"))
+	a.reconcile(ctx, broken)
+
+	if len(server.rollbacks) != 1 {
+		t.Fatalf("reported %d rollbacks, want 1", len(server.rollbacks))
+	}
+	if report := server.rollbacks[0]; report.FailedScheduleVersion != scheduleVersion(broken) {
+		t.Errorf("reported failed version %s, want %s", report.FailedScheduleVersion, scheduleVersion(broken))
+	}
+	if !a.rollback.refused(broken) {
+		t.Error("failed schedule is not refused")
+	}
+	if a.currentSchedule != good {
+		t.Error("known-good schedule is not recorded as applied")
+	}
+	containerID, ok := runningTasks(t, runtime)["app"]
+	if !ok {
+		t.Fatal("app is not running after the rollback")
+	}
+	inspect, err := runtime.InspectContainer(ctx, containerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inspect.Config.Image != "app:1" {
+		t.Errorf("app runs %s after the rollback, want app:1", inspect.Config.Image)
+	}
+}
+
+func TestReconcileRestartsCrashedTaskWithNothingToRollBackTo(t *testing.T) {
+	ctx := t.Context()
+	a, runtime, server := newTestAgent(t)
+	// the schedule stays on probation, so it never becomes known-good
+	a.rollback.soakPeriod = time.Hour
+	runtime.AddImage("app:1", pkg.FakeImage{})
+
+	schedule := testSchedule("schedule", testTask("app", "app:1"))
+	a.reconcile(ctx, schedule)
+	if !a.rollback.onProbation(schedule) {
+		t.Fatal("schedule is not on probation")
+	}
+	crashed := runningTasks(t, runtime)["app"]
+	if err := runtime.Exit(crashed, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	a.reconcile(ctx, schedule)
+
+	if len(server.rollbacks) != 0 {
+		t.Errorf("reported %d rollbacks, want none", len(server.rollbacks))
+	}
+	if a.rollback.refused(schedule) {
+		t.Error("schedule is refused, although there was nothing to roll back to")
+	}
+	containerID, ok := runningTasks(t, runtime)["app"]
+	if !ok {
+		t.Fatal("app is not running after the crash")
+	}
+	state, err := runtime.ContainerState(ctx, containerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Running {
+		t.Errorf("app was not restarted after the crash")
+	}
+}
+
+func TestApplyScheduleFailedPullRetryable(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     func(runtime *pkg.FakeRuntime)
+		retryable bool
+	}{
+		{
+			name: "registry timeout",
+			setup: func(runtime *pkg.FakeRuntime) {
+				runtime.AddImage("app:2", pkg.FakeImage{})
+				runtime.FailPulls("app:2", &pkg.TimeoutError{Err: errors.New("i/o timeout")})
+			},
+			retryable: true,
+		},
+		{
+			name: "engine down",
+			setup: func(runtime *pkg.FakeRuntime) {
+				runtime.AddImage("app:2", pkg.FakeImage{})
+				runtime.FailPulls("app:2", &pkg.EngineUnavailableError{Err: errors.New("cannot connect to the docker daemon")})
+			},
+			retryable: true,
+		},
+		{
+			name:      "missing image",
+			setup:     func(runtime *pkg.FakeRuntime) {},
+			retryable: false,
+		},
+		{
+			name: "refused credentials",
+			setup: func(runtime *pkg.FakeRuntime) {
+				runtime.AddImage("app:2", pkg.FakeImage{})
+				runtime.FailPulls("app:2", &pkg.RegistryAuthError{Image: "app:2", Err: errors.New("unauthorized")})
+			},
+			retryable: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			a, runtime, _ := newTestAgent(t)
+			runtime.AddImage("app:1", pkg.FakeImage{})
+			tt.setup(runtime)
+
+			if _, _, err := a.applySchedule(ctx, testSchedule("v1", testTask("app", "app:1")), true); err != nil {
+				t.Fatalf("applying v1: %v", err)
+			}
+			changed, _, err := a.applySchedule(ctx, testSchedule("v2", testTask("app", "app:2")), true)
+			if err == nil {
+				t.Fatal("applying v2 succeeded, although its image could not be pulled")
+			}
+			if changed {
+				t.Error("applying v2 reported changes, although its image could not be pulled")
+			}
+			if got := retryable(err); got != tt.retryable {
+				t.Errorf("retryable(%v) is %t, want %t", err, got, tt.retryable)
+			}
+		})
+	}
+}
+
+func TestApplyScheduleStartFirst(t *testing.T) {
+	tests := []struct {
+		name string
+		// the replacement's health
+		health   string
+		replaced bool
+	}{
+		{name: "healthy", health: "healthy", replaced: true},
+		{name: "no health check", replaced: true},
+		{name: "unhealthy", health: "unhealthy", replaced: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			a, runtime, _ := newTestAgent(t)
+			a.cfg.StartFirstTimeout = time.Minute
+			runtime.AddImage("app:1", pkg.FakeImage{})
+			runtime.AddImage("app:2", pkg.FakeImage{Health: tt.health})
+
+			v1 := testTask("app", "app:1")
+			v1.UpdateStrategy = pkg.UpdateStrategyStartFirst
+			if _, _, err := a.applySchedule(ctx, testSchedule("v1", v1), true); err != nil {
+				t.Fatalf("applying v1: %v", err)
+			}
+			old := runningTasks(t, runtime)["app"]
+
+			v2 := testTask("app", "app:2")
+			v2.UpdateStrategy = pkg.UpdateStrategyStartFirst
+			_, _, err := a.applySchedule(ctx, testSchedule("v2", v2), true)
+			if tt.replaced && err != nil {
+				t.Fatalf("applying v2: %v", err)
+			}
+			if !tt.replaced && err == nil {
+				t.Fatal("applying v2 succeeded, although its replacement was unhealthy")
+			}
+
+			containers, err := runtime.ListContainersMatchingLabel(ctx, pkg.LabelTaskID, "app")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(containers) != 1 {
+				t.Fatalf("app has %d containers, want 1", len(containers))
+			}
+			current := containers[0]
+			if tt.replaced == (current.ID == old) {
+				t.Errorf("app runs in container %s, which replaced is %t, want %t", current.ID, current.ID != old, tt.replaced)
+			}
+			if len(current.Names) != 1 || current.Names[0] != "/app" {
+				t.Errorf("app's container is named %v, want /app", current.Names)
+			}
+		})
+	}
+}
+
+func TestApplyScheduleHoldsBackDisruptiveChanges(t *testing.T) {
+	ctx := t.Context()
+	a, runtime, _ := newTestAgent(t)
+	runtime.AddImage("app:1", pkg.FakeImage{})
+	runtime.AddImage("app:2", pkg.FakeImage{})
+	runtime.AddImage("db:1", pkg.FakeImage{})
+	runtime.AddImage("cache:1", pkg.FakeImage{})
+
+	if _, _, err := a.applySchedule(ctx, testSchedule("v1", testTask("app", "app:1"), testTask("db", "db:1")), true); err != nil {
+		t.Fatalf("applying v1: %v", err)
+	}
+	before := runningTasks(t, runtime)
+	// db has crashed, so restarting it interrupts nothing
+	if err := runtime.Exit(before["db"], 1); err != nil {
+		t.Fatal(err)
+	}
+
+	v2 := testSchedule("v2", testTask("app", "app:2"), testTask("db", "db:1"), testTask("cache", "cache:1"))
+	changed, deferred, err := a.applySchedule(ctx, v2, false)
+	if err != nil {
+		t.Fatalf("applying v2 outside a window: %v", err)
+	}
+	if !changed || deferred != 1 {
+		t.Errorf("applying v2 outside a window returned changed %t and %d deferred, want true and 1", changed, deferred)
+	}
+	during := runningTasks(t, runtime)
+	if during["app"] != before["app"] {
+		t.Error("app was replaced outside a maintenance window")
+	}
+	if during["db"] == before["db"] {
+		t.Error("crashed task db was not restarted")
+	}
+	if during["cache"] == "" {
+		t.Error("new task cache was not started")
+	}
+
+	changed, deferred, err = a.applySchedule(ctx, v2, true)
+	if err != nil {
+		t.Fatalf("applying v2 in a window: %v", err)
+	}
+	if !changed || deferred != 0 {
+		t.Errorf("applying v2 in a window returned changed %t and %d deferred, want true and 0", changed, deferred)
+	}
+	after := runningTasks(t, runtime)
+	if after["app"] == during["app"] {
+		t.Error("app was not replaced in the maintenance window")
+	}
+	if after["db"] != during["db"] || after["cache"] != during["cache"] {
+		t.Error("tasks that were already up to date were replaced")
+	}
+}
@@ -0,0 +1,35 @@
+package agent
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/pkg"
+)
+
+// startError is returned by switchover when tasks failed to start.
+type startError struct {
+	failed int
+	// Failures that retrying will not fix
+	permanent int
+}
+
+func (e *startError) Error() string {
+	return fmt.Sprintf("%d task(s) failed to start, %d permanently", e.failed, e.permanent)
+}
+
+// retryable reports whether trying again, with the schedule unchanged, might succeed where err failed: the engine
+// was unreachable or slow to answer, or a container being replaced had not yet gone. Anything else, such as a
+// missing image or credentials the registry refused, needs the schedule to change, as does an error of unknown
+// cause.
+func retryable(err error) bool {
+	var start *startError
+	if errors.As(err, &start) {
+		return start.permanent == 0
+	}
+	var unavailable *pkg.EngineUnavailableError
+	var timeout *pkg.TimeoutError
+	var conflict *pkg.NameConflictError
+	return errors.As(err, &unavailable) || errors.As(err, &timeout) || errors.As(err, &conflict)
+}
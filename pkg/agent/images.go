@@ -0,0 +1,121 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/pkg"
+)
+
+const pulledImagesStateFile = "images.json"
+
+// persistedPulledImages is the on-disk form of pulledImages.
+type persistedPulledImages struct {
+	Images []string `json:"images"`
+}
+
+// pulledImages records the images the agent pulled, which are the only ones garbage collection removes, so that
+// images someone else put on the device are left alone.
+type pulledImages struct {
+	mu     sync.Mutex
+	path   string
+	images map[string]bool
+}
+
+func loadPulledImages(stateDir string) (*pulledImages, error) {
+	p := &pulledImages{
+		path:   filepath.Join(stateDir, pulledImagesStateFile),
+		images: map[string]bool{},
+	}
+
+	contents, err := os.ReadFile(p.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return p, nil
+		}
+		return nil, errors.Wrap(err, "failed to read pulled images")
+	}
+	state := persistedPulledImages{}
+	if err := json.Unmarshal(contents, &state); err != nil {
+		return nil, errors.Wrap(err, "failed to parse pulled images")
+	}
+	for _, image := range state.Images {
+		p.images[image] = true
+	}
+	return p, nil
+}
+
+// save writes the record; the caller holds p.mu.
+func (p *pulledImages) save() error {
+	images := make([]string, 0, len(p.images))
+	for image := range p.images {
+		images = append(images, image)
+	}
+	slices.Sort(images)
+	contents, err := json.Marshal(persistedPulledImages{Images: images})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode pulled images")
+	}
+	return errors.Wrap(writeStateFile(p.path, contents), "failed to save pulled images")
+}
+
+// add records images as pulled, returning early when they all already are.
+func (p *pulledImages) add(images ...string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	added := false
+	for _, image := range images {
+		if !p.images[image] {
+			p.images[image] = true
+			added = true
+		}
+	}
+	if !added {
+		return nil
+	}
+	return p.save()
+}
+
+// remove forgets images that are no longer on the engine.
+func (p *pulledImages) remove(images []string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(images) == 0 {
+		return nil
+	}
+	for _, image := range images {
+		delete(p.images, image)
+	}
+	return p.save()
+}
+
+func (p *pulledImages) list() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	images := make([]string, 0, len(p.images))
+	for image := range p.images {
+		images = append(images, image)
+	}
+	slices.Sort(images)
+	return images
+}
+
+// pullImage pulls an image, recording it so garbage collection may remove it once no schedule needs it.
+func (a *Agent) pullImage(ctx context.Context, imageReference string) error {
+	if err := a.runner.PullImage(ctx, imageReference); err != nil {
+		a.metrics.imagePulls.WithLabelValues("failure").Inc()
+		return err
+	}
+	a.metrics.imagePulls.WithLabelValues("success").Inc()
+	if err := a.pulled.add(imageReference); err != nil {
+		slog.ErrorContext(ctx, "Failed to record pulled image", "image", imageReference, pkg.Err(err))
+	}
+	return nil
+}
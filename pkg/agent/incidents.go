@@ -0,0 +1,141 @@
+package agent
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+const (
+	incidentRetryDelay = 5 * time.Second
+	// Incidents waiting for the next report; the oldest are dropped while the server is unreachable
+	maxPendingIncidents = 100
+	// How long a stopped container's exit is expected for; the engine delivers it within moments of the stop
+	expectedExitWindow = time.Minute
+)
+
+// incidents collects task containers that exited or went unhealthy on their own, for reportState to send.
+type incidents struct {
+	mu      sync.Mutex
+	pending []*com.ContainerState
+	// Containers being stopped by the agent, whose exit is not a crash, by when they were stopped
+	expected map[string]time.Time
+}
+
+func newIncidents() *incidents {
+	return &incidents{expected: map[string]time.Time{}}
+}
+
+// expectExit marks a container the agent is about to stop.
+func (i *incidents) expectExit(containerID string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	now := time.Now()
+	for id, stoppedAt := range i.expected {
+		if now.Sub(stoppedAt) > expectedExitWindow {
+			delete(i.expected, id)
+		}
+	}
+	i.expected[containerID] = now
+}
+
+func (i *incidents) add(incident *com.ContainerState) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.pending = append(i.pending, incident)
+	if len(i.pending) > maxPendingIncidents {
+		i.pending = i.pending[len(i.pending)-maxPendingIncidents:]
+	}
+}
+
+// take removes and returns every pending incident.
+func (i *incidents) take() []*com.ContainerState {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	taken := i.pending
+	i.pending = nil
+	return taken
+}
+
+// restore puts back incidents that could not be reported, ahead of any recorded since.
+func (i *incidents) restore(taken []*com.ContainerState) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.pending = append(taken, i.pending...)
+	if len(i.pending) > maxPendingIncidents {
+		i.pending = i.pending[len(i.pending)-maxPendingIncidents:]
+	}
+}
+
+// record turns a container event into an incident, unless it is the exit of a container the agent stopped or of a
+// job run, returning the incident recorded if any.
+func (i *incidents) record(event events.Message) *com.ContainerState {
+	attributes := event.Actor.Attributes
+	if attributes[pkg.LabelJob] == "true" {
+		// how a job run ended is reported with the run
+		return nil
+	}
+	incident := &com.ContainerState{
+		Id:         attributes[pkg.LabelTaskID],
+		Name:       attributes[pkg.LabelTaskName],
+		ScheduleId: attributes[pkg.LabelScheduleID],
+	}
+	switch event.Action {
+	case events.ActionDie:
+		i.mu.Lock()
+		_, expected := i.expected[event.Actor.ID]
+		delete(i.expected, event.Actor.ID)
+		i.mu.Unlock()
+		if expected {
+			return nil
+		}
+		incident.Status = "exited"
+		incident.Error = "exited with code " + attributes["exitCode"]
+	case events.ActionHealthStatusUnhealthy:
+		incident.Status = "unhealthy"
+		incident.Error = "failed its health check"
+	default:
+		return nil
+	}
+	slog.Warn("Task container failed", pkg.LogKeyTaskID, incident.Id, pkg.LogKeyContainer, event.Actor.ID,
+		pkg.LogKeyScheduleID, incident.ScheduleId, "status", incident.Status, "reason", incident.Error)
+	i.add(incident)
+	return incident
+}
+
+// watchIncidents follows the engine's events for managed containers until ctx is done, reconnecting when the
+// stream fails.
+func (a *Agent) watchIncidents(ctx context.Context) {
+	for ctx.Err() == nil {
+		messages, errs := a.runner.ContainerEvents(ctx, pkg.LabelManaged, "true")
+		func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case message := <-messages:
+					if incident := a.incidents.record(message); incident != nil {
+						a.metrics.incidents.WithLabelValues(incident.Status).Inc()
+					}
+				case err := <-errs:
+					if ctx.Err() == nil {
+						slog.ErrorContext(ctx, "Failed to watch container events", pkg.Err(err))
+					}
+					return
+				}
+			}
+		}()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(incidentRetryDelay):
+		}
+	}
+}
@@ -0,0 +1,468 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+const (
+	jobStateFile = "jobs.json"
+	// Job runs waiting for the next report; the oldest are dropped while the server is unreachable
+	maxPendingJobRuns = 100
+	// Lines of output kept with each run
+	jobLogTailLines = 50
+	// How long a run's last lines of output have to arrive once its container has exited
+	jobLogDrainTimeout = 2 * time.Second
+	// How long a job that runs once waits before trying again after failing to start for a passing reason
+	jobRetryDelay = 30 * time.Second
+	// How long the job loop sleeps when no job is due, unless the jobs change
+	jobIdleWait = time.Hour
+)
+
+// The statuses of job runs
+const (
+	jobRunSucceeded = "succeeded"
+	jobRunFailed    = "failed"
+	jobRunTimedOut  = "timed_out"
+	jobRunReplaced  = "replaced"
+	jobRunSkipped   = "skipped"
+)
+
+var (
+	errJobTimedOut = errors.New("job run timed out")
+	errJobReplaced = errors.New("job run replaced by a newer one")
+)
+
+// job is a job container of the applied schedule.
+type job struct {
+	schedule *com.Schedule
+	task     *com.Container
+	hash     string
+	// Nil for a job that runs once
+	cron     *pkg.CronSchedule
+	location *time.Location
+	// When the job is next due; zero once a job that runs once has run
+	next time.Time
+	// Runs in progress, by container name, shared with the job's earlier versions so that their runs count
+	// towards its concurrency policy
+	runs map[string]context.CancelCauseFunc
+}
+
+// persistedJobState is the on-disk form of jobs.
+type persistedJobState struct {
+	RanOnce map[string]string `json:"ran_once"`
+}
+
+// jobs runs the job containers of the applied schedule when they fall due, and keeps their finished runs for
+// reportState to send.
+type jobs struct {
+	mu   sync.Mutex
+	path string
+
+	byTask map[string]*job
+	// Jobs that run once and have run, by task ID, with the task hash of the version that ran
+	ranOnce map[string]string
+	pending []*com.JobRun
+
+	// Signalled when the jobs change, so the job loop works out again when the next one is due
+	changed chan struct{}
+}
+
+func loadJobs(stateDir string) (*jobs, error) {
+	j := &jobs{
+		path:    filepath.Join(stateDir, jobStateFile),
+		byTask:  map[string]*job{},
+		ranOnce: map[string]string{},
+		changed: make(chan struct{}, 1),
+	}
+
+	contents, err := os.ReadFile(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return j, nil
+		}
+		return nil, errors.Wrap(err, "failed to read job state")
+	}
+	state := persistedJobState{}
+	if err := json.Unmarshal(contents, &state); err != nil {
+		return nil, errors.Wrap(err, "failed to parse job state")
+	}
+	if state.RanOnce != nil {
+		j.ranOnce = state.RanOnce
+	}
+	return j, nil
+}
+
+// save persists which jobs have run once; the caller holds mu.
+func (j *jobs) save() error {
+	contents, err := json.Marshal(persistedJobState{RanOnce: j.ranOnce})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode job state")
+	}
+	return errors.Wrap(writeStateFile(j.path, contents), "failed to save job state")
+}
+
+// newJob works out when task, a job of schedule, is first due after now.
+func newJob(schedule *com.Schedule, task *com.Container, now time.Time, ranOnce bool) (*job, error) {
+	location, err := time.LoadLocation(task.Job.TimeZone)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load time zone %q", task.Job.TimeZone)
+	}
+	j := &job{
+		schedule: schedule,
+		task:     task,
+		hash:     pkg.TaskHash(task),
+		location: location,
+		runs:     map[string]context.CancelCauseFunc{},
+	}
+	if task.Job.Cron == "" {
+		if !ranOnce {
+			j.next = now
+		}
+		return j, nil
+	}
+	j.cron, err = pkg.ParseCron(task.Job.Cron)
+	if err != nil {
+		return nil, err
+	}
+	j.next = j.cron.Next(now.In(location))
+	return j, nil
+}
+
+// update makes the jobs those of schedule. Jobs that are unchanged keep when they are next due; runs in progress
+// are left to finish, even those of jobs that changed or were removed.
+func (j *jobs) update(ctx context.Context, schedule *com.Schedule) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	byTask := map[string]*job{}
+	changed := false
+	for _, task := range schedule.Containers {
+		if task.Job == nil {
+			continue
+		}
+		existing, ok := j.byTask[task.Id]
+		if ok && existing.hash == pkg.TaskHash(task) {
+			existing.schedule = schedule
+			byTask[task.Id] = existing
+			continue
+		}
+		updated, err := newJob(schedule, task, now, j.ranOnce[task.Id] == pkg.TaskHash(task))
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to schedule job", pkg.LogKeyTaskID, task.Id, pkg.Err(err))
+			continue
+		}
+		if ok {
+			updated.runs = existing.runs
+		}
+		slog.InfoContext(ctx, "Scheduled job", pkg.LogKeyTaskID, task.Id, "cron", task.Job.Cron, "next_run_at", updated.next)
+		byTask[task.Id] = updated
+		changed = true
+	}
+	for id := range j.byTask {
+		if _, ok := byTask[id]; !ok {
+			slog.InfoContext(ctx, "Job removed from schedule", pkg.LogKeyTaskID, id)
+			changed = true
+		}
+	}
+	j.byTask = byTask
+
+	// Forgotten once removed, so that a job added back runs again
+	forgot := false
+	for id := range j.ranOnce {
+		if _, ok := byTask[id]; !ok {
+			delete(j.ranOnce, id)
+			forgot = true
+		}
+	}
+	if forgot {
+		if err := j.save(); err != nil {
+			slog.ErrorContext(ctx, "Failed to save job state", pkg.Err(err))
+		}
+	}
+
+	if changed {
+		select {
+		case j.changed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// takeDue returns the jobs due at now, moving each on to when it is next due, and when the next job is due after
+// that; the zero time if none is.
+func (j *jobs) takeDue(now time.Time) ([]*job, time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	due := []*job{}
+	var next time.Time
+	for _, jb := range j.byTask {
+		if !jb.next.IsZero() && !now.Before(jb.next) {
+			due = append(due, jb)
+			jb.next = time.Time{}
+			if jb.cron != nil {
+				jb.next = jb.cron.Next(now.In(jb.location))
+			}
+		}
+		if !jb.next.IsZero() && (next.IsZero() || jb.next.Before(next)) {
+			next = jb.next
+		}
+	}
+	return due, next
+}
+
+func (j *jobs) addRun(run *com.JobRun) {
+	j.pending = append(j.pending, run)
+	if len(j.pending) > maxPendingJobRuns {
+		j.pending = j.pending[len(j.pending)-maxPendingJobRuns:]
+	}
+}
+
+// takeRuns removes and returns every finished run waiting to be reported.
+func (j *jobs) takeRuns() []*com.JobRun {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	taken := j.pending
+	j.pending = nil
+	return taken
+}
+
+// restoreRuns puts back runs that could not be reported, ahead of any finished since.
+func (j *jobs) restoreRuns(taken []*com.JobRun) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.pending = append(taken, j.pending...)
+	if len(j.pending) > maxPendingJobRuns {
+		j.pending = j.pending[len(j.pending)-maxPendingJobRuns:]
+	}
+}
+
+// finish records a run of jb that ended, or, when retry is set, makes a job that runs once due again shortly.
+func (j *jobs) finish(ctx context.Context, jb *job, name string, run *com.JobRun, retry bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	delete(jb.runs, name)
+	if jb.cron == nil {
+		if retry {
+			jb.next = time.Now().Add(jobRetryDelay)
+			select {
+			case j.changed <- struct{}{}:
+			default:
+			}
+			return
+		}
+		j.ranOnce[jb.task.Id] = jb.hash
+		if err := j.save(); err != nil {
+			slog.ErrorContext(ctx, "Failed to save job state", pkg.Err(err))
+		}
+	}
+	j.addRun(run)
+}
+
+// logTail keeps the last lines a job run wrote.
+type logTail struct {
+	mu      sync.Mutex
+	lines   []string
+	drained chan struct{}
+}
+
+func newLogTail() *logTail {
+	return &logTail{drained: make(chan struct{})}
+}
+
+func (t *logTail) add(line string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lines = append(t.lines, line)
+	if len(t.lines) > jobLogTailLines {
+		t.lines = t.lines[len(t.lines)-jobLogTailLines:]
+	}
+}
+
+// finish marks the output as complete.
+func (t *logTail) finish() {
+	close(t.drained)
+}
+
+// String waits briefly for the rest of the output to arrive, then returns the lines kept.
+func (t *logTail) String() string {
+	select {
+	case <-t.drained:
+	case <-time.After(jobLogDrainTimeout):
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return strings.Join(t.lines, "\n")
+}
+
+// runJobs starts job runs as they fall due, until ctx is done. Runs left behind by an earlier agent are stopped
+// first, as nothing would enforce their timeout or record how they ended.
+func (a *Agent) runJobs(ctx context.Context) {
+	a.stopLeftoverJobRuns(ctx)
+	for {
+		now := time.Now()
+		due, next := a.jobs.takeDue(now)
+		for _, jb := range due {
+			a.startJobRun(ctx, jb, now)
+		}
+
+		wait := jobIdleWait
+		if !next.IsZero() {
+			wait = time.Until(next)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-a.jobs.changed:
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (a *Agent) stopLeftoverJobRuns(ctx context.Context) {
+	containers, err := a.runner.ListContainersMatchingLabel(ctx, pkg.LabelJob, "true")
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list leftover job runs", pkg.Err(err))
+		return
+	}
+	for _, container := range containers {
+		slog.WarnContext(ctx, "Stopping job run left behind by an earlier agent", pkg.LogKeyTaskID, container.Labels[pkg.LabelTaskID], pkg.LogKeyContainer, container.ID)
+		if err := a.runner.StopContainer(ctx, container.ID); err != nil {
+			slog.ErrorContext(ctx, "Failed to stop leftover job run", pkg.LogKeyContainer, container.ID, pkg.Err(err))
+		}
+	}
+}
+
+// startJobRun starts a run of jb in the background, applying its concurrency policy if a run is still going.
+func (a *Agent) startJobRun(ctx context.Context, jb *job, dueAt time.Time) {
+	ctx = pkg.WithLogAttrs(ctx, slog.String(pkg.LogKeyTaskID, jb.task.Id))
+	a.jobs.mu.Lock()
+	if len(jb.runs) > 0 {
+		switch jb.task.Job.ConcurrencyPolicy {
+		case pkg.ConcurrencyPolicyReplace:
+			slog.InfoContext(ctx, "Stopping the job's previous run, which is still going", "runs", len(jb.runs))
+			for _, cancel := range jb.runs {
+				cancel(errJobReplaced)
+			}
+		case pkg.ConcurrencyPolicyAllow:
+		default:
+			slog.WarnContext(ctx, "Skipping job run, as the previous run is still going")
+			a.jobs.addRun(&com.JobRun{
+				TaskId:     jb.task.Id,
+				Name:       jb.task.Name,
+				ScheduleId: jb.schedule.Id,
+				Status:     jobRunSkipped,
+				StartedAt:  timestamppb.New(dueAt),
+				FinishedAt: timestamppb.New(dueAt),
+				Error:      "the previous run was still going",
+			})
+			a.jobs.mu.Unlock()
+			a.metrics.jobRuns.WithLabelValues(jobRunSkipped).Inc()
+			return
+		}
+	}
+	// Named apart, as runs may overlap
+	name := jb.task.Id + "-" + strconv.FormatInt(dueAt.Unix(), 10)
+	runCtx, cancel := context.WithCancelCause(ctx)
+	jb.runs[name] = cancel
+	schedule := jb.schedule
+	a.jobs.mu.Unlock()
+
+	go func() {
+		defer cancel(nil)
+		run, err := a.runJob(runCtx, schedule, jb.task, name)
+		if ctx.Err() != nil {
+			// the agent is shutting down; the run is stopped when it starts again
+			return
+		}
+		retry := err != nil && retryable(err)
+		if retry && jb.cron == nil {
+			slog.ErrorContext(ctx, "Failed to run job, trying again shortly", pkg.Err(err))
+		} else {
+			a.metrics.jobRuns.WithLabelValues(run.Status).Inc()
+		}
+		a.jobs.finish(ctx, jb, name, run, retry)
+	}()
+}
+
+// runJob runs task, a job, in a container named name and waits for it to finish, stopping it if it outlasts its
+// timeout or ctx is cancelled. It returns the run, and why it could not be started or waited for, if it could not.
+func (a *Agent) runJob(ctx context.Context, schedule *com.Schedule, task *com.Container, name string) (*com.JobRun, error) {
+	if seconds := task.Job.TimeoutSeconds; seconds > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, time.Duration(seconds)*time.Second, errJobTimedOut)
+		defer cancel()
+	}
+	run := &com.JobRun{
+		TaskId:     task.Id,
+		Name:       task.Name,
+		ScheduleId: schedule.Id,
+		StartedAt:  timestamppb.Now(),
+	}
+
+	slog.InfoContext(ctx, "Running job", pkg.LogKeyContainer, name)
+	output := newLogTail()
+	containerID, err := a.runTask(ctx, schedule, task, name, output)
+	var notFound *pkg.ImageNotFoundError
+	if containerID == "" && errors.As(err, &notFound) {
+		// Job images are only pulled once a run needs them, as a job may not run until long after it is scheduled
+		slog.InfoContext(ctx, "Pulling image", "image", task.ContainerImage)
+		err = a.pullImage(ctx, task.ContainerImage)
+		if err == nil {
+			output = newLogTail()
+			containerID, err = a.runTask(ctx, schedule, task, name, output)
+		}
+	}
+	run.FinishedAt = timestamppb.Now()
+
+	var exitErr *pkg.ExitError
+	switch {
+	case err == nil:
+		run.Status = jobRunSucceeded
+	case errors.As(err, &exitErr):
+		run.Status = jobRunFailed
+		run.ExitCode = int32(exitErr.ExitCode)
+		err = nil
+	case ctx.Err() != nil:
+		cause := context.Cause(ctx)
+		switch {
+		case errors.Is(cause, errJobTimedOut):
+			run.Status = jobRunTimedOut
+			run.Error = "stopped after running for " + (time.Duration(task.Job.TimeoutSeconds) * time.Second).String()
+		case errors.Is(cause, errJobReplaced):
+			run.Status = jobRunReplaced
+			run.Error = "stopped for a newer run"
+		default:
+			run.Status = jobRunFailed
+			run.Error = cause.Error()
+		}
+		err = nil
+		if containerID != "" {
+			if stopErr := a.runner.StopContainer(context.WithoutCancel(ctx), containerID); stopErr != nil {
+				slog.ErrorContext(ctx, "Failed to stop job run", pkg.LogKeyContainer, containerID, pkg.Err(stopErr))
+			}
+		}
+	default:
+		run.Status = jobRunFailed
+		run.Error = err.Error()
+	}
+	if containerID != "" {
+		run.LogTail = output.String()
+	}
+	slog.InfoContext(ctx, "Job run finished", pkg.LogKeyContainer, name, "status", run.Status, "exit_code", run.ExitCode)
+	return run, err
+}
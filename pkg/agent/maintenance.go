@@ -0,0 +1,95 @@
+package agent
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	// Devices rarely ship a zoneinfo database, and maintenance windows are laid out in local time
+	_ "time/tzdata"
+
+	"github.com/docker/docker/api/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+// interrupts reports whether stopping container would interrupt something running, rather than clear away a
+// container that has already exited or never started.
+func interrupts(container types.Container) bool {
+	switch container.State {
+	case "created", "exited", "dead":
+		return false
+	}
+	return true
+}
+
+// holdBack splits off the plan's disruptive changes, which are stopping running containers and starting the tasks
+// that have to wait for them, leaving only the changes that interrupt nothing: clearing away stopped containers and
+// starting tasks that are new or whose container went away.
+func (p *schedulePlan) holdBack() *schedulePlan {
+	held := &schedulePlan{}
+	toStop := []types.Container{}
+	for _, container := range p.toStop {
+		if interrupts(container) {
+			held.toStop = append(held.toStop, container)
+		} else {
+			toStop = append(toStop, container)
+		}
+	}
+	toStart := []*com.Container{}
+	for _, task := range p.toStart {
+		waits := false
+		for _, container := range held.toStop {
+			if (&stopping{container: container}).conflictsWith(task) {
+				waits = true
+				break
+			}
+		}
+		if waits {
+			held.toStart = append(held.toStart, task)
+		} else {
+			toStart = append(toStart, task)
+		}
+	}
+	p.toStop, p.toStart = toStop, toStart
+	return held
+}
+
+// maintenanceWindowOpen reports whether the device may make disruptive changes now and, if not, when it next may.
+// A time zone the agent cannot load keeps changes deferred until an operator applies them now.
+func (a *Agent) maintenanceWindowOpen(ctx context.Context) (bool, time.Time) {
+	open, next, err := pkg.MaintenanceWindowOpen(a.maintenance, time.Now())
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to evaluate maintenance windows", pkg.Err(err))
+	}
+	return open, next
+}
+
+// deferUpdate records that schedule is only partly applied, with deferred containers waiting for the maintenance
+// window opening at nextWindow, so the server and the status API can show it.
+func (a *Agent) deferUpdate(ctx context.Context, schedule *com.Schedule, deferred int, nextWindow time.Time) {
+	pending := &com.PendingUpdate{
+		ScheduleId:      schedule.Id,
+		DeferredChanges: int32(deferred),
+	}
+	if !nextWindow.IsZero() {
+		pending.NextWindowAt = timestamppb.New(nextWindow)
+	}
+	if !proto.Equal(pending, a.pendingUpdate) {
+		slog.InfoContext(ctx, "Deferring disruptive changes until the next maintenance window", pkg.LogKeyScheduleID, schedule.Id,
+			"deferred_changes", deferred, "next_window_at", nextWindow)
+	}
+	a.pendingSchedule = schedule
+	a.pendingUpdate = pending
+}
+
+// clearPendingUpdate notes that nothing is waiting for a maintenance window any more.
+func (a *Agent) clearPendingUpdate(ctx context.Context) {
+	if a.pendingUpdate != nil {
+		slog.InfoContext(ctx, "Applied deferred changes", pkg.LogKeyScheduleID, a.pendingUpdate.ScheduleId)
+	}
+	a.pendingSchedule = nil
+	a.pendingUpdate = nil
+}
@@ -0,0 +1,64 @@
+package agent
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// agentMetrics are registered whether or not they are served, so the agent can record them unconditionally.
+type agentMetrics struct {
+	reconcileDuration prometheus.Histogram
+	imagePulls        *prometheus.CounterVec
+	containerRestarts prometheus.Counter
+	incidents         *prometheus.CounterVec
+	serverErrors      *prometheus.CounterVec
+	jobRuns           *prometheus.CounterVec
+}
+
+func newAgentMetrics(registry prometheus.Registerer) *agentMetrics {
+	m := &agentMetrics{
+		reconcileDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "pando_agent_reconcile_duration_seconds",
+			Help:    "Time taken to bring the engine in line with the schedule, image downloads included.",
+			Buckets: []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120, 300, 600},
+		}),
+		imagePulls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pando_agent_image_pulls_total",
+			Help: "Image pulls, by result: success or failure.",
+		}, []string{"result"}),
+		containerRestarts: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "pando_agent_container_restarts_total",
+			Help: "Tasks of the applied schedule started again after their container went away.",
+		}),
+		incidents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pando_agent_container_incidents_total",
+			Help: "Task containers that exited on their own or failed their health check, by status.",
+		}, []string{"status"}),
+		serverErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pando_agent_server_errors_total",
+			Help: "Failed calls to the server, by procedure and code.",
+		}, []string{"procedure", "code"}),
+		jobRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pando_agent_job_runs_total",
+			Help: "Finished runs of job containers, by status.",
+		}, []string{"status"}),
+	}
+	registry.MustRegister(m.reconcileDuration, m.imagePulls, m.containerRestarts, m.incidents, m.serverErrors, m.jobRuns)
+	return m
+}
+
+// countServerErrors records every failed call to the server. Calls abandoned because the agent is shutting
+// down are not errors.
+func (m *agentMetrics) countServerErrors() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			resp, err := next(ctx, req)
+			if err != nil && ctx.Err() == nil {
+				m.serverErrors.WithLabelValues(req.Spec().Procedure, connect.CodeOf(err).String()).Inc()
+			}
+			return resp, err
+		}
+	}
+}
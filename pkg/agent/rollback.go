@@ -0,0 +1,228 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+const rollbackStateFile = "rollback.json"
+
+// scheduleVersion fingerprints a schedule's full content, so any edit to it or its containers yields a new version.
+func scheduleVersion(schedule *com.Schedule) string {
+	return pkg.Fingerprint(schedule)
+}
+
+// persistedRollbackState is the on-disk form of rollbackTracker.
+type persistedRollbackState struct {
+	LastKnownGood  json.RawMessage   `json:"last_known_good,omitempty"`
+	FailedVersions map[string]string `json:"failed_versions"`
+}
+
+// rollbackTracker remembers the last schedule that ran healthily for a full soak period, and the schedule
+// versions that failed on this device. A newly applied schedule is on probation until its soak period ends.
+type rollbackTracker struct {
+	path       string
+	soakPeriod time.Duration
+
+	lastKnownGood *com.Schedule
+	// schedule ID -> version that failed and must not be applied again
+	failedVersions map[string]string
+
+	probation      *com.Schedule
+	probationSince time.Time
+
+	// A rollback to lastKnownGood that has yet to be applied and reported; reconcile retries it each tick
+	unreported *com.ReportRollbackRequest
+}
+
+func loadRollbackTracker(stateDir string, soakPeriod time.Duration) (*rollbackTracker, error) {
+	t := &rollbackTracker{
+		path:           filepath.Join(stateDir, rollbackStateFile),
+		soakPeriod:     soakPeriod,
+		failedVersions: map[string]string{},
+	}
+
+	contents, err := os.ReadFile(t.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return t, nil
+		}
+		return nil, errors.Wrap(err, "failed to read rollback state")
+	}
+
+	state := persistedRollbackState{}
+	if err := json.Unmarshal(contents, &state); err != nil {
+		return nil, errors.Wrap(err, "failed to parse rollback state")
+	}
+	if len(state.LastKnownGood) > 0 {
+		lastKnownGood := &com.Schedule{}
+		if err := protojson.Unmarshal(state.LastKnownGood, lastKnownGood); err != nil {
+			return nil, errors.Wrap(err, "failed to parse last known-good schedule")
+		}
+		t.lastKnownGood = lastKnownGood
+	}
+	if state.FailedVersions != nil {
+		t.failedVersions = state.FailedVersions
+	}
+	return t, nil
+}
+
+func (t *rollbackTracker) save() error {
+	state := persistedRollbackState{
+		FailedVersions: t.failedVersions,
+	}
+	if t.lastKnownGood != nil {
+		encoded, err := protojson.Marshal(t.lastKnownGood)
+		if err != nil {
+			return errors.Wrap(err, "failed to encode last known-good schedule")
+		}
+		state.LastKnownGood = encoded
+	}
+	contents, err := json.Marshal(state)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode rollback state")
+	}
+	return errors.Wrap(writeStateFile(t.path, contents), "failed to save rollback state")
+}
+
+// refused reports whether this exact version of schedule previously failed on this device.
+func (t *rollbackTracker) refused(schedule *com.Schedule) bool {
+	version, ok := t.failedVersions[schedule.Id]
+	return ok && version == scheduleVersion(schedule)
+}
+
+func (t *rollbackTracker) onProbation(schedule *com.Schedule) bool {
+	return t.probation != nil && proto.Equal(t.probation, schedule)
+}
+
+// observeApplied is called after schedule was applied successfully and, if it was on probation, passed its
+// health check. It starts probation for new schedules and promotes those that have soaked long enough.
+func (t *rollbackTracker) observeApplied(schedule *com.Schedule) {
+	if t.lastKnownGood != nil && proto.Equal(t.lastKnownGood, schedule) {
+		t.probation = nil
+		return
+	}
+	if !t.onProbation(schedule) {
+		slog.Info("Schedule is on probation", pkg.LogKeyScheduleID, schedule.Id, "soak_period", t.soakPeriod)
+		t.probation = schedule
+		t.probationSince = time.Now()
+		return
+	}
+	if time.Since(t.probationSince) < t.soakPeriod {
+		return
+	}
+
+	slog.Info("Schedule ran healthily, marking it known-good", pkg.LogKeyScheduleID, schedule.Id, "soak_period", t.soakPeriod)
+	t.lastKnownGood = schedule
+	t.probation = nil
+	// a schedule that has proven itself is no longer considered failed, whatever its history
+	delete(t.failedVersions, schedule.Id)
+	if err := t.save(); err != nil {
+		slog.Error("Failed to save rollback state", pkg.Err(err))
+	}
+}
+
+// checkScheduleHealth reports why schedule is unhealthy, or an empty string if every task is running and
+// none is failing its health check.
+func (a *Agent) checkScheduleHealth(ctx context.Context, schedule *com.Schedule) (string, error) {
+	existingContainers, err := a.runner.ListContainersMatchingLabel(ctx, pkg.LabelManaged, "true")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list containers")
+	}
+	containerIDs := map[string]string{}
+	for _, container := range existingContainers {
+		containerIDs[container.Labels[pkg.LabelTaskID]] = container.ID
+	}
+
+	for _, task := range schedule.Containers {
+		if task.Job != nil {
+			// jobs are expected to exit, and their failures are reported as job runs
+			continue
+		}
+		containerID, ok := containerIDs[task.Id]
+		if !ok {
+			return fmt.Sprintf("task %s (%s) is not running", task.Name, task.Id), nil
+		}
+		state, err := a.runner.ContainerState(ctx, containerID)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to inspect container for task %s", task.Id)
+		}
+		if !state.Running {
+			return fmt.Sprintf("task %s (%s) exited with code %d", task.Name, task.Id, state.ExitCode), nil
+		}
+		if state.Health != nil && state.Health.Status == "unhealthy" {
+			return fmt.Sprintf("task %s (%s) is unhealthy", task.Name, task.Id), nil
+		}
+	}
+	return "", nil
+}
+
+// rollbackFrom reverts to the last known-good schedule after failed broke the device, refuses failed until it
+// changes, and tells the server why. It reports false when there was nothing to roll back to, leaving failed in
+// place.
+func (a *Agent) rollbackFrom(ctx context.Context, failed *com.Schedule, reason string) bool {
+	lastKnownGood := a.rollback.lastKnownGood
+	if lastKnownGood == nil {
+		slog.WarnContext(ctx, "Schedule failed but there is no known-good schedule to roll back to", pkg.LogKeyScheduleID, failed.Id, "reason", reason)
+		return false
+	}
+	if proto.Equal(lastKnownGood, failed) {
+		slog.WarnContext(ctx, "Known-good schedule failed; nothing older to roll back to", pkg.LogKeyScheduleID, failed.Id, "reason", reason)
+		return false
+	}
+
+	failedVersion := scheduleVersion(failed)
+	slog.WarnContext(ctx, "Rolling back", pkg.LogKeyScheduleID, failed.Id, "version", failedVersion, "restored_schedule_id", lastKnownGood.Id, "reason", reason)
+	a.rollback.failedVersions[failed.Id] = failedVersion
+	a.rollback.probation = nil
+	if err := a.rollback.save(); err != nil {
+		slog.ErrorContext(ctx, "Failed to save rollback state", pkg.Err(err))
+	}
+
+	a.rollback.unreported = &com.ReportRollbackRequest{
+		DeviceId:              a.deviceID,
+		FailedScheduleId:      failed.Id,
+		FailedScheduleVersion: failedVersion,
+		RestoredScheduleId:    lastKnownGood.Id,
+		Reason:                reason,
+	}
+
+	// Rolling back does not wait for a maintenance window, as the failed schedule is already disrupting the device
+	a.jobs.update(ctx, lastKnownGood)
+	if _, _, err := a.applySchedule(ctx, lastKnownGood, true); err != nil {
+		// the failed version is refused from now on, so the next reconcile applies the known-good schedule again
+		slog.ErrorContext(ctx, "Failed to apply known-good schedule; retrying on the next tick", pkg.LogKeyScheduleID, lastKnownGood.Id, pkg.Err(err))
+		return true
+	}
+	a.clearPendingUpdate(ctx)
+	a.recordApplied(lastKnownGood)
+	a.reportRollback(ctx)
+	return true
+}
+
+// reportRollback tells the server about a rollback once the known-good schedule has been restored. A rollback
+// that fails to be reported is sent again after the next successful reconcile.
+func (a *Agent) reportRollback(ctx context.Context) {
+	report := a.rollback.unreported
+	if report == nil {
+		return
+	}
+	if _, err := a.client.ReportRollback(ctx, &connect.Request[com.ReportRollbackRequest]{Msg: report}); err != nil {
+		slog.ErrorContext(ctx, "Failed to report rollback", pkg.Err(err))
+		return
+	}
+	a.rollback.unreported = nil
+}
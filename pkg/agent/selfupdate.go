@@ -0,0 +1,238 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+const (
+	agentUpdateStateFile = "agent-update.json"
+	// The old agent waits this much past the new one's deadline before removing it, so the two never both act
+	handoverGrace = 30 * time.Second
+	// How often a new agent tries to check in until its deadline
+	handoverCheckInInterval = 5 * time.Second
+)
+
+// The timestamp suffix given to each new agent container's name, replaced on every update
+var handoverSuffix = regexp.MustCompile(`-[0-9]{10,}$`)
+
+// handover is a new agent container that has been started but has not yet taken over.
+type handover struct {
+	ContainerID string    `json:"container_id"`
+	Image       string    `json:"image"`
+	Deadline    time.Time `json:"deadline"`
+}
+
+// persistedAgentUpdateState is the on-disk form of a selfUpdater, so neither a failed image nor a handover in
+// progress is forgotten when the agent restarts.
+type persistedAgentUpdateState struct {
+	FailedImage string    `json:"failed_image,omitempty"`
+	Handover    *handover `json:"handover,omitempty"`
+}
+
+// selfUpdater tracks the agent's updates of itself to its fleet's agent image. The old agent starts the new one
+// alongside itself and carries on; the new one checks in with the server and then stops the old one. If it has
+// not done so by its deadline, the old agent removes it instead.
+type selfUpdater struct {
+	path string
+
+	mu sync.Mutex
+	// An image whose handover failed is not tried again until the fleet's agent image changes
+	failedImage string
+	handover    *handover
+}
+
+func loadSelfUpdater(stateDir string) (*selfUpdater, error) {
+	u := &selfUpdater{path: filepath.Join(stateDir, agentUpdateStateFile)}
+
+	contents, err := os.ReadFile(u.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return u, nil
+		}
+		return nil, errors.Wrap(err, "failed to read agent update state")
+	}
+	state := persistedAgentUpdateState{}
+	if err := json.Unmarshal(contents, &state); err != nil {
+		return nil, errors.Wrap(err, "failed to parse agent update state")
+	}
+	u.failedImage = state.FailedImage
+	u.handover = state.Handover
+	return u, nil
+}
+
+// save writes the state; the caller holds u.mu.
+func (u *selfUpdater) save() error {
+	contents, err := json.Marshal(persistedAgentUpdateState{FailedImage: u.failedImage, Handover: u.handover})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode agent update state")
+	}
+	return errors.Wrap(writeStateFile(u.path, contents), "failed to save agent update state")
+}
+
+// updateAgent starts a new agent running image, if that differs from the image this agent runs and no handover
+// is already under way.
+func (a *Agent) updateAgent(ctx context.Context, image string) {
+	if image == "" || a.cfg.AgentContainer == "" {
+		return
+	}
+	a.updates.mu.Lock()
+	busy := a.updates.handover != nil || a.updates.failedImage == image
+	a.updates.mu.Unlock()
+	if busy {
+		return
+	}
+
+	self, err := a.runner.InspectContainer(ctx, a.cfg.AgentContainer)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to inspect the agent's own container", "container", a.cfg.AgentContainer, pkg.Err(err))
+		return
+	}
+	if self.Config.Image == image {
+		return
+	}
+
+	slog.InfoContext(ctx, "Updating agent", "from", self.Config.Image, "to", image)
+	if err := a.pullImage(ctx, image); err != nil {
+		slog.ErrorContext(ctx, "Failed to pull agent image", "image", image, "retryable", retryable(err), pkg.Err(err))
+		// Only an image the registry refused is given up on; anything else, such as the network failing in a way
+		// the engine does not make clear, is tried again on the next tick
+		var notFound *pkg.ImageNotFoundError
+		var auth *pkg.RegistryAuthError
+		if errors.As(err, &notFound) || errors.As(err, &auth) {
+			a.failUpdate(ctx, image)
+		}
+		return
+	}
+
+	baseName := handoverSuffix.ReplaceAllString(strings.TrimPrefix(self.Name, "/"), "")
+	name := baseName + "-" + strconv.FormatInt(time.Now().Unix(), 10)
+	deadline := time.Now().Add(a.cfg.HandoverTimeout).UTC()
+	containerID, err := a.runner.ReplicateContainer(ctx, self, image, name, map[string]string{
+		"AGENT_CONTAINER":         name,
+		"PANDO_HANDOVER_FROM":     self.ID,
+		"PANDO_HANDOVER_DEADLINE": deadline.Format(time.RFC3339),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to start new agent", "image", image, "retryable", retryable(err), pkg.Err(err))
+		if !retryable(err) {
+			a.failUpdate(ctx, image)
+		}
+		return
+	}
+	slog.InfoContext(ctx, "Started new agent, waiting for it to take over", pkg.LogKeyContainer, containerID, "deadline", deadline)
+
+	started := &handover{ContainerID: containerID, Image: image, Deadline: deadline}
+	a.updates.mu.Lock()
+	a.updates.handover = started
+	if err := a.updates.save(); err != nil {
+		slog.ErrorContext(ctx, "Failed to save agent update state", pkg.Err(err))
+	}
+	a.updates.mu.Unlock()
+	go a.awaitHandover(ctx, started)
+}
+
+// awaitHandover removes the new agent if this one is still running past the new one's deadline. Until then it
+// does nothing: a new agent that takes over stops this one, ending ctx.
+func (a *Agent) awaitHandover(ctx context.Context, h *handover) {
+	timer := time.NewTimer(time.Until(h.Deadline.Add(handoverGrace)))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return
+	case <-timer.C:
+	}
+
+	slog.ErrorContext(ctx, "New agent did not take over in time, removing it", pkg.LogKeyContainer, h.ContainerID, "image", h.Image)
+	if err := a.runner.StopContainer(ctx, h.ContainerID); err != nil {
+		slog.ErrorContext(ctx, "Failed to remove new agent", pkg.LogKeyContainer, h.ContainerID, pkg.Err(err))
+	}
+	a.failUpdate(ctx, h.Image)
+}
+
+// failUpdate stops image from being tried again until the fleet's agent image changes.
+func (a *Agent) failUpdate(ctx context.Context, image string) {
+	a.updates.mu.Lock()
+	defer a.updates.mu.Unlock()
+	a.updates.failedImage = image
+	a.updates.handover = nil
+	if err := a.updates.save(); err != nil {
+		slog.ErrorContext(ctx, "Failed to save agent update state", pkg.Err(err))
+	}
+}
+
+// resumeHandover picks up waiting for a handover that was under way when this agent last stopped. A record of
+// the handover to this agent itself, saved by the old agent after this one had taken over, is dropped.
+func (a *Agent) resumeHandover(ctx context.Context) {
+	a.updates.mu.Lock()
+	h := a.updates.handover
+	a.updates.mu.Unlock()
+	if h == nil {
+		return
+	}
+	if a.cfg.AgentContainer != "" {
+		if self, err := a.runner.InspectContainer(ctx, a.cfg.AgentContainer); err == nil && self.ID == h.ContainerID {
+			a.updates.mu.Lock()
+			a.updates.handover = nil
+			if err := a.updates.save(); err != nil {
+				slog.ErrorContext(ctx, "Failed to save agent update state", pkg.Err(err))
+			}
+			a.updates.mu.Unlock()
+			return
+		}
+	}
+	go a.awaitHandover(ctx, h)
+}
+
+// takeOver completes this agent's side of a handover: once it has checked in with the server, it stops the old
+// agent. It returns an error if the deadline passes first, leaving the old agent to remove this one.
+func (a *Agent) takeOver(ctx context.Context) error {
+	_, err := a.runner.InspectContainer(ctx, a.cfg.HandoverFrom)
+	if errdefs.IsNotFound(err) {
+		// restarted after taking over already
+		return nil
+	}
+
+	checkInCtx, cancel := context.WithDeadline(ctx, a.cfg.HandoverDeadline)
+	defer cancel()
+	for {
+		_, err := a.client.GetSchedule(checkInCtx, &connect.Request[com.GetScheduleRequest]{
+			Msg: &com.GetScheduleRequest{DeviceId: a.deviceID},
+		})
+		if err == nil {
+			break
+		}
+		slog.WarnContext(ctx, "New agent failed to check in", pkg.Err(err))
+		select {
+		case <-checkInCtx.Done():
+			return errors.Errorf("did not check in with the server by %s", a.cfg.HandoverDeadline.Format(time.RFC3339))
+		case <-time.After(handoverCheckInInterval):
+		}
+	}
+
+	a.updates.mu.Lock()
+	a.updates.failedImage = ""
+	a.updates.handover = nil
+	if err := a.updates.save(); err != nil {
+		slog.ErrorContext(ctx, "Failed to save agent update state", pkg.Err(err))
+	}
+	a.updates.mu.Unlock()
+
+	slog.InfoContext(ctx, "Checked in with the server, stopping the old agent", pkg.LogKeyContainer, a.cfg.HandoverFrom)
+	return errors.Wrap(a.runner.StopContainer(ctx, a.cfg.HandoverFrom), "failed to stop the old agent")
+}
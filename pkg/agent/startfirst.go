@@ -0,0 +1,115 @@
+package agent
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+// How often a start-first replacement's health is checked while waiting for it
+const startFirstPollInterval = time.Second
+
+// startFirstReplacements finds the running containers that the plan's start-first tasks can replace without
+// downtime, by task ID. Tasks on host networking whose old container is too are replaced the usual way. Nothing
+// else is checked for clashing host ports, as the agent does not publish task.Ports.
+func (a *Agent) startFirstReplacements(ctx context.Context, plan *schedulePlan) map[string]types.Container {
+	replacing := map[string]types.Container{}
+	for _, task := range plan.toStart {
+		if task.UpdateStrategy != pkg.UpdateStrategyStartFirst {
+			continue
+		}
+		for _, container := range plan.toStop {
+			if container.Labels[pkg.LabelTaskID] != task.Id || !interrupts(container) {
+				continue
+			}
+			if hostNetworkConflict(container, task) {
+				slog.InfoContext(ctx, "Replacing task by recreating it, as host networking rules out starting it first",
+					pkg.LogKeyTaskID, task.Id)
+				break
+			}
+			replacing[task.Id] = container
+			break
+		}
+	}
+	return replacing
+}
+
+// startFirst replaces old with task: it starts task alongside old under a temporary name, waits for it to be
+// healthy, then stops old and gives the new container the task's usual name. If the new container does not
+// become healthy, it is removed and old is left running.
+func (a *Agent) startFirst(ctx context.Context, schedule *com.Schedule, task *com.Container, old types.Container) (string, error) {
+	// Named after the task's version, so it cannot clash with a container left over from an earlier update
+	name := task.Id + "-" + pkg.TaskHash(task)[:12]
+	slog.InfoContext(ctx, "Starting task alongside the container it replaces", pkg.LogKeyTaskID, task.Id, pkg.LogKeyContainer, old.ID)
+	containerID, err := a.startTaskAs(ctx, schedule, task, name)
+	if err != nil {
+		return "", err
+	}
+
+	if err := a.waitHealthy(ctx, containerID); err != nil {
+		a.incidents.expectExit(containerID)
+		if stopErr := a.runner.StopContainer(context.WithoutCancel(ctx), containerID); stopErr != nil {
+			slog.ErrorContext(ctx, "Failed to remove replacement that did not become healthy", pkg.LogKeyContainer, containerID, pkg.Err(stopErr))
+		}
+		return "", errors.Wrapf(err, "replacement for task %s did not become healthy; keeping the old container", task.Id)
+	}
+
+	slog.InfoContext(ctx, "Replacement is healthy, stopping the old container", pkg.LogKeyTaskID, task.Id, pkg.LogKeyContainer, old.ID)
+	a.incidents.expectExit(old.ID)
+	if err := a.runner.StopContainer(ctx, old.ID); err != nil {
+		// both keep running; the next tick stops the old one, as its task hash no longer matches
+		return containerID, errors.Wrap(err, "failed to stop the replaced container")
+	}
+	if err := a.runner.RenameContainer(ctx, containerID, task.Id); err != nil {
+		// the container carries the task's labels, so it is managed all the same
+		slog.WarnContext(ctx, "Failed to rename replacement", pkg.LogKeyContainer, containerID, "name", task.Id, pkg.Err(err))
+	}
+	return containerID, nil
+}
+
+// waitHealthy waits for a container to pass its health check or, without one, to keep running for
+// StartFirstMinUptime. It fails if the container exits, turns unhealthy or takes longer than StartFirstTimeout.
+func (a *Agent) waitHealthy(ctx context.Context, containerID string) error {
+	ctx, cancel := context.WithTimeout(ctx, a.cfg.StartFirstTimeout)
+	defer cancel()
+	startedAt := time.Now()
+	for {
+		state, err := a.runner.ContainerState(ctx, containerID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return errors.Errorf("container was not healthy within %s", a.cfg.StartFirstTimeout)
+			}
+			if errdefs.IsNotFound(err) {
+				// containers are removed once they exit
+				return errors.New("container exited")
+			}
+			return errors.Wrap(err, "failed to check container health")
+		}
+		if !state.Running {
+			return errors.Errorf("container exited with code %d", state.ExitCode)
+		}
+		if state.Health != nil {
+			switch state.Health.Status {
+			case "healthy":
+				return nil
+			case "unhealthy":
+				return errors.New("container is unhealthy")
+			}
+		} else if time.Since(startedAt) >= a.cfg.StartFirstMinUptime {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Errorf("container was not healthy within %s", a.cfg.StartFirstTimeout)
+		case <-time.After(startFirstPollInterval):
+		}
+	}
+}
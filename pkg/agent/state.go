@@ -0,0 +1,45 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// writeStateFile replaces the file at path with contents. The contents are synced to disk before the rename, and
+// the directory after it, so that after a power cut the file holds either the old contents or the new ones.
+func writeStateFile(path string, contents []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrap(err, "failed to create state directory")
+	}
+
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s", tmp)
+	}
+	if _, err := f.Write(contents); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed to write %s", tmp)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed to sync %s", tmp)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close %s", tmp)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Wrapf(err, "failed to replace %s", path)
+	}
+
+	// the rename is only durable once the directory entry is
+	d, err := os.Open(dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to open state directory")
+	}
+	defer d.Close()
+	return errors.Wrap(d.Sync(), "failed to sync state directory")
+}
@@ -0,0 +1,433 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+	"github.com/uinta-labs/pando/pkg"
+)
+
+// Errors logged most recently are kept for the status API, up to this many
+const maxRecentErrors = 50
+
+// statusError is an error the agent logged, or the last call to the server that failed.
+type statusError struct {
+	Time    time.Time         `json:"time"`
+	Message string            `json:"message"`
+	Attrs   map[string]string `json:"attrs,omitempty"`
+}
+
+// agentStatus is what the local status API reports beyond what it asks the engine for. The scheduler and the
+// client's interceptor keep it up to date, so the API never touches the scheduler's own state.
+type agentStatus struct {
+	mu sync.Mutex
+
+	connected       bool
+	lastContact     time.Time
+	lastServerError *statusError
+
+	// The schedule the server last sent, and the one last applied, which differ when a schedule was refused
+	desired       *com.Schedule
+	applied       *com.Schedule
+	lastReconcile time.Time
+	// Set while disruptive changes wait for a maintenance window
+	pending *com.PendingUpdate
+
+	recentErrors []statusError
+}
+
+// observeServer records whether each call to the server succeeded. Calls abandoned because the agent is shutting
+// down are not failures.
+func (s *agentStatus) observeServer() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			resp, err := next(ctx, req)
+			if err != nil && ctx.Err() != nil {
+				return resp, err
+			}
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.connected = err == nil
+			if err != nil {
+				s.lastServerError = &statusError{Time: time.Now().UTC(), Message: err.Error(), Attrs: map[string]string{"procedure": req.Spec().Procedure}}
+			} else {
+				s.lastContact = time.Now().UTC()
+			}
+			return resp, err
+		}
+	}
+}
+
+func (s *agentStatus) recordDesired(schedule *com.Schedule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.desired = proto.Clone(schedule).(*com.Schedule)
+}
+
+// recordReconciled notes that the scheduler finished a reconcile, leaving applied running and pending, if set,
+// waiting for a maintenance window.
+func (s *agentStatus) recordReconciled(applied *com.Schedule, pending *com.PendingUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastReconcile = time.Now().UTC()
+	if applied != nil {
+		s.applied = proto.Clone(applied).(*com.Schedule)
+	}
+	s.pending = nil
+	if pending != nil {
+		s.pending = proto.Clone(pending).(*com.PendingUpdate)
+	}
+}
+
+func (s *agentStatus) recordError(e statusError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recentErrors = append(s.recentErrors, e)
+	if overflow := len(s.recentErrors) - maxRecentErrors; overflow > 0 {
+		s.recentErrors = slices.Clone(s.recentErrors[overflow:])
+	}
+}
+
+// errorRecorder keeps every error-level record for the status API as well as passing it on.
+type errorRecorder struct {
+	slog.Handler
+	status *agentStatus
+	attrs  []slog.Attr
+}
+
+func (h *errorRecorder) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level >= slog.LevelError {
+		e := statusError{Time: record.Time.UTC(), Message: record.Message, Attrs: map[string]string{}}
+		for _, attr := range h.attrs {
+			e.Attrs[attr.Key] = attr.Value.Resolve().String()
+		}
+		record.Attrs(func(attr slog.Attr) bool {
+			e.Attrs[attr.Key] = attr.Value.Resolve().String()
+			return true
+		})
+		h.status.recordError(e)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *errorRecorder) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &errorRecorder{Handler: h.Handler.WithAttrs(attrs), status: h.status, attrs: append(slices.Clone(h.attrs), attrs...)}
+}
+
+func (h *errorRecorder) WithGroup(name string) slog.Handler {
+	return &errorRecorder{Handler: h.Handler.WithGroup(name), status: h.status, attrs: h.attrs}
+}
+
+// restartRequest asks the scheduler to restart a task's container, and receives the outcome.
+type restartRequest struct {
+	task string
+	done chan error
+}
+
+// restartTask stops the container running the applied schedule's task and starts it again. It runs on the
+// scheduler's goroutine, and needs no server, so it works while the device is offline.
+func (a *Agent) restartTask(ctx context.Context, name string) error {
+	if a.currentSchedule == nil {
+		return errors.New("no schedule has been applied")
+	}
+	var task *com.Container
+	for _, t := range a.currentSchedule.Containers {
+		if t.Name == name || t.Id == name {
+			task = t
+		}
+	}
+	if task == nil {
+		return errors.Errorf("task %s is not in the applied schedule", name)
+	}
+	if task.Job != nil {
+		return errors.Errorf("task %s is a job, which runs on its own schedule", name)
+	}
+
+	slog.InfoContext(ctx, "Restarting task on request", pkg.LogKeyTaskID, task.Id)
+	// a task whose container has already gone is simply started
+	if containerID, err := a.findTaskContainer(ctx, task.Id); err == nil {
+		a.incidents.expectExit(containerID)
+		if err := a.runner.StopContainer(ctx, containerID); err != nil {
+			return errors.Wrap(err, "failed to stop task")
+		}
+	}
+	if _, err := a.startTask(ctx, a.currentSchedule, task); err != nil {
+		return errors.Wrap(err, "failed to start task")
+	}
+	return nil
+}
+
+type serverStatus struct {
+	URL         string       `json:"url"`
+	Connected   bool         `json:"connected"`
+	LastContact *time.Time   `json:"last_contact,omitempty"`
+	LastError   *statusError `json:"last_error,omitempty"`
+}
+
+type taskStatus struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Image string `json:"image"`
+	// Whether the task is in the desired schedule; containers of other tasks are on their way out
+	Desired     bool   `json:"desired"`
+	ScheduleID  string `json:"schedule_id,omitempty"`
+	ContainerID string `json:"container_id,omitempty"`
+	// The engine's state for the task's container, or "missing" if it has none
+	State     string `json:"state"`
+	Health    string `json:"health,omitempty"`
+	StartedAt string `json:"started_at,omitempty"`
+}
+
+type pendingStatus struct {
+	ScheduleID      string     `json:"schedule_id"`
+	DeferredChanges int32      `json:"deferred_changes"`
+	NextWindowAt    *time.Time `json:"next_window_at,omitempty"`
+}
+
+type statusResponse struct {
+	DeviceID          string         `json:"device_id"`
+	AgentVersion      string         `json:"agent_version"`
+	AgentContainer    string         `json:"agent_container,omitempty"`
+	Server            serverStatus   `json:"server"`
+	DesiredScheduleID string         `json:"desired_schedule_id,omitempty"`
+	AppliedScheduleID string         `json:"applied_schedule_id,omitempty"`
+	LastReconcile     *time.Time     `json:"last_reconcile,omitempty"`
+	PendingUpdate     *pendingStatus `json:"pending_update,omitempty"`
+	Tasks             []taskStatus   `json:"tasks"`
+	RecentErrors      []statusError  `json:"recent_errors"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func (a *Agent) getStatus(w http.ResponseWriter, r *http.Request) {
+	a.status.mu.Lock()
+	resp := statusResponse{
+		DeviceID:       a.deviceID,
+		AgentVersion:   pkg.Version,
+		AgentContainer: a.cfg.AgentContainer,
+		Server: serverStatus{
+			URL:         a.cfg.APIURL,
+			Connected:   a.status.connected,
+			LastContact: optionalTime(a.status.lastContact),
+			LastError:   a.status.lastServerError,
+		},
+		LastReconcile: optionalTime(a.status.lastReconcile),
+		RecentErrors:  slices.Clone(a.status.recentErrors),
+	}
+	desired := a.status.desired
+	if desired != nil {
+		resp.DesiredScheduleID = desired.Id
+	}
+	if a.status.applied != nil {
+		resp.AppliedScheduleID = a.status.applied.Id
+	}
+	if pending := a.status.pending; pending != nil {
+		resp.PendingUpdate = &pendingStatus{ScheduleID: pending.ScheduleId, DeferredChanges: pending.DeferredChanges}
+		if pending.NextWindowAt.IsValid() {
+			resp.PendingUpdate.NextWindowAt = optionalTime(pending.NextWindowAt.AsTime())
+		}
+	}
+	a.status.mu.Unlock()
+	if resp.RecentErrors == nil {
+		resp.RecentErrors = []statusError{}
+	}
+
+	containers, err := a.runner.ListContainersMatchingLabel(r.Context(), pkg.LabelManaged, "true")
+	if err != nil {
+		writeError(w, http.StatusBadGateway, errors.Wrap(err, "failed to list containers"))
+		return
+	}
+	resp.Tasks = []taskStatus{}
+	listed := map[string]bool{}
+	if desired != nil {
+		for _, task := range desired.Containers {
+			status := taskStatus{ID: task.Id, Name: task.Name, Image: task.ContainerImage, Desired: true, State: "missing"}
+			if task.Job != nil {
+				// a job only has a container while it runs
+				status.State = "idle"
+			}
+			for _, container := range containers {
+				if container.Labels[pkg.LabelTaskID] == task.Id {
+					listed[container.ID] = true
+					status.ScheduleID = container.Labels[pkg.LabelScheduleID]
+					status.ContainerID = container.ID
+					status.Image = container.Image
+					status.State = container.State
+				}
+			}
+			resp.Tasks = append(resp.Tasks, status)
+		}
+	}
+	for _, container := range containers {
+		if listed[container.ID] {
+			continue
+		}
+		resp.Tasks = append(resp.Tasks, taskStatus{
+			ID:          container.Labels[pkg.LabelTaskID],
+			Name:        container.Labels[pkg.LabelTaskName],
+			Image:       container.Image,
+			ScheduleID:  container.Labels[pkg.LabelScheduleID],
+			ContainerID: container.ID,
+			State:       container.State,
+		})
+	}
+	for i := range resp.Tasks {
+		if resp.Tasks[i].ContainerID == "" {
+			continue
+		}
+		state, err := a.runner.ContainerState(r.Context(), resp.Tasks[i].ContainerID)
+		if err != nil {
+			// gone since it was listed
+			continue
+		}
+		resp.Tasks[i].StartedAt = state.StartedAt
+		if state.Health != nil {
+			resp.Tasks[i].Health = state.Health.Status
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// getSchedule returns the schedule the server last sent, in full.
+func (a *Agent) getSchedule(w http.ResponseWriter, r *http.Request) {
+	a.status.mu.Lock()
+	desired := a.status.desired
+	a.status.mu.Unlock()
+	if desired == nil {
+		writeError(w, http.StatusNotFound, errors.New("no schedule has been received from the server"))
+		return
+	}
+	body, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(desired)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, json.RawMessage(body))
+}
+
+// requestReconcile makes the scheduler run a tick as soon as it is free, without waiting for it.
+func (a *Agent) requestReconcile(w http.ResponseWriter, r *http.Request) {
+	select {
+	case a.reconcileRequests <- struct{}{}:
+	default:
+		// one is already queued
+	}
+	writeJSON(w, http.StatusAccepted, map[string]string{"status": "queued"})
+}
+
+// requestRestart restarts a task through the scheduler, and waits for it to finish.
+func (a *Agent) requestRestart(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("task")
+	a.status.mu.Lock()
+	applied := a.status.applied
+	a.status.mu.Unlock()
+	if applied == nil || !slices.ContainsFunc(applied.Containers, func(t *com.Container) bool { return t.Name == name || t.Id == name }) {
+		writeError(w, http.StatusNotFound, errors.Errorf("task %s is not in the applied schedule", name))
+		return
+	}
+
+	req := restartRequest{task: name, done: make(chan error, 1)}
+	select {
+	case <-r.Context().Done():
+		return
+	case a.restartRequests <- req:
+	}
+	select {
+	case <-r.Context().Done():
+	case err := <-req.done:
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "restarted"})
+	}
+}
+
+// serveStatus serves the local status API on the configured unix socket and loopback address until ctx is done.
+// It has no authentication: whoever can reach it is already on the device.
+func (a *Agent) serveStatus(ctx context.Context) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", a.getStatus)
+	mux.HandleFunc("GET /schedule", a.getSchedule)
+	mux.HandleFunc("POST /reconcile", a.requestReconcile)
+	mux.HandleFunc("POST /tasks/{task}/restart", a.requestRestart)
+	statusServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	listeners := []net.Listener{}
+	if a.cfg.StatusSocket != "" {
+		if err := os.MkdirAll(filepath.Dir(a.cfg.StatusSocket), 0o755); err != nil {
+			slog.ErrorContext(ctx, "Failed to create status socket directory", pkg.Err(err))
+		}
+		// a socket left behind by an earlier agent would stop this one listening
+		if err := os.Remove(a.cfg.StatusSocket); err != nil && !os.IsNotExist(err) {
+			slog.ErrorContext(ctx, "Failed to remove old status socket", pkg.Err(err))
+		}
+		listener, err := net.Listen("unix", a.cfg.StatusSocket)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to listen on status socket", "socket", a.cfg.StatusSocket, pkg.Err(err))
+		} else {
+			listeners = append(listeners, listener)
+		}
+	}
+	if a.cfg.StatusAddress != "" {
+		listener, err := net.Listen("tcp", a.cfg.StatusAddress)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to listen on status address", "address", a.cfg.StatusAddress, pkg.Err(err))
+		} else {
+			listeners = append(listeners, listener)
+		}
+	}
+	if len(listeners) == 0 {
+		return
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = statusServer.Shutdown(shutdownCtx)
+	}()
+	for _, listener := range listeners {
+		slog.InfoContext(ctx, "Serving status API", "address", listener.Addr().String())
+		go func() {
+			if err := statusServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+				slog.ErrorContext(ctx, "Status API failed", pkg.Err(err))
+			}
+		}()
+	}
+}
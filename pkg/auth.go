@@ -0,0 +1,283 @@
+package pkg
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/parrotmac/goutil"
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/models"
+)
+
+const (
+	// Name of the cookie the Remix app keeps its session in
+	sessionCookieName = "__session"
+	sessionUserIDKey  = "userId"
+
+	APIKeyPrefix           = "pando_"
+	DeviceRelayTokenPrefix = "pandodev_"
+)
+
+// Principal is whoever an authenticated request acts as: a user signed in to the web app, or an API key.
+type Principal struct {
+	UserID uuid.UUID
+	Email  string
+
+	APIKeyID   uuid.UUID
+	APIKeyName string
+
+	// The organization the request was authorized to act on, and the principal's role in it. API keys only
+	// ever act on the organization they were created in, with the role they were created with.
+	OrganizationID uuid.UUID
+	Role           Role
+}
+
+func (p *Principal) IsAPIKey() bool {
+	return p.APIKeyID != uuid.Nil
+}
+
+type principalKey struct{}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
+
+// Authenticator resolves the principal behind a request from an API key, the web app's session cookie, or,
+// in development, DEVELOPMENT_AUTH_USER_EMAIL.
+type Authenticator struct {
+	cfg Config
+	q   models.Querier
+}
+
+func NewAuthenticator(cfg Config, q models.Querier) *Authenticator {
+	return &Authenticator{
+		cfg: cfg,
+		q:   q,
+	}
+}
+
+// Interceptor rejects unauthenticated requests, and requests the principal's role in the organization they
+// name does not permit, and makes the principal available through PrincipalFromContext.
+func (a *Authenticator) Interceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			principal, err := a.Authenticate(ctx, req.Header())
+			if err != nil {
+				return nil, err
+			}
+			if err := a.authorize(ctx, principal, req.Spec().Procedure, req.Any()); err != nil {
+				return nil, err
+			}
+			return next(context.WithValue(ctx, principalKey{}, principal), req)
+		}
+	}
+}
+
+// organizationRequest is implemented by the request messages of every organization-scoped RPC.
+type organizationRequest interface {
+	GetOrganizationId() string
+}
+
+// authorize checks principal may call procedure with msg, and records the organization it acts on and its role there.
+func (a *Authenticator) authorize(ctx context.Context, principal *Principal, procedure string, msg any) error {
+	permission, ok := procedurePermissions[procedure]
+	if !ok {
+		return connect.NewError(connect.CodePermissionDenied, errors.Errorf("no permission is defined for %s", procedure))
+	}
+	if permission == PermissionAuthenticated {
+		return nil
+	}
+	request, ok := msg.(organizationRequest)
+	if !ok {
+		return connect.NewError(connect.CodeInternal, errors.Errorf("%s does not name an organization", procedure))
+	}
+	organizationID, err := uuid.Parse(request.GetOrganizationId())
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, errors.Wrap(err, "invalid organization id"))
+	}
+
+	if principal.IsAPIKey() {
+		if principal.OrganizationID != organizationID {
+			return connect.NewError(connect.CodePermissionDenied, errors.New("API key belongs to another organization"))
+		}
+	} else {
+		role, err := a.q.GetOrganizationRole(ctx, principal.UserID, organizationID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return connect.NewError(connect.CodePermissionDenied, errors.New("not a member of this organization"))
+			}
+			return errors.Wrap(err, "failed to look up organization membership")
+		}
+		principal.OrganizationID = organizationID
+		principal.Role = Role(goutil.UnwrapOr(role, ""))
+	}
+	return requirePermission(principal, permission)
+}
+
+func requirePermission(principal *Principal, permission Permission) error {
+	if !principal.Role.Can(permission) {
+		return connect.NewError(connect.CodePermissionDenied, errors.Errorf("the %s role does not grant %s", principal.Role, permission))
+	}
+	return nil
+}
+
+func (a *Authenticator) Authenticate(ctx context.Context, header http.Header) (*Principal, error) {
+	if a.cfg.DevelopmentAuthUserEmail != "" {
+		return a.userPrincipal(ctx, func() (models.GetUserByIDRow, error) {
+			row, err := a.q.GetUserByEmail(ctx, &a.cfg.DevelopmentAuthUserEmail)
+			return models.GetUserByIDRow(row), err
+		})
+	}
+
+	if authorization := header.Get("Authorization"); authorization != "" {
+		token, ok := strings.CutPrefix(authorization, "Bearer ")
+		if !ok || !strings.HasPrefix(token, APIKeyPrefix) {
+			return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("malformed authorization header"))
+		}
+		tokenHash := HashAPIKeyToken(token)
+		key, err := a.q.GetActiveAPIKeyByTokenHash(ctx, &tokenHash)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("unknown or revoked API key"))
+			}
+			return nil, errors.Wrap(err, "failed to look up API key")
+		}
+		return &Principal{
+			APIKeyID:       key.ID,
+			APIKeyName:     goutil.UnwrapOr(key.Name, ""),
+			OrganizationID: key.OrganizationID,
+			Role:           Role(goutil.UnwrapOr(key.Role, "")),
+		}, nil
+	}
+
+	for _, cookie := range readCookies(header, sessionCookieName) {
+		userID, err := a.sessionUserID(cookie.Value)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeUnauthenticated, err)
+		}
+		return a.userPrincipal(ctx, func() (models.GetUserByIDRow, error) {
+			return a.q.GetUserByID(ctx, userID)
+		})
+	}
+
+	return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing credentials"))
+}
+
+func (a *Authenticator) userPrincipal(ctx context.Context, lookup func() (models.GetUserByIDRow, error)) (*Principal, error) {
+	user, err := lookup()
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("unknown user"))
+		}
+		return nil, errors.Wrap(err, "failed to look up user")
+	}
+	principal := &Principal{UserID: user.ID}
+	if user.Email != nil {
+		principal.Email = *user.Email
+	}
+	return principal, nil
+}
+
+// sessionUserID verifies a cookie signed by the web app's cookie session storage and returns its user ID.
+// The cookie holds base64 encoded JSON, followed by "." and an unpadded base64 HMAC-SHA256 of it.
+func (a *Authenticator) sessionUserID(cookieValue string) (uuid.UUID, error) {
+	if a.cfg.SessionSecret == "" {
+		return uuid.Nil, errors.New("session cookies are not accepted without SESSION_SECRET")
+	}
+	value, err := url.QueryUnescape(cookieValue)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "malformed session cookie")
+	}
+	separator := strings.LastIndex(value, ".")
+	if separator < 0 {
+		return uuid.Nil, errors.New("unsigned session cookie")
+	}
+	data, signature := value[:separator], value[separator+1:]
+
+	mac := hmac.New(sha256.New, []byte(a.cfg.SessionSecret))
+	mac.Write([]byte(data))
+	expected := base64.RawStdEncoding.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return uuid.Nil, errors.New("invalid session signature")
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(err, "malformed session data")
+	}
+	session := map[string]any{}
+	if err := json.Unmarshal(decoded, &session); err != nil {
+		return uuid.Nil, errors.Wrap(err, "malformed session data")
+	}
+	userID, _ := session[sessionUserIDKey].(string)
+	parsed, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, errors.New("session is not signed in")
+	}
+	return parsed, nil
+}
+
+// RequireOrganization parses organizationID and checks it is the organization the interceptor authorized the
+// principal in ctx to act on.
+func (a *Authenticator) RequireOrganization(ctx context.Context, organizationID string) (uuid.UUID, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return uuid.Nil, connect.NewError(connect.CodeUnauthenticated, errors.New("missing credentials"))
+	}
+	orgUUID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return uuid.Nil, connect.NewError(connect.CodeInvalidArgument, errors.Wrap(err, "invalid organization id"))
+	}
+	if principal.Role == "" || principal.OrganizationID != orgUUID {
+		return uuid.Nil, connect.NewError(connect.CodePermissionDenied, errors.New("not authorized for this organization"))
+	}
+	return orgUUID, nil
+}
+
+// RequirePermission checks the principal in ctx has permission in the organization it was authorized for, for
+// handlers that need more than their RPC's permission in some cases.
+func (a *Authenticator) RequirePermission(ctx context.Context, permission Permission) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return connect.NewError(connect.CodeUnauthenticated, errors.New("missing credentials"))
+	}
+	return requirePermission(principal, permission)
+}
+
+// NewAPIKeyToken returns a new random API key token and the hash stored in its place.
+func NewAPIKeyToken() (token string, tokenHash string, err error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", errors.Wrap(err, "failed to generate API key")
+	}
+	token = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	return token, HashAPIKeyToken(token), nil
+}
+
+// NewDeviceRelayToken returns a new random relay token for a device and the hash stored in its place.
+func NewDeviceRelayToken() (token string, tokenHash string, err error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", errors.Wrap(err, "failed to generate device relay token")
+	}
+	token = DeviceRelayTokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	return token, HashAPIKeyToken(token), nil
+}
+
+func HashAPIKeyToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
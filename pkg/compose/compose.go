@@ -0,0 +1,433 @@
+// Package compose converts between docker compose files and schedules. Compose can describe far more than a
+// schedule can hold, so conversion keeps what maps cleanly and reports everything else instead of guessing.
+package compose
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+)
+
+// Conversion is a schedule converted from a compose file.
+type Conversion struct {
+	Schedule *com.Schedule
+	// Every part of the file that was ignored or only partly converted, each with where it is and why
+	Unsupported []string
+}
+
+// Host paths that compose volumes may bind, each with the container field that requests it
+var bindTargets = map[string]func(*com.Container) *bool{
+	"/dev":                 func(c *com.Container) *bool { return &c.BindDev },
+	"/proc":                func(c *com.Container) *bool { return &c.BindProc },
+	"/sys":                 func(c *com.Container) *bool { return &c.BindSys },
+	"/dev/shm":             func(c *com.Container) *bool { return &c.BindShm },
+	"/sys/fs/cgroup":       func(c *com.Container) *bool { return &c.BindCgroup },
+	"/var/run/docker.sock": func(c *com.Container) *bool { return &c.BindDockerSocket },
+	"/boot":                func(c *com.Container) *bool { return &c.BindBoot },
+}
+
+// Top-level keys with no meaning for a schedule that are dropped without comment
+var ignoredKeys = map[string]bool{
+	// obsolete since compose v2
+	"version": true,
+}
+
+type converter struct {
+	unsupported []string
+}
+
+func (c *converter) unsupportedf(path string, format string, args ...any) {
+	c.unsupported = append(c.unsupported, path+": "+fmt.Sprintf(format, args...))
+}
+
+// ToSchedule converts a compose file to a schedule named name, or after the file's own name when name is empty.
+// Containers are listed so that each comes after the services it depends on, but devices start a schedule's
+// containers without waiting on each other, so depends_on is also reported as only partly supported. The
+// schedule still needs pkg.ValidateSchedule.
+func ToSchedule(data []byte, name string) (*Conversion, error) {
+	file := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, errors.Wrap(err, "failed to parse compose file")
+	}
+
+	c := &converter{}
+	schedule := &com.Schedule{Name: name, State: "active"}
+	var services map[string]interface{}
+	for _, key := range sortedKeys(file) {
+		value := file[key]
+		switch {
+		case key == "services":
+			var ok bool
+			if services, ok = asMap(value); !ok {
+				return nil, errors.New("services must be a mapping")
+			}
+		case key == "name":
+			if schedule.Name == "" {
+				schedule.Name, _ = asString(value)
+			}
+		case ignoredKeys[key] || strings.HasPrefix(key, "x-"):
+		default:
+			c.unsupportedf(key, "top-level %s are not supported", key)
+		}
+	}
+	if len(services) == 0 {
+		return nil, errors.New("compose file has no services")
+	}
+
+	containers := map[string]*com.Container{}
+	dependencies := map[string][]string{}
+	for _, serviceName := range sortedKeys(services) {
+		spec, ok := asMap(services[serviceName])
+		if !ok {
+			return nil, errors.Errorf("service %s must be a mapping", serviceName)
+		}
+		container, dependsOn, err := c.service(serviceName, spec)
+		if err != nil {
+			return nil, err
+		}
+		containers[serviceName] = container
+		dependencies[serviceName] = dependsOn
+	}
+
+	order, err := startOrder(dependencies)
+	if err != nil {
+		return nil, err
+	}
+	for _, serviceName := range order {
+		schedule.Containers = append(schedule.Containers, containers[serviceName])
+	}
+
+	return &Conversion{
+		Schedule:    schedule,
+		Unsupported: c.unsupported,
+	}, nil
+}
+
+// service converts one service, returning the services it depends on.
+func (c *converter) service(name string, spec map[string]interface{}) (*com.Container, []string, error) {
+	container := &com.Container{Name: name}
+	var dependsOn []string
+	path := "services." + name
+
+	for _, key := range sortedKeys(spec) {
+		value := spec[key]
+		keyPath := path + "." + key
+		switch key {
+		case "image":
+			container.ContainerImage, _ = asString(value)
+		case "container_name":
+			if containerName, _ := asString(value); containerName != name {
+				c.unsupportedf(keyPath, "containers are always named after their service")
+			}
+		case "environment":
+			container.Env = c.environment(keyPath, value)
+		case "command":
+			container.Command = c.commandLine(keyPath, value)
+		case "entrypoint":
+			container.Entrypoint = c.commandLine(keyPath, value)
+		case "ports":
+			items, ok := value.([]interface{})
+			if !ok {
+				return nil, nil, errors.Errorf("%s must be a list", keyPath)
+			}
+			for i, item := range items {
+				if port := c.port(fmt.Sprintf("%s[%d]", keyPath, i), item); port != nil {
+					container.Ports = append(container.Ports, port)
+				}
+			}
+			if len(items) > 0 {
+				c.unsupportedf(keyPath, "kept in the schedule, but devices do not publish ports; use network_mode: host to reach the service")
+			}
+		case "network_mode":
+			switch mode, _ := asString(value); mode {
+			case "bridge":
+				container.NetworkMode = com.Container_BRIDGE
+			case "host":
+				container.NetworkMode = com.Container_HOST
+			case "none":
+				container.NetworkMode = com.Container_NONE
+			default:
+				c.unsupportedf(keyPath, "network mode %q is not supported; using bridge", mode)
+			}
+		case "privileged":
+			container.Privileged, _ = value.(bool)
+		case "volumes":
+			items, ok := value.([]interface{})
+			if !ok {
+				return nil, nil, errors.Errorf("%s must be a list", keyPath)
+			}
+			for i, item := range items {
+				c.volume(fmt.Sprintf("%s[%d]", keyPath, i), item, container)
+			}
+		case "depends_on":
+			dependsOn = c.dependsOn(keyPath, value)
+		case "stop_signal":
+			container.StopSignal, _ = asString(value)
+		case "stop_grace_period":
+			text, _ := asString(value)
+			period, err := time.ParseDuration(text)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "%s is not a duration", keyPath)
+			}
+			container.StopGracePeriodSeconds = int32(math.Ceil(period.Seconds()))
+		case "deploy":
+			c.deploy(keyPath, value, container)
+		default:
+			if !strings.HasPrefix(key, "x-") {
+				c.unsupportedf(keyPath, "not supported")
+			}
+		}
+	}
+
+	if container.ContainerImage == "" {
+		return nil, nil, errors.Errorf("%s has no image; services that only build are not supported", path)
+	}
+	return container, dependsOn, nil
+}
+
+// deploy takes the update order from deploy.update_config; the rest of deploy is for swarm.
+func (c *converter) deploy(path string, value interface{}, container *com.Container) {
+	spec, _ := asMap(value)
+	for _, key := range sortedKeys(spec) {
+		keyPath := path + "." + key
+		if key != "update_config" {
+			c.unsupportedf(keyPath, "not supported")
+			continue
+		}
+		updateConfig, _ := asMap(spec[key])
+		for _, key := range sortedKeys(updateConfig) {
+			if key != "order" {
+				c.unsupportedf(keyPath+"."+key, "not supported")
+				continue
+			}
+			switch order, _ := asString(updateConfig[key]); order {
+			case "start-first":
+				container.UpdateStrategy = "start-first"
+			case "stop-first":
+				container.UpdateStrategy = "recreate"
+			default:
+				c.unsupportedf(keyPath+"."+key, "update order %q is not supported; using stop-first", order)
+			}
+		}
+	}
+}
+
+func (c *converter) environment(path string, value interface{}) map[string]string {
+	env := map[string]string{}
+	set := func(key string, value interface{}) {
+		if value == nil {
+			c.unsupportedf(path+"."+key, "values taken from the host's environment are not supported")
+			return
+		}
+		env[key], _ = asString(value)
+	}
+
+	if entries, ok := asMap(value); ok {
+		for _, key := range sortedKeys(entries) {
+			set(key, entries[key])
+		}
+		return env
+	}
+	items, _ := value.([]interface{})
+	for _, item := range items {
+		entry, _ := asString(item)
+		key, val, ok := strings.Cut(entry, "=")
+		if !ok {
+			set(key, nil)
+			continue
+		}
+		set(key, val)
+	}
+	return env
+}
+
+// commandLine takes a command or entrypoint in either string or list form.
+func (c *converter) commandLine(path string, value interface{}) string {
+	if text, ok := asString(value); ok {
+		return text
+	}
+	items, _ := value.([]interface{})
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		part, _ := asString(item)
+		parts = append(parts, part)
+	}
+	if len(parts) > 1 {
+		c.unsupportedf(path, "joined into one string, as a schedule stores it as a single string")
+	}
+	return strings.Join(parts, " ")
+}
+
+func (c *converter) port(path string, value interface{}) *com.Container_Port {
+	if spec, ok := asMap(value); ok {
+		target, _ := asString(spec["target"])
+		published, _ := asString(spec["published"])
+		protocol, _ := asString(spec["protocol"])
+		if hostIP, _ := asString(spec["host_ip"]); hostIP != "" {
+			c.unsupportedf(path, "host_ip is not supported; publishing on every interface")
+		}
+		if published == "" {
+			c.unsupportedf(path, "ports without a published host port are not supported")
+			return nil
+		}
+		return c.portMapping(path, published, target, protocol)
+	}
+
+	text, _ := asString(value)
+	mapping, protocol, _ := strings.Cut(text, "/")
+	separator := strings.LastIndex(mapping, ":")
+	if separator < 0 {
+		c.unsupportedf(path, "ports without a published host port are not supported")
+		return nil
+	}
+	target := mapping[separator+1:]
+	published := mapping[:separator]
+	if separator = strings.LastIndex(published, ":"); separator >= 0 {
+		c.unsupportedf(path, "binding to a host IP is not supported; publishing on every interface")
+		published = published[separator+1:]
+	}
+	return c.portMapping(path, published, target, protocol)
+}
+
+func (c *converter) portMapping(path string, published string, target string, protocol string) *com.Container_Port {
+	if strings.Contains(published, "-") || strings.Contains(target, "-") {
+		c.unsupportedf(path, "port ranges are not supported")
+		return nil
+	}
+	return &com.Container_Port{
+		Host:      published,
+		Container: target,
+		Protocol:  protocol,
+	}
+}
+
+// volume accepts only binds of the host paths a container can request, mounted at the same place.
+func (c *converter) volume(path string, value interface{}, container *com.Container) {
+	var source, target string
+	readOnly := false
+	if spec, ok := asMap(value); ok {
+		if kind, _ := asString(spec["type"]); kind != "bind" {
+			c.unsupportedf(path, "only bind mounts of %s are supported", strings.Join(sortedKeys(bindTargets), ", "))
+			return
+		}
+		source, _ = asString(spec["source"])
+		target, _ = asString(spec["target"])
+		readOnly, _ = spec["read_only"].(bool)
+	} else {
+		text, _ := asString(value)
+		parts := strings.Split(text, ":")
+		if len(parts) < 2 {
+			c.unsupportedf(path, "anonymous volumes are not supported")
+			return
+		}
+		source, target = parts[0], parts[1]
+		readOnly = len(parts) > 2 && strings.Contains(parts[2], "ro")
+	}
+
+	field, ok := bindTargets[target]
+	// the agent decides where the engine's socket is on the host
+	sameSource := source == target || (target == "/var/run/docker.sock" && strings.HasSuffix(source, "docker.sock"))
+	if !ok || !sameSource {
+		c.unsupportedf(path, "only bind mounts of %s are supported", strings.Join(sortedKeys(bindTargets), ", "))
+		return
+	}
+	if readOnly {
+		c.unsupportedf(path, "read-only mounts are not supported; mounting read-write")
+	}
+	*field(container) = true
+}
+
+func (c *converter) dependsOn(path string, value interface{}) []string {
+	c.unsupportedf(path, "containers are started without waiting for their dependencies")
+	var services []string
+	if conditions, ok := asMap(value); ok {
+		return sortedKeys(conditions)
+	}
+	items, _ := value.([]interface{})
+	for _, item := range items {
+		service, _ := asString(item)
+		services = append(services, service)
+	}
+	return services
+}
+
+// startOrder sorts services so each comes after its dependencies, and otherwise by name.
+func startOrder(dependencies map[string][]string) ([]string, error) {
+	order := make([]string, 0, len(dependencies))
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+	var visit func(service string, from string) error
+	visit = func(service string, from string) error {
+		switch state[service] {
+		case visiting:
+			return errors.Errorf("services %s and %s depend on each other", from, service)
+		case done:
+			return nil
+		}
+		if _, ok := dependencies[service]; !ok {
+			return errors.Errorf("service %s depends on undefined service %s", from, service)
+		}
+		state[service] = visiting
+		for _, dependency := range dependencies[service] {
+			if err := visit(dependency, service); err != nil {
+				return err
+			}
+		}
+		state[service] = done
+		order = append(order, service)
+		return nil
+	}
+	for _, service := range sortedKeys(dependencies) {
+		if err := visit(service, ""); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// asMap accepts the mappings the YAML decoder produces, whose keys may be any scalar.
+func asMap(value interface{}) (map[string]interface{}, bool) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			converted[fmt.Sprint(k)] = v
+		}
+		return converted, true
+	default:
+		return nil, false
+	}
+}
+
+// asString accepts any scalar, as compose files freely write numbers and booleans where strings are meant.
+func asString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case int, int64, uint64, float64, bool:
+		return fmt.Sprint(v), true
+	default:
+		return "", false
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,83 @@
+package compose
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+)
+
+type file struct {
+	Name     string             `yaml:"name,omitempty"`
+	Services map[string]service `yaml:"services"`
+}
+
+type service struct {
+	Image           string            `yaml:"image"`
+	Command         string            `yaml:"command,omitempty"`
+	Entrypoint      string            `yaml:"entrypoint,omitempty"`
+	Environment     map[string]string `yaml:"environment,omitempty"`
+	Ports           []string          `yaml:"ports,omitempty"`
+	NetworkMode     string            `yaml:"network_mode,omitempty"`
+	Privileged      bool              `yaml:"privileged,omitempty"`
+	Volumes         []string          `yaml:"volumes,omitempty"`
+	StopSignal      string            `yaml:"stop_signal,omitempty"`
+	StopGracePeriod string            `yaml:"stop_grace_period,omitempty"`
+	Deploy          *deploy           `yaml:"deploy,omitempty"`
+}
+
+type deploy struct {
+	UpdateConfig updateConfig `yaml:"update_config"`
+}
+
+type updateConfig struct {
+	Order string `yaml:"order"`
+}
+
+// FromSchedule renders a schedule as a compose file.
+func FromSchedule(schedule *com.Schedule) ([]byte, error) {
+	f := file{
+		Name:     schedule.Name,
+		Services: map[string]service{},
+	}
+	for _, container := range schedule.Containers {
+		s := service{
+			Image:       container.ContainerImage,
+			Command:     container.Command,
+			Entrypoint:  container.Entrypoint,
+			Privileged:  container.Privileged,
+			StopSignal:  container.StopSignal,
+			Environment: container.Env,
+		}
+		for _, port := range container.Ports {
+			mapping := port.Host + ":" + port.Container
+			if port.Protocol != "" && port.Protocol != "tcp" {
+				mapping += "/" + port.Protocol
+			}
+			s.Ports = append(s.Ports, mapping)
+		}
+		switch container.NetworkMode {
+		case com.Container_HOST:
+			s.NetworkMode = "host"
+		case com.Container_NONE:
+			s.NetworkMode = "none"
+		}
+		for _, target := range sortedKeys(bindTargets) {
+			if *bindTargets[target](container) {
+				s.Volumes = append(s.Volumes, target+":"+target)
+			}
+		}
+		if container.StopGracePeriodSeconds > 0 {
+			s.StopGracePeriod = fmt.Sprintf("%ds", container.StopGracePeriodSeconds)
+		}
+		if container.UpdateStrategy == "start-first" {
+			s.Deploy = &deploy{UpdateConfig: updateConfig{Order: "start-first"}}
+		}
+		f.Services[container.Name] = s
+	}
+
+	encoded, err := yaml.Marshal(f)
+	return encoded, errors.Wrap(err, "failed to encode compose file")
+}
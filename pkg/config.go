@@ -1,7 +1,9 @@
 package pkg
 
 import (
-	"log"
+	"log/slog"
+	"net"
+	"time"
 
 	"github.com/caarlos0/env/v10"
 	"github.com/pkg/errors"
@@ -16,10 +18,93 @@ type Config struct {
 	SentryDSN         string   `env:"SENTRY_DSN" envDefault:""`
 	Environment       string   `env:"ENVIRONMENT" envDefault:"development"`
 
+	// Prometheus metrics are served at /metrics on this address; empty disables them. Listen on a routable address
+	// such as "0.0.0.0:9090" only when the scraper cannot reach the server otherwise.
+	MetricsAddress string `env:"METRICS_ADDRESS" envDefault:"127.0.0.1:9090"`
+	// When set, the log level is served at /loglevel on this address, such as "127.0.0.1:9093", and can be changed
+	// with a PUT. It has no authentication, so keep it on loopback.
+	LogLevelAddress string `env:"LOG_LEVEL_ADDRESS" envDefault:""`
+	// "debug", "info", "warn" or "error"
+	LogLevel string `env:"LOG_LEVEL" envDefault:"info"`
+	// "text" or "json"
+	LogFormat string `env:"LOG_FORMAT" envDefault:"text"`
+	TracingConfig
+
+	// A device is online if its agent called within DeviceOnlineThreshold, stale until DeviceOfflineThreshold,
+	// and offline after that
+	DeviceOnlineThreshold  time.Duration `env:"DEVICE_ONLINE_THRESHOLD" envDefault:"1m"`
+	DeviceOfflineThreshold time.Duration `env:"DEVICE_OFFLINE_THRESHOLD" envDefault:"10m"`
+	// Take agents' remote address from X-Forwarded-For; only enable behind a proxy that sets it
+	TrustForwardedFor bool `env:"TRUST_FORWARDED_FOR" envDefault:"false"`
+
+	// Audit log entries are deleted once older than this; 0 keeps them forever
+	AuditLogRetention time.Duration `env:"AUDIT_LOG_RETENTION" envDefault:"8760h"`
+	// Likewise for the history of job containers' runs
+	JobRunRetention time.Duration `env:"JOB_RUN_RETENTION" envDefault:"720h"`
+
+	// Serve the relay for remote exec and container logs. Devices poll for actions and push logs over the
+	// unauthenticated RemoteService, presenting a relay token issued with IssueDeviceRelayToken; anyone holding a
+	// device's token can read the exec requests and forge the results and logs of that device
+	DeviceRelayEnabled bool `env:"DEVICE_RELAY_ENABLED" envDefault:"false"`
+
+	// Shared with the web app, to verify its session cookie on API requests
+	SessionSecret string `env:"SESSION_SECRET" envDefault:""`
+
 	// When set, all HTTP requests will be authenticated as this user, regardless of the actual token (or lack thereof)
 	DevelopmentAuthUserEmail string `env:"DEVELOPMENT_AUTH_USER_EMAIL" envDefault:""`
 }
 
+// AgentConfig configures the on-device agent (cmd/remote).
+type AgentConfig struct {
+	APIURL     string `env:"API_URL" envDefault:"https://graphene.fluffy-broadnose.ts.net"`
+	DockerHost string `env:"DOCKER_HOST" envDefault:"/var/run/balena-engine.sock"`
+	// Relay token issued to this device with IssueDeviceRelayToken ("pandoctl device-token"); without one the agent
+	// neither takes remote exec requests nor ships container logs
+	DeviceToken string `env:"DEVICE_TOKEN" envDefault:""`
+
+	// As for the server
+	LogLevel  string `env:"LOG_LEVEL" envDefault:"info"`
+	LogFormat string `env:"LOG_FORMAT" envDefault:"text"`
+	TracingConfig
+
+	// Filesystem holding the engine's images, used to decide when to garbage collect
+	GCDiskPath string `env:"GC_DISK_PATH" envDefault:"/"`
+	// Garbage collection runs whenever free space on GCDiskPath drops below this percentage
+	GCMinFreePercent float64 `env:"GC_MIN_FREE_PERCENT" envDefault:"15"`
+
+	// How often the agent fetches its schedule, applies it and reports its state
+	ScheduleInterval time.Duration `env:"SCHEDULE_INTERVAL" envDefault:"15s"`
+
+	// Directory where the agent persists state, such as the last known-good schedule, across restarts
+	StateDir string `env:"STATE_DIR" envDefault:"/var/lib/pando"`
+	// A newly applied schedule must run healthily for this long before it becomes the rollback target
+	RollbackSoakPeriod time.Duration `env:"ROLLBACK_SOAK_PERIOD" envDefault:"5m"`
+	// How long a container updated start-first has to become healthy before the agent gives up on it and keeps the
+	// old one. Containers without a health check count as healthy once they have run for StartFirstMinUptime.
+	StartFirstTimeout   time.Duration `env:"START_FIRST_TIMEOUT" envDefault:"2m"`
+	StartFirstMinUptime time.Duration `env:"START_FIRST_MIN_UPTIME" envDefault:"10s"`
+
+	// When set, Prometheus metrics are served at /metrics on this address, such as "0.0.0.0:9091"
+	MetricsAddress string `env:"METRICS_ADDRESS" envDefault:""`
+	// As for the server
+	LogLevelAddress string `env:"LOG_LEVEL_ADDRESS" envDefault:""`
+
+	// The local status API, for technicians on site, is served on this unix socket; empty disables it
+	StatusSocket string `env:"STATUS_SOCKET" envDefault:"/var/run/pando/agent.sock"`
+	// When set, the status API is also served on this loopback address, such as "127.0.0.1:9092". It has no
+	// authentication, so other addresses are refused.
+	StatusAddress string `env:"STATUS_ADDRESS" envDefault:""`
+
+	// Name of the container the agent runs in. The agent only updates itself to its fleet's agent image when set.
+	AgentContainer string `env:"AGENT_CONTAINER" envDefault:""`
+	// How long a new agent has to check in with the server before the old one removes it and carries on
+	HandoverTimeout time.Duration `env:"HANDOVER_TIMEOUT" envDefault:"5m"`
+	// Set by the agent on the container it hands over to: the container to stop once the new agent has checked
+	// in, and when it must have done so by
+	HandoverFrom     string    `env:"PANDO_HANDOVER_FROM" envDefault:""`
+	HandoverDeadline time.Time `env:"PANDO_HANDOVER_DEADLINE"`
+}
+
 func ReadConfig() (Config, error) {
 	cfg := Config{}
 	parseErr := env.Parse(&cfg)
@@ -35,7 +120,58 @@ func ReadConfig() (Config, error) {
 			return cfg, errors.New("DEVELOPMENT_AUTH_USER_EMAIL can only be set when ENVIRONMENT is development")
 		}
 
-		log.Printf("!!!\n!!! DEVELOPMENT_AUTH_USER_EMAIL is set to %s\n!!!", cfg.DevelopmentAuthUserEmail)
+		slog.Warn("!!! DEVELOPMENT_AUTH_USER_EMAIL is set; every request is authenticated as this user !!!", "email", cfg.DevelopmentAuthUserEmail)
+	}
+
+	if cfg.DeviceOnlineThreshold <= 0 || cfg.DeviceOfflineThreshold <= cfg.DeviceOnlineThreshold {
+		return cfg, errors.New("DEVICE_OFFLINE_THRESHOLD must be longer than DEVICE_ONLINE_THRESHOLD, which must be positive")
+	}
+	if cfg.AuditLogRetention < 0 {
+		return cfg, errors.New("AUDIT_LOG_RETENTION must not be negative")
+	}
+	if cfg.JobRunRetention < 0 {
+		return cfg, errors.New("JOB_RUN_RETENTION must not be negative")
+	}
+	if err := cfg.TracingConfig.validate(); err != nil {
+		return cfg, err
+	}
+
+	return cfg, nil
+}
+
+func ReadAgentConfig() (AgentConfig, error) {
+	cfg := AgentConfig{}
+	parseErr := env.Parse(&cfg)
+	if parseErr != nil {
+		return cfg, errors.Wrap(parseErr, "failed to parse environment variables")
+	}
+
+	if cfg.GCMinFreePercent < 0 || cfg.GCMinFreePercent > 100 {
+		return cfg, errors.New("GC_MIN_FREE_PERCENT must be between 0 and 100")
+	}
+	if cfg.RollbackSoakPeriod <= 0 {
+		return cfg, errors.New("ROLLBACK_SOAK_PERIOD must be positive")
+	}
+	if cfg.StartFirstTimeout <= cfg.StartFirstMinUptime {
+		return cfg, errors.New("START_FIRST_TIMEOUT must be longer than START_FIRST_MIN_UPTIME")
+	}
+	if cfg.HandoverTimeout <= 0 {
+		return cfg, errors.New("HANDOVER_TIMEOUT must be positive")
+	}
+	if cfg.StatusAddress != "" {
+		host, _, err := net.SplitHostPort(cfg.StatusAddress)
+		if err != nil {
+			return cfg, errors.Wrap(err, "invalid STATUS_ADDRESS")
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return cfg, errors.New("STATUS_ADDRESS must be a loopback address")
+		}
+	}
+	if cfg.HandoverFrom != "" && cfg.HandoverDeadline.IsZero() {
+		return cfg, errors.New("PANDO_HANDOVER_DEADLINE must be set with PANDO_HANDOVER_FROM")
+	}
+	if err := cfg.TracingConfig.validate(); err != nil {
+		return cfg, err
 	}
 
 	return cfg, nil
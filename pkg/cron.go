@@ -0,0 +1,176 @@
+package pkg
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CronSchedule is a parsed five-field cron expression, as used by job containers.
+type CronSchedule struct {
+	// Bit n is set when the field matches n
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// As in cron, a day matches either day field when both are restricted, and the restricted one otherwise
+	dayOfMonthRestricted, dayOfWeekRestricted bool
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	cronMonthNames = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	cronDayNames   = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+)
+
+// How far ahead Next looks before deciding a schedule never fires, such as one for February 30th
+const cronSearchYears = 5
+
+// ParseCron parses a cron expression: minute, hour, day of month, month and day of week, each a "*", a number, a
+// range such as "1-5" or a list of them, optionally with a step such as "*/15". Months and days of the week may
+// also be given by their first three letters, and Sunday is both 0 and 7. The macros @hourly, @daily, @weekly,
+// @monthly and @yearly are understood too.
+func ParseCron(expression string) (*CronSchedule, error) {
+	expression = strings.TrimSpace(expression)
+	if macro, ok := cronMacros[strings.ToLower(expression)]; ok {
+		expression = macro
+	}
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("cron expression %q must have five fields: minute, hour, day of month, month and day of week", expression)
+	}
+
+	schedule := &CronSchedule{}
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], "minute", 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = parseCronField(fields[1], "hour", 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if schedule.dayOfMonth, err = parseCronField(fields[2], "day of month", 1, 31, nil); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = parseCronField(fields[3], "month", 1, 12, cronMonthNames); err != nil {
+		return nil, err
+	}
+	if schedule.dayOfWeek, err = parseCronField(fields[4], "day of week", 0, 7, cronDayNames); err != nil {
+		return nil, err
+	}
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek |= 1 << 0
+	}
+	schedule.dayOfMonthRestricted = !strings.HasPrefix(fields[2], "*")
+	schedule.dayOfWeekRestricted = !strings.HasPrefix(fields[4], "*")
+	return schedule, nil
+}
+
+// parseCronField parses one field of a cron expression into a bitset of the values it matches. names, if any,
+// stand for the values from min up.
+func parseCronField(field string, name string, min int, max int, names []string) (uint64, error) {
+	value := func(s string) (int, error) {
+		for i, n := range names {
+			if strings.EqualFold(s, n) {
+				return min + i, nil
+			}
+		}
+		v, err := strconv.Atoi(s)
+		if err != nil || v < min || v > max {
+			return 0, errors.Errorf("%s %q must be between %d and %d", name, s, min, max)
+		}
+		return v, nil
+	}
+
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step < 1 {
+				return 0, errors.Errorf("%s step %q must be a positive number", name, stepPart)
+			}
+		}
+
+		low, high := min, max
+		if rangePart != "*" {
+			first, last, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = value(first); err != nil {
+				return 0, err
+			}
+			high = low
+			if isRange {
+				if high, err = value(last); err != nil {
+					return 0, err
+				}
+				if high < low {
+					return 0, errors.Errorf("%s range %q must not end before it starts", name, rangePart)
+				}
+			} else if hasStep {
+				// as in cron, "5/15" means from 5 to the end in steps of 15
+				high = max
+			}
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func (c *CronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := c.dayOfMonth&(1<<t.Day()) != 0
+	dayOfWeek := c.dayOfWeek&(1<<t.Weekday()) != 0
+	if c.dayOfMonthRestricted && c.dayOfWeekRestricted {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
+
+// Next returns the first minute after t that the schedule fires at, laid out on the calendar of t's location; the
+// zero time if it never fires.
+func (c *CronSchedule) Next(t time.Time) time.Time {
+	location := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<t.Month()) == 0:
+			t = nextHourOr(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, location))
+		case !c.matchesDay(t):
+			t = nextHourOr(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location))
+		case c.hour&(1<<t.Hour()) == 0:
+			t = nextHour(t)
+		case c.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// nextHour returns the start of the hour after t. It counts on from t rather than building the time from its
+// fields, as time.Date turns an hour that daylight saving skips into one before it.
+func nextHour(t time.Time) time.Time {
+	return t.Add(time.Duration(60-t.Minute()) * time.Minute)
+}
+
+// nextHourOr returns next, unless daylight saving skipped it and time.Date turned it into a time no later than t,
+// in which case it returns the start of the hour after t.
+func nextHourOr(t time.Time, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return nextHour(t)
+}
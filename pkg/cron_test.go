@@ -0,0 +1,88 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+		"@reboot",
+	} {
+		if _, err := ParseCron(expression); err == nil {
+			t.Errorf("ParseCron(%q) succeeded", expression)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(location *time.Location, year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, location)
+	}
+	// 2026-10-17 is a Saturday
+	saturday := at(time.UTC, 2026, 10, 17, 10, 7)
+
+	tests := []struct {
+		expression string
+		from       time.Time
+		want       time.Time
+	}{
+		{"* * * * *", saturday, at(time.UTC, 2026, 10, 17, 10, 8)},
+		{"* * * * *", saturday.Add(30 * time.Second), at(time.UTC, 2026, 10, 17, 10, 8)},
+		{"7 * * * *", saturday, at(time.UTC, 2026, 10, 17, 11, 7)},
+		{"*/15 * * * *", saturday, at(time.UTC, 2026, 10, 17, 10, 15)},
+		{"5/20 * * * *", saturday, at(time.UTC, 2026, 10, 17, 10, 25)},
+		{"0,50 9-17 * * *", saturday, at(time.UTC, 2026, 10, 17, 10, 50)},
+		{"0 0 * * *", at(time.UTC, 2026, 10, 17, 23, 59), at(time.UTC, 2026, 10, 18, 0, 0)},
+		{"30 2 * * mon-fri", saturday, at(time.UTC, 2026, 10, 19, 2, 30)},
+		{"0 0 * * 7", saturday, at(time.UTC, 2026, 10, 18, 0, 0)},
+		{"0 0 1 * *", saturday, at(time.UTC, 2026, 11, 1, 0, 0)},
+		{"0 0 31 * *", saturday, at(time.UTC, 2026, 10, 31, 0, 0)},
+		{"0 0 31 * *", at(time.UTC, 2026, 10, 31, 0, 0), at(time.UTC, 2026, 12, 31, 0, 0)},
+		{"0 0 29 feb *", saturday, at(time.UTC, 2028, 2, 29, 0, 0)},
+		// with both day fields restricted, either matches
+		{"0 12 13 * fri", saturday, at(time.UTC, 2026, 10, 23, 12, 0)},
+		{"@weekly", saturday, at(time.UTC, 2026, 10, 18, 0, 0)},
+		{"@yearly", saturday, at(time.UTC, 2027, 1, 1, 0, 0)},
+		{"0 0 1 JAN *", saturday, at(time.UTC, 2027, 1, 1, 0, 0)},
+		{"0 0 30 2 *", saturday, time.Time{}},
+		// laid out in local time, across the start of daylight saving on 2026-03-08
+		{"0 3 * * *", at(denver, 2026, 3, 7, 12, 0), at(denver, 2026, 3, 8, 3, 0)},
+		// 02:30 does not happen on 2026-03-08 in Denver
+		{"30 2 * * *", at(denver, 2026, 3, 7, 12, 0), at(denver, 2026, 3, 9, 2, 30)},
+		// midnight does not happen on 2026-09-06 in Santiago
+		{"0 0 * * *", at(santiago, 2026, 9, 5, 12, 0), at(santiago, 2026, 9, 7, 0, 0)},
+		{"0 1 * * *", at(santiago, 2026, 9, 5, 12, 0), at(santiago, 2026, 9, 6, 1, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := schedule.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next(%s) is %s, want %s", tt.from, got, tt.want)
+			}
+		})
+	}
+}
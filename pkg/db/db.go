@@ -2,11 +2,9 @@ package db
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
-	"net/http"
+	"log/slog"
 	"reflect"
 	"time"
 
@@ -15,6 +13,11 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/uinta-labs/pando/models"
 )
 
@@ -24,9 +27,8 @@ type genericConn interface {
 	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
 }
 
-var spanData = map[string]interface{}{
-	"system": "postgresql",
-}
+// tracer records a span for every query, named after the pggen query that ran it.
+var tracer = otel.Tracer("github.com/uinta-labs/pando/pkg/db")
 
 type genericBatch interface {
 	// Queue queues a query to batch b. query can be an SQL query or the name of a
@@ -34,59 +36,99 @@ type genericBatch interface {
 	Queue(query string, arguments ...interface{})
 }
 
-type sentryWrappedQuerier struct {
-	pool *pgxpool.Pool
+// tracedConn wraps the pool, or a transaction, so each query runs in its own span.
+type tracedConn struct {
+	conn genericConn
 }
 
-func (s *sentryWrappedQuerier) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+func (t *tracedConn) startSpan(ctx context.Context, sql string) (context.Context, trace.Span) {
 	operationName, _ := ctx.Value("pggen_query_name").(string)
-	span := sentry.StartSpan(ctx, "db.query")
-	defer span.Finish()
-	span.Data = spanData
-	span.Name = operationName
-	span.Description = sql
+	if operationName == "" {
+		operationName = "db.query"
+	}
+	return tracer.Start(ctx, operationName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation.name", operationName),
+			attribute.String("db.query.text", sql),
+		),
+	)
+}
 
-	rows, err := s.pool.Query(span.Context(), sql, args...)
-	if err != nil {
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		sentry.CaptureException(err)
 	}
-	return rows, err
+	span.End()
 }
 
-func (s *sentryWrappedQuerier) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
-	operationName, _ := ctx.Value("pggen_query_name").(string)
-	span := sentry.StartSpan(ctx, "db.query")
-	defer span.Finish()
-	span.Data = spanData
-	span.Name = operationName
-	span.Description = sql
-
-	r := s.pool.QueryRow(span.Context(), sql, args...)
-	return r
+func (t *tracedConn) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	ctx, span := t.startSpan(ctx, sql)
+	rows, err := t.conn.Query(ctx, sql, args...)
+	if err != nil {
+		endSpan(span, err)
+		return rows, err
+	}
+	return &tracedRows{Rows: rows, span: span}, nil
 }
 
-func (s *sentryWrappedQuerier) Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error) {
-	operationName, _ := ctx.Value("pggen_query_name").(string)
-	span := sentry.StartSpan(ctx, "db.query")
-	defer span.Finish()
-	span.Data = spanData
-	span.Name = operationName
-	span.Description = sql
+func (t *tracedConn) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	ctx, span := t.startSpan(ctx, sql)
+	return &tracedRow{row: t.conn.QueryRow(ctx, sql, args...), span: span}
+}
 
-	tag, err := s.pool.Exec(span.Context(), sql, arguments...)
-	if err != nil {
-		sentry.CaptureException(err)
+// tracedRows ends its query's span once the rows are closed, as reading them is part of the query.
+type tracedRows struct {
+	pgx.Rows
+	span   trace.Span
+	closed bool
+}
+
+func (r *tracedRows) Close() {
+	r.Rows.Close()
+	if r.closed {
+		return
 	}
+	r.closed = true
+	endSpan(r.span, r.Rows.Err())
+}
+
+// tracedRow ends its query's span once the row is scanned, which is when the query's error surfaces.
+type tracedRow struct {
+	row  pgx.Row
+	span trace.Span
+}
+
+func (r *tracedRow) Scan(dest ...interface{}) error {
+	err := r.row.Scan(dest...)
+	endSpan(r.span, err)
+	return err
+}
+
+func (t *tracedConn) Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error) {
+	ctx, span := t.startSpan(ctx, sql)
+	tag, err := t.conn.Exec(ctx, sql, arguments...)
+	endSpan(span, err)
 	return tag, err
 }
 
-var _ genericConn = &sentryWrappedQuerier{}
+var _ genericConn = &tracedConn{}
 
 type DB struct {
 	Pool *pgxpool.Pool
 	Q    models.Querier
 }
 
+// InTx runs fn in a transaction, committing it if fn returns nil and rolling it back otherwise.
+func (d *DB) InTx(ctx context.Context, fn func(q models.Querier) error) error {
+	return d.Pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		return fn(models.NewQuerier(&tracedConn{conn: tx}))
+	})
+}
+
 type logger struct{}
 
 func capStringLen(s string, maxLen int) string {
@@ -169,13 +211,27 @@ func logQueryData(kind string, data map[string]interface{}) string {
 	return capStringLen(fmt.Sprintf("%v", data), 100)
 }
 
+// slogLevel maps pgx's levels onto slog's. pgx logs every query at info, which is debug detail here.
+func slogLevel(level pgx.LogLevel) slog.Level {
+	switch {
+	case level >= pgx.LogLevelInfo:
+		return slog.LevelDebug
+	case level == pgx.LogLevelWarn:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
 func (l *logger) Log(ctx context.Context, level pgx.LogLevel, msg string, data map[string]interface{}) {
+	attrs := []any{}
+	if queryName, ok := ctx.Value("pggen_query_name").(string); ok {
+		attrs = append(attrs, "query", queryName)
+	}
 	if data != nil {
-		dataStr := logQueryData(msg, data)
-		log.Printf("%s: %s: %s", level, msg, dataStr)
-		return
+		attrs = append(attrs, "data", logQueryData(msg, data))
 	}
-	log.Printf("%s: %s", level, msg)
+	slog.Log(ctx, slogLevel(level), "pgx: "+msg, attrs...)
 }
 
 func statMap(stats *pgxpool.Stat) map[string]interface{} {
@@ -194,7 +250,7 @@ func statMap(stats *pgxpool.Stat) map[string]interface{} {
 	}
 }
 
-func New(ctx context.Context, databaseURL string, runStatsServer bool) (*DB, error) {
+func New(ctx context.Context, databaseURL string) (*DB, error) {
 	l := &logger{}
 
 	pgxConfig, err := pgxpool.ParseConfig(databaseURL)
@@ -217,52 +273,19 @@ func New(ctx context.Context, databaseURL string, runStatsServer bool) (*DB, err
 			case <-time.After(5 * time.Minute):
 				if err := pool.Ping(ctx); err != nil {
 					sentry.CaptureMessage("Error pinging database")
-					log.Printf("Error pinging database: %#v", err)
+					slog.Error("Failed to ping database", "error", err)
 				}
-				// print db conn stats
-				stats := pool.Stat()
-				log.Printf("DB Stats: %v", statMap(stats))
+				slog.Debug("Database pool stats", "stats", statMap(pool.Stat()))
 			}
 		}
 	}()
-	if runStatsServer {
-		go func() {
-			time.Sleep(2 * time.Second)
-			log.Printf("Starting postgres/db stat http server on :6065")
-			runStatHttpServer(ctx, pool)
-		}()
-	}
-
-	sentryQuerier := &sentryWrappedQuerier{pool: pool}
 
 	return &DB{
 		Pool: pool,
-		Q:    models.NewQuerier(sentryQuerier),
+		Q:    models.NewQuerier(&tracedConn{conn: pool}),
 	}, nil
 }
 
-func runStatHttpServer(ctx context.Context, pool *pgxpool.Pool) {
-	httpStatAddress := "0.0.0.0:6065"
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		fmt.Fprintf(w, `<h1>Postgres DB Stats</h1>
-		<p><a href="/stat">/stat</a></p>`)
-	})
-	http.HandleFunc("/stat", func(w http.ResponseWriter, r *http.Request) {
-		stats := pool.Stat()
-		asJson, err := json.Marshal(statMap(stats))
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		fmt.Fprintf(w, "%s", asJson)
-	})
-
-	err := http.ListenAndServe(httpStatAddress, nil)
-	if err != nil {
-		log.Printf("Error starting http server: %v", err)
-	}
-}
-
 func WaitForDatabase(ctx context.Context, timeoutSeconds int, databaseURL string) error {
 	deadlineTime := time.Now().Add(time.Duration(timeoutSeconds) * time.Second)
 	fmt.Printf("[DEBUG] Waiting for database at %s\n", databaseURL)
@@ -0,0 +1,39 @@
+package pkg
+
+import "time"
+
+// AgentVersionHeader carries the agent's version on every call it makes to the server.
+const AgentVersionHeader = "Pando-Agent-Version"
+
+// DeviceTokenHeader carries the device's relay token on its calls to the action and log relay.
+const DeviceTokenHeader = "Pando-Device-Token"
+
+// Version is set at build time with -ldflags "-X github.com/uinta-labs/pando/pkg.Version=..."
+var Version = "dev"
+
+const (
+	DeviceOnline  = "online"
+	DeviceStale   = "stale"
+	DeviceOffline = "offline"
+)
+
+// DeviceStatusCutoffs returns the earliest last-seen time that still counts as online, and as stale.
+func (c Config) DeviceStatusCutoffs(now time.Time) (onlineAfter time.Time, offlineAfter time.Time) {
+	return now.Add(-c.DeviceOnlineThreshold), now.Add(-c.DeviceOfflineThreshold)
+}
+
+// DeviceStatus classifies a device by when its agent last called; a device that never called is offline.
+func (c Config) DeviceStatus(lastSeen *time.Time, now time.Time) string {
+	if lastSeen == nil {
+		return DeviceOffline
+	}
+	onlineAfter, offlineAfter := c.DeviceStatusCutoffs(now)
+	switch {
+	case !lastSeen.Before(onlineAfter):
+		return DeviceOnline
+	case !lastSeen.Before(offlineAfter):
+		return DeviceStale
+	default:
+		return DeviceOffline
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !linux && !darwin
+
+package pkg
+
+import (
+	"github.com/pkg/errors"
+)
+
+// FreeDiskPercent is not supported on this platform.
+func FreeDiskPercent(path string) (float64, error) {
+	return 0, errors.New("free disk measurement is not supported on this platform")
+}
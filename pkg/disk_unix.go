@@ -0,0 +1,21 @@
+//go:build linux || darwin
+
+package pkg
+
+import (
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// FreeDiskPercent returns the percentage of the filesystem containing path that is available to unprivileged users.
+func FreeDiskPercent(path string) (float64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, errors.Wrapf(err, "failed to stat filesystem at %s", path)
+	}
+	if stat.Blocks == 0 {
+		return 0, errors.Errorf("filesystem at %s reports zero blocks", path)
+	}
+	return float64(stat.Bavail) / float64(stat.Blocks) * 100, nil
+}
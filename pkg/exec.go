@@ -0,0 +1,132 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// ExecOptions configures a command run inside a running container by Runner.Exec.
+type ExecOptions struct {
+	Command    []string
+	Env        []string
+	WorkingDir string
+	User       string
+
+	// When set, copied to the command's standard input, which is then closed
+	Stdin io.Reader
+
+	// Bounds the command in addition to the context's deadline; zero means no extra bound
+	Timeout time.Duration
+}
+
+// MaxExecOutputBytes bounds how much of each of an exec's stdout and stderr is kept; the rest is discarded.
+const MaxExecOutputBytes = 1 << 20
+
+// ExecResult is the outcome of a command run by Runner.Exec.
+type ExecResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	Duration time.Duration
+	// Set when Stdout or Stderr was cut short at MaxExecOutputBytes
+	Truncated bool
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the rest, while still accepting them, so
+// the command writing them is not blocked.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); len(p) > room {
+		b.truncated = true
+		_, _ = b.Buffer.Write(p[:max(room, 0)])
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+// Exec runs a command in a container without a TTY, so stdout and stderr stay separate, and waits for it to
+// exit. A non-zero exit code is reported in the result rather than as an error. If the context ends first an
+// error is returned; the engine has no way to kill an exec, so the process itself may keep running.
+func (r *Runner) Exec(ctx context.Context, containerReference string, options ExecOptions) (*ExecResult, error) {
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	startTime := time.Now()
+	execID, err := r.client.ContainerExecCreate(ctx, containerReference, container.ExecOptions{
+		AttachStdin:  options.Stdin != nil,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          options.Command,
+		Env:          options.Env,
+		WorkingDir:   options.WorkingDir,
+		User:         options.User,
+	})
+	if err != nil {
+		return nil, engineError(err, "failed to create exec")
+	}
+
+	// Attaching also starts the exec
+	resp, err := r.client.ContainerExecAttach(ctx, execID.ID, container.ExecStartOptions{})
+	if err != nil {
+		return nil, engineError(err, "failed to attach to exec")
+	}
+	defer resp.Close()
+
+	stdout := &limitedBuffer{limit: MaxExecOutputBytes}
+	stderr := &limitedBuffer{limit: MaxExecOutputBytes}
+	copied := make(chan error, 1)
+	go func() {
+		_, err := stdcopy.StdCopy(stdout, stderr, resp.Reader)
+		copied <- err
+	}()
+	if options.Stdin != nil {
+		go func() {
+			_, _ = io.Copy(resp.Conn, options.Stdin)
+			_ = resp.CloseWrite()
+		}()
+	}
+
+	select {
+	case err := <-copied:
+		if err != nil {
+			return nil, engineError(err, "failed to read exec output")
+		}
+	case <-ctx.Done():
+		return nil, engineError(ctx.Err(), "exec did not finish")
+	}
+
+	// Output ends when the process exits, but the engine can take a moment to record the exit code
+	for {
+		inspect, err := r.client.ContainerExecInspect(ctx, execID.ID)
+		if err != nil {
+			return nil, engineError(err, "failed to inspect exec")
+		}
+		if !inspect.Running {
+			return &ExecResult{
+				Stdout:    stdout.String(),
+				Stderr:    stderr.String(),
+				ExitCode:  inspect.ExitCode,
+				Duration:  time.Since(startTime),
+				Truncated: stdout.truncated || stderr.truncated,
+			}, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, engineError(ctx.Err(), "exec did not finish")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
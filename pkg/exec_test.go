@@ -0,0 +1,35 @@
+package pkg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+func TestExecOutputLimit(t *testing.T) {
+	var multiplexed bytes.Buffer
+	stdoutWriter := stdcopy.NewStdWriter(&multiplexed, stdcopy.Stdout)
+	stderrWriter := stdcopy.NewStdWriter(&multiplexed, stdcopy.Stderr)
+	chunk := strings.Repeat("x", 1000)
+	for range 5 {
+		_, _ = stdoutWriter.Write([]byte(chunk))
+	}
+	_, _ = stderrWriter.Write([]byte("failed\n"))
+	for range 5 {
+		_, _ = stdoutWriter.Write([]byte(chunk))
+	}
+
+	stdout := &limitedBuffer{limit: 2500}
+	stderr := &limitedBuffer{limit: 2500}
+	if _, err := stdcopy.StdCopy(stdout, stderr, &multiplexed); err != nil {
+		t.Fatal(err)
+	}
+	if stdout.Len() != 2500 || !stdout.truncated {
+		t.Errorf("stdout kept %d bytes and truncated is %t, want 2500 and true", stdout.Len(), stdout.truncated)
+	}
+	if stderr.String() != "failed\n" || stderr.truncated {
+		t.Errorf("stderr is %q and truncated is %t, want %q and false", stderr.String(), stderr.truncated, "failed\n")
+	}
+}
@@ -0,0 +1,585 @@
+package pkg
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+)
+
+// FakeImage describes how containers started from an image behave in a FakeRuntime.
+type FakeImage struct {
+	// Containers exit with ExitCode this long after starting; zero leaves them running until stopped or Exit is called
+	ExitAfter time.Duration
+	ExitCode  int
+	// Containers fail to start with this error, and are removed again
+	StartError error
+	// Written to each container's logs when it starts
+	Logs []string
+	// When set, Logs are written again this often until the container exits
+	LogEvery time.Duration
+	// When set, containers report this health status from the start, as SetHealth would
+	Health string
+}
+
+// FakeRuntime is a ContainerRuntime that simulates an engine in memory, for exercising the agent without one.
+// Images must be added to its registry before they can be pulled, and containers run until they are stopped,
+// their image's ExitAfter passes, or Exit is called. Container IDs are assigned in sequence, so runs are
+// repeatable.
+type FakeRuntime struct {
+	mu          sync.Mutex
+	registry    map[string]FakeImage
+	pullErrors  map[string]error
+	pulled      map[string]bool
+	containers  map[string]*fakeContainer
+	subscribers []*fakeSubscriber
+	execHandler func(containerID string, options ExecOptions) (*ExecResult, error)
+	unavailable bool
+	lastID      int
+}
+
+type fakeContainer struct {
+	inspect types.ContainerJSON
+	// Closed when the container exits
+	exited chan struct{}
+	timer  *time.Timer
+}
+
+type fakeSubscriber struct {
+	ctx      context.Context
+	label    string
+	value    string
+	messages chan events.Message
+	errs     chan error
+}
+
+var _ ContainerRuntime = (*FakeRuntime)(nil)
+
+func NewFakeRuntime() *FakeRuntime {
+	return &FakeRuntime{
+		registry:   map[string]FakeImage{},
+		pullErrors: map[string]error{},
+		pulled:     map[string]bool{},
+		containers: map[string]*fakeContainer{},
+	}
+}
+
+// AddImage makes imageReference available to pull, with containers behaving as image describes.
+func (f *FakeRuntime) AddImage(imageReference string, image FakeImage) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.registry[imageReference] = image
+}
+
+// FailPulls makes every pull of imageReference fail with err, such as a RegistryAuthError or TimeoutError, until
+// called again with a nil err.
+func (f *FakeRuntime) FailPulls(imageReference string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err == nil {
+		delete(f.pullErrors, imageReference)
+		return
+	}
+	f.pullErrors[imageReference] = err
+}
+
+// SetUnavailable makes every call fail with an EngineUnavailableError, as when the engine is down, and ends any
+// event streams, until called again with false.
+func (f *FakeRuntime) SetUnavailable(unavailable bool) {
+	f.mu.Lock()
+	f.unavailable = unavailable
+	subscribers := f.subscribers
+	if unavailable {
+		f.subscribers = nil
+	}
+	f.mu.Unlock()
+	if unavailable {
+		for _, s := range subscribers {
+			s.errs <- f.unavailableError()
+		}
+	}
+}
+
+// SetExecHandler decides the result of every Exec; without one, commands succeed with no output.
+func (f *FakeRuntime) SetExecHandler(handler func(containerID string, options ExecOptions) (*ExecResult, error)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.execHandler = handler
+}
+
+// Exit simulates a running container exiting by itself with exitCode, as a crash would.
+func (f *FakeRuntime) Exit(containerReference string, exitCode int) error {
+	f.mu.Lock()
+	c := f.find(containerReference)
+	if c == nil {
+		f.mu.Unlock()
+		return f.notFound(containerReference)
+	}
+	message, ok := f.exit(c, exitCode)
+	f.mu.Unlock()
+	if ok {
+		f.publish(message)
+	}
+	return nil
+}
+
+// SetHealth simulates a running container's health check reporting status, one of "starting", "healthy" or
+// "unhealthy".
+func (f *FakeRuntime) SetHealth(containerReference string, status string) error {
+	f.mu.Lock()
+	c := f.find(containerReference)
+	if c == nil {
+		f.mu.Unlock()
+		return f.notFound(containerReference)
+	}
+	if !c.inspect.State.Running {
+		f.mu.Unlock()
+		return errdefs.Conflict(errors.Errorf("container %s is not running", containerReference))
+	}
+	c.inspect.State.Health = &types.Health{Status: status}
+	message := containerEvent(c, events.Action(string(events.ActionHealthStatus)+": "+status), nil)
+	f.mu.Unlock()
+	f.publish(message)
+	return nil
+}
+
+func (f *FakeRuntime) PullImage(ctx context.Context, imageReference string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.unavailable {
+		return f.unavailableError()
+	}
+	if err, ok := f.pullErrors[imageReference]; ok {
+		return err
+	}
+	if _, ok := f.registry[imageReference]; !ok {
+		return &ImageNotFoundError{Image: imageReference, Err: errors.Errorf("failed to pull %s: manifest unknown", imageReference)}
+	}
+	f.pulled[imageReference] = true
+	return nil
+}
+
+func (f *FakeRuntime) RunContainer(ctx context.Context, imageReference string, containerReference string, commands []string, environmentVariables []string, additionalLabels map[string]string, advancedOptions *AdvancedOptions, logs *LogChannels, waitOnContainer bool) (string, error) {
+	config := &container.Config{
+		Image: imageReference,
+		Cmd:   commands,
+		Env:   environmentVariables,
+		Labels: map[string]string{
+			LabelManaged:            "true",
+			LabelContainerReference: containerReference,
+		},
+	}
+	maps.Copy(config.Labels, additionalLabels)
+	hostConfig := &container.HostConfig{AutoRemove: true, NetworkMode: "bridge"}
+	if advancedOptions != nil {
+		if advancedOptions.NetworkModeContainer != "" && advancedOptions.NetworkModeHost {
+			return "", errors.New("cannot specify both network mode container and network mode host")
+		}
+		if advancedOptions.NetworkModeContainer != "" {
+			hostConfig.NetworkMode = container.NetworkMode(advancedOptions.NetworkModeContainer)
+		}
+		if advancedOptions.NetworkModeHost {
+			hostConfig.NetworkMode = "host"
+		}
+		config.StopSignal = advancedOptions.StopSignal
+		if advancedOptions.StopGracePeriod > 0 {
+			seconds := int(advancedOptions.StopGracePeriod.Seconds())
+			config.StopTimeout = &seconds
+		}
+	}
+
+	c, image, err := f.start(containerReference, config, hostConfig)
+	if err != nil {
+		return "", err
+	}
+	if logs != nil && len(image.Logs) > 0 {
+		logs.AttachScanner(bufio.NewScanner(fakeLogs(c, image)))
+	}
+
+	if waitOnContainer {
+		select {
+		case <-ctx.Done():
+			return c.inspect.ID, engineError(ctx.Err(), "failed to wait for container")
+		case <-c.exited:
+		}
+		f.mu.Lock()
+		exitCode := c.inspect.State.ExitCode
+		f.mu.Unlock()
+		if exitCode != 0 {
+			return c.inspect.ID, &ExitError{ExitCode: exitCode, Err: errors.Errorf("container exited with code %d", exitCode)}
+		}
+	}
+	return c.inspect.ID, nil
+}
+
+// fakeLogs returns the output of c, started from image, which ends when c exits if image writes its logs repeatedly.
+func fakeLogs(c *fakeContainer, image FakeImage) io.Reader {
+	output := strings.Join(image.Logs, "\n") + "\n"
+	if image.LogEvery <= 0 {
+		return strings.NewReader(output)
+	}
+	reader, writer := io.Pipe()
+	go func() {
+		<-c.exited
+		writer.Close()
+	}()
+	go func() {
+		ticker := time.NewTicker(image.LogEvery)
+		defer ticker.Stop()
+		for {
+			if _, err := io.WriteString(writer, output); err != nil {
+				return
+			}
+			select {
+			case <-c.exited:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return reader
+}
+
+func (f *FakeRuntime) ReplicateContainer(ctx context.Context, source types.ContainerJSON, imageReference string, name string, env map[string]string) (string, error) {
+	config := *source.Config
+	config.Image = imageReference
+	config.Labels = maps.Clone(source.Config.Labels)
+	config.Env = []string{}
+	for _, variable := range source.Config.Env {
+		key, _, _ := strings.Cut(variable, "=")
+		if _, overridden := env[key]; !overridden {
+			config.Env = append(config.Env, variable)
+		}
+	}
+	for key, value := range env {
+		config.Env = append(config.Env, key+"="+value)
+	}
+	hostConfig := *source.HostConfig
+
+	c, _, err := f.start(name, &config, &hostConfig)
+	if err != nil {
+		return "", err
+	}
+	return c.inspect.ID, nil
+}
+
+// start creates and starts a container, as the engine would, arranging for it to exit if its image says to.
+func (f *FakeRuntime) start(name string, config *container.Config, hostConfig *container.HostConfig) (*fakeContainer, FakeImage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.unavailable {
+		return nil, FakeImage{}, f.unavailableError()
+	}
+	if !f.pulled[config.Image] {
+		return nil, FakeImage{}, &ImageNotFoundError{Image: config.Image, Err: errors.Errorf("failed to create container %s: No such image: %s", name, config.Image)}
+	}
+	if f.find(name) != nil {
+		return nil, FakeImage{}, &NameConflictError{Name: name, Err: errors.Errorf("failed to create container %s: name is already in use", name)}
+	}
+	image := f.registry[config.Image]
+	if image.StartError != nil {
+		return nil, FakeImage{}, errors.Wrapf(image.StartError, "failed to start container %s", name)
+	}
+
+	f.lastID++
+	c := &fakeContainer{
+		inspect: types.ContainerJSON{
+			ContainerJSONBase: &types.ContainerJSONBase{
+				ID:      fmt.Sprintf("%064x", f.lastID),
+				Created: time.Now().UTC().Format(time.RFC3339Nano),
+				Name:    "/" + name,
+				Image:   fakeImageID(config.Image),
+				State: &types.ContainerState{
+					Status:    "running",
+					Running:   true,
+					StartedAt: time.Now().UTC().Format(time.RFC3339Nano),
+				},
+				HostConfig: hostConfig,
+			},
+			Config:          config,
+			NetworkSettings: &types.NetworkSettings{},
+		},
+		exited: make(chan struct{}),
+	}
+	if image.Health != "" {
+		c.inspect.State.Health = &types.Health{Status: image.Health}
+	}
+	f.containers[c.inspect.ID] = c
+	if image.ExitAfter > 0 {
+		c.timer = time.AfterFunc(image.ExitAfter, func() {
+			_ = f.Exit(c.inspect.ID, image.ExitCode)
+		})
+	}
+	return c, image, nil
+}
+
+func (f *FakeRuntime) StopContainer(ctx context.Context, containerReference string) error {
+	f.mu.Lock()
+	if f.unavailable {
+		f.mu.Unlock()
+		return f.unavailableError()
+	}
+	c := f.find(containerReference)
+	if c == nil {
+		f.mu.Unlock()
+		return nil
+	}
+	// as though the container honoured SIGTERM
+	message, ok := f.exit(c, 143)
+	delete(f.containers, c.inspect.ID)
+	f.mu.Unlock()
+	if ok {
+		f.publish(message)
+	}
+	return nil
+}
+
+func (f *FakeRuntime) RenameContainer(ctx context.Context, containerReference string, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.unavailable {
+		return f.unavailableError()
+	}
+	c := f.find(containerReference)
+	if c == nil {
+		return f.notFound(containerReference)
+	}
+	if other := f.find(name); other != nil && other != c {
+		return &NameConflictError{Name: name, Err: errors.Errorf("failed to rename container to %s: name is already in use", name)}
+	}
+	c.inspect.Name = "/" + name
+	return nil
+}
+
+// exit marks c as exited, removing it if it was started with AutoRemove, and returns the die event to publish if
+// it was running; the caller holds f.mu.
+func (f *FakeRuntime) exit(c *fakeContainer, exitCode int) (events.Message, bool) {
+	if !c.inspect.State.Running {
+		return events.Message{}, false
+	}
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+	c.inspect.State.Running = false
+	c.inspect.State.Status = "exited"
+	c.inspect.State.ExitCode = exitCode
+	c.inspect.State.FinishedAt = time.Now().UTC().Format(time.RFC3339Nano)
+	close(c.exited)
+	if c.inspect.HostConfig.AutoRemove {
+		delete(f.containers, c.inspect.ID)
+	}
+	return containerEvent(c, events.ActionDie, map[string]string{"exitCode": strconv.Itoa(exitCode)}), true
+}
+
+func (f *FakeRuntime) ListContainersMatchingLabel(ctx context.Context, label string, value string) ([]types.Container, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.unavailable {
+		return nil, f.unavailableError()
+	}
+	// like the engine's default listing, only running containers are included
+	containers := []types.Container{}
+	for _, c := range f.containers {
+		if !c.inspect.State.Running || c.inspect.Config.Labels[label] != value {
+			continue
+		}
+		created, _ := time.Parse(time.RFC3339Nano, c.inspect.Created)
+		containers = append(containers, types.Container{
+			ID:      c.inspect.ID,
+			Names:   []string{c.inspect.Name},
+			Image:   c.inspect.Config.Image,
+			ImageID: c.inspect.Image,
+			Created: created.Unix(),
+			Labels:  maps.Clone(c.inspect.Config.Labels),
+			State:   c.inspect.State.Status,
+			Status:  "Up",
+		})
+	}
+	return containers, nil
+}
+
+func (f *FakeRuntime) InspectContainer(ctx context.Context, containerReference string) (types.ContainerJSON, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.unavailable {
+		return types.ContainerJSON{}, f.unavailableError()
+	}
+	c := f.find(containerReference)
+	if c == nil {
+		return types.ContainerJSON{}, f.notFound(containerReference)
+	}
+	base := *c.inspect.ContainerJSONBase
+	state := *base.State
+	base.State = &state
+	config := *c.inspect.Config
+	config.Labels = maps.Clone(config.Labels)
+	return types.ContainerJSON{ContainerJSONBase: &base, Config: &config, NetworkSettings: c.inspect.NetworkSettings}, nil
+}
+
+func (f *FakeRuntime) ContainerState(ctx context.Context, containerReference string) (*types.ContainerState, error) {
+	inspect, err := f.InspectContainer(ctx, containerReference)
+	if err != nil {
+		return nil, err
+	}
+	return inspect.State, nil
+}
+
+func (f *FakeRuntime) Exec(ctx context.Context, containerReference string, options ExecOptions) (*ExecResult, error) {
+	f.mu.Lock()
+	if f.unavailable {
+		f.mu.Unlock()
+		return nil, f.unavailableError()
+	}
+	c := f.find(containerReference)
+	if c == nil {
+		f.mu.Unlock()
+		return nil, f.notFound(containerReference)
+	}
+	if !c.inspect.State.Running {
+		f.mu.Unlock()
+		return nil, errors.Wrap(errdefs.Conflict(errors.Errorf("container %s is not running", containerReference)), "failed to create exec")
+	}
+	id := c.inspect.ID
+	handler := f.execHandler
+	f.mu.Unlock()
+
+	if handler == nil {
+		return &ExecResult{}, nil
+	}
+	return handler(id, options)
+}
+
+func (f *FakeRuntime) ContainerEvents(ctx context.Context, label string, value string) (<-chan events.Message, <-chan error) {
+	s := &fakeSubscriber{
+		ctx:      ctx,
+		label:    label,
+		value:    value,
+		messages: make(chan events.Message),
+		errs:     make(chan error, 1),
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.unavailable {
+		s.errs <- f.unavailableError()
+		return s.messages, s.errs
+	}
+	f.subscribers = append(f.subscribers, s)
+	return s.messages, s.errs
+}
+
+// publish delivers message to every subscriber whose filter it matches, waiting for each to receive it so that
+// events are never lost or reordered.
+func (f *FakeRuntime) publish(message events.Message) {
+	f.mu.Lock()
+	live := f.subscribers[:0]
+	var matching []*fakeSubscriber
+	for _, s := range f.subscribers {
+		if s.ctx.Err() != nil {
+			continue
+		}
+		live = append(live, s)
+		if message.Actor.Attributes[s.label] == s.value {
+			matching = append(matching, s)
+		}
+	}
+	f.subscribers = live
+	f.mu.Unlock()
+
+	for _, s := range matching {
+		select {
+		case s.messages <- message:
+		case <-s.ctx.Done():
+		}
+	}
+}
+
+func (f *FakeRuntime) PruneUnreferenced(ctx context.Context, pulledImages []string, keepImages []string) (*PruneReport, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.unavailable {
+		return nil, f.unavailableError()
+	}
+	report := &PruneReport{}
+	for id, c := range f.containers {
+		if !c.inspect.State.Running && c.inspect.Config.Labels[LabelManaged] == "true" {
+			delete(f.containers, id)
+			report.ContainersDeleted = append(report.ContainersDeleted, id)
+		}
+	}
+
+	keep := map[string]bool{}
+	for _, ref := range keepImages {
+		keep[ref] = true
+	}
+	for _, c := range f.containers {
+		keep[c.inspect.Config.Image] = true
+	}
+	for _, ref := range pulledImages {
+		if !f.pulled[ref] {
+			report.PulledImagesGone = append(report.PulledImagesGone, ref)
+			continue
+		}
+		if !keep[ref] {
+			delete(f.pulled, ref)
+			report.ImagesDeleted = append(report.ImagesDeleted, fakeImageID(ref))
+			report.PulledImagesGone = append(report.PulledImagesGone, ref)
+		}
+	}
+	return report, nil
+}
+
+// find looks a container up by ID, name or ID prefix, as the engine does; the caller holds f.mu.
+func (f *FakeRuntime) find(containerReference string) *fakeContainer {
+	if c, ok := f.containers[containerReference]; ok {
+		return c
+	}
+	if containerReference == "" {
+		return nil
+	}
+	for id, c := range f.containers {
+		if c.inspect.Name == "/"+strings.TrimPrefix(containerReference, "/") || strings.HasPrefix(id, containerReference) {
+			return c
+		}
+	}
+	return nil
+}
+
+func (f *FakeRuntime) notFound(containerReference string) error {
+	return errors.Wrap(errdefs.NotFound(errors.Errorf("No such container: %s", containerReference)), "failed to inspect container")
+}
+
+func (f *FakeRuntime) unavailableError() error {
+	return &EngineUnavailableError{Err: errors.New("Cannot connect to the container engine")}
+}
+
+func containerEvent(c *fakeContainer, action events.Action, attributes map[string]string) events.Message {
+	actorAttributes := maps.Clone(c.inspect.Config.Labels)
+	actorAttributes["name"] = strings.TrimPrefix(c.inspect.Name, "/")
+	actorAttributes["image"] = c.inspect.Config.Image
+	maps.Copy(actorAttributes, attributes)
+	now := time.Now()
+	return events.Message{
+		Type:     events.ContainerEventType,
+		Action:   action,
+		Actor:    events.Actor{ID: c.inspect.ID, Attributes: actorAttributes},
+		Scope:    "local",
+		Time:     now.Unix(),
+		TimeNano: now.UnixNano(),
+	}
+}
+
+func fakeImageID(imageReference string) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(imageReference)))
+}
@@ -0,0 +1,142 @@
+package pkg
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+)
+
+// PruneReport summarizes what a garbage collection pass removed from the engine.
+type PruneReport struct {
+	ContainersDeleted []string
+	ImagesDeleted     []string
+	VolumesDeleted    []string
+	SpaceReclaimed    uint64
+	// References from pulledImages that no longer name an image, whether removed now or already gone
+	PulledImagesGone []string
+}
+
+// Engines mark the volumes they create for a container's anonymous mounts with this label. Older engines do not,
+// but name them with 64 hex digits.
+const anonymousVolumeLabel = "com.docker.volume.anonymous"
+
+var anonymousVolumeName = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// PruneUnreferenced removes stopped pando-managed containers, the images in pulledImages that are neither listed in
+// keepImages nor used by an existing container, and unused anonymous volumes. Images and named volumes that pando
+// did not create are never touched.
+func (r *Runner) PruneUnreferenced(ctx context.Context, pulledImages []string, keepImages []string) (*PruneReport, error) {
+	report := &PruneReport{}
+
+	stopped, err := r.client.ContainerList(ctx, container.ListOptions{
+		All: true,
+		Filters: filters.NewArgs(
+			filters.Arg("label", LabelManaged+"=true"),
+			filters.Arg("status", "created"),
+			filters.Arg("status", "exited"),
+			filters.Arg("status", "dead"),
+		),
+	})
+	if err != nil {
+		return nil, engineError(err, "failed to list stopped containers")
+	}
+	for _, c := range stopped {
+		err := r.client.ContainerRemove(ctx, c.ID, container.RemoveOptions{RemoveVolumes: true})
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				continue
+			}
+			slog.WarnContext(ctx, "Failed to remove container", LogKeyContainer, c.ID, Err(err))
+			continue
+		}
+		report.ContainersDeleted = append(report.ContainersDeleted, c.ID)
+	}
+
+	keep := map[string]bool{}
+	for _, ref := range keepImages {
+		inspect, _, err := r.client.ImageInspectWithRaw(ctx, ref)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				continue
+			}
+			return report, engineError(err, "failed to inspect image "+ref)
+		}
+		keep[inspect.ID] = true
+	}
+
+	// Never remove an image out from under a container, pando-managed or not (this includes the agent itself)
+	all, err := r.client.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return report, engineError(err, "failed to list containers")
+	}
+	for _, c := range all {
+		keep[c.ImageID] = true
+	}
+
+	for _, ref := range pulledImages {
+		inspect, _, err := r.client.ImageInspectWithRaw(ctx, ref)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				report.PulledImagesGone = append(report.PulledImagesGone, ref)
+				continue
+			}
+			return report, engineError(err, "failed to inspect image "+ref)
+		}
+		if keep[inspect.ID] {
+			continue
+		}
+		// Removed by reference, so an image also tagged under another name only loses pando's tag
+		deleted, err := r.client.ImageRemove(ctx, ref, image.RemoveOptions{PruneChildren: true})
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				report.PulledImagesGone = append(report.PulledImagesGone, ref)
+				continue
+			}
+			slog.WarnContext(ctx, "Failed to remove image", "image", ref, Err(err))
+			continue
+		}
+		report.PulledImagesGone = append(report.PulledImagesGone, ref)
+		freed := false
+		for _, d := range deleted {
+			if d.Deleted != "" {
+				report.ImagesDeleted = append(report.ImagesDeleted, d.Deleted)
+				freed = true
+			}
+		}
+		if freed && inspect.Size > 0 {
+			report.SpaceReclaimed += uint64(inspect.Size)
+		}
+	}
+
+	// Listed rather than pruned, as engines before API 1.42 prune named volumes too, which may hold a task's data
+	// while it is stopped between schedules
+	volumes, err := r.client.VolumeList(ctx, volume.ListOptions{Filters: filters.NewArgs(filters.Arg("dangling", "true"))})
+	if err != nil {
+		return report, engineError(err, "failed to list unused volumes")
+	}
+	for _, v := range volumes.Volumes {
+		if _, anonymous := v.Labels[anonymousVolumeLabel]; !anonymous && !anonymousVolumeName.MatchString(v.Name) {
+			continue
+		}
+		if err := r.client.VolumeRemove(ctx, v.Name, false); err != nil {
+			if errdefs.IsNotFound(err) {
+				continue
+			}
+			// a container may have started using it since it was listed
+			slog.WarnContext(ctx, "Failed to remove volume", "volume", v.Name, Err(err))
+			continue
+		}
+		report.VolumesDeleted = append(report.VolumesDeleted, v.Name)
+		if v.UsageData != nil && v.UsageData.Size > 0 {
+			report.SpaceReclaimed += uint64(v.UsageData.Size)
+		}
+	}
+
+	return report, nil
+}
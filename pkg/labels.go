@@ -0,0 +1,40 @@
+package pkg
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+)
+
+// The labels the agent gives the containers it runs, and by which it finds them again. The simulator labels its
+// simulated containers the same way.
+const (
+	LabelManaged            = "io.uinta.pando.managed"
+	LabelContainerReference = "io.uinta.pando.container-reference"
+	LabelTaskID             = "io.uinta.pando.task-id"
+	// TaskHash of the task the container runs, so that edited tasks are replaced rather than left running
+	LabelTaskHash   = "io.uinta.pando.task-hash"
+	LabelTaskName   = "io.uinta.pando.task-name"
+	LabelScheduleID = "io.uinta.pando-schedule-id"
+	// Set to "true" on job runs
+	LabelJob = "io.uinta.pando.job"
+)
+
+// Fingerprint hashes a message's deterministic encoding.
+func Fingerprint(m proto.Message) string {
+	encoded, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		// Unreachable for a valid message; an empty fingerprint never matches, forcing a replacement
+		return ""
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// TaskHash fingerprints a task's desired configuration.
+func TaskHash(task *com.Container) string {
+	return Fingerprint(task)
+}
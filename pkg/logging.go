@@ -0,0 +1,119 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attribute keys shared by the server and agent, so a log pipeline can filter on the same names everywhere
+const (
+	LogKeyRequestID  = "request_id"
+	LogKeyDeviceID   = "device_id"
+	LogKeyTaskID     = "task_id"
+	LogKeyScheduleID = "schedule_id"
+	LogKeyContainer  = "container_id"
+	LogKeyError      = "error"
+	LogKeyTraceID    = "trace_id"
+)
+
+// logLevel is the minimum level logged, changeable while running through LogLevelHandler.
+var logLevel = new(slog.LevelVar)
+
+// SetupLogging makes slog's default logger, and the standard log package, write format ("text" or "json") to
+// stderr at level and above.
+func SetupLogging(format string, level string) error {
+	if err := logLevel.UnmarshalText([]byte(level)); err != nil {
+		return errors.Wrapf(err, "invalid log level %q", level)
+	}
+	options := &slog.HandlerOptions{Level: logLevel}
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case "", "text":
+		handler = slog.NewTextHandler(os.Stderr, options)
+	case "json":
+		handler = slog.NewJSONHandler(os.Stderr, options)
+	default:
+		return errors.Errorf("invalid log format %q; use text or json", format)
+	}
+	slog.SetDefault(slog.New(&contextHandler{Handler: handler}))
+	// whatever still uses the log package, such as libraries, logs at info
+	log.SetFlags(0)
+	return nil
+}
+
+// Err is the attribute errors are logged under.
+func Err(err error) slog.Attr {
+	return slog.Any(LogKeyError, err)
+}
+
+type logAttrsKey struct{}
+
+// WithLogAttrs returns a context whose log records, written with slog's *Context functions, carry attrs along
+// with any the context already had.
+func WithLogAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing, _ := ctx.Value(logAttrsKey{}).([]slog.Attr)
+	combined := make([]slog.Attr, 0, len(existing)+len(attrs))
+	combined = append(combined, existing...)
+	combined = append(combined, attrs...)
+	return context.WithValue(ctx, logAttrsKey{}, combined)
+}
+
+// contextHandler adds the attributes attached by WithLogAttrs to each record, and the ID of the trace the record
+// was logged in.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if attrs, ok := ctx.Value(logAttrsKey{}).([]slog.Attr); ok {
+		record.AddAttrs(attrs...)
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		record.AddAttrs(slog.String(LogKeyTraceID, spanContext.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// LogLevelHandler reports the log level on GET, and changes it on PUT or POST to the level in the body, such as
+// "debug" or "warn".
+func LogLevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			body, err := io.ReadAll(io.LimitReader(r.Body, 64))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			previous := logLevel.Level()
+			if err := logLevel.UnmarshalText([]byte(strings.TrimSpace(string(body)))); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			slog.Warn("Log level changed", "from", previous.String(), "to", logLevel.Level().String())
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		fmt.Fprintln(w, logLevel.Level().String())
+	})
+}
@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+)
+
+// MaxMaintenanceWindowMinutes is a week, so that windows of a weekly schedule never overlap themselves.
+const MaxMaintenanceWindowMinutes = 7 * 24 * 60
+
+// MaintenanceDays are the day names maintenance windows use, indexed by time.Weekday.
+var MaintenanceDays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// ValidateMaintenance checks maintenance windows and the time zone they are in, listing every problem found.
+func ValidateMaintenance(windows []*com.MaintenanceWindow, timeZone string) error {
+	var problems []string
+	if timeZone != "" {
+		if _, err := time.LoadLocation(timeZone); err != nil {
+			problems = append(problems, fmt.Sprintf("time zone %q is not a known IANA time zone", timeZone))
+		}
+	}
+	for i, window := range windows {
+		problemf := func(format string, args ...any) {
+			problems = append(problems, fmt.Sprintf("window %d: ", i+1)+fmt.Sprintf(format, args...))
+		}
+		for _, day := range window.Days {
+			if !slices.Contains(MaintenanceDays, day) {
+				problemf("day %q must be one of %s", day, strings.Join(MaintenanceDays, ", "))
+			}
+		}
+		if _, _, err := parseWindowStart(window.Start); err != nil {
+			problemf("%v", err)
+		}
+		if window.DurationMinutes < 1 || window.DurationMinutes > MaxMaintenanceWindowMinutes {
+			problemf("duration_minutes must be between 1 and %d", MaxMaintenanceWindowMinutes)
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New("invalid maintenance windows: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// parseWindowStart parses a window's "HH:MM" start time.
+func parseWindowStart(start string) (int, int, error) {
+	hours, minutes, ok := strings.Cut(start, ":")
+	if ok && len(hours) == 2 && len(minutes) == 2 {
+		hour, hourErr := strconv.Atoi(hours)
+		minute, minuteErr := strconv.Atoi(minutes)
+		if hourErr == nil && minuteErr == nil && hour >= 0 && hour < 24 && minute >= 0 && minute < 60 {
+			return hour, minute, nil
+		}
+	}
+	return 0, 0, errors.Errorf("start %q must be a time of day as HH:MM", start)
+}
+
+// MaintenanceWindowOpen reports whether policy lets a device make disruptive changes at now and, if not, when it
+// next may; the zero time if never. A nil policy, or one without windows, always allows them. Windows are laid out
+// on the calendar of the policy's time zone, so they keep their local time across daylight saving changes.
+func MaintenanceWindowOpen(policy *com.MaintenancePolicy, now time.Time) (bool, time.Time, error) {
+	if len(policy.GetWindows()) == 0 {
+		return true, time.Time{}, nil
+	}
+	if policy.GetApplyNowUntil().IsValid() && now.Before(policy.GetApplyNowUntil().AsTime()) {
+		return true, time.Time{}, nil
+	}
+	location, err := time.LoadLocation(policy.GetTimeZone())
+	if err != nil {
+		return false, time.Time{}, errors.Wrapf(err, "failed to load time zone %q", policy.GetTimeZone())
+	}
+
+	local := now.In(location)
+	var next time.Time
+	// A window can last up to a week, so one that opened on any of the past seven days may still be open
+	for offset := -7; offset <= 7; offset++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, location)
+		for _, window := range policy.GetWindows() {
+			if len(window.Days) > 0 && !slices.Contains(window.Days, MaintenanceDays[day.Weekday()]) {
+				continue
+			}
+			hour, minute, err := parseWindowStart(window.Start)
+			if err != nil {
+				return false, time.Time{}, err
+			}
+			opens := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, location)
+			closes := opens.Add(time.Duration(window.DurationMinutes) * time.Minute)
+			if !now.Before(opens) && now.Before(closes) {
+				return true, time.Time{}, nil
+			}
+			if opens.After(now) && (next.IsZero() || opens.Before(next)) {
+				next = opens
+			}
+		}
+	}
+	return false, next, nil
+}
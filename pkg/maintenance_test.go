@@ -0,0 +1,138 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+)
+
+func TestMaintenanceWindowOpen(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(location *time.Location, year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, location)
+	}
+	nightly := []*com.MaintenanceWindow{{Start: "02:00", DurationMinutes: 60}}
+	// 2026-10-17 is a Saturday
+	saturdayNight := []*com.MaintenanceWindow{{Days: []string{"sat"}, Start: "23:00", DurationMinutes: 180}}
+
+	tests := []struct {
+		name     string
+		policy   *com.MaintenancePolicy
+		now      time.Time
+		wantOpen bool
+		wantNext time.Time
+	}{
+		{
+			name:     "no policy",
+			now:      at(time.UTC, 2026, 10, 17, 12, 0),
+			wantOpen: true,
+		},
+		{
+			name:     "no windows",
+			policy:   &com.MaintenancePolicy{TimeZone: "America/Denver"},
+			now:      at(time.UTC, 2026, 10, 17, 12, 0),
+			wantOpen: true,
+		},
+		{
+			name:     "inside a window",
+			policy:   &com.MaintenancePolicy{Windows: nightly},
+			now:      at(time.UTC, 2026, 10, 17, 2, 30),
+			wantOpen: true,
+		},
+		{
+			name:     "as a window closes",
+			policy:   &com.MaintenancePolicy{Windows: nightly},
+			now:      at(time.UTC, 2026, 10, 17, 3, 0),
+			wantNext: at(time.UTC, 2026, 10, 18, 2, 0),
+		},
+		{
+			name:     "before a window",
+			policy:   &com.MaintenancePolicy{Windows: nightly, TimeZone: "America/Denver"},
+			now:      at(denver, 2026, 10, 17, 1, 59),
+			wantNext: at(denver, 2026, 10, 17, 2, 0),
+		},
+		{
+			name:     "past midnight, in a window opened the day before",
+			policy:   &com.MaintenancePolicy{Windows: saturdayNight},
+			now:      at(time.UTC, 2026, 10, 18, 1, 0),
+			wantOpen: true,
+		},
+		{
+			name:     "past midnight, after the window closed",
+			policy:   &com.MaintenancePolicy{Windows: saturdayNight},
+			now:      at(time.UTC, 2026, 10, 18, 2, 0),
+			wantNext: at(time.UTC, 2026, 10, 24, 23, 0),
+		},
+		{
+			name:     "on a day without a window",
+			policy:   &com.MaintenancePolicy{Windows: saturdayNight},
+			now:      at(time.UTC, 2026, 10, 16, 23, 30),
+			wantNext: at(time.UTC, 2026, 10, 17, 23, 0),
+		},
+		{
+			// Clocks in Denver go forward on 2026-03-08, so the window opens an hour earlier in UTC from then on
+			name:     "next window across the start of daylight saving",
+			policy:   &com.MaintenancePolicy{Windows: []*com.MaintenanceWindow{{Start: "03:00", DurationMinutes: 60}}, TimeZone: "America/Denver"},
+			now:      at(time.UTC, 2026, 3, 7, 12, 0),
+			wantNext: at(time.UTC, 2026, 3, 8, 9, 0),
+		},
+		{
+			name:     "in daylight saving time",
+			policy:   &com.MaintenancePolicy{Windows: []*com.MaintenanceWindow{{Start: "03:00", DurationMinutes: 60}}, TimeZone: "America/Denver"},
+			now:      at(time.UTC, 2026, 3, 9, 9, 30),
+			wantOpen: true,
+		},
+		{
+			// Clocks in Denver go back on 2026-11-01, so 01:30 comes around twice and the window spans two hours
+			name:     "through the end of daylight saving",
+			policy:   &com.MaintenancePolicy{Windows: []*com.MaintenanceWindow{{Start: "01:00", DurationMinutes: 90}}, TimeZone: "America/Denver"},
+			now:      at(time.UTC, 2026, 11, 1, 8, 15),
+			wantOpen: true,
+		},
+		{
+			name: "applying now",
+			policy: &com.MaintenancePolicy{
+				Windows:       nightly,
+				ApplyNowUntil: timestamppb.New(at(time.UTC, 2026, 10, 17, 13, 0)),
+			},
+			now:      at(time.UTC, 2026, 10, 17, 12, 0),
+			wantOpen: true,
+		},
+		{
+			name: "after applying now expired",
+			policy: &com.MaintenancePolicy{
+				Windows:       nightly,
+				ApplyNowUntil: timestamppb.New(at(time.UTC, 2026, 10, 17, 11, 0)),
+			},
+			now:      at(time.UTC, 2026, 10, 17, 12, 0),
+			wantNext: at(time.UTC, 2026, 10, 18, 2, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open, next, err := MaintenanceWindowOpen(tt.policy, tt.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if open != tt.wantOpen || !next.Equal(tt.wantNext) {
+				t.Errorf("MaintenanceWindowOpen at %s returned %t and %s, want %t and %s", tt.now, open, next, tt.wantOpen, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindowOpenUnknownTimeZone(t *testing.T) {
+	policy := &com.MaintenancePolicy{
+		Windows:  []*com.MaintenanceWindow{{Start: "02:00", DurationMinutes: 60}},
+		TimeZone: "Mars/Olympus_Mons",
+	}
+	if _, _, err := MaintenanceWindowOpen(policy, time.Now()); err == nil {
+		t.Error("MaintenanceWindowOpen succeeded with an unknown time zone")
+	}
+}
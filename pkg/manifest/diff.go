@@ -0,0 +1,92 @@
+package manifest
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Diff lists the changes that turn current into desired, comparing containers by name. current is nil for a
+// schedule that doesn't exist yet. Both are compared in manifest form, so differences that a manifest can't
+// express, such as IDs or the order of containers, are ignored.
+func Diff(current *com.Schedule, desired *com.Schedule) []*com.ManifestChange {
+	want := FromSchedule(desired)
+	if current == nil {
+		changes := []*com.ManifestChange{{Action: ActionCreate}}
+		for _, container := range want.Containers {
+			changes = append(changes, &com.ManifestChange{Action: ActionCreate, Container: container.Name})
+		}
+		return changes
+	}
+	have := FromSchedule(current)
+
+	changes := []*com.ManifestChange{}
+	if have.State != want.State {
+		changes = append(changes, &com.ManifestChange{
+			Action: ActionUpdate,
+			Fields: []*com.FieldChange{fieldChange("state", have.State, want.State)},
+		})
+	}
+
+	existing := map[string]Container{}
+	for _, container := range have.Containers {
+		existing[container.Name] = container
+	}
+	for _, container := range want.Containers {
+		before, ok := existing[container.Name]
+		if !ok {
+			changes = append(changes, &com.ManifestChange{Action: ActionCreate, Container: container.Name})
+			continue
+		}
+		delete(existing, container.Name)
+		if fields := containerChanges(before, container); len(fields) > 0 {
+			changes = append(changes, &com.ManifestChange{Action: ActionUpdate, Container: container.Name, Fields: fields})
+		}
+	}
+	// what is left in existing is in name order, as have.Containers is
+	for _, container := range have.Containers {
+		if _, ok := existing[container.Name]; ok {
+			changes = append(changes, &com.ManifestChange{Action: ActionDelete, Container: container.Name})
+		}
+	}
+	return changes
+}
+
+// containerChanges compares two containers field by field, naming fields as manifests do.
+func containerChanges(before Container, after Container) []*com.FieldChange {
+	fields := []*com.FieldChange{}
+	b := reflect.ValueOf(before)
+	a := reflect.ValueOf(after)
+	for i := 0; i < b.NumField(); i++ {
+		if reflect.DeepEqual(b.Field(i).Interface(), a.Field(i).Interface()) {
+			continue
+		}
+		name, _, _ := strings.Cut(b.Type().Field(i).Tag.Get("yaml"), ",")
+		fields = append(fields, fieldChange(name, b.Field(i).Interface(), a.Field(i).Interface()))
+	}
+	return fields
+}
+
+func fieldChange(field string, before any, after any) *com.FieldChange {
+	return &com.FieldChange{
+		Field: field,
+		From:  encodeValue(before),
+		To:    encodeValue(after),
+	}
+}
+
+func encodeValue(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "?"
+	}
+	return string(encoded)
+}
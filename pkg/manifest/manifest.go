@@ -0,0 +1,237 @@
+// Package manifest reads and writes schedule manifests: versioned YAML or JSON files describing a schedule and
+// its containers, so schedules can be kept in version control and applied declaratively.
+package manifest
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+)
+
+const (
+	APIVersion = "pando/v1"
+	Kind       = "Schedule"
+
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// Manifest is a schedule as written in a manifest file. Containers are identified by name, so a manifest
+// carries no IDs and applies equally to any server.
+type Manifest struct {
+	APIVersion string      `yaml:"apiVersion" json:"apiVersion"`
+	Kind       string      `yaml:"kind" json:"kind"`
+	Name       string      `yaml:"name" json:"name"`
+	State      string      `yaml:"state,omitempty" json:"state,omitempty"`
+	Containers []Container `yaml:"containers" json:"containers"`
+}
+
+type Container struct {
+	Name       string            `yaml:"name" json:"name"`
+	Image      string            `yaml:"image" json:"image"`
+	Command    string            `yaml:"command,omitempty" json:"command,omitempty"`
+	Entrypoint string            `yaml:"entrypoint,omitempty" json:"entrypoint,omitempty"`
+	Env        map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+	Privileged bool              `yaml:"privileged,omitempty" json:"privileged,omitempty"`
+	// "bridge" (the default), "host" or "none"
+	NetworkMode string `yaml:"networkMode,omitempty" json:"networkMode,omitempty"`
+	Ports       []Port `yaml:"ports,omitempty" json:"ports,omitempty"`
+	// Host paths mounted into the container, from BindNames
+	Binds                  []string `yaml:"binds,omitempty" json:"binds,omitempty"`
+	StopSignal             string   `yaml:"stopSignal,omitempty" json:"stopSignal,omitempty"`
+	StopGracePeriodSeconds int32    `yaml:"stopGracePeriodSeconds,omitempty" json:"stopGracePeriodSeconds,omitempty"`
+	// "recreate" (the default) or "start-first"
+	UpdateStrategy string `yaml:"updateStrategy,omitempty" json:"updateStrategy,omitempty"`
+	// Makes the container a job, run to completion on a cron schedule or once; "job: {}" runs it once
+	Job *Job `yaml:"job,omitempty" json:"job,omitempty"`
+}
+
+type Job struct {
+	// Five fields or a macro such as "@daily"; empty runs the job once
+	Cron     string `yaml:"cron,omitempty" json:"cron,omitempty"`
+	TimeZone string `yaml:"timeZone,omitempty" json:"timeZone,omitempty"`
+	// Zero lets a run go on for as long as it takes
+	TimeoutSeconds int32 `yaml:"timeoutSeconds,omitempty" json:"timeoutSeconds,omitempty"`
+	// "forbid" (the default), "replace" or "allow"
+	ConcurrencyPolicy string `yaml:"concurrencyPolicy,omitempty" json:"concurrencyPolicy,omitempty"`
+}
+
+type Port struct {
+	Host      string `yaml:"host" json:"host"`
+	Container string `yaml:"container" json:"container"`
+	Protocol  string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+}
+
+// BindNames lists, in the order manifests write them, the host mounts a container can ask for.
+var BindNames = []string{"dev", "proc", "sys", "shm", "cgroup", "docker-socket", "boot"}
+
+var networkModes = map[string]com.Container_NetworkMode{
+	"bridge": com.Container_BRIDGE,
+	"host":   com.Container_HOST,
+	"none":   com.Container_NONE,
+}
+
+// binds maps each of BindNames to its field of a container.
+func binds(container *com.Container) map[string]*bool {
+	return map[string]*bool{
+		"dev":           &container.BindDev,
+		"proc":          &container.BindProc,
+		"sys":           &container.BindSys,
+		"shm":           &container.BindShm,
+		"cgroup":        &container.BindCgroup,
+		"docker-socket": &container.BindDockerSocket,
+		"boot":          &container.BindBoot,
+	}
+}
+
+// Parse reads a manifest from YAML or JSON, rejecting unknown fields and unsupported versions.
+func Parse(data []byte) (*Manifest, error) {
+	m := &Manifest{}
+	// JSON is valid YAML, so one decoder handles both
+	if err := yaml.UnmarshalStrict(data, m); err != nil {
+		return nil, errors.Wrap(err, "failed to parse manifest")
+	}
+	if m.APIVersion != APIVersion {
+		return nil, errors.Errorf("unsupported apiVersion %q; expected %q", m.APIVersion, APIVersion)
+	}
+	if m.Kind != Kind {
+		return nil, errors.Errorf("unsupported kind %q; expected %q", m.Kind, Kind)
+	}
+	return m, nil
+}
+
+// Encode renders the manifest in the given format.
+func (m *Manifest) Encode(format string) ([]byte, error) {
+	switch format {
+	case "", FormatYAML:
+		encoded, err := yaml.Marshal(m)
+		return encoded, errors.Wrap(err, "failed to encode manifest")
+	case FormatJSON:
+		encoded, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode manifest")
+		}
+		return append(encoded, '\n'), nil
+	default:
+		return nil, errors.Errorf("unknown format %q; use %s or %s", format, FormatYAML, FormatJSON)
+	}
+}
+
+// ToSchedule converts the manifest to a schedule without IDs, defaulting its state to active. The result
+// still needs pkg.ValidateSchedule.
+func (m *Manifest) ToSchedule() (*com.Schedule, error) {
+	schedule := &com.Schedule{
+		Name:  m.Name,
+		State: m.State,
+	}
+	if schedule.State == "" {
+		schedule.State = "active"
+	}
+
+	for _, c := range m.Containers {
+		container := &com.Container{
+			Name:                   c.Name,
+			ContainerImage:         c.Image,
+			Command:                c.Command,
+			Entrypoint:             c.Entrypoint,
+			Env:                    c.Env,
+			Privileged:             c.Privileged,
+			StopSignal:             c.StopSignal,
+			StopGracePeriodSeconds: c.StopGracePeriodSeconds,
+			UpdateStrategy:         c.UpdateStrategy,
+		}
+		if c.Job != nil {
+			container.Job = &com.Job{
+				Cron:              c.Job.Cron,
+				TimeZone:          c.Job.TimeZone,
+				TimeoutSeconds:    c.Job.TimeoutSeconds,
+				ConcurrencyPolicy: c.Job.ConcurrencyPolicy,
+			}
+		}
+		if c.NetworkMode != "" {
+			mode, ok := networkModes[c.NetworkMode]
+			if !ok {
+				return nil, errors.Errorf("container %q: unknown networkMode %q", c.Name, c.NetworkMode)
+			}
+			container.NetworkMode = mode
+		}
+		for _, port := range c.Ports {
+			container.Ports = append(container.Ports, &com.Container_Port{
+				Host:      port.Host,
+				Container: port.Container,
+				Protocol:  port.Protocol,
+			})
+		}
+		fields := binds(container)
+		for _, bind := range c.Binds {
+			field, ok := fields[bind]
+			if !ok {
+				return nil, errors.Errorf("container %q: unknown bind %q; expected one of %s", c.Name, bind, strings.Join(BindNames, ", "))
+			}
+			*field = true
+		}
+		schedule.Containers = append(schedule.Containers, container)
+	}
+	return schedule, nil
+}
+
+// FromSchedule renders a schedule as a manifest, with containers sorted by name. Converting a manifest to a
+// schedule and back yields the same manifest up to ordering and defaults, which is what Diff relies on.
+func FromSchedule(schedule *com.Schedule) *Manifest {
+	m := &Manifest{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		Name:       schedule.Name,
+		State:      schedule.State,
+		Containers: []Container{},
+	}
+	for _, container := range schedule.Containers {
+		c := Container{
+			Name:                   container.Name,
+			Image:                  container.ContainerImage,
+			Command:                container.Command,
+			Entrypoint:             container.Entrypoint,
+			Privileged:             container.Privileged,
+			StopSignal:             container.StopSignal,
+			StopGracePeriodSeconds: container.StopGracePeriodSeconds,
+			UpdateStrategy:         container.UpdateStrategy,
+		}
+		if len(container.Env) > 0 {
+			c.Env = container.Env
+		}
+		if container.Job != nil {
+			c.Job = &Job{
+				Cron:              container.Job.Cron,
+				TimeZone:          container.Job.TimeZone,
+				TimeoutSeconds:    container.Job.TimeoutSeconds,
+				ConcurrencyPolicy: container.Job.ConcurrencyPolicy,
+			}
+		}
+		for name, mode := range networkModes {
+			if mode == container.NetworkMode && mode != com.Container_BRIDGE {
+				c.NetworkMode = name
+			}
+		}
+		for _, port := range container.Ports {
+			c.Ports = append(c.Ports, Port{
+				Host:      port.Host,
+				Container: port.Container,
+				Protocol:  port.Protocol,
+			})
+		}
+		fields := binds(container)
+		for _, bind := range BindNames {
+			if *fields[bind] {
+				c.Binds = append(c.Binds, bind)
+			}
+		}
+		m.Containers = append(m.Containers, c)
+	}
+	sort.Slice(m.Containers, func(i, j int) bool { return m.Containers[i].Name < m.Containers[j].Name })
+	return m
+}
@@ -0,0 +1,59 @@
+package pkg
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// NewMetricsRegistry returns a registry holding the Go runtime and process collectors, for the caller to add its
+// own metrics to. Each binary uses its own registry rather than the global one.
+func NewMetricsRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// ServeMetrics serves registry at /metrics on address until ctx is done. It runs its own listener, so metrics stay
+// off the API's address and out of its middleware.
+func ServeMetrics(ctx context.Context, address string, registry *prometheus.Registry) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry}))
+	serve(ctx, "metrics", address, mux)
+}
+
+// ServeLogLevel serves LogLevelHandler at /loglevel on address until ctx is done. It is unauthenticated and changes
+// what is logged, so it has a listener of its own rather than sharing the one metrics are scraped from.
+func ServeLogLevel(ctx context.Context, address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/loglevel", LogLevelHandler())
+	serve(ctx, "log level", address, mux)
+}
+
+func serve(ctx context.Context, name string, address string, handler http.Handler) {
+	server := &http.Server{
+		Addr:              address,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	slog.Info("Serving "+name, "address", address)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		slog.Error("Failed to serve "+name, Err(err))
+	}
+}
@@ -0,0 +1,170 @@
+package pkg
+
+import (
+	"slices"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com/comconnect"
+)
+
+// Role is what a member, or an API key, may do within an organization. Each role can do everything the roles
+// below it can.
+type Role string
+
+const (
+	// Manages members, including other owners; an organization always keeps at least one
+	RoleOwner Role = "owner"
+	// Manages fleets, devices, secrets, API keys and members other than owners
+	RoleAdmin Role = "admin"
+	// Deploys schedules and runs remote actions on devices
+	RoleOperator Role = "operator"
+	// Sees fleets, schedules and devices
+	RoleViewer Role = "viewer"
+)
+
+// Roles lists every role, most powerful first.
+var Roles = []Role{RoleOwner, RoleAdmin, RoleOperator, RoleViewer}
+
+func (r Role) Valid() bool {
+	return slices.Contains(Roles, r)
+}
+
+// AtLeast reports whether r can do everything other can.
+func (r Role) AtLeast(other Role) bool {
+	rank, otherRank := slices.Index(Roles, r), slices.Index(Roles, other)
+	return rank >= 0 && otherRank >= 0 && rank <= otherRank
+}
+
+// Can reports whether r grants permission.
+func (r Role) Can(permission Permission) bool {
+	rank := slices.Index(Roles, r)
+	if rank < 0 {
+		return false
+	}
+	for _, role := range Roles[rank:] {
+		if slices.Contains(rolePermissions[role], permission) {
+			return true
+		}
+	}
+	return false
+}
+
+type Permission string
+
+const (
+	PermissionFleetsRead  Permission = "fleets:read"
+	PermissionFleetsWrite Permission = "fleets:write"
+	// Choose the schedule a fleet runs
+	PermissionFleetsDeploy Permission = "fleets:deploy"
+
+	PermissionSchedulesRead  Permission = "schedules:read"
+	PermissionSchedulesWrite Permission = "schedules:write"
+
+	PermissionDevicesRead  Permission = "devices:read"
+	PermissionDevicesWrite Permission = "devices:write"
+
+	// For the secrets schedules will reference; no RPC reads or writes them yet
+	PermissionSecretsRead  Permission = "secrets:read"
+	PermissionSecretsWrite Permission = "secrets:write"
+
+	// Remote actions, relayed to a device's agent
+	PermissionRemoteLogs Permission = "remote:logs"
+	PermissionRemoteExec Permission = "remote:exec"
+
+	PermissionMembersRead  Permission = "members:read"
+	PermissionMembersWrite Permission = "members:write"
+	PermissionAPIKeysRead  Permission = "api_keys:read"
+	PermissionAPIKeysWrite Permission = "api_keys:write"
+	PermissionAuditRead    Permission = "audit:read"
+	// Webhooks receive every event of the organization they belong to
+	PermissionWebhooksRead  Permission = "webhooks:read"
+	PermissionWebhooksWrite Permission = "webhooks:write"
+
+	// Any authenticated caller, whatever its role; for RPCs that are not about one organization
+	PermissionAuthenticated Permission = ""
+)
+
+// rolePermissions lists what each role adds to the roles below it.
+var rolePermissions = map[Role][]Permission{
+	RoleViewer: {
+		PermissionFleetsRead,
+		PermissionSchedulesRead,
+		PermissionDevicesRead,
+		PermissionMembersRead,
+	},
+	RoleOperator: {
+		PermissionSchedulesWrite,
+		PermissionFleetsDeploy,
+		PermissionRemoteLogs,
+		PermissionRemoteExec,
+	},
+	RoleAdmin: {
+		PermissionFleetsWrite,
+		PermissionDevicesWrite,
+		PermissionSecretsRead,
+		PermissionSecretsWrite,
+		PermissionMembersWrite,
+		PermissionAPIKeysRead,
+		PermissionAPIKeysWrite,
+		PermissionAuditRead,
+		PermissionWebhooksRead,
+		PermissionWebhooksWrite,
+	},
+	RoleOwner: {},
+}
+
+// procedurePermissions is the permission each operator RPC requires, in the organization its request names.
+// The authorization interceptor rejects procedures missing from here, so every new RPC must be added.
+var procedurePermissions = map[string]Permission{
+	comconnect.OrganizationServiceListOrganizationsProcedure: PermissionAuthenticated,
+	comconnect.OrganizationServiceCreateAPIKeyProcedure:      PermissionAPIKeysWrite,
+	comconnect.OrganizationServiceListAPIKeysProcedure:       PermissionAPIKeysRead,
+	comconnect.OrganizationServiceRevokeAPIKeyProcedure:      PermissionAPIKeysWrite,
+	comconnect.OrganizationServiceListMembersProcedure:       PermissionMembersRead,
+	comconnect.OrganizationServiceInviteUserProcedure:        PermissionMembersWrite,
+	comconnect.OrganizationServiceSetMemberRoleProcedure:     PermissionMembersWrite,
+
+	comconnect.FleetServiceListFleetsProcedure:              PermissionFleetsRead,
+	comconnect.FleetServiceCreateFleetProcedure:             PermissionFleetsWrite,
+	comconnect.FleetServiceRenameFleetProcedure:             PermissionFleetsWrite,
+	comconnect.FleetServiceDeleteFleetProcedure:             PermissionFleetsWrite,
+	comconnect.FleetServiceSetFleetDefaultScheduleProcedure: PermissionFleetsDeploy,
+	comconnect.FleetServiceSetFleetAgentImageProcedure:      PermissionFleetsDeploy,
+	comconnect.FleetServiceSetFleetMaintenanceProcedure:     PermissionFleetsWrite,
+	comconnect.FleetServiceSetDeviceMaintenanceProcedure:    PermissionDevicesWrite,
+	comconnect.FleetServiceApplyUpdatesNowProcedure:         PermissionFleetsDeploy,
+	comconnect.FleetServiceListDevicesProcedure:             PermissionDevicesRead,
+	comconnect.FleetServiceCreateDeviceProcedure:            PermissionDevicesWrite,
+	comconnect.FleetServiceRenameDeviceProcedure:            PermissionDevicesWrite,
+	comconnect.FleetServiceMoveDeviceProcedure:              PermissionDevicesWrite,
+	comconnect.FleetServiceDeleteDeviceProcedure:            PermissionDevicesWrite,
+
+	comconnect.ScheduleServiceListSchedulesProcedure:    PermissionSchedulesRead,
+	comconnect.ScheduleServiceDescribeScheduleProcedure: PermissionSchedulesRead,
+	comconnect.ScheduleServiceCreateScheduleProcedure:   PermissionSchedulesWrite,
+	comconnect.ScheduleServiceUpdateScheduleProcedure:   PermissionSchedulesWrite,
+	comconnect.ScheduleServiceDeleteScheduleProcedure:   PermissionSchedulesWrite,
+	comconnect.ScheduleServiceCreateContainerProcedure:  PermissionSchedulesWrite,
+	comconnect.ScheduleServiceUpdateContainerProcedure:  PermissionSchedulesWrite,
+	comconnect.ScheduleServiceDeleteContainerProcedure:  PermissionSchedulesWrite,
+	comconnect.ScheduleServiceApplyManifestProcedure:    PermissionSchedulesWrite,
+	comconnect.ScheduleServiceExportScheduleProcedure:   PermissionSchedulesRead,
+	// deploying to a fleet also needs PermissionFleetsDeploy, checked by the handler
+	comconnect.ScheduleServiceImportComposeProcedure: PermissionSchedulesWrite,
+	comconnect.ScheduleServiceExportComposeProcedure: PermissionSchedulesRead,
+
+	comconnect.DeviceServiceDescribeDeviceProcedure:   PermissionDevicesRead,
+	comconnect.DeviceServiceGetContainerLogsProcedure: PermissionRemoteLogs,
+	comconnect.DeviceServiceExecInContainerProcedure:  PermissionRemoteExec,
+	// runs carry the tail of their output
+	comconnect.DeviceServiceListJobRunsProcedure:           PermissionRemoteLogs,
+	comconnect.DeviceServiceIssueDeviceRelayTokenProcedure: PermissionDevicesWrite,
+
+	comconnect.AuditServiceListAuditEntriesProcedure: PermissionAuditRead,
+
+	comconnect.WebhookServiceListWebhooksProcedure:          PermissionWebhooksRead,
+	comconnect.WebhookServiceCreateWebhookProcedure:         PermissionWebhooksWrite,
+	comconnect.WebhookServiceUpdateWebhookProcedure:         PermissionWebhooksWrite,
+	comconnect.WebhookServiceDeleteWebhookProcedure:         PermissionWebhooksWrite,
+	comconnect.WebhookServiceListWebhookDeliveriesProcedure: PermissionWebhooksRead,
+	comconnect.WebhookServiceRedeliverWebhookProcedure:      PermissionWebhooksWrite,
+}
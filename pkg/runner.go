@@ -5,18 +5,28 @@ import (
 	"context"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/registry"
-	"io"
-	"log"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"log/slog"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 )
 
+// DefaultStopGracePeriod matches the engine's own default for containers without a stop timeout.
+const DefaultStopGracePeriod = 10 * time.Second
+
+// Runner is the ContainerRuntime for Docker-compatible engines, including balena-engine.
 type Runner struct {
 	client *client.Client
 }
@@ -25,6 +35,8 @@ type LogChannels struct {
 	BaseContext   context.Context
 	Mixed         chan string
 	ChannelClosed chan struct{}
+	// Closed once the attached scanner stops, after every line it read has been received from Mixed
+	Finished chan struct{}
 }
 
 func NewLogChannels(ctx context.Context) *LogChannels {
@@ -32,6 +44,7 @@ func NewLogChannels(ctx context.Context) *LogChannels {
 		BaseContext:   ctx,
 		Mixed:         make(chan string),
 		ChannelClosed: make(chan struct{}),
+		Finished:      make(chan struct{}),
 	}
 }
 
@@ -47,20 +60,21 @@ func NewRegistryCredentials(ghcrUsername string, ghcrToken string) *registryCred
 	}
 }
 
-func (r *registryCredentials) GetAuthenticationString() string {
+func (r *registryCredentials) GetAuthenticationString() (string, error) {
 	authConfig := registry.AuthConfig{
 		Username: r.GHCRUsername,
 		Password: r.GHCRToken,
 	}
 	resp, err := registry.EncodeAuthConfig(authConfig)
 	if err != nil {
-		log.Panicln(err)
+		return "", errors.Wrap(err, "failed to encode registry credentials")
 	}
-	return resp
+	return resp, nil
 }
 
 func (l *LogChannels) AttachScanner(scanner *bufio.Scanner) {
 	go func() {
+		defer close(l.Finished)
 		for scanner.Scan() {
 			select {
 			case <-l.BaseContext.Done():
@@ -88,44 +102,44 @@ func (l *LogChannels) Consumer() <-chan string {
 	return l.Mixed
 }
 
-func NewRunner(hostSocketLocation string) *Runner {
-
-	var cli *client.Client
-	var err error
-
-	cli, err = client.NewClientWithOpts(client.WithHost(hostSocketLocation), client.WithAPIVersionNegotiation())
+// NewRunner connects to the engine at hostSocketLocation. The connection is only made on first use, so an
+// engine that is down is reported by each call as an EngineUnavailableError, not here.
+func NewRunner(hostSocketLocation string) (*Runner, error) {
+	cli, err := client.NewClientWithOpts(client.WithHost(hostSocketLocation), client.WithAPIVersionNegotiation())
 	if err != nil {
-		log.Panicln("failed to create docker client:", err)
+		return nil, errors.Wrap(err, "failed to create docker client")
 	}
 
 	return &Runner{
 		client: cli,
-	}
+	}, nil
 }
 
 func (r *Runner) PullImage(ctx context.Context, imageReference string) error {
-	reader, err := r.client.ImagePull(ctx, imageReference, image.PullOptions{})
-	if err != nil {
-		log.Panicf("failed to pull image: %s", err)
-	}
-
-	defer reader.Close()
-	_, err = io.Copy(os.Stdout, reader)
-	return err
+	return r.pullImage(ctx, imageReference, image.PullOptions{})
 }
 
 func (r *Runner) PullImageWithCredentials(ctx context.Context, imageReference string, credentials *registryCredentials) error {
-	reader, err := r.client.ImagePull(ctx, imageReference, image.PullOptions{
-		RegistryAuth: credentials.GetAuthenticationString(),
-	})
+	auth, err := credentials.GetAuthenticationString()
 	if err != nil {
-		log.Printf("failed to pull image: %s", err)
 		return err
 	}
+	return r.pullImage(ctx, imageReference, image.PullOptions{RegistryAuth: auth})
+}
+
+// pullImage copies the pull's progress to stdout, returning a typed error whether the pull fails to start or
+// fails partway.
+func (r *Runner) pullImage(ctx context.Context, imageReference string, options image.PullOptions) error {
+	reader, err := r.client.ImagePull(ctx, imageReference, options)
+	if err != nil {
+		return pullError(err, imageReference)
+	}
 
 	defer reader.Close()
-	_, err = io.Copy(os.Stdout, reader)
-	return err
+	if err := jsonmessage.DisplayJSONMessagesStream(reader, os.Stdout, 0, false, nil); err != nil {
+		return pullError(err, imageReference)
+	}
+	return nil
 }
 
 func (r *Runner) FindFirstAvailableImage(ctx context.Context, credentials *registryCredentials, imageReferences []string) (string, error) {
@@ -134,7 +148,7 @@ func (r *Runner) FindFirstAvailableImage(ctx context.Context, credentials *regis
 			return "", ctx.Err()
 		}
 		if err := r.PullImageWithCredentials(ctx, imageReference, credentials); err != nil {
-			log.Printf("failed to pull image %s: %s", imageReference, err)
+			slog.WarnContext(ctx, "Image not available", "image", imageReference, Err(err))
 			continue
 		}
 		return imageReference, nil
@@ -147,12 +161,23 @@ type AdvancedOptions struct {
 	NetworkModeContainer       string
 	NetworkModeHost            bool
 	DockerEngineSocketOverride string
+
+	// Used by StopContainer; the engine's defaults (SIGTERM, 10 seconds) apply when unset
+	StopSignal      string
+	StopGracePeriod time.Duration
 }
 
 func (r *Runner) RunContainer(ctx context.Context, imageReference string, containerReference string, commands []string, environmentVariables []string, additionalLabels map[string]string, advancedOptions *AdvancedOptions, logs *LogChannels, waitOnContainer bool) (string, error) {
 	networkMode := container.NetworkMode("bridge")
 	binds := []string{}
+	var stopSignal string
+	var stopTimeout *int
 	if advancedOptions != nil {
+		stopSignal = advancedOptions.StopSignal
+		if advancedOptions.StopGracePeriod > 0 {
+			seconds := int(advancedOptions.StopGracePeriod.Seconds())
+			stopTimeout = &seconds
+		}
 		if advancedOptions.NetworkModeContainer != "" && advancedOptions.NetworkModeHost {
 			return "", errors.New("cannot specify both network mode container and network mode host")
 		}
@@ -173,8 +198,8 @@ func (r *Runner) RunContainer(ctx context.Context, imageReference string, contai
 	}
 
 	labels := map[string]string{
-		"io.uinta.pando.managed":             "true",
-		"io.uinta.pando.container-reference": containerReference,
+		LabelManaged:            "true",
+		LabelContainerReference: containerReference,
 	}
 	for k, v := range additionalLabels {
 		labels[k] = v
@@ -188,6 +213,8 @@ func (r *Runner) RunContainer(ctx context.Context, imageReference string, contai
 		AttachStdout: true,
 		Labels:       labels,
 		Env:          environmentVariables,
+		StopSignal:   stopSignal,
+		StopTimeout:  stopTimeout,
 	}, &container.HostConfig{
 		AutoRemove: true,
 		ConsoleSize: [2]uint{
@@ -198,140 +225,335 @@ func (r *Runner) RunContainer(ctx context.Context, imageReference string, contai
 		Binds:       binds,
 	}, nil, nil, containerReference)
 	if err != nil {
-		return "", err
-	}
-
-	if err := r.client.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
-		log.Panicf("failed to start container: %s", err)
+		if errdefs.IsConflict(err) {
+			return "", &NameConflictError{Name: containerReference, Err: errors.Wrapf(err, "failed to create container %s", containerReference)}
+		}
+		if errdefs.IsNotFound(err) {
+			return "", &ImageNotFoundError{Image: imageReference, Err: errors.Wrapf(err, "failed to create container %s", containerReference)}
+		}
+		return "", engineError(err, "failed to create container "+containerReference)
 	}
 
-	go func() {
-		out, err := r.client.ContainerLogs(ctx, resp.ID, container.LogsOptions{
-			ShowStdout: true,
-			ShowStderr: true,
-			Follow:     true,
+	// A container that is waited on may exit, and be removed, as soon as it starts, so as docker run does, the wait
+	// and the attach to its output are set up before starting it
+	var statusCh <-chan container.WaitResponse
+	var errCh <-chan error
+	var attached *types.HijackedResponse
+	if waitOnContainer {
+		statusCh, errCh = r.client.ContainerWait(ctx, resp.ID, container.WaitConditionRemoved)
+		hijacked, err := r.client.ContainerAttach(ctx, resp.ID, container.AttachOptions{
+			Stream: true,
+			Stdout: true,
+			Stderr: true,
 		})
 		if err != nil {
-			log.Println("failed to get container logs:", err)
-			return
+			slog.WarnContext(ctx, "Failed to attach to container, following its logs instead", LogKeyContainer, resp.ID, Err(err))
+		} else {
+			attached = &hijacked
 		}
-		logs.AttachScanner(bufio.NewScanner(out))
-	}()
+	}
+
+	if err := r.startCreated(ctx, resp.ID, containerReference); err != nil {
+		if attached != nil {
+			attached.Close()
+		}
+		return "", err
+	}
+
+	if attached != nil {
+		logs.AttachScanner(bufio.NewScanner(attached.Reader))
+		go func() {
+			select {
+			case <-logs.Finished:
+			case <-ctx.Done():
+			}
+			attached.Close()
+		}()
+	} else {
+		go func() {
+			out, err := r.client.ContainerLogs(ctx, resp.ID, container.LogsOptions{
+				ShowStdout: true,
+				ShowStderr: true,
+				Follow:     true,
+			})
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to follow container logs", LogKeyContainer, resp.ID, Err(err))
+				return
+			}
+			logs.AttachScanner(bufio.NewScanner(out))
+		}()
+	}
 
 	if waitOnContainer {
-		statusCh, errCh := r.client.ContainerWait(ctx, resp.ID, container.WaitConditionNotRunning)
 		select {
 		case err := <-errCh:
 			if err != nil {
-				log.Panicf("failed to wait for container: %s", err)
+				return resp.ID, engineError(err, "failed to wait for container")
 			}
 		case stat := <-statusCh:
 			if stat.StatusCode != 0 {
-				return resp.ID, errors.New("container exited with non-zero exit code")
+				return resp.ID, &ExitError{ExitCode: int(stat.StatusCode), Err: errors.Errorf("container exited with code %d", stat.StatusCode)}
+			}
+		}
+	}
+
+	return resp.ID, nil
+}
+
+// InspectContainer returns the engine's full description of a container.
+func (r *Runner) InspectContainer(ctx context.Context, containerReference string) (types.ContainerJSON, error) {
+	inspect, err := r.client.ContainerInspect(ctx, containerReference)
+	if err != nil {
+		return inspect, engineError(err, "failed to inspect container")
+	}
+	return inspect, nil
+}
+
+// ReplicateContainer creates and starts a container configured like source, but running imageReference, named
+// name, and with env set on top of source's environment. Whatever source's image set by default, such as its
+// environment and command, is left for imageReference's image to set instead.
+func (r *Runner) ReplicateContainer(ctx context.Context, source types.ContainerJSON, imageReference string, name string, env map[string]string) (string, error) {
+	sourceImage, _, err := r.client.ImageInspectWithRaw(ctx, source.Image)
+	if err != nil {
+		return "", engineError(err, "failed to inspect image")
+	}
+	imageConfig := sourceImage.Config
+	if imageConfig == nil {
+		imageConfig = &container.Config{}
+	}
+
+	config := *source.Config
+	config.Image = imageReference
+	imageEnv := map[string]bool{}
+	for _, variable := range imageConfig.Env {
+		imageEnv[variable] = true
+	}
+	config.Env = []string{}
+	for _, variable := range source.Config.Env {
+		key, _, _ := strings.Cut(variable, "=")
+		if _, overridden := env[key]; overridden || imageEnv[variable] {
+			continue
+		}
+		config.Env = append(config.Env, variable)
+	}
+	for key, value := range env {
+		config.Env = append(config.Env, key+"="+value)
+	}
+	if slices.Equal(config.Cmd, imageConfig.Cmd) {
+		config.Cmd = nil
+	}
+	if slices.Equal(config.Entrypoint, imageConfig.Entrypoint) {
+		config.Entrypoint = nil
+	}
+
+	hostConfig := *source.HostConfig
+	networkingConfig := &network.NetworkingConfig{}
+	networkMode := hostConfig.NetworkMode
+	if !networkMode.IsHost() && !networkMode.IsContainer() && !networkMode.IsNone() && source.NetworkSettings != nil {
+		networkingConfig.EndpointsConfig = map[string]*network.EndpointSettings{}
+		for networkName, endpoint := range source.NetworkSettings.Networks {
+			networkingConfig.EndpointsConfig[networkName] = &network.EndpointSettings{
+				NetworkID: endpoint.NetworkID,
+				Aliases:   endpoint.Aliases,
 			}
 		}
 	}
 
+	resp, err := r.client.ContainerCreate(ctx, &config, &hostConfig, networkingConfig, nil, name)
+	if err != nil {
+		if errdefs.IsConflict(err) {
+			return "", &NameConflictError{Name: name, Err: errors.Wrapf(err, "failed to create container %s", name)}
+		}
+		return "", engineError(err, "failed to create container "+name)
+	}
+	if err := r.startCreated(ctx, resp.ID, name); err != nil {
+		return "", err
+	}
 	return resp.ID, nil
 }
 
+// startCreated starts a container just created as name. One that fails to start is removed again, as AutoRemove
+// only applies once a container has run, so that its name is free for a retry.
+func (r *Runner) startCreated(ctx context.Context, containerID string, name string) error {
+	err := r.client.ContainerStart(ctx, containerID, container.StartOptions{})
+	if err == nil {
+		return nil
+	}
+	if removeErr := r.client.ContainerRemove(context.WithoutCancel(ctx), containerID, container.RemoveOptions{Force: true}); removeErr != nil && !errdefs.IsNotFound(removeErr) {
+		slog.WarnContext(ctx, "Failed to remove container that did not start", LogKeyContainer, containerID, Err(removeErr))
+	}
+	return engineError(err, "failed to start container "+name)
+}
+
+// ExecCommand runs a command with a TTY, streaming its output to logs until it exits or logs' context ends.
 func (r *Runner) ExecCommand(ctx context.Context, containerReference string, command []string, logs *LogChannels) error {
 	execConfig := container.ExecOptions{
 		AttachStdout: true,
 		AttachStderr: true,
+		Tty:          true,
 		Cmd:          command,
 	}
 	execID, err := r.client.ContainerExecCreate(ctx, containerReference, execConfig)
 	if err != nil {
-		return err
+		return engineError(err, "failed to create exec")
 	}
 
+	// Attaching also starts the exec
 	resp, err := r.client.ContainerExecAttach(ctx, execID.ID, container.ExecStartOptions{
 		Detach:      false,
 		Tty:         true,
 		ConsoleSize: &[2]uint{140, 60},
 	})
 	if err != nil {
-		return err
+		return engineError(err, "failed to attach to exec")
 	}
-	defer resp.Close()
+	go func() {
+		<-logs.BaseContext.Done()
+		resp.Close()
+	}()
 
 	logs.AttachScanner(bufio.NewScanner(resp.Reader))
 
-	if err := r.client.ContainerExecStart(ctx, execID.ID, container.ExecStartOptions{
-		Detach: false,
-		Tty:    true,
-		ConsoleSize: &[2]uint{
-			140,
-			60,
-		},
-	}); err != nil {
-		return err
-	}
-
 	return nil
 }
 
-// ExecCommandString runs a single command, waiting for it to complete, then returns all stdout/stderr as a string
+// ExecCommandString runs a single command, waiting for it to complete, then returns all stdout/stderr as a string.
+// Use Exec to get stdout and stderr separately.
 func (r *Runner) ExecCommandString(ctx context.Context, containerReference string, command []string) (string, error) {
-	execConfig := container.ExecOptions{
-		AttachStdout: true,
-		AttachStderr: true,
-		Cmd:          command,
-	}
-	execID, err := r.client.ContainerExecCreate(ctx, containerReference, execConfig)
+	result, err := r.Exec(ctx, containerReference, ExecOptions{Command: command})
 	if err != nil {
 		return "", err
 	}
+	output := result.Stdout + result.Stderr
+	if result.ExitCode != 0 {
+		return output, errors.Errorf("command exited with code %d", result.ExitCode)
+	}
+	return output, nil
+}
 
-	resp, err := r.client.ContainerExecAttach(ctx, execID.ID, container.ExecStartOptions{
-		Detach:      false,
-		Tty:         true,
-		ConsoleSize: &[2]uint{140, 60},
-	})
+func (r *Runner) KillContainer(ctx context.Context, containerReference string) error {
+	return engineError(r.client.ContainerKill(ctx, containerReference, "SIGTERM"), "failed to kill container")
+}
+
+// StopContainer sends a container its configured stop signal, escalates to SIGKILL if it has not exited within its
+// grace period, and only returns once the container has been removed, so that its name and ports are free again.
+func (r *Runner) StopContainer(ctx context.Context, containerReference string) error {
+	inspect, err := r.client.ContainerInspect(ctx, containerReference)
 	if err != nil {
-		return "", err
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return engineError(err, "failed to inspect container")
+	}
+
+	if inspect.State.Running {
+		signal := "SIGTERM"
+		if inspect.Config.StopSignal != "" {
+			signal = inspect.Config.StopSignal
+		}
+		gracePeriod := DefaultStopGracePeriod
+		if inspect.Config.StopTimeout != nil {
+			gracePeriod = time.Duration(*inspect.Config.StopTimeout) * time.Second
+		}
+
+		exited := r.waitForExit(ctx, inspect.ID)
+		if err := r.client.ContainerKill(ctx, inspect.ID, signal); err != nil && !errdefs.IsNotFound(err) && !errdefs.IsConflict(err) {
+			return engineError(err, "failed to send "+signal)
+		}
+
+		timer := time.NewTimer(gracePeriod)
+		defer timer.Stop()
+		select {
+		case err := <-exited:
+			if err != nil {
+				return err
+			}
+		case <-timer.C:
+			slog.WarnContext(ctx, "Container did not exit in time, sending SIGKILL", LogKeyContainer, inspect.ID, "grace_period", gracePeriod, "signal", signal)
+			if err := r.client.ContainerKill(ctx, inspect.ID, "SIGKILL"); err != nil && !errdefs.IsNotFound(err) && !errdefs.IsConflict(err) {
+				return engineError(err, "failed to send SIGKILL")
+			}
+			if err := <-exited; err != nil {
+				return err
+			}
+		}
 	}
-	defer resp.Close()
 
-	var output string
-	scanner := bufio.NewScanner(resp.Reader)
-	for scanner.Scan() {
-		output += scanner.Text()
+	// AutoRemove deletes the container asynchronously; remove it ourselves in case it was created without it
+	err = r.client.ContainerRemove(ctx, inspect.ID, container.RemoveOptions{Force: true})
+	if err != nil && !errdefs.IsNotFound(err) && !errdefs.IsConflict(err) {
+		return engineError(err, "failed to remove container")
 	}
+	return r.waitForRemoval(ctx, inspect.ID)
+}
 
-	if err := r.client.ContainerExecStart(ctx, execID.ID, container.ExecStartOptions{
-		Detach: false,
-		Tty:    true,
-		ConsoleSize: &[2]uint{
-			140,
-			60,
-		},
-	}); err != nil {
-		return "", err
+func (r *Runner) RenameContainer(ctx context.Context, containerReference string, name string) error {
+	if err := r.client.ContainerRename(ctx, containerReference, name); err != nil {
+		if errdefs.IsConflict(err) {
+			return &NameConflictError{Name: name, Err: errors.Wrapf(err, "failed to rename container to %s", name)}
+		}
+		return engineError(err, "failed to rename container")
 	}
+	return nil
+}
 
-	return output, nil
+// waitForExit delivers nil once the container stops running, or the error that prevented waiting for it.
+func (r *Runner) waitForExit(ctx context.Context, containerID string) <-chan error {
+	exited := make(chan error, 1)
+	statusCh, errCh := r.client.ContainerWait(ctx, containerID, container.WaitConditionNotRunning)
+	go func() {
+		select {
+		case <-statusCh:
+			exited <- nil
+		case err := <-errCh:
+			if errdefs.IsNotFound(err) {
+				// already exited and auto-removed
+				exited <- nil
+				return
+			}
+			exited <- engineError(err, "failed to wait for container")
+		}
+	}()
+	return exited
 }
 
-func (r *Runner) KillContainer(ctx context.Context, containerReference string) error {
-	return r.client.ContainerKill(ctx, containerReference, "SIGTERM")
+func (r *Runner) waitForRemoval(ctx context.Context, containerID string) error {
+	for {
+		_, err := r.client.ContainerInspect(ctx, containerID)
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return engineError(ctx.Err(), "container was not removed")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
 }
 
 func (r *Runner) ContainerIsRunning(ctx context.Context, containerReference string) (bool, error) {
 	c, err := r.client.ContainerInspect(ctx, containerReference)
 	if err != nil {
-		return false, err
+		return false, engineError(err, "failed to inspect container")
 	}
 	return c.State.Running, nil
 }
 
+// ContainerState returns the engine's view of a container, including its health check status if it has one.
+func (r *Runner) ContainerState(ctx context.Context, containerReference string) (*types.ContainerState, error) {
+	c, err := r.client.ContainerInspect(ctx, containerReference)
+	if err != nil {
+		return nil, engineError(err, "failed to inspect container")
+	}
+	return c.State, nil
+}
+
 func (r *Runner) WaitForContainerToExit(ctx context.Context, containerReference string) error {
 	statusCh, errCh := r.client.ContainerWait(ctx, containerReference, container.WaitConditionNotRunning)
 	select {
 	case err := <-errCh:
 		if err != nil {
-			return err
+			return engineError(err, "failed to wait for container")
 		}
 	case <-statusCh:
 	case <-ctx.Done():
@@ -341,7 +563,25 @@ func (r *Runner) WaitForContainerToExit(ctx context.Context, containerReference
 }
 
 func (r *Runner) ListContainersMatchingLabel(ctx context.Context, label string, value string) ([]types.Container, error) {
-	return r.client.ContainerList(ctx, container.ListOptions{
+	containers, err := r.client.ContainerList(ctx, container.ListOptions{
 		Filters: filters.NewArgs(filters.Arg("label", label+"="+value)),
 	})
+	if err != nil {
+		return nil, engineError(err, "failed to list containers")
+	}
+	return containers, nil
+}
+
+// ContainerEvents streams the engine's die and health_status events for containers labelled label=value, until ctx
+// is done or the stream fails. Each event's actor attributes include the container's labels, and, for die events,
+// its exit code.
+func (r *Runner) ContainerEvents(ctx context.Context, label string, value string) (<-chan events.Message, <-chan error) {
+	return r.client.Events(ctx, events.ListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("type", string(events.ContainerEventType)),
+			filters.Arg("label", label+"="+value),
+			filters.Arg("event", string(events.ActionDie)),
+			filters.Arg("event", string(events.ActionHealthStatus)),
+		),
+	})
 }
@@ -0,0 +1,124 @@
+package pkg
+
+import (
+	"context"
+	"io"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+)
+
+// ContainerRuntime methods return these, wrapping the engine's error, for the failures callers handle differently; check
+// for them with errors.As. Each one's message is that of the error it wraps.
+
+// ImageNotFoundError means the registry has no such image, or will not say whether it has one.
+type ImageNotFoundError struct {
+	Image string
+	Err   error
+}
+
+func (e *ImageNotFoundError) Error() string { return e.Err.Error() }
+func (e *ImageNotFoundError) Unwrap() error { return e.Err }
+
+// RegistryAuthError means the registry refused the credentials given for an image, or required some.
+type RegistryAuthError struct {
+	Image string
+	Err   error
+}
+
+func (e *RegistryAuthError) Error() string { return e.Err.Error() }
+func (e *RegistryAuthError) Unwrap() error { return e.Err }
+
+// NameConflictError means a container with the name already exists, such as one still being removed.
+type NameConflictError struct {
+	Name string
+	Err  error
+}
+
+func (e *NameConflictError) Error() string { return e.Err.Error() }
+func (e *NameConflictError) Unwrap() error { return e.Err }
+
+// EngineUnavailableError means the engine could not be reached at all.
+type EngineUnavailableError struct {
+	Err error
+}
+
+func (e *EngineUnavailableError) Error() string { return e.Err.Error() }
+func (e *EngineUnavailableError) Unwrap() error { return e.Err }
+
+// TimeoutError means the engine, or a registry it called, did not answer in time, or the network to the registry
+// failed part way, as when its name did not resolve or the connection was refused or reset.
+type TimeoutError struct {
+	Err error
+}
+
+func (e *TimeoutError) Error() string { return e.Err.Error() }
+func (e *TimeoutError) Unwrap() error { return e.Err }
+
+// ExitError means a container RunContainer waited on exited with a non-zero code.
+type ExitError struct {
+	ExitCode int
+	Err      error
+}
+
+func (e *ExitError) Error() string { return e.Err.Error() }
+func (e *ExitError) Unwrap() error { return e.Err }
+
+// engineError wraps an error from the engine with message, as an EngineUnavailableError or TimeoutError when it
+// is one.
+func engineError(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	wrapped := errors.Wrap(err, message)
+	switch {
+	case client.IsErrConnectionFailed(err):
+		return &EngineUnavailableError{Err: wrapped}
+	case errors.Is(err, context.DeadlineExceeded) || errdefs.IsDeadline(err):
+		return &TimeoutError{Err: wrapped}
+	}
+	return wrapped
+}
+
+// registryNetworkFailures are fragments of the messages the engine reports when it cannot reach a registry, which
+// arrive as plain strings.
+var registryNetworkFailures = []string{
+	"no such host",
+	"temporary failure in name resolution",
+	"server misbehaving",
+	"connection refused",
+	"connection reset",
+	"network is unreachable",
+	"no route to host",
+	"i/o timeout",
+	"tls handshake timeout",
+	"timeout exceeded while awaiting headers",
+	"unexpected eof",
+}
+
+// pullError classifies a failed pull of imageReference. Errors reported partway through the pull's progress
+// stream carry no error type, so their messages are matched too.
+func pullError(err error, imageReference string) error {
+	wrapped := errors.Wrapf(err, "failed to pull %s", imageReference)
+	message := strings.ToLower(err.Error())
+	var netErr net.Error
+	switch {
+	case client.IsErrConnectionFailed(err):
+		return &EngineUnavailableError{Err: wrapped}
+	case errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		slices.ContainsFunc(registryNetworkFailures, func(failure string) bool { return strings.Contains(message, failure) }):
+		return &TimeoutError{Err: wrapped}
+	case errdefs.IsUnauthorized(err) || errdefs.IsForbidden(err) ||
+		strings.Contains(message, "unauthorized") || strings.Contains(message, "authentication required"):
+		return &RegistryAuthError{Image: imageReference, Err: wrapped}
+	case errdefs.IsNotFound(err) ||
+		strings.Contains(message, "not found") || strings.Contains(message, "manifest unknown") ||
+		strings.Contains(message, "pull access denied"):
+		return &ImageNotFoundError{Image: imageReference, Err: wrapped}
+	}
+	return engineError(err, "failed to pull "+imageReference)
+}
@@ -0,0 +1,55 @@
+package pkg
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+)
+
+// pullErrorKind names the type pullError gave err, or "" for an unclassified error.
+func pullErrorKind(err error) string {
+	var auth *RegistryAuthError
+	var notFound *ImageNotFoundError
+	var timeout *TimeoutError
+	var unavailable *EngineUnavailableError
+	switch {
+	case errors.As(err, &auth):
+		return "auth"
+	case errors.As(err, &notFound):
+		return "not found"
+	case errors.As(err, &timeout):
+		return "timeout"
+	case errors.As(err, &unavailable):
+		return "unavailable"
+	}
+	return ""
+}
+
+func TestPullError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"unauthorized", errdefs.Unauthorized(errors.New("unauthorized: authentication required")), "auth"},
+		{"manifest unknown", errors.New("manifest unknown: manifest unknown"), "not found"},
+		{"no such image", errdefs.NotFound(errors.New("no such image")), "not found"},
+		{"dns", errors.New(`Get "https://ghcr.io/v2/": dial tcp: lookup ghcr.io on 127.0.0.53:53: no such host`), "timeout"},
+		{"refused", errors.New(`Get "https://ghcr.io/v2/": dial tcp 140.82.112.34:443: connect: connection refused`), "timeout"},
+		{"reset", errors.New("read tcp 10.0.0.2:51234->140.82.112.34:443: read: connection reset by peer"), "timeout"},
+		{"tls", errors.New(`Get "https://ghcr.io/v2/": net/http: TLS handshake timeout`), "timeout"},
+		{"net error", &net.OpError{Op: "read", Net: "tcp", Err: errors.New("network is down")}, "timeout"},
+		{"deadline", errors.Wrap(context.DeadlineExceeded, "pulling"), "timeout"},
+		{"invalid reference", errors.New("invalid reference format"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pullErrorKind(pullError(tt.err, "ghcr.io/uinta-labs/app:1")); got != tt.want {
+				t.Errorf("pullError(%q) is %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package pkg
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+)
+
+// ContainerRuntime is the container engine the agent drives. Runner implements it for Docker and balena-engine;
+// FakeRuntime simulates one in memory.
+//
+// Implementations report the failures callers handle differently with the error types in runner_errors.go, and a
+// missing container with an error satisfying errdefs.IsNotFound.
+type ContainerRuntime interface {
+	PullImage(ctx context.Context, imageReference string) error
+	// RunContainer creates and starts a container, streaming its output to logs, and optionally waits for it to exit,
+	// returning an ExitError if it exits with a non-zero code.
+	RunContainer(ctx context.Context, imageReference string, containerReference string, commands []string, environmentVariables []string, additionalLabels map[string]string, advancedOptions *AdvancedOptions, logs *LogChannels, waitOnContainer bool) (string, error)
+	// ReplicateContainer creates and starts a copy of source running imageReference, used by the agent to update itself.
+	ReplicateContainer(ctx context.Context, source types.ContainerJSON, imageReference string, name string, env map[string]string) (string, error)
+	// StopContainer stops a container and returns once it is removed; a container that does not exist is not an error.
+	StopContainer(ctx context.Context, containerReference string) error
+	// RenameContainer gives a container a name, which must not be in use.
+	RenameContainer(ctx context.Context, containerReference string, name string) error
+	ListContainersMatchingLabel(ctx context.Context, label string, value string) ([]types.Container, error)
+	InspectContainer(ctx context.Context, containerReference string) (types.ContainerJSON, error)
+	ContainerState(ctx context.Context, containerReference string) (*types.ContainerState, error)
+	Exec(ctx context.Context, containerReference string, options ExecOptions) (*ExecResult, error)
+	// ContainerEvents streams die and health_status events for containers labelled label=value.
+	ContainerEvents(ctx context.Context, label string, value string) (<-chan events.Message, <-chan error)
+	PruneUnreferenced(ctx context.Context, pulledImages []string, keepImages []string) (*PruneReport, error)
+}
+
+var _ ContainerRuntime = (*Runner)(nil)
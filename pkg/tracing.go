@@ -0,0 +1,98 @@
+package pkg
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/otelconnect"
+	"github.com/getsentry/sentry-go"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TracingConfig is shared by the server and agent, embedded in each one's config.
+type TracingConfig struct {
+	// Spans are exported over OTLP/HTTP to this URL, such as "http://localhost:4318"; empty records nothing, but
+	// trace context is still passed along. Other OTEL_EXPORTER_OTLP_* variables, such as headers, also apply.
+	OTLPEndpoint string `env:"OTEL_EXPORTER_OTLP_ENDPOINT" envDefault:""`
+	// Fraction of new traces recorded; traces started by a caller follow the caller's decision
+	TraceSampleRatio float64 `env:"TRACE_SAMPLE_RATIO" envDefault:"1"`
+}
+
+func (c TracingConfig) validate() error {
+	if c.TraceSampleRatio < 0 || c.TraceSampleRatio > 1 {
+		return errors.New("TRACE_SAMPLE_RATIO must be between 0 and 1")
+	}
+	return nil
+}
+
+// SetupTracing installs the global tracer provider, naming spans' source serviceName, and W3C trace context
+// propagation. The returned function flushes any spans not yet exported, and is safe to call when nothing is.
+func SetupTracing(ctx context.Context, serviceName string, cfg TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if cfg.OTLPEndpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create trace exporter")
+	}
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES take precedence over the defaults given here
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", Version),
+		),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe trace resource")
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TraceSampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// TracingInterceptor traces every RPC it sees, through the global tracer provider. Metrics are left to Prometheus.
+// Handlers only continue a caller's trace when trustRemote is set; otherwise they start their own, linked to the
+// caller's.
+func TracingInterceptor(trustRemote bool) (connect.Interceptor, error) {
+	options := []otelconnect.Option{otelconnect.WithoutMetrics(), otelconnect.WithoutServerPeerAttributes()}
+	if trustRemote {
+		options = append(options, otelconnect.WithTrustRemote())
+	}
+	interceptor, err := otelconnect.NewInterceptor(options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create tracing interceptor")
+	}
+	return interceptor, nil
+}
+
+// SetupSentry reports errors to Sentry when dsn is set. The returned function waits for reports still being sent.
+func SetupSentry(dsn string, environment string) (func(), error) {
+	if dsn == "" {
+		return func() {}, nil
+	}
+	err := sentry.Init(sentry.ClientOptions{
+		Dsn:         dsn,
+		Environment: environment,
+		Release:     Version,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize Sentry")
+	}
+	return func() { sentry.Flush(5 * time.Second) }, nil
+}
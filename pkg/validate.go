@@ -0,0 +1,189 @@
+package pkg
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+
+	"github.com/uinta-labs/pando/gen/protos/remote/upd88/com"
+)
+
+const MaxStopGracePeriodSeconds = 3600
+
+const (
+	UpdateStrategyRecreate   = "recreate"
+	UpdateStrategyStartFirst = "start-first"
+)
+
+const (
+	ConcurrencyPolicyForbid  = "forbid"
+	ConcurrencyPolicyReplace = "replace"
+	ConcurrencyPolicyAllow   = "allow"
+)
+
+var (
+	// Same rules the engine applies to container names
+	containerNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+	envKeyPattern        = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	ScheduleStates = []string{"active", "inactive"}
+	// How the agent replaces a task's container; empty means UpdateStrategyRecreate
+	UpdateStrategies = []string{UpdateStrategyRecreate, UpdateStrategyStartFirst}
+	// What a job does when a run is due while the previous one is still going; empty means ConcurrencyPolicyForbid
+	ConcurrencyPolicies = []string{ConcurrencyPolicyForbid, ConcurrencyPolicyReplace, ConcurrencyPolicyAllow}
+
+	stopSignals = map[string]bool{
+		"SIGHUP": true, "SIGINT": true, "SIGQUIT": true, "SIGKILL": true, "SIGUSR1": true,
+		"SIGUSR2": true, "SIGTERM": true, "SIGWINCH": true, "SIGPWR": true,
+	}
+	portProtocols = map[string]bool{"tcp": true, "udp": true, "sctp": true}
+)
+
+// ValidationError lists every problem found with a schedule, so callers can fix them all in one go.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid schedule: " + strings.Join(e.Problems, "; ")
+}
+
+// ValidateSchedule checks a schedule and its containers as a whole, including conflicts between containers
+// that would only show up once a device tried to run them side by side.
+func ValidateSchedule(schedule *com.Schedule) error {
+	var problems []string
+
+	if strings.TrimSpace(schedule.Name) == "" {
+		problems = append(problems, "name is required")
+	}
+	if !isScheduleState(schedule.State) {
+		problems = append(problems, fmt.Sprintf("state must be one of %s", strings.Join(ScheduleStates, ", ")))
+	}
+
+	names := map[string]bool{}
+	hostPorts := map[string]string{}
+	for _, container := range schedule.Containers {
+		problems = append(problems, validateContainer(container)...)
+
+		if names[container.Name] {
+			problems = append(problems, fmt.Sprintf("container name %q is used more than once", container.Name))
+		}
+		names[container.Name] = true
+
+		for _, port := range container.Ports {
+			key := port.Host + "/" + portProtocol(port)
+			if other, ok := hostPorts[key]; ok {
+				problems = append(problems, fmt.Sprintf("containers %q and %q both publish host port %s", other, container.Name, key))
+				continue
+			}
+			hostPorts[key] = container.Name
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func validateContainer(container *com.Container) []string {
+	var problems []string
+	problemf := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf("container %q: ", container.Name)+fmt.Sprintf(format, args...))
+	}
+
+	if !containerNamePattern.MatchString(container.Name) {
+		problemf("name must start with a letter or digit and contain only letters, digits, '_', '.' and '-'")
+	}
+	if container.ContainerImage == "" {
+		problemf("container_image is required")
+	} else if _, err := reference.ParseNormalizedNamed(container.ContainerImage); err != nil {
+		problemf("container_image %q is not a valid image reference: %s", container.ContainerImage, err)
+	}
+	for key := range container.Env {
+		if !envKeyPattern.MatchString(key) {
+			problemf("env key %q is not a valid variable name", key)
+		}
+	}
+
+	if _, ok := com.Container_NetworkMode_name[int32(container.NetworkMode)]; !ok {
+		problemf("unknown network_mode %d", container.NetworkMode)
+	}
+	if len(container.Ports) > 0 && container.NetworkMode != com.Container_BRIDGE {
+		problemf("ports can only be published with bridge networking")
+	}
+	for _, port := range container.Ports {
+		if !isPortNumber(port.Host) {
+			problemf("host port %q must be a number between 1 and 65535", port.Host)
+		}
+		if !isPortNumber(port.Container) {
+			problemf("container port %q must be a number between 1 and 65535", port.Container)
+		}
+		if !portProtocols[portProtocol(port)] {
+			problemf("port protocol %q must be tcp, udp or sctp", port.Protocol)
+		}
+	}
+
+	if container.StopSignal != "" && !stopSignals[container.StopSignal] {
+		problemf("unsupported stop_signal %q", container.StopSignal)
+	}
+	if container.StopGracePeriodSeconds < 0 || container.StopGracePeriodSeconds > MaxStopGracePeriodSeconds {
+		problemf("stop_grace_period_seconds must be between 0 and %d", MaxStopGracePeriodSeconds)
+	}
+	if container.UpdateStrategy != "" && !slices.Contains(UpdateStrategies, container.UpdateStrategy) {
+		problemf("update_strategy must be one of %s", strings.Join(UpdateStrategies, ", "))
+	}
+	if job := container.Job; job != nil {
+		if job.Cron != "" {
+			if _, err := ParseCron(job.Cron); err != nil {
+				problemf("job: %s", err)
+			}
+		}
+		if job.TimeZone != "" {
+			if _, err := time.LoadLocation(job.TimeZone); err != nil {
+				problemf("job: time zone %q is not a known IANA time zone", job.TimeZone)
+			}
+		}
+		if job.TimeoutSeconds < 0 {
+			problemf("job: timeout_seconds must not be negative")
+		}
+		if job.ConcurrencyPolicy != "" && !slices.Contains(ConcurrencyPolicies, job.ConcurrencyPolicy) {
+			problemf("job: concurrency_policy must be one of %s", strings.Join(ConcurrencyPolicies, ", "))
+		}
+		// runs are named apart, and may overlap
+		if len(container.Ports) > 0 {
+			problemf("job: ports cannot be published by jobs")
+		}
+		if container.UpdateStrategy != "" {
+			problemf("job: update_strategy does not apply to jobs")
+		}
+	}
+
+	return problems
+}
+
+func isScheduleState(state string) bool {
+	for _, s := range ScheduleStates {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+func isPortNumber(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n >= 1 && n <= 65535
+}
+
+func portProtocol(port *com.Container_Port) string {
+	if port.Protocol == "" {
+		return "tcp"
+	}
+	return strings.ToLower(port.Protocol)
+}
@@ -0,0 +1,15 @@
+-- CreateTable
+CREATE TABLE "device_rollback" (
+    "id" UUID NOT NULL,
+    "device_id" UUID NOT NULL,
+    "failed_schedule_id" UUID NOT NULL,
+    "failed_schedule_version" TEXT NOT NULL,
+    "restored_schedule_id" UUID NOT NULL,
+    "reason" TEXT NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "device_rollback_pkey" PRIMARY KEY ("id")
+);
+
+-- AddForeignKey
+ALTER TABLE "device_rollback" ADD CONSTRAINT "device_rollback_device_id_fkey" FOREIGN KEY ("device_id") REFERENCES "device"("id") ON DELETE CASCADE ON UPDATE CASCADE;
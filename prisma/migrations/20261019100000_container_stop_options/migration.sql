@@ -0,0 +1,3 @@
+-- AlterTable
+ALTER TABLE "container" ADD COLUMN     "stop_signal" TEXT NOT NULL DEFAULT '',
+ADD COLUMN     "stop_grace_period_seconds" INTEGER NOT NULL DEFAULT 10;
@@ -0,0 +1,36 @@
+-- AlterTable
+ALTER TABLE "schedule" ADD COLUMN "organization_id" UUID;
+
+-- Backfill schedules already used as a fleet default with that fleet's organization
+UPDATE "schedule" AS s
+SET "organization_id" = f."organization_id"
+FROM "fleet" AS f
+WHERE f."default_schedule_id" = s."id";
+
+-- CreateTable
+CREATE TABLE "api_key" (
+    "id" UUID NOT NULL,
+    "organization_id" UUID NOT NULL,
+    "name" TEXT NOT NULL,
+    "token_hash" TEXT NOT NULL,
+    "created_by_user_id" UUID,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "revoked_at" TIMESTAMP(3),
+
+    CONSTRAINT "api_key_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "schedule_organization_id_idx" ON "schedule"("organization_id");
+
+-- CreateIndex
+CREATE UNIQUE INDEX "api_key_token_hash_key" ON "api_key"("token_hash");
+
+-- AddForeignKey
+ALTER TABLE "schedule" ADD CONSTRAINT "schedule_organization_id_fkey" FOREIGN KEY ("organization_id") REFERENCES "organization"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "api_key" ADD CONSTRAINT "api_key_organization_id_fkey" FOREIGN KEY ("organization_id") REFERENCES "organization"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "api_key" ADD CONSTRAINT "api_key_created_by_user_id_fkey" FOREIGN KEY ("created_by_user_id") REFERENCES "user"("id") ON DELETE SET NULL ON UPDATE CASCADE;
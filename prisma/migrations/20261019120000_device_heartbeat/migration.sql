@@ -0,0 +1,4 @@
+-- AlterTable
+ALTER TABLE "device" ADD COLUMN     "agent_version" TEXT,
+ADD COLUMN     "last_seen_at" TIMESTAMP(3),
+ADD COLUMN     "remote_address" TEXT;
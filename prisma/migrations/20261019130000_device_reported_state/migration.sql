@@ -0,0 +1,3 @@
+-- AlterTable
+ALTER TABLE "device" ADD COLUMN     "reported_at" TIMESTAMP(3),
+ADD COLUMN     "reported_state" JSONB;
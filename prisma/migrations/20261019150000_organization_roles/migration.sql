@@ -0,0 +1,42 @@
+-- "administrator" was the only role, and could do everything
+UPDATE "organization_user" SET "role" = 'owner' WHERE "role" = 'administrator';
+
+-- AlterTable
+ALTER TABLE "organization_user" ALTER COLUMN "role" SET DEFAULT 'owner';
+
+-- Keep one membership per user and organization before making that unique
+DELETE FROM "organization_user" AS a
+USING "organization_user" AS b
+WHERE a."user_id" = b."user_id"
+  AND a."organization_id" = b."organization_id"
+  AND a."id" > b."id";
+
+-- CreateIndex
+CREATE UNIQUE INDEX "organization_user_user_id_organization_id_key" ON "organization_user"("user_id", "organization_id");
+
+-- AlterTable; existing keys could do everything but make owners
+ALTER TABLE "api_key" ADD COLUMN "role" TEXT NOT NULL DEFAULT 'admin';
+
+-- CreateTable
+CREATE TABLE "organization_invitation" (
+    "id" UUID NOT NULL,
+    "organization_id" UUID NOT NULL,
+    "email" TEXT NOT NULL,
+    "role" TEXT NOT NULL,
+    "invited_by_user_id" UUID,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "organization_invitation_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "organization_invitation_organization_id_email_key" ON "organization_invitation"("organization_id", "email");
+
+-- CreateIndex
+CREATE INDEX "organization_invitation_email_idx" ON "organization_invitation"("email");
+
+-- AddForeignKey
+ALTER TABLE "organization_invitation" ADD CONSTRAINT "organization_invitation_organization_id_fkey" FOREIGN KEY ("organization_id") REFERENCES "organization"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE "organization_invitation" ADD CONSTRAINT "organization_invitation_invited_by_user_id_fkey" FOREIGN KEY ("invited_by_user_id") REFERENCES "user"("id") ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "fleet" ADD COLUMN     "agent_image" TEXT;
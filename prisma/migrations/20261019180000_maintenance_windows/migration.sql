@@ -0,0 +1,8 @@
+-- AlterTable
+ALTER TABLE "fleet" ADD COLUMN     "maintenance_windows" JSONB,
+ADD COLUMN     "time_zone" TEXT;
+
+-- AlterTable
+ALTER TABLE "device" ADD COLUMN     "maintenance_windows" JSONB,
+ADD COLUMN     "time_zone" TEXT,
+ADD COLUMN     "apply_now_until" TIMESTAMP(3);
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "container" ADD COLUMN     "update_strategy" TEXT NOT NULL DEFAULT '';
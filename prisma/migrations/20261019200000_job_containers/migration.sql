@@ -0,0 +1,29 @@
+-- AlterTable
+ALTER TABLE "container" ADD COLUMN     "job" JSONB;
+
+-- CreateTable
+CREATE TABLE "job_run" (
+    "id" UUID NOT NULL,
+    "device_id" UUID NOT NULL,
+    "task_id" TEXT NOT NULL,
+    "task_name" TEXT NOT NULL,
+    "schedule_id" TEXT NOT NULL,
+    "status" TEXT NOT NULL,
+    "started_at" TIMESTAMP(3) NOT NULL,
+    "finished_at" TIMESTAMP(3),
+    "exit_code" INTEGER NOT NULL DEFAULT 0,
+    "error" TEXT NOT NULL DEFAULT '',
+    "log_tail" TEXT NOT NULL DEFAULT '',
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "job_run_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "job_run_device_id_started_at_id_idx" ON "job_run"("device_id", "started_at", "id");
+
+-- CreateIndex
+CREATE INDEX "job_run_created_at_idx" ON "job_run"("created_at");
+
+-- AddForeignKey
+ALTER TABLE "job_run" ADD CONSTRAINT "job_run_device_id_fkey" FOREIGN KEY ("device_id") REFERENCES "device"("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "device" ADD COLUMN     "relay_token_hash" TEXT;